@@ -0,0 +1,125 @@
+// Package adapter plugs the validator into web frameworks as their
+// validation engine, without changing handler code. The adapters match the
+// framework interfaces structurally, so this package does not depend on any
+// framework:
+//
+//	binding.Validator = adapter.Gin{} // gin
+//	e.Validator = adapter.Echo{}      // echo
+//	app := fiber.New(fiber.Config{StructValidator: adapter.Fiber{}}) // fiber v3
+//
+// Like the engines they replace, the adapters accept pointers to structs and
+// slices or arrays of structs, and ignore values that are not structs.
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Nadya2002/validator"
+)
+
+// Gin implements binding.StructValidator of gin. Options are passed to
+// every validation.
+type Gin struct {
+	Options []validator.Option
+}
+
+// ValidateStruct validates obj after gin bound a request to it.
+func (g Gin) ValidateStruct(obj any) error {
+	return validateAny(context.Background(), obj, g.Options)
+}
+
+// Engine returns the adapter itself, as there is no engine to configure
+// beyond its Options.
+func (g Gin) Engine() any { return g }
+
+// Echo implements echo.Validator, used by echo.Context.Validate.
+type Echo struct {
+	Options []validator.Option
+}
+
+// Validate validates i.
+func (e Echo) Validate(i any) error {
+	return validateAny(context.Background(), i, e.Options)
+}
+
+// Fiber implements fiber.StructValidator of fiber v3, used when binding
+// requests.
+type Fiber struct {
+	Options []validator.Option
+}
+
+// Validate validates out after fiber bound a request to it.
+func (f Fiber) Validate(out any) error {
+	return validateAny(context.Background(), out, f.Options)
+}
+
+// validateAny validates v when it is a struct, a pointer to one or a slice
+// or an array of them. The errors of the elements are merged into one
+// ValidationErrors, with paths like "[2].Name".
+func validateAny(ctx context.Context, v any, opts []validator.Option) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.CanAddr() {
+		// Lets default rules fill in the fields of the bound structs.
+		value = value.Addr()
+	}
+
+	switch elemKind(value.Type()) {
+	case reflect.Struct:
+		return validator.ValidateCtx(ctx, value.Interface(), opts...)
+	case reflect.Slice, reflect.Array:
+		// Collections of collections, validated one by one.
+		value = reflect.Indirect(value)
+		var all validator.ValidationErrors
+		for i := 0; i < value.Len(); i++ {
+			elem := value.Index(i)
+			if elem.CanAddr() {
+				elem = elem.Addr()
+			}
+			err := validator.Prefix(fmt.Sprintf("[%d]", i), validateAny(ctx, elem.Interface(), opts))
+			if err == nil {
+				continue
+			}
+			errs, ok := err.(validator.ValidationErrors)
+			if !ok {
+				return err
+			}
+			all = append(all, errs...)
+		}
+		if len(all) != 0 {
+			return all
+		}
+	}
+	return nil
+}
+
+// elemKind returns reflect.Struct for the structs, pointers to structs and
+// the slices and arrays of structs and of pointers to structs the validator
+// checks at once, the kind of t for other slices and arrays, and
+// reflect.Invalid for other types.
+func elemKind(t reflect.Type) reflect.Kind {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		return reflect.Struct
+	}
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return reflect.Invalid
+	}
+	elemT := t.Elem()
+	for elemT.Kind() == reflect.Pointer {
+		elemT = elemT.Elem()
+	}
+	if elemT.Kind() == reflect.Struct {
+		return reflect.Struct
+	}
+	return t.Kind()
+}
@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+// The interfaces of the frameworks, as they declare them.
+type (
+	ginStructValidator interface {
+		ValidateStruct(any) error
+		Engine() any
+	}
+	echoValidator interface {
+		Validate(i interface{}) error
+	}
+	fiberStructValidator interface {
+		Validate(out any) error
+	}
+)
+
+var (
+	_ ginStructValidator   = Gin{}
+	_ echoValidator        = Echo{}
+	_ fiberStructValidator = Fiber{}
+)
+
+type login struct {
+	User     string `validate:"required"`
+	Password string `validate:"min:8"`
+}
+
+func TestAdapters(t *testing.T) {
+	valid, invalid := login{User: "nadya", Password: "long enough"}, login{Password: "short"}
+
+	for name, validate := range map[string]func(any) error{
+		"gin":   Gin{}.ValidateStruct,
+		"echo":  Echo{}.Validate,
+		"fiber": Fiber{}.Validate,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, validate(&valid))
+			assert.NoError(t, validate("not a struct"))
+			assert.NoError(t, validate((*login)(nil)))
+
+			err := validate(&invalid)
+			require.Error(t, err)
+			assert.Len(t, err.(validator.ValidationErrors), 2)
+
+			err = validate([]*login{&valid, &invalid, &invalid})
+			require.Error(t, err)
+			assert.Equal(t, []string{"[1].User", "[1].Password", "[2].User", "[2].Password"}, fields(err))
+
+			err = validate([][]login{{valid}, {valid, invalid}})
+			require.Error(t, err)
+			assert.Equal(t, []string{"[1][1].User", "[1][1].Password"}, fields(err))
+			assert.Len(t, fields(validate([2]login{invalid, valid})), 2)
+			assert.NoError(t, validate([]string{"not a struct"}))
+		})
+	}
+}
+
+func TestDefaultsOfElements(t *testing.T) {
+	type page struct {
+		Size int `validate:"default:20&max:100"`
+	}
+	pages := []page{{}, {Size: 50}}
+	require.NoError(t, Gin{}.ValidateStruct(pages))
+	assert.Equal(t, []page{{Size: 20}, {Size: 50}}, pages)
+
+	nested := [][]page{{{}}}
+	require.NoError(t, Echo{}.Validate(nested))
+	assert.Equal(t, 20, nested[0][0].Size)
+}
+
+// fields returns the paths of the fields of the ValidationErrors err.
+func fields(err error) []string {
+	var names []string
+	for _, e := range err.(validator.ValidationErrors) {
+		names = append(names, e.Field())
+	}
+	return names
+}
+
+func TestGinOptions(t *testing.T) {
+	type base struct {
+		ID string `validate:"required"`
+	}
+	type item struct{ base }
+
+	err := Gin{Options: []validator.Option{validator.WithEmbeddedNaming(validator.QualifyEmbedded)}}.ValidateStruct(item{})
+	require.Error(t, err)
+	assert.Equal(t, "base.ID", err.(validator.ValidationErrors)[0].Field())
+	assert.Equal(t, Gin{}, Gin{}.Engine())
+}
@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of the date strings minage applies to, the full
+// dates of RFC 3339 like "2006-01-02".
+const dateLayout = "2006-01-02"
+
+// parseMinAge parses the argument of minage, a number of years.
+func parseMinAge(params string) (rule, error) {
+	years, err := strconv.Atoi(strings.TrimSpace(params))
+	if err != nil || years < 0 {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	return rule{name: "minage", argsStr: []string{params}, argsInt: []int{years}}, nil
+}
+
+// validateMinAge requires a birth date, a time.Time or a string like
+// "2006-01-02", at least years before now. Dates without a time are read in
+// the location of now, so people come of age at the start of their birthday.
+// People born on February 29 do on March 1 in common years.
+func validateMinAge(field reflect.Value, years int, now time.Time) error {
+	var birth time.Time
+	switch {
+	case !field.IsValid():
+		return ErrFieldNotValid
+	case field.Kind() == reflect.String:
+		var err error
+		if birth, err = time.ParseInLocation(dateLayout, field.String(), now.Location()); err != nil {
+			return ErrFieldNotValid
+		}
+	case field.Type() == timeType && field.CanInterface():
+		birth = field.Interface().(time.Time)
+	default:
+		return ErrFieldNotValid
+	}
+	if birth.AddDate(years, 0, 0).After(now) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMinAge(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 10, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+
+	type person struct {
+		Born     time.Time `validate:"minage:18"`
+		BornDate string    `validate:"minage:18"`
+		Optional string    `validate:"omitempty&minage:21"`
+	}
+	tests := []struct {
+		name    string
+		v       person
+		invalid []string
+	}{
+		{
+			name: "adult",
+			v:    person{Born: time.Date(2006, time.March, 15, 0, 0, 0, 0, time.UTC), BornDate: "2006-03-15"},
+		},
+		{
+			name:    "minor",
+			v:       person{Born: time.Date(2006, time.March, 16, 0, 0, 0, 0, time.UTC), BornDate: "2006-03-16", Optional: "2003-03-16"},
+			invalid: []string{"Born", "BornDate", "Optional"},
+		},
+		{
+			name:    "not a date",
+			v:       person{Born: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), BornDate: "15.03.2006"},
+			invalid: []string{"BornDate"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v, clock)
+			if tt.invalid == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.invalid, fieldsOf(errs))
+		})
+	}
+
+	// February 29 birthdays are reached on March 1 in common years.
+	now = time.Date(2022, time.February, 28, 12, 0, 0, 0, time.UTC)
+	assert.Error(t, Validate(person{Born: time.Date(2004, time.February, 29, 0, 0, 0, 0, time.UTC), BornDate: "2000-01-01"}, clock))
+
+	assert.Error(t, ValidateVar(18, "minage:18"))
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(struct {
+		Born     *time.Time `validate:"minage:18"`
+		BornDate *string    `validate:"minage:18"`
+	}{}, clock), &errs)
+	assert.Equal(t, []string{"Born", "BornDate"}, fieldsOf(errs))
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+
+	require.ErrorAs(t, ValidateVar("2000-01-01", "minage:x"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
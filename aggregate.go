@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// parseUniqueField parses the rule "uniquefield:Email", which requires the
+// structs of a slice to differ in the fields listed, all of them at once
+// when several are:
+//
+//	Users []User `validate:"uniquefield:Email"`
+//	Lines []Line `validate:"uniquefield:OrderID,Position"`
+//
+// Paths like "Address.Zip" reach the fields of nested structs. Empty values
+// are not compared, like NULLs in a unique index.
+func parseUniqueField(params string) (rule, error) {
+	fields := aggregateArgs(params)
+	for _, field := range fields {
+		if !tags.IsFieldPath(field) {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+	}
+	return rule{name: "uniquefield", argsStr: fields}, nil
+}
+
+// parseSumField parses the rule "sumfield:Amount,max:1000", which bounds the
+// sum of a field holding a number over the structs of a slice by min and
+// max rules, whose arguments may reference fields like those of max:
+//
+//	Lines []Line `validate:"sumfield:Amount,min:1,max:$CreditLimit"`
+func parseSumField(params string) (rule, error) {
+	args := aggregateArgs(params)
+	if !tags.IsFieldPath(args[0]) || len(args) < 2 {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	sum := rule{name: "sumfield", argsStr: args}
+	for _, arg := range args[1:] {
+		bound, err := parseValidator(arg)
+		if err != nil || bound.name != "min" && bound.name != "max" || len(bound.argsInt) != 1 {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+		sum.each = append(sum.each, bound)
+	}
+	return sum, nil
+}
+
+// aggregateArgs splits the arguments of an aggregate rule.
+func aggregateArgs(params string) []string {
+	args := tags.SplitArgs(params)
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+	return args
+}
+
+// isAggregate reports whether the rule name applies to the elements of a
+// slice as a whole.
+func isAggregate(name string) bool {
+	return name == "uniquefield" || name == "sumfield"
+}
+
+// validateAggregate applies the aggregate rule validator to the elements
+// of the slice value.
+func (s *validation) validateAggregate(validator rule, value reflect.Value) error {
+	if validator.name == "sumfield" {
+		return s.validateSum(validator, value)
+	}
+
+	seen := make(map[string]int, value.Len())
+	values := make([]any, len(validator.argsStr))
+	for i := 0; i < value.Len(); i++ {
+		empty := false
+		for j, path := range validator.argsStr {
+			field, err := aggregateValue(value.Index(i), path)
+			if err != nil {
+				return err
+			}
+			if empty = isEmpty(field, field); empty {
+				break
+			}
+			values[j] = field.Interface()
+		}
+		if empty {
+			continue
+		}
+		key := fmt.Sprintf("%#v", values)
+		if first, ok := seen[key]; ok {
+			return fmt.Errorf("%w: [%d] repeats %s of [%d]", ErrFieldNotValid, i, strings.Join(validator.argsStr, ","), first)
+		}
+		seen[key] = i
+	}
+	return nil
+}
+
+// validateSum checks the sum of the field of sumfield over the elements of
+// the slice value against its bounds.
+func (s *validation) validateSum(validator rule, value reflect.Value) error {
+	var sum float64
+	for i := 0; i < value.Len(); i++ {
+		field, err := aggregateValue(value.Index(i), validator.argsStr[0])
+		if err != nil {
+			return err
+		}
+		switch kindClass(field.Kind()) {
+		case reflect.Int:
+			sum += float64(field.Int())
+		case reflect.Uint:
+			sum += float64(field.Uint())
+		case reflect.Float64:
+			sum += field.Float()
+		case reflect.Invalid:
+			// Missing values add nothing.
+		default:
+			return fmt.Errorf("%w: field %s summed by a rule does not hold a number", ErrInvalidValidatorSyntax, validator.argsStr[0])
+		}
+	}
+	if err := s.validateValue(validator.each, reflect.Float64, reflect.ValueOf(sum)); err != nil {
+		return fmt.Errorf("%w: sum of %s is %v", ErrFieldNotValid, validator.argsStr[0], sum)
+	}
+	return nil
+}
+
+// aggregateValue returns the value of the field at path of the struct elem
+// holds, the invalid value when a pointer on the way is nil.
+func aggregateValue(elem reflect.Value, path string) (reflect.Value, error) {
+	raw, ok := fieldByKey(elem, path)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: %s of the elements", ErrUnknownField, path)
+	}
+	return customValue(raw)
+}
+
+// aggregateError returns an error when an aggregate rule of validators, the
+// rules of the field name of type fieldT, applies to a field that is not a
+// slice of structs or names a field its elements do not have.
+func aggregateError(fieldT reflect.Type, name string, validators []rule) error {
+	t := derefType(fieldT)
+	for _, validator := range validators {
+		if !isAggregate(validator.name) || t.Kind() == reflect.Interface {
+			continue
+		}
+		elemT := ruleType(t)
+		if t.Kind() != reflect.Slice || elemT.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: %s on %s", ErrRuleNotApplicable, validator.name, fieldT)
+		}
+		paths := validator.argsStr
+		if validator.name == "sumfield" {
+			paths = paths[:1]
+		}
+		for _, path := range paths {
+			first, _, _ := strings.Cut(path, ".")
+			if _, ok := structFieldIndex(elemT, first); !ok {
+				return fmt.Errorf("%w: %s of the elements of field %s", ErrUnknownField, first, name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAggregate(t *testing.T) {
+	type address struct{ Zip string }
+	type line struct {
+		SKU      string
+		Position int
+		Qty      uint
+		Price    float64
+		Address  *address
+	}
+	type order struct {
+		Limit int
+		Lines []line  `validate:"uniquefield:SKU,Position&sumfield:Qty,min:1,max:$Limit"`
+		Items []*line `validate:"uniquefield:Address.Zip&sumfield:Price,max:100"`
+		Tags  []line  `validate:"warn:uniquefield:SKU"`
+		Any   any     `validate:"omitempty&uniquefield:SKU"`
+	}
+
+	valid := order{
+		Limit: 10,
+		Lines: []line{{SKU: "a", Position: 1, Qty: 4}, {SKU: "a", Position: 2, Qty: 6}},
+		Items: []*line{nil, {Price: 50.5, Address: &address{Zip: "1"}}, {Price: 49.5}, {Address: &address{}}},
+		Tags:  []line{{SKU: "x"}, {SKU: ""}, {SKU: ""}},
+		Any:   []line{{SKU: "a"}},
+	}
+	result := ValidateResult(valid)
+	require.NoError(t, result.Err())
+	assert.Empty(t, result.Warnings())
+
+	tests := []struct {
+		name   string
+		change func(*order)
+		rule   string
+		err    string
+	}{
+		{"duplicate", func(o *order) { o.Lines[1].Position = 1 }, "uniquefield", "field not valid: [1] repeats SKU,Position of [0]"},
+		{"sum above", func(o *order) { o.Limit = 9 }, "sumfield", "field not valid: sum of Qty is 10"},
+		{"sum below", func(o *order) { o.Lines = nil }, "sumfield", "field not valid: sum of Qty is 0"},
+		{"nested duplicate", func(o *order) { o.Items[2].Address = &address{Zip: "1"} }, "uniquefield", "field not valid: [2] repeats Address.Zip of [1]"},
+		{"float sum", func(o *order) { o.Items[2].Price = 50 }, "sumfield", "field not valid: sum of Price is 100.5"},
+		{"interface", func(o *order) { o.Any = []line{{SKU: "a"}, {SKU: "a"}} }, "uniquefield", "field not valid: [1] repeats SKU of [0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			o.Lines = append([]line(nil), valid.Lines...)
+			o.Items = []*line{nil, {Price: 50.5, Address: &address{Zip: "1"}}, {Price: 49.5}, {Address: &address{}}}
+			tt.change(&o)
+			var errs ValidationErrors
+			require.ErrorAs(t, Validate(o), &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.rule, errs[0].Rule())
+			assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+			assert.EqualError(t, errs[0].Err.(*fieldError).err, tt.err)
+		})
+	}
+
+	valid.Tags = []line{{SKU: "x"}, {SKU: "x"}}
+	result = ValidateResult(valid)
+	require.NoError(t, result.Err())
+	assert.Equal(t, []string{"Tags"}, fieldsOf(result.Warnings()))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, ValidateVar([]line{{SKU: "a"}, {SKU: "a"}}, "uniquefield:SKU"), &errs)
+	assert.Equal(t, "uniquefield", errs[0].Rule())
+	require.ErrorAs(t, ValidateVar([]line{{}}, "uniquefield:Name"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+	for _, rules := range []string{"uniquefield:", "uniquefield:a b", "sumfield:Qty", "sumfield:Qty,len:1", "sumfield:Qty,max:x", "sumfield:1,max:1"} {
+		require.ErrorAs(t, ValidateVar([]line{}, rules), &errs, rules)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+
+	err := Lint(reflect.TypeOf(struct {
+		Lines []line   `validate:"uniquefield:Name"`
+		Line  line     `validate:"sumfield:Qty,max:1"`
+		Names []string `validate:"uniquefield:SKU"`
+	}{}))
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 3)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+	assert.ErrorIs(t, errs[1].Err, ErrRuleNotApplicable)
+	assert.ErrorIs(t, errs[2].Err, ErrRuleNotApplicable)
+
+	explained, err := ExplainRules(struct {
+		Lines []line `validate:"uniquefield:SKU&sumfield:Qty,min:1,max:10"`
+	}{}, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "Lines — with unique SKU, with Qty summing to between 1 and 10\n", explained)
+}
@@ -0,0 +1,73 @@
+package validator
+
+import "context"
+
+// BatchResult is the outcome of validating many values of the same type with
+// ValidateAll.
+type BatchResult struct {
+	// Errors holds the error of every value by its index, nil for the valid
+	// ones.
+	Errors []error
+	// Valid and Invalid count the values that are valid and not valid.
+	Valid   int
+	Invalid int
+}
+
+// ItemError is the error of a value at Index in a BatchResult.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+// Failures returns the errors of the first n values that are not valid, in
+// the order of the values, e.g. to report a sample of an import rejecting
+// thousands of rows. A negative n returns all of them.
+func (r BatchResult) Failures(n int) []ItemError {
+	var failures []ItemError
+	for i, err := range r.Errors {
+		if n >= 0 && len(failures) == n {
+			break
+		}
+		if err != nil {
+			failures = append(failures, ItemError{Index: i, Err: err})
+		}
+	}
+	return failures
+}
+
+// ValidateAll validates every value of items, e.g. the rows of an import, and
+// reports the results by index. The tags of the struct type T are parsed
+// once for all values, and errors of tags are returned like by Compile
+// instead of for every value.
+//
+//	result, err := validator.ValidateAll(rows)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Printf("%d rows valid, %d not", result.Valid, result.Invalid)
+//	for _, f := range result.Failures(10) {
+//		log.Printf("row %d: %v", f.Index, f.Err)
+//	}
+func ValidateAll[T any](items []T, opts ...Option) (BatchResult, error) {
+	t, err := Compile[T](opts...)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	return t.ValidateAll(items), nil
+}
+
+// ValidateAll validates every value of items like the package level
+// ValidateAll function.
+func (t *Typed[T]) ValidateAll(items []T) BatchResult {
+	result := BatchResult{Errors: make([]error, len(items))}
+	for i, item := range items {
+		err := t.ValidateCtx(context.Background(), item)
+		if err != nil {
+			result.Errors[i] = err
+			result.Invalid++
+		} else {
+			result.Valid++
+		}
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAll(t *testing.T) {
+	rows := []compiledUser{
+		{Name: "Ann", Age: 30},
+		{Name: "Bob", Age: 17},
+		{Age: 40},
+		{Name: "Eve", Age: 25},
+	}
+
+	result, err := ValidateAll(rows)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Valid)
+	assert.Equal(t, 2, result.Invalid)
+	require.Len(t, result.Errors, 4)
+	assert.NoError(t, result.Errors[0])
+	assert.EqualError(t, result.Errors[1], "field: Age not valid for min:18")
+	assert.EqualError(t, result.Errors[2], "field: Name not valid for required&max:32")
+	assert.NoError(t, result.Errors[3])
+
+	assert.Equal(t, []ItemError{{Index: 1, Err: result.Errors[1]}}, result.Failures(1))
+	assert.Len(t, result.Failures(-1), 2)
+	assert.Nil(t, result.Failures(0))
+
+	empty, err := ValidateAll([]compiledUser(nil))
+	require.NoError(t, err)
+	assert.Equal(t, BatchResult{Errors: []error{}}, empty)
+}
+
+func TestValidateAllErrors(t *testing.T) {
+	_, err := ValidateAll([]int{1, 2})
+	assert.ErrorIs(t, err, ErrNotStruct)
+
+	type row struct {
+		Name string `validate:"max:x"`
+	}
+	_, err = ValidateAll([]row{{}})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
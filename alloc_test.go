@@ -0,0 +1,104 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type allocUser struct {
+	Name    string   `validate:"required&min:2&max:32"`
+	Email   string   `validate:"required&email"`
+	Age     int      `validate:"min:18&max:120"`
+	Role    string   `validate:"in:admin,user"`
+	Tags    []string `validate:"max:10"`
+	Address Address
+	Manager *Base
+}
+
+func validAllocUser() allocUser {
+	return allocUser{
+		Name:    "Ann",
+		Email:   "ann@example.com",
+		Age:     30,
+		Role:    "admin",
+		Tags:    []string{"a", "b"},
+		Address: Address{City: "Moscow", Zip: "123456"},
+		Manager: &Base{ID: 1, Name: "Bob"},
+	}
+}
+
+func TestValidateDoesNotAllocate(t *testing.T) {
+	var v any = validAllocUser()
+	typed, err := Compile[allocUser]()
+	assert.NoError(t, err)
+	u := validAllocUser()
+	assert.NoError(t, Validate(v))
+
+	tests := []struct {
+		name   string
+		fn     func()
+		allocs float64
+	}{
+		{name: "Validate", fn: func() { _ = Validate(v) }},
+		// Typed.Validate takes T by value, passing it to reflect boxes it.
+		{name: "Typed.Validate", fn: func() { _ = typed.Validate(u) }, allocs: 1},
+		{name: "ValidateVar", fn: func() { _ = ValidateVar(u.Age, "min:18&max:120") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.allocs, testing.AllocsPerRun(100, tt.fn))
+		})
+	}
+}
+
+func TestValidateInvalidSharesErrors(t *testing.T) {
+	var v any = allocUser{Name: "A", Email: "ann@example.com", Age: 30, Address: Address{City: "Moscow", Zip: "123456"}}
+	first, second := Validate(v).(ValidationErrors), Validate(v).(ValidationErrors)
+	require.Len(t, first, 2)
+	assert.Same(t, first[0].Err, second[0].Err)
+	assert.Equal(t, "field: Name not valid for required&min:2&max:32", first[0].Err.Error())
+	assert.Equal(t, "min", first[0].Rule())
+	assert.Equal(t, "in", first[1].Rule())
+
+	// Only the slice of the errors, grown once, and its conversion to error
+	// allocate.
+	assert.Equal(t, 3.0, testing.AllocsPerRun(100, func() { _ = Validate(v) }))
+
+	nested := Validate(allocUser{Name: "Ann", Email: "ann@example.com", Age: 30, Role: "user", Address: Address{City: "M", Zip: "123456"}}).(ValidationErrors)
+	require.Len(t, nested, 1)
+	assert.Equal(t, "field: Address.City not valid for min:2", nested[0].Err.Error())
+}
+
+func BenchmarkValidateValid(b *testing.B) {
+	var v any = validAllocUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Validate(v)
+	}
+}
+
+func BenchmarkValidateInvalid(b *testing.B) {
+	var v any = allocUser{Address: Address{City: "M"}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Validate(v)
+	}
+}
+
+func BenchmarkTypedValidate(b *testing.B) {
+	typed, _ := Compile[allocUser]()
+	u := validAllocUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = typed.Validate(u)
+	}
+}
+
+func BenchmarkValidateVar(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateVar(30, "min:18&max:120")
+	}
+}
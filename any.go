@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// ValidateAny validates v whatever its type, for code like middleware that
+// does not know the types of the values it is given. The rules, written like
+// those of ValidateVar and possibly "", apply to v itself, then the fields of
+// a struct v, of the struct v points to, or of the structs of a slice, an
+// array or a map v are validated like by Validate:
+//
+//	err := validator.ValidateAny(req, "required")
+//
+// A nil v or nil pointer is only checked against the rules, so it is valid
+// unless they require a value. Any other value without fields, like a
+// string or a slice of strings, is only checked against the rules too.
+// Errors of the rules have the field "".
+func ValidateAny(v any, rules string, opts ...Option) error {
+	var validators []rule
+	if rules != "" {
+		var err error
+		if validators, err = cachedValidators(rules); err != nil {
+			return ValidationErrors{{err}}
+		}
+	}
+
+	s := validation{ctx: context.Background(), config: newConfig(opts)}
+	valueV := reflect.ValueOf(v)
+	s.checkVar("", rules, validators, reflect.Value{}, valueV)
+	if s.err == nil {
+		s.validateAnyFields(valueV)
+	}
+	return s.result()
+}
+
+// validateAnyFields validates the fields of the struct valueV, or of the
+// struct it points to, or of the structs of the collection valueV.
+func (s *validation) validateAnyFields(valueV reflect.Value) {
+	defer s.recoverPanic()
+	if structV, ok := nestedStruct(valueV); ok {
+		s.validateStruct(fieldPath{}, structV, true)
+		return
+	}
+	for valueV.Kind() == reflect.Pointer || valueV.Kind() == reflect.Interface {
+		if valueV.IsNil() {
+			return
+		}
+		valueV = valueV.Elem()
+	}
+	if valueV.IsValid() && structElems(valueV.Type()) {
+		s.validateElems(fieldPath{}.child(""), valueV, true)
+	}
+}
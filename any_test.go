@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAny(t *testing.T) {
+	var nilBase *Base
+
+	tests := []struct {
+		name   string
+		v      any
+		rules  string
+		fields []string
+	}{
+		{name: "nil", v: nil, rules: ""},
+		{name: "nil required", v: nil, rules: "required", fields: []string{""}},
+		{name: "nil pointer", v: nilBase, rules: "omitempty"},
+		{name: "nil pointer required", v: nilBase, rules: "required", fields: []string{""}},
+		{name: "scalar", v: "ab", rules: "min:3", fields: []string{""}},
+		{name: "scalar valid", v: 5, rules: "min:3"},
+		{name: "strings", v: []string{"a"}, rules: "min:2", fields: []string{""}},
+		{name: "struct", v: Base{}, rules: "", fields: []string{"ID", "Name"}},
+		{name: "struct pointer", v: &Base{ID: 1, Name: "x"}, rules: "required"},
+		{name: "structs", v: []Base{{ID: 1, Name: "x"}, {Name: "y"}}, rules: "min:3", fields: []string{"", "[1].ID"}},
+		{name: "map of structs", v: map[string]*Base{"a": {ID: 1}}, fields: []string{"[a].Name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAny(tt.v, tt.rules)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.fields, fieldsOf(errs))
+		})
+	}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, ValidateAny(1, "min:x"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
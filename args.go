@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// ArgCheck is a check of a function argument, see Check.
+type ArgCheck struct {
+	name  string
+	value any
+	rules string
+}
+
+// Check checks the argument name of a function against rules written in the
+// syntax of the validate tag, reporting errors for the field name:
+//
+//	func Transfer(from, to string, amount int) error {
+//		if err := validator.All(
+//			validator.Check("from", from, "required&ulid"),
+//			validator.Check("to", to, "required&ulid"),
+//			validator.Check("amount", amount, "min:1"),
+//		); err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The check is done by All or by Err.
+func Check(name string, value any, rules string) ArgCheck {
+	return ArgCheck{name: name, value: value, rules: rules}
+}
+
+// Err does the check alone, like All with a single check.
+func (c ArgCheck) Err() error {
+	return All(c)
+}
+
+// All does the checks and returns ValidationErrors for the arguments that
+// are not valid and rules that cannot be parsed, in the order of the checks.
+// Like ValidateVar, it validates structs passed as arguments only against
+// their own rules, not the rules of their fields.
+func All(checks ...ArgCheck) error {
+	s := validation{ctx: context.Background()}
+	for _, c := range checks {
+		validators, err := cachedValidators(c.rules)
+		if err != nil {
+			s.errors = append(s.errors, ValidationError{err})
+			continue
+		}
+		s.checkVar(c.name, c.rules, validators, reflect.Value{}, reflect.ValueOf(c.value))
+	}
+	return s.result()
+}
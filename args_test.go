@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	assert.NoError(t, All(
+		Check("from", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "required&ulid"),
+		Check("amount", 10, "min:1"),
+	))
+	assert.NoError(t, All())
+
+	err := All(
+		Check("from", "", "required&ulid"),
+		Check("to", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "required&ulid"),
+		Check("amount", 0, "min:1"),
+		Check("note", "x", "max:y"),
+	)
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "from", errs[0].Field())
+	assert.Equal(t, "required", errs[0].Rule())
+	assert.Equal(t, "amount", errs[1].Field())
+	assert.Equal(t, "min", errs[1].Rule())
+	assert.ErrorIs(t, errs[2].Err, ErrInvalidValidatorSyntax)
+	assert.Equal(t, "field: from not valid for required&ulid", errs[0].Err.Error())
+}
+
+func TestCheckErr(t *testing.T) {
+	assert.NoError(t, Check("id", 5, "min:1").Err())
+	assert.Error(t, Check("id", 0, "min:1").Err())
+
+	errTaken := errors.New("taken")
+	RegisterRule("checkedfree", func(ctx context.Context, field reflect.Value, params []string) error {
+		return errTaken
+	})
+	t.Cleanup(func() {
+		delete(customRules, "checkedfree")
+		resetPlanCache()
+	})
+	var errs ValidationErrors
+	require.ErrorAs(t, Check("login", "ann", "checkedfree").Err(), &errs)
+	assert.ErrorIs(t, errs[0].Err, errTaken)
+}
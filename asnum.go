@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// asNumber reports whether validators have the asnum rule, which makes the
+// rules of a string field apply to the number it holds, e.g. for query
+// parameters and environment variables:
+//
+//	Port string `validate:"asnum&min:1&max:65535"`
+//
+// Strings that are not numbers fail asnum. The rules of a slice apply to the
+// numbers of its elements. parseValidators puts asnum in front, after groups
+// and astext, so the textual form of a value can be read as a number too.
+func asNumber(validators []rule) bool {
+	for _, validator := range validators {
+		switch validator.name {
+		case "asnum":
+			return true
+		case "groups", "astext":
+			continue
+		}
+		return false
+	}
+	return false
+}
+
+// numberValue returns the number the string field holds, an int64 for
+// integers and a float64 for other numbers. ok is false when field holds no
+// finite number. Values of other kinds are returned as they are.
+func numberValue(field reflect.Value) (number reflect.Value, ok bool) {
+	if field.Kind() != reflect.String {
+		return field, true
+	}
+	s := field.String()
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return reflect.ValueOf(n), true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(f), true
+}
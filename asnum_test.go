@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAsNumber(t *testing.T) {
+	type query struct {
+		Port    string            `validate:"min:1&asnum&max:65535"`
+		Ratio   string            `validate:"omitempty&asnum&min:0&max:1"`
+		Pages   []string          `validate:"asnum&in:1,2,3"`
+		Limits  map[string]string `validate:"values:(asnum&min:0)"`
+		Retries *string           `validate:"omitempty&asnum&max:5"`
+		Level   string            `validate:"asnum&warn:max:10"`
+	}
+
+	retries := "3"
+	valid := query{Port: "8080", Ratio: "0.5", Pages: []string{"1", "3"}, Limits: map[string]string{"cpu": "2"}, Retries: &retries, Level: "7"}
+	result := ValidateResult(valid)
+	require.NoError(t, result.Err())
+	assert.Empty(t, result.Warnings())
+	assert.NoError(t, Validate(query{Port: "1", Level: "-1"}))
+
+	tests := []struct {
+		name   string
+		change func(*query)
+		rule   string
+	}{
+		{"out of range", func(q *query) { q.Port = "65536" }, "max"},
+		{"not a number", func(q *query) { q.Port = "80a" }, "asnum"},
+		{"empty", func(q *query) { q.Port = "" }, "asnum"},
+		{"spaces", func(q *query) { q.Port = " 80" }, "asnum"},
+		{"float", func(q *query) { q.Ratio = "1.5" }, "max"},
+		{"not finite", func(q *query) { q.Ratio = "NaN" }, "asnum"},
+		{"slice element", func(q *query) { q.Pages = []string{"1", "4"} }, "in"},
+		{"slice element not a number", func(q *query) { q.Pages = []string{"x"} }, "asnum"},
+		{"map value", func(q *query) { q.Limits = map[string]string{"cpu": "-1"} }, "min"},
+		{"pointer", func(q *query) { retries := "6"; q.Retries = &retries }, "max"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := valid
+			tt.change(&q)
+			var errs ValidationErrors
+			require.ErrorAs(t, Validate(q), &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.rule, errs[0].Rule())
+		})
+	}
+
+	valid.Level = "11"
+	assert.Equal(t, []string{"Level"}, fieldsOf(ValidateResult(valid).Warnings()))
+
+	assert.NoError(t, ValidateVar("9223372036854775807", "asnum&min:1"))
+	assert.NoError(t, ValidateVar("1e3", "asnum&min:1000&max:1000"))
+	assert.Error(t, ValidateVar("1e400", "asnum"))
+
+	err := Lint(reflect.TypeOf(struct {
+		Port  string `validate:"asnum&min:1"`
+		Count int    `validate:"asnum&min:1"`
+		Email string `validate:"asnum&email"`
+	}{}))
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0].Err, ".Count: rule does not apply to the field type: asnum on int")
+	assert.ErrorIs(t, errs[1].Err, ErrRuleNotApplicable)
+
+	explained, err := ExplainRules(struct {
+		Port string `validate:"asnum&min:1&max:65535"`
+	}{}, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "Port — a number, between 1 and 65535\n", explained)
+}
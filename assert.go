@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// parseAssert parses the argument of the assert rule, a comparison of
+// arithmetic expressions over the numeric fields of the struct holding the
+// field, for the consistency of amounts checked by struct-level validations
+// otherwise:
+//
+//	type Invoice struct {
+//		Subtotal float64
+//		Tax      float64
+//		Discount float64 `validate:"assert:Discount<=Subtotal"`
+//		Total    float64 `validate:"assert:Total==Subtotal+Tax-Discount"`
+//	}
+//
+// Expressions are made of numbers, field paths like "Shipping.Cost", the
+// operators "+", "-", "*" and "/", and parentheses, compared with "==",
+// "!=", "<", "<=", ">" or ">=". Numbers are equal when they differ by no
+// more than a billionth of the larger one, so float amounts compare as
+// expected. Fields hold numbers of numeric kinds, or values turned into them
+// by RegisterCustomType, and nil pointers count as 0. Like exclusive rules,
+// assertions apply to empty fields too.
+func parseAssert(params string) (rule, error) {
+	a, err := tags.ParseAssertion(params)
+	if err != nil {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	refs := a.Fields()
+	return rule{name: "assert", argsStr: []string{strings.TrimSpace(params)}, argsInt: make([]int, len(refs)), refs: refs, assertion: &a}, nil
+}
+
+// refNumber returns the number held by the field at path in root, 0 for a
+// nil pointer, for assertions.
+func refNumber(root reflect.Value, path string) (float64, error) {
+	raw, ok := reflect.Value{}, false
+	if root.IsValid() {
+		raw, ok = fieldByKey(root, path)
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s referenced by a rule", ErrUnknownField, path)
+	}
+	field, err := customValue(raw)
+	if err != nil {
+		return 0, err
+	}
+	if !field.IsValid() {
+		return 0, nil
+	}
+	switch kindClass(field.Kind()) {
+	case reflect.Int:
+		return float64(field.Int()), nil
+	case reflect.Uint:
+		return float64(field.Uint()), nil
+	case reflect.Float64:
+		return field.Float(), nil
+	}
+	return 0, fmt.Errorf("%w: field %s referenced by a rule does not hold a number", ErrInvalidValidatorSyntax, path)
+}
+
+// assertionHolds reports whether the assertion of validator holds for the
+// numbers withRefs read from the fields it references.
+func assertionHolds(validator rule) bool {
+	return validator.assertion.Holds(func(field string) float64 {
+		for i, ref := range validator.refs {
+			if ref == field {
+				return validator.nums[i]
+			}
+		}
+		return 0
+	})
+}
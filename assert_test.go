@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAssert(t *testing.T) {
+	type shipping struct {
+		Cost *float64
+	}
+	type invoice struct {
+		Subtotal float64
+		Tax      float64
+		Discount uint    `validate:"assert:Discount<=Subtotal"`
+		Total    float64 `validate:"assert:Total == Subtotal + Tax - Discount + Shipping.Cost"`
+		Shipping shipping
+		Lines    int `validate:"assert:Lines*(Subtotal/Lines)==Subtotal"`
+	}
+	cost := 5.0
+	tests := []struct {
+		name    string
+		v       invoice
+		invalid []string
+	}{
+		{
+			name: "consistent",
+			v:    invoice{Subtotal: 0.1, Tax: 0.2, Total: 0.3, Lines: 3},
+		},
+		{
+			name: "with shipping",
+			v:    invoice{Subtotal: 100, Tax: 20, Discount: 10, Total: 115, Shipping: shipping{Cost: &cost}, Lines: 1},
+		},
+		{
+			name:    "wrong total",
+			v:       invoice{Subtotal: 100, Tax: 20, Total: 100, Lines: 1},
+			invalid: []string{"Total"},
+		},
+		{
+			name:    "empty fields",
+			v:       invoice{Subtotal: 100, Discount: 200},
+			invalid: []string{"Discount", "Total", "Lines"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.invalid == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.invalid, fieldsOf(errs))
+			assert.Equal(t, "assert", errs[0].Rule())
+		})
+	}
+
+	type unknown struct {
+		Total float64 `validate:"assert:Total==Sum"`
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(unknown{}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+
+	type text struct {
+		Name  string
+		Total float64 `validate:"assert:Total==Name"`
+	}
+	require.ErrorAs(t, Validate(text{}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+
+	require.ErrorAs(t, ValidateVar(1, "assert:A=="), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// asText reports whether validators have the astext rule, which makes the
+// rules of a field apply to its textual form, from MarshalText or else
+// String, e.g. for custom ID or decimal types:
+//
+//	ID OrderID `validate:"astext&len:12"`
+//
+// The rules of a slice without these methods apply to the textual forms of
+// its elements. parseValidators puts astext in front, after groups.
+func asText(validators []rule) bool {
+	if len(validators) != 0 && validators[0].name == "groups" {
+		validators = validators[1:]
+	}
+	return len(validators) != 0 && validators[0].name == "astext"
+}
+
+// textValue returns the textual form of field as a string value. ok is false
+// when field has neither a MarshalText nor a String method. A nil pointer
+// or interface has the invalid value.
+func textValue(field reflect.Value) (text reflect.Value, ok bool, err error) {
+	for field.IsValid() {
+		if (field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface) && field.IsNil() {
+			return reflect.Value{}, true, nil
+		}
+		if field.Kind() == reflect.Interface {
+			field = field.Elem()
+			continue
+		}
+		if field.CanInterface() && hasText(field.Type()) {
+			// The methods of a pointer include those of its value.
+			v := field
+			if field.Kind() != reflect.Pointer {
+				if field.CanAddr() {
+					v = field.Addr()
+				} else {
+					v = reflect.New(field.Type())
+					v.Elem().Set(field)
+				}
+			}
+			s, err := marshalText(v.Interface())
+			return reflect.ValueOf(s), true, err
+		}
+		if field.Kind() != reflect.Pointer {
+			break
+		}
+		field = field.Elem()
+	}
+	return field, false, nil
+}
+
+// hasText reports whether values of type t, or pointers to them, have a
+// textual form.
+func hasText(t reflect.Type) bool {
+	for _, iface := range []reflect.Type{textMarshalerType, stringerType} {
+		if t.Implements(iface) || reflect.PointerTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// marshalText returns the textual form of v, which has one by hasText.
+func marshalText(v any) (string, error) {
+	if m, ok := v.(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		return string(b), err
+	}
+	return v.(fmt.Stringer).String(), nil
+}
+
+// errNoText is the error of the astext rule for values without a textual
+// form.
+func errNoText(t reflect.Type) error {
+	return fmt.Errorf("%w: astext on %s", ErrRuleNotApplicable, t)
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type textOrderID int
+
+func (id textOrderID) String() string { return fmt.Sprintf("ORD-%06d", int(id)) }
+
+type textDecimal struct{ cents int }
+
+func (d *textDecimal) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d", d.cents/100, d.cents%100)), nil
+}
+
+func TestValidateAsText(t *testing.T) {
+	type order struct {
+		ID       textOrderID            `validate:"len:10&astext"`
+		Price    textDecimal            `validate:"astext&regexp:^[0-9]+\\.[0-9]{2}$&max:6"`
+		Refs     []textOrderID          `validate:"astext&in:ORD-000001,ORD-000002"`
+		Parent   *textOrderID           `validate:"omitempty&astext&len:10"`
+		Addr     net.IP                 `validate:"astext&in:127.0.0.1"`
+		Labels   map[textOrderID]string `validate:"keys:astext&len:10,endkeys"`
+		Reviewer textOrderID            `validate:"astext&warn:in:ORD-000001"`
+	}
+
+	valid := order{
+		ID:       1,
+		Price:    textDecimal{cents: 1250},
+		Refs:     []textOrderID{1, 2},
+		Addr:     net.IPv4(127, 0, 0, 1),
+		Labels:   map[textOrderID]string{3: "a"},
+		Reviewer: 1,
+	}
+	result := ValidateResult(valid)
+	require.NoError(t, result.Err())
+	assert.Empty(t, result.Warnings())
+
+	tests := []struct {
+		name   string
+		change func(*order)
+		field  string
+	}{
+		{"stringer", func(o *order) { o.ID = 1234567 }, "ID"},
+		{"text marshaler", func(o *order) { o.Price.cents = 123456 }, "Price"},
+		{"slice element", func(o *order) { o.Refs = append(o.Refs, 3) }, "Refs"},
+		{"pointer", func(o *order) { parent := textOrderID(12345678); o.Parent = &parent }, "Parent"},
+		{"slice with text", func(o *order) { o.Addr = net.IPv4(10, 0, 0, 1) }, "Addr"},
+		{"map key", func(o *order) { o.Labels[10000000] = "b" }, "Labels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := valid
+			o.Labels = map[textOrderID]string{3: "a"}
+			tt.change(&o)
+			err := Validate(&o)
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.field, errs[0].Field())
+		})
+	}
+
+	valid.Reviewer = 2
+	assert.Len(t, ValidateResult(valid).Warnings(), 1)
+
+	err := ValidateVar(42, "astext&len:2")
+	require.Error(t, err)
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrRuleNotApplicable)
+
+	err = Lint(reflect.TypeOf(struct {
+		ID    textOrderID `validate:"astext&len:10"`
+		Count int         `validate:"astext&len:2"`
+	}{}))
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "Count: rule does not apply to the field type: astext on int")
+	assert.Len(t, err.(ValidationErrors), 1)
+}
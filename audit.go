@@ -0,0 +1,280 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// Report lists what the validation of a struct type does not cover, see
+// Audit.
+type Report struct {
+	// Findings are the problems found, in the order of the fields.
+	Findings []Finding
+	// Err is ErrNotStruct when the audited value is not a struct.
+	Err error
+}
+
+// Finding is a field of an audited struct the validation does not fully
+// cover.
+type Finding struct {
+	// Field is the path of the field, e.g. "Address.Zip", or "Items.SKU" for
+	// the structs in slices, arrays and maps, like for Describe.
+	Field    string
+	Type     reflect.Type
+	Problem  Problem
+	Severity Severity
+	// Detail tells what is wrong in a sentence, e.g. "unexported field with
+	// rules in its tag".
+	Detail string
+}
+
+// Problem is the kind of a Finding.
+type Problem int
+
+const (
+	// UnsupportedKind is a field whose values no built-in rule can check,
+	// like a channel or a complex number, beyond required and notnil.
+	UnsupportedKind Problem = iota
+	// IgnoredStruct is a field holding a struct with rules in its fields
+	// that are never checked, e.g. because the field is unexported or
+	// tagged structonly.
+	IgnoredStruct
+	// UnexportedRules is an unexported field with rules in its tag.
+	UnexportedRules
+	// InvalidRules is a field whose tag cannot be applied, as reported by
+	// Validate.
+	InvalidRules
+)
+
+var problemNames = [...]string{"unsupported kind", "ignored struct", "unexported rules", "invalid rules"}
+
+func (p Problem) String() string {
+	if p < 0 || int(p) >= len(problemNames) {
+		return fmt.Sprintf("Problem(%d)", int(p))
+	}
+	return problemNames[p]
+}
+
+// Severity tells how much a Finding matters.
+type Severity int
+
+const (
+	// SeverityInfo is a field the validation covers less than it may seem,
+	// as intended, like a struct tagged structonly.
+	SeverityInfo Severity = iota
+	// SeverityWarning is a field whose rules are silently not checked.
+	SeverityWarning
+	// SeverityError is a field failing every validation, whatever its value.
+	SeverityError
+)
+
+var severityNames = [...]string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// String returns the findings one per line, like
+// "warning: Address: ignored struct: unexported field holding a struct with rules".
+func (r Report) String() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	var sb strings.Builder
+	for _, f := range r.Findings {
+		fmt.Fprintf(&sb, "%s: %s: %s: %s\n", f.Severity, f.Field, f.Problem, f.Detail)
+	}
+	return sb.String()
+}
+
+// Max returns the highest severity of the findings, SeverityInfo without
+// any.
+func (r Report) Max() Severity {
+	max := SeverityInfo
+	for _, f := range r.Findings {
+		if f.Severity > max {
+			max = f.Severity
+		}
+	}
+	return max
+}
+
+// Audit walks the struct type of v, which may also be a pointer to a
+// struct, and the structs nested in it, and reports what their validation
+// does not cover, so teams adopting the validator see what is checked in
+// their models:
+//
+//	report := validator.Audit(User{})
+//	for _, f := range report.Findings {
+//		log.Printf("%s %s: %s", f.Severity, f.Field, f.Detail)
+//	}
+//
+// It lists every field whose kind no built-in rule supports, every field
+// holding a struct with rules that are never checked, every unexported field
+// with rules and every tag that cannot be applied. Their severity depends on
+// opts, e.g. unexported fields with rules are errors by default, warnings
+// with SkipUnexported. Fields whose values are known at run time only, like
+// interfaces, custom types and driver.Valuer implementations, are not
+// listed.
+func Audit(v any, opts ...Option) Report {
+	typeV := reflect.TypeOf(v)
+	for typeV != nil && typeV.Kind() == reflect.Pointer {
+		typeV = typeV.Elem()
+	}
+	if typeV == nil || typeV.Kind() != reflect.Struct {
+		return Report{Err: ErrNotStruct}
+	}
+
+	a := auditor{config: newConfig(opts), visiting: map[reflect.Type]bool{}}
+	a.audit("", typeV)
+	return Report{Findings: a.findings}
+}
+
+type auditor struct {
+	config
+	findings []Finding
+	// visiting holds the struct types being audited, as types may refer to
+	// themselves.
+	visiting map[reflect.Type]bool
+}
+
+// audit adds the findings of the fields of the struct type typeV at the path
+// prefix.
+func (a *auditor) audit(prefix string, typeV reflect.Type) {
+	if a.visiting[typeV] {
+		return
+	}
+	a.visiting[typeV] = true
+	defer delete(a.visiting, typeV)
+
+	plan := a.cachedPlan(typeV)
+	plans := make(map[int]*fieldPlan, len(plan.fields))
+	for i := range plan.fields {
+		plans[plan.fields[i].index] = &plan.fields[i]
+	}
+
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+		f := plans[i]
+		name := fieldT.Name
+		if f != nil {
+			name = f.name
+		}
+		path := joinPath(prefix, name)
+		if f == nil && fieldRules(typeV, fieldT, a.tagKey()) == "-" {
+			continue
+		}
+		add := func(problem Problem, severity Severity, detail string) {
+			a.findings = append(a.findings, Finding{Field: path, Type: fieldT.Type, Problem: problem, Severity: severity, Detail: detail})
+		}
+
+		if f != nil && f.tagged {
+			switch err := a.fieldErr(f); {
+			case f.unexported:
+				severity, detail := SeverityError, "unexported field with rules in its tag, failing every validation"
+				switch a.unexported {
+				case SkipUnexported:
+					severity, detail = SeverityWarning, "unexported field with rules in its tag, which are skipped"
+				case ValidateUnexportedUnsafe:
+					severity, detail = SeverityInfo, "unexported field with rules in its tag, read with package unsafe"
+				}
+				add(UnexportedRules, severity, detail)
+				if err != nil && a.unexported == ValidateUnexportedUnsafe {
+					add(InvalidRules, SeverityError, err.Error())
+				}
+			case err != nil:
+				add(InvalidRules, SeverityError, err.Error())
+			}
+		}
+
+		if kind, ok := ruleKind(fieldT.Type); ok && (fieldT.IsExported() || f != nil && f.tagged) && kind != reflect.Struct && !kindChecked(kind) {
+			if f != nil && f.tagged && !a.skips(f) {
+				add(UnsupportedKind, SeverityWarning, fmt.Sprintf("no rule but required and notnil checks values of kind %s", kind))
+			} else {
+				add(UnsupportedKind, SeverityInfo, fmt.Sprintf("no rule checks values of kind %s", kind))
+			}
+		}
+
+		nestedT := fieldT.Type
+		if isCollection(nestedT) {
+			nestedT = nestedT.Elem()
+		}
+		nestedT = derefType(nestedT)
+		if nestedT.Kind() != reflect.Struct || !describesNested(nestedT) {
+			continue
+		}
+		switch {
+		case f == nil || !f.descend:
+			if a.hasRules(nestedT, map[reflect.Type]bool{}) {
+				detail := "unexported field holding a struct with rules"
+				severity := SeverityWarning
+				if f != nil && hasValidator(f.validators, "structonly") {
+					severity, detail = SeverityInfo, "field tagged structonly holding a struct with rules"
+				}
+				add(IgnoredStruct, severity, detail)
+			}
+		case !a.recurses(nestedT):
+			if a.hasRules(nestedT, map[reflect.Type]bool{}) {
+				add(IgnoredStruct, SeverityInfo, fmt.Sprintf("struct %s with rules not recursed into by the options", nestedT))
+			}
+		default:
+			if f.anonymous && !f.elems && a.embeddedNaming == FlattenEmbedded {
+				path = prefix
+			}
+			a.audit(path, nestedT)
+		}
+	}
+}
+
+// tagKey returns the key of the tags holding the rules.
+func (a *auditor) tagKey() string {
+	if a.tagName != "" {
+		return a.tagName
+	}
+	return defaultTagName
+}
+
+// kindChecked reports whether a built-in rule checking values, not only
+// whether they are set, applies to values of kind.
+func kindChecked(kind reflect.Kind) bool {
+	for _, name := range tags.Builtins() {
+		r := rule{name: name}
+		// Rules applying to every kind only control the validation or check
+		// whether values are set, or are enums of any kind.
+		if !r.appliesTo(reflect.Invalid) && r.appliesTo(kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRules reports whether the struct type typeV or the structs nested in it
+// have fields with rules.
+func (a *auditor) hasRules(typeV reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[typeV] {
+		return false
+	}
+	seen[typeV] = true
+	for _, f := range a.cachedPlan(typeV).fields {
+		if f.tagged {
+			return true
+		}
+		if !f.descend {
+			continue
+		}
+		nestedT := typeV.Field(f.index).Type
+		if f.elems {
+			nestedT = nestedT.Elem()
+		}
+		if nestedT = derefType(nestedT); nestedT.Kind() == reflect.Struct && describesNested(nestedT) && a.hasRules(nestedT, seen) {
+			return true
+		}
+	}
+	return false
+}
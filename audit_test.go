@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type auditAddress struct {
+	Zip string `validate:"len:5"`
+}
+
+type auditBase struct {
+	ID string `validate:"required"`
+}
+
+type auditUser struct {
+	auditBase
+	Name     string `validate:"required"`
+	Updates  chan int
+	Ratio    complex128 `validate:"required"`
+	Address  auditAddress
+	Billing  auditAddress `validate:"structonly"`
+	Previous []*auditAddress
+	Note     sql.NullString
+	Any      any
+	Ignored  auditAddress `validate:"-"`
+	Broken   string       `validate:"min:x"`
+	home     auditAddress
+	secret   string `validate:"min:8"`
+	done     chan struct{}
+	Self     *auditUser
+}
+
+func TestAudit(t *testing.T) {
+	report := Audit(&auditUser{})
+	assert.NoError(t, report.Err)
+
+	type finding struct {
+		field    string
+		problem  Problem
+		severity Severity
+	}
+	var got []finding
+	for _, f := range report.Findings {
+		got = append(got, finding{f.Field, f.Problem, f.Severity})
+	}
+	assert.Equal(t, []finding{
+		{"Updates", UnsupportedKind, SeverityInfo},
+		{"Ratio", UnsupportedKind, SeverityWarning},
+		{"Billing", IgnoredStruct, SeverityInfo},
+		{"Broken", InvalidRules, SeverityError},
+		{"home", IgnoredStruct, SeverityWarning},
+		{"secret", UnexportedRules, SeverityError},
+	}, got)
+	assert.Equal(t, reflect.TypeOf(complex128(0)), report.Findings[1].Type)
+	assert.Equal(t, SeverityError, report.Max())
+	assert.Contains(t, report.String(), "info: Updates: unsupported kind: no rule checks values of kind chan\n")
+
+	skipped := Audit(auditUser{}, WithUnexportedPolicy(SkipUnexported), WithNoRecurse(auditAddress{}))
+	var problems []string
+	for _, f := range skipped.Findings {
+		problems = append(problems, f.Field+" "+f.Severity.String()+" "+f.Problem.String())
+	}
+	assert.Equal(t, []string{
+		"Updates info unsupported kind",
+		"Ratio warning unsupported kind",
+		"Address info ignored struct",
+		"Billing info ignored struct",
+		"Previous info ignored struct",
+		"Broken error invalid rules",
+		"home warning ignored struct",
+		"secret warning unexported rules",
+	}, problems)
+
+	assert.ErrorIs(t, Audit(1).Err, ErrNotStruct)
+	assert.Equal(t, "Problem(7)", Problem(7).String())
+}
@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+var ErrUnknownField = errors.New("unknown field")
+
+// Rule is a single validation rule built in code instead of a struct tag,
+// described by Describe or passed to the functions of RegisterRuleCheck and
+// RegisterJSONSchema. The rules package provides constructors for all built-in
+// rules.
+type Rule struct {
+	Name   string
+	Params []string
+}
+
+// String returns the rule in struct tag syntax, e.g. "min:18", or
+// "(email|len:0)" for a rule named or with the alternatives as parameters.
+func (r Rule) String() string {
+	if len(r.Params) == 0 {
+		return r.Name
+	}
+	if r.Name == "or" {
+		return "(" + strings.Join(r.Params, "|") + ")"
+	}
+	return r.Name + ":" + strings.Join(r.Params, ",")
+}
+
+// Param returns the parameter i of the rule, the empty Param when it has
+// fewer parameters, like a parameter left out in a tag.
+func (r Rule) Param(i int) Param {
+	if i < 0 || i >= len(r.Params) {
+		return ""
+	}
+	return Param(r.Params[i])
+}
+
+// Param is a parameter of a Rule as written in a tag, e.g. "18" for
+// min:18, read as the type the rule expects by its methods. Their errors wrap
+// ErrInvalidValidatorSyntax.
+type Param string
+
+// String returns the parameter as written, without surrounding spaces.
+func (p Param) String() string {
+	return strings.TrimSpace(string(p))
+}
+
+// Int returns the parameter as an integer, like the arguments of min.
+func (p Param) Int() (int, error) {
+	n, err := strconv.Atoi(p.String())
+	if err != nil {
+		return 0, p.syntaxError("an integer")
+	}
+	return n, nil
+}
+
+// Float returns the parameter as a floating point number.
+func (p Param) Float() (float64, error) {
+	f, err := strconv.ParseFloat(p.String(), 64)
+	if err != nil {
+		return 0, p.syntaxError("a number")
+	}
+	return f, nil
+}
+
+// Bool returns the parameter as a boolean, written like for
+// strconv.ParseBool.
+func (p Param) Bool() (bool, error) {
+	b, err := strconv.ParseBool(p.String())
+	if err != nil {
+		return false, p.syntaxError("a boolean")
+	}
+	return b, nil
+}
+
+// Duration returns the parameter as a duration, written like for
+// time.ParseDuration, e.g. "90s".
+func (p Param) Duration() (time.Duration, error) {
+	d, err := time.ParseDuration(p.String())
+	if err != nil {
+		return 0, p.syntaxError("a duration")
+	}
+	return d, nil
+}
+
+// Field returns the path of the field the parameter references, like
+// "Start" for $Start, and whether it references one.
+func (p Param) Field() (string, bool) {
+	path, ok := strings.CutPrefix(p.String(), "$")
+	if !ok || !tags.IsFieldPath(path) {
+		return "", false
+	}
+	return path, true
+}
+
+func (p Param) syntaxError(want string) error {
+	return fmt.Errorf("%w: parameter %q is not %s", ErrInvalidValidatorSyntax, p.String(), want)
+}
+
+// FieldRules are the rules for one field of a struct, see F and Describe.
+type FieldRules struct {
+	name  string
+	typ   reflect.Type
+	rules []Rule
+}
+
+// F returns the rules for the field name of a struct passed to Struct. Fields
+// of nested structs are addressed by dotted paths like "Address.Zip".
+func F(name string, rules ...Rule) FieldRules {
+	return FieldRules{name: name, rules: rules}
+}
+
+// Field validates the value ptr points to against rules, e.g.
+//
+//	err := Field(&u.Email, rules.Required(), rules.Email())
+//
+// The value is the field, so an empty string fails required; a pointer to a
+// pointer field checks the pointer like a struct field of its type.
+func Field(ptr any, rules ...Rule) error {
+	validators, cond, err := compileRules(rules)
+	if err != nil {
+		return ValidationErrors{{err}}
+	}
+
+	field := reflect.ValueOf(ptr)
+	if field.Kind() == reflect.Pointer && !field.IsNil() {
+		field = field.Elem()
+	}
+	s := validation{ctx: context.Background()}
+	s.checkField(&fieldPath{}, "", cond, validators, field)
+	return s.result()
+}
+
+// Struct validates the fields of v against the given rules instead of the
+// rules from its validate tags, e.g.
+//
+//	err := Struct(u, F("Age", rules.Min(18)), F("Email", rules.Email()))
+func Struct(v any, fields ...FieldRules) error {
+	valueV := reflect.ValueOf(v)
+	for valueV.Kind() == reflect.Pointer && !valueV.IsNil() {
+		valueV = valueV.Elem()
+	}
+	if valueV.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	s := validation{ctx: context.Background()}
+	for _, f := range fields {
+		validators, cond, err := compileRules(f.rules)
+		if err != nil {
+			s.errors = append(s.errors, ValidationError{err})
+			continue
+		}
+
+		field, ok := fieldByPath(valueV, f.name)
+		if !ok {
+			s.errors = append(s.errors, ValidationError{fmt.Errorf("%w: %s", ErrUnknownField, f.name)})
+			continue
+		}
+
+		if !conditionsHold(validators, valueV) {
+			continue
+		}
+		if hasRefs(validators) {
+			if validators, err = withRefs(validators, valueV); err != nil {
+				s.errors = append(s.errors, ValidationError{err})
+				continue
+			}
+		}
+		s.checkField(&fieldPath{}, f.name, cond, validators, field)
+	}
+	return s.result()
+}
+
+// compileRules converts rules to validators, also returning their struct tag
+// form for error messages.
+func compileRules(rules []Rule) ([]rule, string, error) {
+	conds := make([]string, len(rules))
+	validators := make([]rule, len(rules))
+	for i, rule := range rules {
+		conds[i] = rule.String()
+
+		validator, err := parseValidator(conds[i])
+		if err != nil {
+			return nil, "", err
+		}
+		validators[i] = validator
+	}
+	return validators, strings.Join(conds, "&"), nil
+}
+
+// fieldByPath returns the exported field of the struct v at the dotted path,
+// dereferencing pointers on the way.
+func fieldByPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		fieldT, ok := v.Type().FieldByName(name)
+		if !ok || !fieldT.IsExported() {
+			return reflect.Value{}, false
+		}
+		v = v.FieldByIndex(fieldT.Index)
+	}
+	return v, true
+}
@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// validateCardExpiry checks that field is the expiry date of a payment card,
+// like "09/27", that has not passed at now. Cards expire at the end of their
+// month, in the location of now.
+func validateCardExpiry(field string, now time.Time) error {
+	mm, yy, ok := strings.Cut(field, "/")
+	if !ok || len(mm) != 2 || len(yy) != 2 || !isDigits(mm) || !isDigits(yy) {
+		return ErrFieldNotValid
+	}
+	month, _ := strconv.Atoi(mm)
+	year, _ := strconv.Atoi(yy)
+	if month < 1 || month > 12 {
+		return ErrFieldNotValid
+	}
+	year += now.Year() / 100 * 100
+	if year < now.Year() || year == now.Year() && time.Month(month) < now.Month() {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// parseCVV parses the arguments of cvv, the numbers of digits allowed, like
+// "3,4" for the codes of Visa and American Express cards.
+func parseCVV(params string) (rule, error) {
+	args := tags.SplitArgs(params)
+	digits := make([]int, len(args))
+	for i, arg := range args {
+		n, err := strconv.Atoi(strings.TrimSpace(arg))
+		if err != nil || n < 1 {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+		args[i], digits[i] = strings.TrimSpace(arg), n
+	}
+	return rule{name: "cvv", argsStr: args, argsInt: digits}, nil
+}
+
+// validateCVV checks that field is a card security code of one of the
+// numbers of digits allowed.
+func validateCVV(field string, digits []int) error {
+	if !isDigits(field) {
+		return ErrFieldNotValid
+	}
+	for _, n := range digits {
+		if len(field) == n {
+			return nil
+		}
+	}
+	return ErrFieldNotValid
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCardExpiry(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 23, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+	check := func(s string) error { return validateVar(s, "card_expiry", newConfig([]Option{clock})) }
+	for _, s := range []string{"03/25", "04/25", "12/25", "01/26", "09/40"} {
+		assert.NoError(t, check(s), s)
+	}
+	for _, s := range []string{"02/25", "12/24", "00/26", "13/26", "3/26", "03/2026", "03-26", "ab/cd", ""} {
+		assert.Error(t, check(s), s)
+	}
+
+	type card struct {
+		Expiry string `validate:"card_expiry"`
+	}
+	now = time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(card{Expiry: "03/25"}, clock), &errs)
+	assert.Equal(t, "card_expiry", errs[0].Rule())
+	assert.Error(t, ValidateVar(325, "card_expiry"))
+}
+
+func TestValidateCVV(t *testing.T) {
+	for _, s := range []string{"123", "0000"} {
+		assert.NoError(t, ValidateVar(s, "cvv:3,4"), s)
+	}
+	for _, s := range []string{"", "12", "12345", "12a", " 123"} {
+		assert.Error(t, ValidateVar(s, "cvv:3,4"), s)
+	}
+	assert.NoError(t, ValidateVar("123", "cvv:3"))
+	assert.Error(t, ValidateVar("1234", "cvv:3"))
+	assert.Error(t, ValidateVar(123, "cvv:3"))
+
+	for _, tag := range []string{"cvv", "cvv:", "cvv:x", "cvv:0"} {
+		var errs ValidationErrors
+		require.ErrorAs(t, ValidateVar("123", tag), &errs, tag)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, tag)
+	}
+}
@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// FieldChange is a change made to a field before it was validated, for
+// audit logs to record that the input was altered before it was accepted.
+type FieldChange struct {
+	// Field is the path of the field, e.g. "Address.Zip", or of an element
+	// of a slice of strings, e.g. "Tags[2]".
+	Field string
+	// Modifier is the modifier of the mod tag making the change, like
+	// "trim", or "default" for a default value.
+	Modifier string
+	// Old and New are the values before and after the change; pointers are
+	// reported as the values they point to, nil pointers as nil.
+	Old, New any
+}
+
+// Modifications returns the changes made to the value before it was
+// validated, in the order they were made: by the mod tags for
+// ModifyAndValidateResult, then by the default rules of a struct validated
+// through a pointer. Modifiers leaving a value as it was make no change.
+func (r *Result) Modifications() []FieldChange {
+	return r.changes
+}
+
+// ModifyAndValidateResult normalizes the struct v points to like Modify and
+// validates it like ValidateResult, reporting the changes made to it along
+// with the errors:
+//
+//	result := validator.ModifyAndValidateResult(&u)
+//	for _, change := range result.Modifications() {
+//		log.Printf("%s: %s changed %q to %q", change.Field, change.Modifier, change.Old, change.New)
+//	}
+//
+// The default rules of the validate tags set the fields of v too. The error
+// of a mod tag that cannot be applied is the error of the result.
+func ModifyAndValidateResult(v any, opts ...Option) *Result {
+	valueV := reflect.ValueOf(v)
+	if valueV.Kind() != reflect.Pointer || valueV.IsNil() || valueV.Elem().Kind() != reflect.Struct {
+		return &Result{err: ErrNotStruct}
+	}
+	var changes []FieldChange
+	if err := modifyStruct(valueV.Elem(), "", &changes); err != nil {
+		return &Result{err: err, changes: changes}
+	}
+	r := validateResult(context.Background(), v, newConfig(opts))
+	r.changes = append(changes, r.changes...)
+	return r
+}
+
+// changedValue returns the value of field for a FieldChange.
+func changedValue(field reflect.Value) any {
+	for field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+	if !field.CanInterface() {
+		return nil
+	}
+	return field.Interface()
+}
+
+// indexPath returns the path of the element i of the slice or array at path.
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
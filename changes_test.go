@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifications(t *testing.T) {
+	nick := " neo"
+	u := modUser{
+		Email:    "  Alice@Example.COM ",
+		Name:     "Ann",
+		Nick:     &nick,
+		Retries:  1,
+		Tags:     []string{"go", " Rust"},
+		Work:     &modAddress{City: "PARIS"},
+		Previous: []modAddress{{City: "oslo"}},
+	}
+	r := ModifyAndValidateResult(&u)
+	require.NoError(t, r.Err())
+	assert.Equal(t, []FieldChange{
+		{Field: "Email", Modifier: "trim", Old: "  Alice@Example.COM ", New: "Alice@Example.COM"},
+		{Field: "Email", Modifier: "lower", Old: "Alice@Example.COM", New: "alice@example.com"},
+		{Field: "Role", Modifier: "default", Old: "", New: "user"},
+		{Field: "Nick", Modifier: "trim", Old: " neo", New: "neo"},
+		{Field: "Limit", Modifier: "default", Old: nil, New: 10},
+		{Field: "Tags[1]", Modifier: "trim", Old: " Rust", New: "Rust"},
+		{Field: "Tags[1]", Modifier: "lower", Old: "Rust", New: "rust"},
+		{Field: "Previous[0].City", Modifier: "upper", Old: "oslo", New: "OSLO"},
+	}, r.Modifications())
+
+	type broken struct {
+		Name string `mod:"trim&nope"`
+	}
+	r = ModifyAndValidateResult(&broken{})
+	assert.ErrorIs(t, r.Err(), ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, ModifyAndValidateResult(u).Err(), ErrNotStruct)
+}
+
+func TestDefaultModifications(t *testing.T) {
+	type embedded struct {
+		Level int `validate:"default:1"`
+	}
+	type settings struct {
+		embedded
+		Mode    string `validate:"default:auto&in:auto,manual"`
+		Retries *int   `validate:"default:3"`
+		Items   []modUser
+		Name    string `mod:"default:x" validate:"default:y"`
+	}
+
+	s := settings{Mode: "manual"}
+	r := ValidateResult(&s)
+	require.NoError(t, r.Err())
+	assert.Equal(t, []FieldChange{
+		{Field: "Level", Modifier: "default", Old: 0, New: 1},
+		{Field: "Retries", Modifier: "default", Old: nil, New: 3},
+		{Field: "Name", Modifier: "default", Old: "", New: "y"},
+	}, r.Modifications())
+	assert.Equal(t, 3, *s.Retries)
+
+	assert.Empty(t, ValidateResult(settings{}).Modifications())
+	assert.Empty(t, ValidateResult(&s).Modifications())
+
+	s = settings{}
+	r = ModifyAndValidateResult(&s, WithParallelism(2))
+	assert.Equal(t, []FieldChange{
+		{Field: "Name", Modifier: "default", Old: "", New: "x"},
+		{Field: "Level", Modifier: "default", Old: 0, New: 1},
+		{Field: "Mode", Modifier: "default", Old: "", New: "auto"},
+		{Field: "Retries", Modifier: "default", Old: nil, New: 3},
+	}, r.Modifications())
+
+	merged := &Result{}
+	merged.Merge(r)
+	assert.Equal(t, r.Modifications(), merged.Modifications())
+}
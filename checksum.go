@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// ChecksumFunc returns the checksum of data computed by an algorithm
+// registered with RegisterChecksum.
+type ChecksumFunc func(data []byte) []byte
+
+// checksums holds the algorithms of the checksum rule by name.
+var checksums = map[string]ChecksumFunc{
+	"crc32":   hashSum(func() hash.Hash { return crc32.NewIEEE() }),
+	"crc32c":  hashSum(func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) }),
+	"adler32": hashSum(func() hash.Hash { return adler32.New() }),
+	"md5":     hashSum(md5.New),
+	"sha1":    hashSum(sha1.New),
+	"sha256":  hashSum(sha256.New),
+	"sha512":  hashSum(sha512.New),
+}
+
+// hashSum returns the ChecksumFunc of the hashes made by h.
+func hashSum(h func() hash.Hash) ChecksumFunc {
+	return func(data []byte) []byte {
+		sum := h()
+		sum.Write(data)
+		return sum.Sum(nil)
+	}
+}
+
+// RegisterChecksum makes fn available to the checksum rule under name, next
+// to the built-in crc32, crc32c, adler32, md5, sha1, sha256 and sha512:
+//
+//	validator.RegisterChecksum("xxhash", func(data []byte) []byte {
+//		return binary.BigEndian.AppendUint64(nil, xxhash.Sum64(data))
+//	})
+//
+//	type Upload struct {
+//		Body   []byte `validate:"checksum:xxhash:$Digest"`
+//		Digest string
+//	}
+//
+// The rule checksum:name:sum applies to strings and byte slices, whose
+// checksum must be sum, written in hexadecimal like the crc32 checksum
+// "414fa339". It may reference a field carrying the checksum along with the
+// data, a string holding it in hexadecimal or a byte slice holding it. It is
+// meant to be called during program initialization.
+func RegisterChecksum(name string, fn ChecksumFunc) error {
+	return register("RegisterChecksum", func() {
+		checksums[name] = fn
+		resetPlanCache()
+	})
+}
+
+// parseChecksum parses the arguments of the checksum rule, the name of a
+// registered algorithm and the checksum, or the field holding it, separated
+// by a colon like "crc32:414fa339" or "sha256:$Digest".
+func parseChecksum(params string) (rule, error) {
+	name, sum, ok := strings.Cut(strings.TrimSpace(params), ":")
+	fn, _ := registered(checksums, strings.TrimSpace(name))
+	if !ok || fn == nil {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	r := rule{name: "checksum", argsStr: []string{params}, checksum: fn}
+	sum = strings.TrimSpace(sum)
+	if ref, ok := strings.CutPrefix(sum, "$"); ok && tags.IsFieldPath(ref) {
+		r.refs, r.argsInt = []string{ref}, []int{0}
+		return r, nil
+	}
+	var err error
+	if r.sum, err = hex.DecodeString(sum); err != nil || len(r.sum) == 0 {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	return r, nil
+}
+
+// refChecksum returns the checksum held by the field at path in root, nil
+// when a string field does not hold one in hexadecimal.
+func refChecksum(root reflect.Value, path string) ([]byte, error) {
+	raw, ok := reflect.Value{}, false
+	if root.IsValid() {
+		raw, ok = fieldByKey(root, path)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s referenced by a rule", ErrUnknownField, path)
+	}
+	field, err := customValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case field.Kind() == reflect.String:
+		sum, _ := hex.DecodeString(field.String())
+		return sum, nil
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		return field.Bytes(), nil
+	}
+	return nil, fmt.Errorf("%w: field %s referenced by a rule does not hold a checksum", ErrInvalidValidatorSyntax, path)
+}
+
+// validateChecksum requires the checksum of the string or byte slice field
+// computed by fn to be sum.
+func validateChecksum(field reflect.Value, fn ChecksumFunc, sum []byte) error {
+	var data []byte
+	switch {
+	case field.Kind() == reflect.String:
+		data = []byte(field.String())
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		data = field.Bytes()
+	default:
+		return ErrFieldNotValid
+	}
+	if len(sum) == 0 || !bytes.Equal(fn(data), sum) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fox = "The quick brown fox jumps over the lazy dog"
+
+func TestValidateChecksum(t *testing.T) {
+	RegisterChecksum("len", func(data []byte) []byte { return []byte{byte(len(data))} })
+	t.Cleanup(func() { delete(checksums, "len") })
+
+	type upload struct {
+		Text   string `validate:"checksum:crc32:414FA339"`
+		Body   []byte `validate:"checksum:sha256:$Digest"`
+		Digest string
+		Raw    []byte `validate:"omitempty&checksum:len:$Size"`
+		Size   []byte
+	}
+	valid := upload{
+		Text:   fox,
+		Body:   []byte(fox),
+		Digest: "d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592",
+		Raw:    []byte("abc"),
+		Size:   []byte{3},
+	}
+	assert.NoError(t, Validate(valid))
+
+	invalid := valid
+	invalid.Text = fox + "."
+	invalid.Body = []byte("tampered")
+	invalid.Size = []byte{4}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(invalid), &errs)
+	assert.Equal(t, []string{"Text", "Body", "Raw"}, fieldsOf(errs))
+
+	invalid = valid
+	invalid.Digest = "not hex"
+	require.ErrorAs(t, Validate(invalid), &errs)
+	assert.Equal(t, []string{"Body"}, fieldsOf(errs))
+
+	assert.NoError(t, ValidateVar([]string{fox}, "checksum:crc32:414fa339"))
+	assert.Error(t, ValidateVar(414, "checksum:crc32:414fa339"))
+	for _, tag := range []string{"checksum:crc32", "checksum:unknown:00", "checksum:crc32:xyz", "checksum:crc32:"} {
+		require.ErrorAs(t, ValidateVar(fox, tag), &errs, tag)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, tag)
+	}
+
+	type unknown struct {
+		Body string `validate:"checksum:crc32:$Digest"`
+	}
+	require.ErrorAs(t, Validate(unknown{Body: fox}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+}
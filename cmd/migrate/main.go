@@ -0,0 +1,187 @@
+// Command migrate rewrites the validate tags of Go source files from the
+// syntax of github.com/go-playground/validator to the syntax of this
+// package, or back with -reverse, easing the move of existing code:
+//
+//	migrate -w ./internal/models
+//
+// It changes
+//
+//	Name string `json:"name" validate:"required,min=3,oneof=a b"`
+//
+// into
+//
+//	Name string `json:"name" validate:"required&min:3&in:a,b"`
+//
+// Arguments are files and directories, searched for .go files
+// recursively. Without -w the tags that would change are printed one per
+// line, prefixed by their position. Tags with rules that cannot be
+// translated, like eqfield, are left unchanged and reported. The exit code
+// is 0 when every tag could be translated, 1 when some could not and 2 when
+// the files cannot be read or written.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	exitOK           = 0
+	exitUntranslated = 1
+	exitError        = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	write := flags.Bool("w", false, "write the rewritten tags to the files instead of printing them")
+	reverse := flags.Bool("reverse", false, "translate from the syntax of this package to the syntax of go-playground/validator")
+	tagName := flags.String("tag", "validate", "`key` of the struct tags holding the rules")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	m := migrator{tagName: *tagName, write: *write, stdout: stdout, stderr: stderr, code: exitOK, translate: fromPlayground}
+	if *reverse {
+		m.translate = toPlayground
+	}
+	for _, arg := range flags.Args() {
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			switch {
+			case err != nil:
+				return err
+			case d.IsDir() && path != arg && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "testdata"):
+				return filepath.SkipDir
+			case !d.IsDir() && (path == arg || strings.HasSuffix(path, ".go")):
+				m.migrate(path)
+			}
+			return nil
+		})
+		if err != nil {
+			m.fail(err)
+		}
+	}
+	return m.code
+}
+
+type migrator struct {
+	tagName        string
+	write          bool
+	translate      func(rules string) (string, []string)
+	stdout, stderr io.Writer
+	code           int
+}
+
+func (m *migrator) fail(err error) {
+	fmt.Fprintf(m.stderr, "migrate: %v\n", err)
+	m.code = exitError
+}
+
+// edit replaces the bytes of a file from start to end.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// migrate rewrites the tags of the Go file name.
+func (m *migrator) migrate(name string) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		m.fail(err)
+		return
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		m.fail(err)
+		return
+	}
+
+	var edits []edit
+	ast.Inspect(file, func(n ast.Node) bool {
+		field, ok := n.(*ast.Field)
+		if !ok || field.Tag == nil {
+			return true
+		}
+		pos := fset.Position(field.Tag.Pos())
+		literal, err := m.rewrite(field.Tag.Value)
+		var u untranslatable
+		switch {
+		case errors.As(err, &u):
+			fmt.Fprintf(m.stderr, "%s: %v\n", pos, err)
+			if m.code == exitOK {
+				m.code = exitUntranslated
+			}
+		case err != nil:
+			m.fail(fmt.Errorf("%s: %w", pos, err))
+		case literal != field.Tag.Value:
+			edits = append(edits, edit{start: pos.Offset, end: pos.Offset + len(field.Tag.Value), text: literal})
+			if !m.write {
+				fmt.Fprintf(m.stdout, "%s: %s\n", pos, literal)
+			}
+		}
+		return true
+	})
+	if !m.write || len(edits) == 0 {
+		return
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, e := range edits {
+		src = bytes.Join([][]byte{src[:e.start], []byte(e.text), src[e.end:]}, nil)
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		m.fail(err)
+		return
+	}
+	if err := os.WriteFile(name, src, info.Mode()); err != nil {
+		m.fail(err)
+	}
+}
+
+// rewrite returns the tag literal with its rules translated, quoted like
+// literal when possible.
+func (m *migrator) rewrite(literal string) (string, error) {
+	tag, err := strconv.Unquote(literal)
+	if err != nil {
+		return "", err
+	}
+	rewritten, err := replaceTag(tag, m.tagName, func(rules string) (string, error) {
+		if rules == "" || rules == "-" {
+			return rules, nil
+		}
+		translated, unknown := m.translate(rules)
+		if len(unknown) != 0 {
+			return "", untranslatable(unknown)
+		}
+		return translated, nil
+	})
+	if err != nil || rewritten == tag {
+		return literal, err
+	}
+	if literal[0] == '`' && strconv.CanBackquote(rewritten) {
+		return "`" + rewritten + "`", nil
+	}
+	return strconv.Quote(rewritten), nil
+}
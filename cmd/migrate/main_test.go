@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPlayground(t *testing.T) {
+	tests := []struct {
+		rules   string
+		want    string
+		unknown []string
+	}{
+		{rules: "required,min=3,max=32", want: "required&min:3&max:32"},
+		{rules: "omitempty,oneof=a b c", want: "omitempty&in:a,b,c"},
+		{rules: "dive,gte=1,lte=5", want: "min:1&max:5"},
+		{rules: "oneofci=a:b c", want: `in_ci:a\:b,c`},
+		{rules: "required,eqfield=Password,url", want: "required", unknown: []string{"eqfield=Password", "url"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			got, unknown := fromPlayground(tt.rules)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.unknown, unknown)
+		})
+	}
+}
+
+func TestToPlayground(t *testing.T) {
+	tests := []struct {
+		rules   string
+		want    string
+		unknown []string
+	}{
+		{rules: "required&min:3&max:32", want: "required,min=3,max=32"},
+		{rules: "omitempty&in:a,b,c", want: "omitempty,oneof=a b c"},
+		{rules: "in_ci:ru,en", want: "oneofci=ru en"},
+		{rules: `in:"a b",c`, want: "", unknown: []string{`in:"a b",c`}},
+		{rules: "required&keys:len:2,endkeys&regexp:^a", want: "required", unknown: []string{"keys:len:2,endkeys", "regexp:^a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			got, unknown := toPlayground(tt.rules)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.unknown, unknown)
+		})
+	}
+}
+
+const testSource = "package models\n\n" +
+	"type User struct {\n" +
+	"\tName  string `json:\"name\" validate:\"required,min=3\"`\n" +
+	"\tRole  string \"validate:\\\"oneof=admin user\\\"\"\n" +
+	"\tEmail string `validate:\"omitempty,email\" json:\"email\"`\n" +
+	"\tPass  string `validate:\"required,eqfield=Confirm\"`\n" +
+	"\tNote  string `json:\"note\"`\n" +
+	"}\n"
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "user.go")
+	require.NoError(t, os.WriteFile(path, []byte(testSource), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("validate"), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitUntranslated, run([]string{dir}, &stdout, &stderr))
+	assert.Equal(t, path+":4:15: `json:\"name\" validate:\"required&min:3\"`\n"+
+		path+":5:15: \"validate:\\\"in:admin,user\\\"\"\n"+
+		path+":6:15: `validate:\"omitempty&email\" json:\"email\"`\n", stdout.String())
+	assert.Equal(t, path+":7:15: cannot translate \"eqfield=Confirm\"\n", stderr.String())
+	src, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testSource, string(src))
+
+	stdout.Reset()
+	stderr.Reset()
+	assert.Equal(t, exitUntranslated, run([]string{"-w", path}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+	src, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "package models\n\n"+
+		"type User struct {\n"+
+		"\tName  string `json:\"name\" validate:\"required&min:3\"`\n"+
+		"\tRole  string \"validate:\\\"in:admin,user\\\"\"\n"+
+		"\tEmail string `validate:\"omitempty&email\" json:\"email\"`\n"+
+		"\tPass  string `validate:\"required,eqfield=Confirm\"`\n"+
+		"\tNote  string `json:\"note\"`\n"+
+		"}\n", string(src))
+
+	// Translated back, the tags are the same as before but for the one
+	// that could not be translated.
+	stderr.Reset()
+	assert.Equal(t, exitUntranslated, run([]string{"-reverse", "-w", path}, &stdout, &stderr))
+	src, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, testSource, string(src))
+
+	assert.Equal(t, exitError, run([]string{filepath.Join(dir, "missing.go")}, &stdout, &stderr))
+	assert.Equal(t, exitError, run(nil, &stdout, &stderr))
+}
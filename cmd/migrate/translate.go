@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Nadya2002/validator"
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// playgroundRules lists the rules of github.com/go-playground/validator
+// that have the same meaning in this package under the same name.
+var playgroundRules = map[string]bool{
+	"required":  true,
+	"omitempty": true,
+	"len":       true,
+	"min":       true,
+	"max":       true,
+	"email":     true,
+	"file":      true,
+	"dir":       true,
+	"filepath":  true,
+	"ulid":      true,
+}
+
+// fromPlayground translates rules written in the syntax of
+// go-playground/validator, like "required,min=3,oneof=a b", to the syntax
+// of this package. It returns the rules that have no translation, in which
+// case the translated rules are incomplete.
+func fromPlayground(rules string) (string, []string) {
+	var translated, unknown []string
+	for _, rule := range strings.Split(rules, ",") {
+		name, args, found := strings.Cut(strings.TrimSpace(rule), "=")
+		if mapped, ok := validator.PlaygroundSyntax.Names[name]; ok {
+			if mapped == "" {
+				// Rules of slices apply to their elements anyway.
+				continue
+			}
+			name = mapped
+		}
+		if !playgroundRules[name] && name != "in" && name != "in_ci" {
+			unknown = append(unknown, rule)
+			continue
+		}
+		if !found {
+			translated = append(translated, name)
+			continue
+		}
+		params := []string{args}
+		if name == "in" || name == "in_ci" {
+			params = strings.Fields(args)
+		}
+		for i, param := range params {
+			params[i] = escapeArg(param)
+		}
+		translated = append(translated, name+":"+strings.Join(params, ","))
+	}
+	return strings.Join(translated, "&"), unknown
+}
+
+// toPlayground translates rules written in the syntax of this package, like
+// "required&min:3&in:a,b", to the syntax of go-playground/validator. It
+// returns the rules that have no translation, in which case the translated
+// rules are incomplete.
+func toPlayground(rules string) (string, []string) {
+	var translated, unknown []string
+	for rest, found := rules, true; found; {
+		var rule string
+		rule, rest, found = tags.Cut(rest, '&')
+		name, params, hasParams := tags.Cut(strings.TrimSpace(rule), ':')
+		name = strings.TrimSpace(name)
+		switch name {
+		case "in":
+			name = "oneof"
+		case "in_ci":
+			name = "oneofci"
+		default:
+			if !playgroundRules[name] {
+				unknown = append(unknown, rule)
+				continue
+			}
+		}
+		if !hasParams {
+			translated = append(translated, name)
+			continue
+		}
+		args := tags.SplitArgs(params)
+		if !playgroundArgs(args) {
+			unknown = append(unknown, rule)
+			continue
+		}
+		translated = append(translated, name+"="+strings.Join(args, " "))
+	}
+	return strings.Join(translated, ","), unknown
+}
+
+// playgroundArgs reports whether args can be written in the syntax of
+// go-playground/validator, which has no quotes or escapes.
+func playgroundArgs(args []string) bool {
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, ", |=") {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeArg escapes the separators of the syntax of this package in the
+// argument arg.
+func escapeArg(arg string) string {
+	if !strings.ContainsAny(arg, `,&:"\`) {
+		return arg
+	}
+	var sb strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if strings.IndexByte(`,&:"\`, arg[i]) >= 0 {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(arg[i])
+	}
+	return sb.String()
+}
+
+// replaceTag returns the struct tag tag with the value of key replaced by
+// the result of translate. It follows the conventions of
+// reflect.StructTag, tags it cannot parse are returned unchanged.
+func replaceTag(tag, key string, translate func(string) (string, error)) (string, error) {
+	for rest := tag; rest != ""; {
+		i := 0
+		for i < len(rest) && rest[i] == ' ' {
+			i++
+		}
+		rest = rest[i:]
+		i = 0
+		for i < len(rest) && rest[i] > ' ' && rest[i] != ':' && rest[i] != '"' && rest[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(rest) || rest[i] != ':' || rest[i+1] != '"' {
+			return tag, nil
+		}
+		name := rest[:i]
+		rest = rest[i+1:]
+
+		i = 1
+		for i < len(rest) && rest[i] != '"' {
+			if rest[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(rest) {
+			return tag, nil
+		}
+		quoted := rest[:i+1]
+		rest = rest[i+1:]
+		if name != key {
+			continue
+		}
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return tag, nil
+		}
+		translated, err := translate(value)
+		if err != nil {
+			return tag, err
+		}
+		start := len(tag) - len(rest) - len(quoted)
+		return tag[:start] + strconv.Quote(translated) + rest, nil
+	}
+	return tag, nil
+}
+
+// untranslatable reports the rules of a tag that have no translation.
+type untranslatable []string
+
+func (u untranslatable) Error() string {
+	quoted := make([]string, len(u))
+	for i, rule := range u {
+		quoted[i] = strconv.Quote(rule)
+	}
+	return fmt.Sprintf("cannot translate %s", strings.Join(quoted, ", "))
+}
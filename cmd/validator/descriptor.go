@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// descriptor describes the struct documents are decoded into:
+//
+//	{
+//		"fields": [
+//			{"name": "Name", "type": "string", "validate": "required&min:2"},
+//			{"name": "Port", "key": "port", "type": "int", "validate": "min:1&max:65535"},
+//			{"name": "Tags", "type": "[]string", "validate": "omitempty&min:2"},
+//			{"name": "Server", "type": "object", "fields": [
+//				{"name": "Host", "type": "string", "validate": "required"}
+//			]}
+//		]
+//	}
+//
+// Key is the name of the field in the documents, the name itself by
+// default. Types are string, bool, int, uint, float, object and slices of
+// them like "[]object".
+type descriptor struct {
+	Fields []fieldDescriptor `json:"fields"`
+}
+
+type fieldDescriptor struct {
+	Name     string            `json:"name"`
+	Key      string            `json:"key"`
+	Type     string            `json:"type"`
+	Validate string            `json:"validate"`
+	Fields   []fieldDescriptor `json:"fields"`
+}
+
+var scalarTypes = map[string]reflect.Type{
+	"string": reflect.TypeOf(""),
+	"bool":   reflect.TypeOf(false),
+	"int":    reflect.TypeOf(int64(0)),
+	"uint":   reflect.TypeOf(uint64(0)),
+	"float":  reflect.TypeOf(float64(0)),
+}
+
+// loadDescriptor reads the descriptor in the file name and builds its struct
+// type.
+func loadDescriptor(name string) (reflect.Type, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var d descriptor
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	t, err := structOf(d.Fields, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return t, nil
+}
+
+func structOf(fields []fieldDescriptor, path string) (reflect.Type, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%sno fields", path)
+	}
+
+	structFields := make([]reflect.StructField, 0, len(fields))
+	for _, f := range fields {
+		if f.Name == "" || !unicode.IsUpper([]rune(f.Name)[0]) {
+			return nil, fmt.Errorf("%sfield name %q must start with an upper case letter", path, f.Name)
+		}
+		t, err := typeOf(f, path+f.Name+".")
+		if err != nil {
+			return nil, err
+		}
+
+		key := f.Key
+		if key == "" {
+			key = f.Name
+		}
+		tag := fmt.Sprintf(`json:%q yaml:%q`, key, key)
+		if f.Validate != "" {
+			tag += fmt.Sprintf(` validate:%q`, f.Validate)
+		}
+		structFields = append(structFields, reflect.StructField{Name: f.Name, Type: t, Tag: reflect.StructTag(tag)})
+	}
+
+	var t reflect.Type
+	err := func() (err error) {
+		// StructOf panics on invalid fields like duplicate names.
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%s%v", path, r)
+			}
+		}()
+		t = reflect.StructOf(structFields)
+		return nil
+	}()
+	return t, err
+}
+
+func typeOf(f fieldDescriptor, path string) (reflect.Type, error) {
+	name := f.Type
+	slices := 0
+	for strings.HasPrefix(name, "[]") {
+		name, slices = name[2:], slices+1
+	}
+
+	var t reflect.Type
+	if name == "object" {
+		var err error
+		if t, err = structOf(f.Fields, path); err != nil {
+			return nil, err
+		}
+	} else if t = scalarTypes[name]; t == nil {
+		return nil, fmt.Errorf("%sunknown type %q", path, f.Type)
+	}
+
+	for ; slices > 0; slices-- {
+		t = reflect.SliceOf(t)
+	}
+	return t, nil
+}
@@ -0,0 +1,142 @@
+// Command validator validates JSON and YAML documents against validate
+// rules, e.g. for CI checks on config and fixture files:
+//
+//	validator -schema config.schema.json config/*.yaml
+//	cat events.ndjson | validator -schema event.schema.json
+//
+// The schema file describes the struct the documents are decoded into, with
+// a validate tag for each field; see descriptor for its format. Every file
+// may hold several documents: a stream of JSON values like NDJSON, or YAML
+// documents separated by "---". Files are read as YAML when their name ends
+// in .yaml or .yml and as JSON otherwise, unless -format is given; stdin is
+// read when no files are given.
+//
+// Violations are printed one per line, prefixed by the file name and the
+// number of the document. The exit code is 0 when all documents are valid,
+// 1 when some are not and 2 when the documents or the schema cannot be read.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Nadya2002/validator"
+)
+
+const (
+	exitValid   = 0
+	exitInvalid = 1
+	exitError   = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validator", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	schema := flags.String("schema", "", "file describing the `struct` of the documents (required)")
+	format := flags.String("format", "", "format of the documents: json or yaml; default by file extension")
+	strict := flags.Bool("strict", false, "reject fields the schema does not describe")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if *schema == "" || *format != "" && *format != "json" && *format != "yaml" {
+		flags.Usage()
+		return exitError
+	}
+
+	typeV, err := loadDescriptor(*schema)
+	if err == nil {
+		err = validator.Lint(typeV)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "validator: %v\n", err)
+		return exitError
+	}
+
+	c := checker{typeV: typeV, strict: *strict, stdout: stdout, stderr: stderr, code: exitValid}
+	if flags.NArg() == 0 {
+		c.check("<stdin>", stdin, *format)
+	}
+	for _, name := range flags.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			c.fail(err)
+			continue
+		}
+		c.check(name, f, *format)
+		f.Close()
+	}
+	return c.code
+}
+
+type checker struct {
+	typeV          reflect.Type
+	strict         bool
+	stdout, stderr io.Writer
+	code           int
+}
+
+func (c *checker) fail(err error) {
+	fmt.Fprintf(c.stderr, "validator: %v\n", err)
+	c.code = exitError
+}
+
+// check validates the documents read from r.
+func (c *checker) check(name string, r io.Reader, format string) {
+	if format == "" {
+		format = "json"
+		if ext := filepath.Ext(name); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+
+	var decode func(v any) error
+	if format == "yaml" {
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(c.strict)
+		decode = dec.Decode
+	} else {
+		dec := json.NewDecoder(r)
+		if c.strict {
+			dec.DisallowUnknownFields()
+		}
+		decode = dec.Decode
+	}
+
+	for doc := 1; ; doc++ {
+		v := reflect.New(c.typeV)
+		if err := decode(v.Interface()); err != nil {
+			if !errors.Is(err, io.EOF) {
+				c.fail(fmt.Errorf("%s:%d: %w", name, doc, err))
+			}
+			return
+		}
+
+		err := validator.Validate(v.Elem().Interface())
+		var errs validator.ValidationErrors
+		if !errors.As(err, &errs) {
+			if err != nil {
+				c.fail(fmt.Errorf("%s:%d: %w", name, doc, err))
+			}
+			continue
+		}
+		for _, e := range errs {
+			fmt.Fprintf(c.stdout, "%s:%d: %s\n", name, doc, strings.TrimPrefix(e.Err.Error(), "field: "))
+		}
+		if c.code == exitValid {
+			c.code = exitInvalid
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"fields": [
+		{"name": "Name", "key": "name", "type": "string", "validate": "required&min:2"},
+		{"name": "Port", "key": "port", "type": "int", "validate": "min:1&max:65535"},
+		{"name": "Tags", "key": "tags", "type": "[]string", "validate": "omitempty&len:3"},
+		{"name": "Server", "key": "server", "type": "object", "fields": [
+			{"name": "Host", "key": "host", "type": "string", "validate": "required"}
+		]}
+	]
+}`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	schema := writeFile(t, dir, "schema.json", testSchema)
+	valid := writeFile(t, dir, "valid.yaml", "name: api\nport: 8080\ntags: [abc]\nserver:\n  host: localhost\n")
+	invalid := writeFile(t, dir, "invalid.yml", "name: a\nport: 8080\nserver:\n  host: localhost\n---\nname: api\nport: 0\n")
+	unknown := writeFile(t, dir, "unknown.json", `{"name": "api", "port": 1, "server": {"host": "h"}, "debug": true}`)
+
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantCode   int
+		wantOut    []string
+		wantStderr string
+	}{
+		{
+			name:     "valid file",
+			args:     []string{"-schema", schema, valid},
+			wantCode: exitValid,
+		},
+		{
+			name:     "violations in yaml documents",
+			args:     []string{"-schema", schema, valid, invalid},
+			wantCode: exitInvalid,
+			wantOut: []string{
+				invalid + ":1: Name not valid for required&min:2",
+				invalid + ":2: Port not valid for min:1&max:65535",
+				invalid + ":2: Server.Host not valid for required",
+			},
+		},
+		{
+			name:     "ndjson from stdin",
+			args:     []string{"-schema", schema},
+			stdin:    "{\"name\": \"api\", \"port\": 1, \"server\": {\"host\": \"h\"}}\n{\"name\": \"api\", \"port\": 70000, \"server\": {\"host\": \"h\"}}\n",
+			wantCode: exitInvalid,
+			wantOut:  []string{"<stdin>:2: Port not valid for min:1&max:65535"},
+		},
+		{
+			name:     "unknown fields are ignored",
+			args:     []string{"-schema", schema, unknown},
+			wantCode: exitValid,
+		},
+		{
+			name:       "strict",
+			args:       []string{"-schema", schema, "-strict", unknown},
+			wantCode:   exitError,
+			wantStderr: `unknown field "debug"`,
+		},
+		{
+			name:       "format flag",
+			args:       []string{"-schema", schema, "-format", "json", valid},
+			wantCode:   exitError,
+			wantStderr: "invalid character",
+		},
+		{
+			name:       "missing file",
+			args:       []string{"-schema", schema, filepath.Join(dir, "missing.json")},
+			wantCode:   exitError,
+			wantStderr: "no such file",
+		},
+		{
+			name:       "missing schema flag",
+			args:       []string{valid},
+			wantCode:   exitError,
+			wantStderr: "Usage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+			var out []string
+			if stdout.Len() != 0 {
+				out = strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+			}
+			assert.Equal(t, tt.wantOut, out)
+			assert.Contains(t, stderr.String(), tt.wantStderr)
+		})
+	}
+}
+
+func TestLoadDescriptor(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{name: "valid", schema: testSchema},
+		{name: "no fields", schema: `{"fields": []}`, wantErr: "no fields"},
+		{name: "unknown type", schema: `{"fields": [{"name": "A", "type": "map"}]}`, wantErr: `A.unknown type "map"`},
+		{name: "lower case name", schema: `{"fields": [{"name": "a", "type": "int"}]}`, wantErr: "upper case"},
+		{name: "duplicate field", schema: `{"fields": [{"name": "A", "type": "int"}, {"name": "A", "type": "int"}]}`, wantErr: "duplicate field"},
+		{name: "empty object", schema: `{"fields": [{"name": "A", "type": "[]object"}]}`, wantErr: "A.no fields"},
+		{name: "invalid json", schema: `{`, wantErr: "unexpected end"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, t.TempDir(), "schema.json", tt.schema)
+			typeV, err := loadDescriptor(path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 4, typeV.NumField())
+		})
+	}
+}
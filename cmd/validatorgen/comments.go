@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"strconv"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// commentPrefix starts the comments of fields holding their rules.
+const commentPrefix = "validate:"
+
+// generateRegistrations returns the source of an init function registering
+// the rules written in the comments of the fields of the struct types names
+// declared in the package in dir with validator.RegisterTypeRules, or of all
+// its struct types with such comments when names is empty:
+//
+//	type User struct {
+//		// validate: required & min:3 & max:32
+//		Name string
+//		Email string // validate: required & email
+//	}
+//
+// The rules of a field are the text after "validate:" in its doc or line
+// comment; several such lines are joined with &. Any rule of the validate
+// tag may be written, including the ones registered at run time, as the
+// reflective validation checks them. The file outName is ignored when
+// reading the package, and constraint is the build constraint of the output
+// as for generate.
+func generateRegistrations(dir, outName string, names []string, constraint string) ([]byte, error) {
+	g, err := newGenerator(dir, outName, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		for _, name := range g.structs {
+			if hasComments(g.decls[name].(*ast.StructType)) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no struct types with validate comments in %s", dir)
+	}
+
+	var registrations bytes.Buffer
+	for _, name := range names {
+		st, ok := g.decls[name].(*ast.StructType)
+		if !ok || g.aliases[name] {
+			return nil, fmt.Errorf("%s is not a struct type declared in %s", name, dir)
+		}
+		fmt.Fprintf(&registrations, "\tvalidator.RegisterTypeRules(map[string]string{\n")
+		for _, field := range st.Fields.List {
+			cond := commentOf(field)
+			if cond == "" {
+				continue
+			}
+			fieldNames := []string{embeddedName(field.Type)}
+			if len(field.Names) != 0 {
+				fieldNames = fieldNames[:0]
+				for _, id := range field.Names {
+					fieldNames = append(fieldNames, id.Name)
+				}
+			}
+			for _, fieldName := range fieldNames {
+				if err := checkComment(field, fieldName, cond); err != nil {
+					return nil, fmt.Errorf("%s: %s.%s: %w", g.fset.Position(field.Pos()), name, fieldName, err)
+				}
+				fmt.Fprintf(&registrations, "\t\t%s: %s,\n", strconv.Quote(fieldName), strconv.Quote(cond))
+			}
+		}
+		fmt.Fprintf(&registrations, "\t}, %s{})\n", name)
+	}
+
+	var out bytes.Buffer
+	g.writeHeader(&out, constraint)
+	out.WriteString("import \"github.com/Nadya2002/validator\"\n")
+	out.WriteString("\n// init registers the rules in the validate comments of the fields.\nfunc init() {\n")
+	out.Write(registrations.Bytes())
+	out.WriteString("}\n")
+
+	return format.Source(out.Bytes())
+}
+
+func hasComments(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if commentOf(field) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// commentOf returns the rules in the validate comments of field, "" when it
+// has none.
+func commentOf(field *ast.Field) string {
+	var conds []string
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if cond, ok := strings.CutPrefix(text, commentPrefix); ok {
+				conds = append(conds, strings.TrimSpace(cond))
+			}
+		}
+	}
+	return strings.Join(conds, " & ")
+}
+
+// checkComment returns an error when the rules cond in the comment of the
+// field name cannot be registered.
+func checkComment(field *ast.Field, name, cond string) error {
+	if tagOf(field) != "" {
+		return fmt.Errorf("field has both a validate tag and a validate comment")
+	}
+	if !ast.IsExported(name) {
+		return fmt.Errorf("rules are not supported for unexported fields")
+	}
+	// The rules registered at run time are not known here.
+	_, err := tags.Parse(cond, func(name string) bool { return !tags.IsBuiltin(name) })
+	return err
+}
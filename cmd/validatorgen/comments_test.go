@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateRegistrationsExample(t *testing.T) {
+	src, err := generateRegistrations("internal/example", "example_rules.go", []string{"Signup"}, "")
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("internal/example/example_rules.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(src), "run go generate in internal/example")
+}
+
+func TestGenerateRegistrations(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "t.go"), []byte(`package t
+
+type Base struct{}
+
+type T struct {
+	// Base is embedded.
+	// validate: required
+	*Base
+	A, B string // validate: unique_email
+	C    int
+}
+
+type U struct {
+	A string `+"`validate:\"min:1\"`"+`
+}
+`), 0o644))
+
+	src, err := generateRegistrations(dir, "t_rules.go", nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by validatorgen. DO NOT EDIT.
+
+package t
+
+import "github.com/Nadya2002/validator"
+
+// init registers the rules in the validate comments of the fields.
+func init() {
+	validator.RegisterTypeRules(map[string]string{
+		"Base": "required",
+		"A":    "unique_email",
+		"B":    "unique_email",
+	}, T{})
+}
+`, string(src))
+
+	_, err = generateRegistrations(dir, "t_rules.go", []string{"U"}, "")
+	assert.NoError(t, err)
+	_, err = generateRegistrations(dir, "t_rules.go", []string{"V"}, "")
+	assert.EqualError(t, err, "V is not a struct type declared in "+dir)
+}
+
+func TestGenerateRegistrationsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{
+			name: "syntax",
+			src:  "type T struct {\n\t// validate: min\n\tA string\n}",
+			err:  "T.A: invalid validator syntax: rule min needs arguments",
+		},
+		{
+			name: "tag and comment",
+			src:  "type T struct {\n\tA string `validate:\"min:1\"` // validate: max:2\n}",
+			err:  "T.A: field has both a validate tag and a validate comment",
+		},
+		{
+			name: "unexported",
+			src:  "type T struct {\n\ta string // validate: min:1\n}",
+			err:  "T.a: rules are not supported for unexported fields",
+		},
+		{
+			name: "no comments",
+			src:  "type T struct {\n\tA string // the name\n}",
+			err:  "no struct types with validate comments in",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "t.go"), []byte("package t\n\n"+tt.src+"\n"), 0o644))
+			_, err := generateRegistrations(dir, "t_rules.go", nil, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
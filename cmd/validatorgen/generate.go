@@ -0,0 +1,822 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	buildconstraint "go/build/constraint"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+const header = "// Code generated by validatorgen. DO NOT EDIT.\n"
+
+// basicKinds maps the predeclared types to their kinds.
+var basicKinds = map[string]tags.Kind{
+	"string": tags.String,
+	"bool":   tags.Bool,
+
+	"int":   tags.Int,
+	"int8":  tags.Int,
+	"int16": tags.Int,
+	"int32": tags.Int,
+	"int64": tags.Int,
+	"rune":  tags.Int,
+
+	"uint":    tags.Uint,
+	"uint8":   tags.Uint,
+	"uint16":  tags.Uint,
+	"uint32":  tags.Uint,
+	"uint64":  tags.Uint,
+	"uintptr": tags.Uint,
+	"byte":    tags.Uint,
+
+	"float32": tags.Float,
+	"float64": tags.Float,
+}
+
+// typeInfo is what the generator knows about the type of a field. Fields of
+// kind tags.Other that are not structs cannot have rules.
+type typeInfo struct {
+	kind tags.Kind
+	// name is the struct type declared in the package the field holds.
+	name    string
+	pointer bool
+	// slice is set for slices of the kind, elemPointer for slices of
+	// pointers to structs.
+	slice       bool
+	elemPointer bool
+}
+
+func (info typeInfo) isStruct() bool { return info.name != "" }
+
+type generator struct {
+	fset *token.FileSet
+	pkg  string
+	// structs lists the struct types of the package in source order.
+	structs []string
+	decls   map[string]ast.Expr
+	aliases map[string]bool
+	// valuers are the types with a Value or ValidateValue method, which the
+	// reflective validation may treat as driver.Valuer or validator.Opener.
+	valuers map[string]bool
+
+	queue    []string
+	queued   map[string]bool
+	methods  bytes.Buffer
+	needConv bool
+}
+
+// generate returns the source of the Validate methods for the struct types
+// names declared in the package in dir, or for all its struct types with
+// validate tags when names is empty, built only when the build constraint
+// expression constraint, if any, is satisfied. The file outName is ignored when
+// reading the package.
+func generate(dir, outName string, names []string, constraint string) ([]byte, error) {
+	g, err := newGenerator(dir, outName, constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(names) == 0 {
+		for _, name := range g.structs {
+			if hasTags(g.decls[name].(*ast.StructType)) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no struct types with validate tags in %s", dir)
+	}
+
+	var validates bytes.Buffer
+	for _, name := range names {
+		if _, ok := g.decls[name].(*ast.StructType); !ok || g.aliases[name] {
+			return nil, fmt.Errorf("%s is not a struct type declared in %s", name, dir)
+		}
+		fmt.Fprintf(&validates, "\n// Validate checks v against the rules in its validate tags.\n")
+		fmt.Fprintf(&validates, "func (v %s) Validate() error {\n", name)
+		fmt.Fprintf(&validates, "\tif errs := v.appendValidationErrors(\"\", nil); len(errs) != 0 {\n\t\treturn errs\n\t}\n\treturn nil\n}\n")
+		g.enqueue(name)
+	}
+
+	for len(g.queue) != 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.emitStruct(name); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	g.writeHeader(&out, constraint)
+	out.WriteString("import (\n")
+	if g.needConv {
+		out.WriteString("\t\"strconv\"\n\n")
+	}
+	out.WriteString("\t\"github.com/Nadya2002/validator\"\n)\n")
+	out.Write(validates.Bytes())
+	out.Write(g.methods.Bytes())
+
+	return format.Source(out.Bytes())
+}
+
+// newGenerator returns a generator for the package in dir, without the file
+// outName, after checking the build constraint expression constraint.
+func newGenerator(dir, outName, constraint string) (*generator, error) {
+	if constraint != "" {
+		if _, err := buildconstraint.Parse("//go:build " + constraint); err != nil {
+			return nil, err
+		}
+	}
+	g := &generator{
+		fset:    token.NewFileSet(),
+		decls:   map[string]ast.Expr{},
+		aliases: map[string]bool{},
+		valuers: map[string]bool{},
+		queued:  map[string]bool{},
+	}
+	if err := g.load(dir, outName); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// writeHeader writes the header of the output file to out, up to the package
+// clause, with the build constraint expression constraint if any.
+func (g *generator) writeHeader(out *bytes.Buffer, constraint string) {
+	out.WriteString(header)
+	if constraint != "" {
+		fmt.Fprintf(out, "\n//go:build %s\n", constraint)
+	}
+	fmt.Fprintf(out, "\npackage %s\n\n", g.pkg)
+}
+
+// load parses the Go files of the package in dir that are part of the build,
+// except outName and other generated files.
+func (g *generator) load(dir, outName string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outName {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if bytes.HasPrefix(src, []byte(header)) {
+			continue
+		}
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), src, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		if g.pkg != "" && g.pkg != file.Name.Name {
+			return fmt.Errorf("found packages %s and %s in %s", g.pkg, file.Name.Name, dir)
+		}
+		g.pkg = file.Name.Name
+		g.collect(file)
+	}
+	if g.pkg == "" {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+	return nil
+}
+
+func (g *generator) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if spec.TypeParams != nil {
+					continue
+				}
+				g.decls[spec.Name.Name] = spec.Type
+				if spec.Assign.IsValid() {
+					g.aliases[spec.Name.Name] = true
+				} else if _, ok := spec.Type.(*ast.StructType); ok {
+					g.structs = append(g.structs, spec.Name.Name)
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || decl.Name.Name != "Value" && decl.Name.Name != "ValidateValue" {
+				continue
+			}
+			recv := decl.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if id, ok := recv.(*ast.Ident); ok {
+				g.valuers[id.Name] = true
+			}
+		}
+	}
+}
+
+func hasTags(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if tag := tagOf(field); tag != "" && tag != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+func tagOf(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return reflect.StructTag(tag).Get("validate")
+}
+
+func (g *generator) enqueue(name string) {
+	if !g.queued[name] {
+		g.queued[name] = true
+		g.queue = append(g.queue, name)
+	}
+}
+
+// typeOf resolves a field type as far as the validation is concerned.
+func (g *generator) typeOf(expr ast.Expr, depth int) typeInfo {
+	if depth > 16 {
+		return typeInfo{}
+	}
+
+	switch t := expr.(type) {
+	case *ast.ParenExpr:
+		return g.typeOf(t.X, depth+1)
+	case *ast.StarExpr:
+		info := g.typeOf(t.X, depth+1)
+		if info.kind == tags.Other && !info.isStruct() || info.pointer || info.slice {
+			return typeInfo{}
+		}
+		info.pointer = true
+		return info
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return typeInfo{}
+		}
+		info := g.typeOf(t.Elt, depth+1)
+		if info.kind == tags.Other && !info.isStruct() || info.slice || info.pointer && !info.isStruct() {
+			return typeInfo{}
+		}
+		info.slice, info.elemPointer, info.pointer = true, info.pointer, false
+		return info
+	case *ast.Ident:
+		if g.valuers[t.Name] {
+			return typeInfo{}
+		}
+		decl, ok := g.decls[t.Name]
+		if !ok {
+			return typeInfo{kind: basicKinds[t.Name]}
+		}
+		if _, ok := decl.(*ast.StructType); ok {
+			return typeInfo{name: t.Name}
+		}
+		info := g.typeOf(decl, depth+1)
+		if info.isStruct() && !info.pointer && !info.slice && !g.aliases[t.Name] {
+			// A defined struct type does not have the methods of its
+			// underlying type.
+			return typeInfo{}
+		}
+		return info
+	}
+	return typeInfo{}
+}
+
+func (g *generator) emitStruct(name string) error {
+	st := g.decls[name].(*ast.StructType)
+
+	fmt.Fprintf(&g.methods, "\n// appendValidationErrors appends the errors of the fields of v to errs; prefix\n// is the path of v followed by a dot.\n")
+	fmt.Fprintf(&g.methods, "func (v %s) appendValidationErrors(prefix string, errs validator.ValidationErrors) validator.ValidationErrors {\n", name)
+
+	for _, field := range st.Fields.List {
+		cond := tagOf(field)
+		if cond == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if err := g.emitField(field, embeddedName(field.Type), true, cond); err != nil {
+				return fmt.Errorf("%s: %s: %w", g.fset.Position(field.Pos()), name, err)
+			}
+			continue
+		}
+		for _, id := range field.Names {
+			if id.Name == "_" {
+				continue
+			}
+			if err := g.emitField(field, id.Name, false, cond); err != nil {
+				return fmt.Errorf("%s: %s.%s: %w", g.fset.Position(id.Pos()), name, id.Name, err)
+			}
+		}
+	}
+
+	fmt.Fprintf(&g.methods, "\treturn errs\n}\n")
+	return nil
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+func (g *generator) emitField(field *ast.Field, name string, anonymous bool, cond string) error {
+	w := &g.methods
+	if !ast.IsExported(name) {
+		if cond != "" {
+			fmt.Fprintf(w, "\terrs = append(errs, validator.ValidationError{Err: validator.ErrValidateForUnexportedFields})\n")
+		}
+		if !anonymous {
+			return nil
+		}
+		cond = ""
+	}
+
+	rules, err := parseRules(cond)
+	if err != nil {
+		return err
+	}
+	info := g.typeOf(field.Type, 0)
+	x := "v." + name
+
+	if info.isStruct() {
+		return g.emitStructField(rules, info, x, name, anonymous, cond)
+	}
+	if cond == "" {
+		return nil
+	}
+	if info.kind == tags.Other {
+		return fmt.Errorf("rules are not supported for type %s", g.exprString(field.Type))
+	}
+	if err := checkIn(rules, info.kind); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("prefix+%q", name)
+	mode := emptyMode(rules)
+
+	switch {
+	case info.slice:
+		elems := ruleChecks(rules, info.kind, "e")
+		c := joinChecks(elems)
+		required := failure(path, cond, []ruleCheck{{name: "required"}})
+		switch {
+		case c == "" && mode == "required":
+			fmt.Fprintf(w, "\tif len(%s) == 0 {\n\t\t%s\t}\n", x, required)
+		case c == "false":
+			// Every element fails, so only an empty slice can be valid.
+			if mode == "required" {
+				fmt.Fprintf(w, "\t%s", sliceFailure(path, cond, x, true, elems))
+			} else {
+				fmt.Fprintf(w, "\tif len(%s) != 0 {\n\t\t%s\t}\n", x, sliceFailure(path, cond, x, false, elems))
+			}
+		case c != "":
+			loop := fmt.Sprintf("for _, e := range %s {\n\t\tif !(%s) {\n\t\t\t%s\t\t\tbreak\n\t\t}\n\t}\n", x, c, sliceFailure(path, cond, x, false, elems))
+			if mode == "required" {
+				fmt.Fprintf(w, "\tif len(%s) == 0 {\n\t\t%s\t} else {\n\t%s\t}\n", x, required, loop)
+			} else {
+				fmt.Fprintf(w, "\t%s", loop)
+			}
+		}
+
+	case info.pointer:
+		values := ruleChecks(rules, info.kind, "*"+x)
+		c := joinChecks(values)
+		// A nil pointer is empty and satisfies none of the other rules.
+		nilFails := mode == "required" || mode == "" && len(values) != 0
+		isNil := ruleCheck{name: "required", cond: x + " == nil"}
+		if mode != "required" && len(values) != 0 {
+			isNil.name = values[0].name
+		}
+		switch {
+		case c == "":
+			if nilFails {
+				emitIf(w, isNil.cond, failure(path, cond, []ruleCheck{isNil}))
+			}
+		case nilFails:
+			emitIf(w, or(isNil.cond, not(c)), failure(path, cond, append([]ruleCheck{isNil}, failing(values)...)))
+		default:
+			emitIf(w, and(x+" != nil", not(c)), failure(path, cond, failing(values)))
+		}
+
+	default:
+		values := ruleChecks(rules, info.kind, x)
+		c := joinChecks(values)
+		switch mode {
+		case "omitempty":
+			if c != "" {
+				emitIf(w, and(isZero(info.kind, x, false), not(c)), failure(path, cond, failing(values)))
+			}
+		case "required":
+			empty := ruleCheck{name: "required", cond: isZero(info.kind, x, true)}
+			guard := empty.cond
+			if c != "" {
+				guard = or(guard, not(c))
+			}
+			emitIf(w, guard, failure(path, cond, append([]ruleCheck{empty}, failing(values)...)))
+		default:
+			if c != "" {
+				emitIf(w, not(c), failure(path, cond, failing(values)))
+			}
+		}
+	}
+	return nil
+}
+
+// ruleCheck is the condition cond of a rule name: for the values of a field
+// satisfying it, or for the failure of the field, see failure.
+type ruleCheck struct {
+	name string
+	cond string
+}
+
+// failing returns the conditions of the fields failing the rules checked by
+// checks, those failing for every value included, leaving out those that
+// always hold.
+func failing(checks []ruleCheck) []ruleCheck {
+	var failures []ruleCheck
+	for _, check := range checks {
+		if check.cond != "" {
+			failures = append(failures, ruleCheck{name: check.name, cond: not(check.cond)})
+		}
+	}
+	return failures
+}
+
+// failure returns the statement appending the error of the field at path
+// with the rules cond, known not to be valid, to errs: failures are the
+// conditions under which each rule is the first to fail, in the order of
+// the rules, the last one failing otherwise. The rule is the one the
+// reflective validation reports, so no reflection is needed to find it.
+func failure(path, cond string, failures []ruleCheck) string {
+	last := failures[len(failures)-1].name
+	rule := strconv.Quote(last)
+	for _, f := range failures {
+		if f.name == last {
+			continue
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "rule := %s\n", rule)
+		if len(failures) == 2 {
+			fmt.Fprintf(&sb, "if %s {\nrule = %q\n}\n", failures[0].cond, failures[0].name)
+		} else {
+			sb.WriteString("switch {\n")
+			for _, f := range failures[:len(failures)-1] {
+				fmt.Fprintf(&sb, "case %s:\nrule = %q\n", f.cond, f.name)
+			}
+			sb.WriteString("}\n")
+		}
+		return sb.String() + fmt.Sprintf("errs = append(errs, validator.RuleError(%s, %q, rule))\n", path, cond)
+	}
+	return fmt.Sprintf("errs = append(errs, validator.RuleError(%s, %q, %s))\n", path, cond, rule)
+}
+
+// sliceFailure returns the statement appending the error of the slice x at
+// path with the rules cond, known not to be valid, to errs. Like the
+// reflective validation it reports the first of the rules checking the
+// elements, elems, failing for any of them, or required for an empty slice
+// when required is set.
+func sliceFailure(path, cond, x string, required bool, elems []ruleCheck) string {
+	failures := failing(elems)
+	if !required && len(failures) == 1 {
+		return failure(path, cond, failures)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "errs = append(errs, validator.RuleError(%s, %q, func() string {\n", path, cond)
+	if required {
+		fmt.Fprintf(&sb, "if len(%s) == 0 {\nreturn \"required\"\n}\n", x)
+	}
+	for _, f := range failures[:len(failures)-1] {
+		fmt.Fprintf(&sb, "for _, e := range %s {\nif %s {\nreturn %q\n}\n}\n", x, f.cond, f.name)
+	}
+	fmt.Fprintf(&sb, "return %q\n}()))\n", failures[len(failures)-1].name)
+	return sb.String()
+}
+
+// emitStructField descends into a field holding structs. Only the rules
+// controlling the descent, and required for pointers and slices, apply to
+// such fields.
+func (g *generator) emitStructField(rules []tags.Rule, info typeInfo, x, name string, anonymous bool, cond string) error {
+	w := &g.methods
+	descend := true
+	for _, r := range rules {
+		switch r.Name {
+		case "omitempty", "nostructlevel", "sensitive", "bail":
+		case "structonly":
+			descend = false
+		case "required":
+			if !info.pointer && !info.slice {
+				return fmt.Errorf("rule required is not supported for struct fields")
+			}
+		default:
+			return fmt.Errorf("rule %s is not supported for struct fields", r.Name)
+		}
+	}
+
+	if emptyMode(rules) == "required" {
+		fail := failure(fmt.Sprintf("prefix+%q", name), cond, []ruleCheck{{name: "required"}})
+		if info.slice {
+			emitIf(w, "len("+x+") == 0", fail)
+		} else {
+			emitIf(w, x+" == nil", fail)
+		}
+	}
+	if !descend {
+		return nil
+	}
+	g.enqueue(info.name)
+
+	prefix := fmt.Sprintf("prefix+%q", name+".")
+	if anonymous {
+		prefix = "prefix"
+	}
+	switch {
+	case info.slice:
+		g.needConv = true
+		call := fmt.Sprintf("errs = %s[i].appendValidationErrors(prefix+%q+strconv.Itoa(i)+\"].\", errs)\n", x, name+"[")
+		if info.elemPointer {
+			call = fmt.Sprintf("if %s[i] != nil {\n\t\t\t%s\t\t}\n", x, call)
+		}
+		fmt.Fprintf(w, "\tfor i := range %s {\n\t\t%s\t}\n", x, call)
+	case info.pointer:
+		emitIf(w, x+" != nil", fmt.Sprintf("errs = %s.appendValidationErrors(%s, errs)\n", x, prefix))
+	default:
+		fmt.Fprintf(w, "\terrs = %s.appendValidationErrors(%s, errs)\n", x, prefix)
+	}
+	return nil
+}
+
+func (g *generator) exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, g.fset, expr); err != nil {
+		return "?"
+	}
+	return buf.String()
+}
+
+// emptyMode returns "required" or "omitempty", whichever comes first in
+// rules, as it decides about an empty value.
+func emptyMode(rules []tags.Rule) string {
+	for _, r := range rules {
+		if r.Name == "required" || r.Name == "omitempty" {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// joinChecks returns the condition for a value satisfying all of checks, the
+// result of ruleChecks. It is "" when no rule checks the value and "false"
+// when a rule can never be satisfied.
+func joinChecks(checks []ruleCheck) string {
+	var conds []string
+	for _, check := range checks {
+		if check.cond == "false" {
+			return "false"
+		}
+		if check.cond != "" {
+			conds = append(conds, check.cond)
+		}
+	}
+	if len(conds) > 1 {
+		for i, cond := range conds {
+			if strings.Contains(cond, " || ") {
+				conds[i] = "(" + cond + ")"
+			}
+		}
+	}
+	return strings.Join(conds, " && ")
+}
+
+// ruleChecks returns the conditions for e, a value of kind k, satisfying
+// each of rules other than those deciding about empty values and the
+// descent, up to the first one that can never be satisfied, whose condition
+// is "false". The condition of a rule always satisfied is "".
+func ruleChecks(rules []tags.Rule, k tags.Kind, e string) []ruleCheck {
+	var checks []ruleCheck
+	for _, r := range rules {
+		var cond string
+		switch r.Name {
+		case "required", "omitempty", "structonly", "nostructlevel", "sensitive", "bail":
+			continue
+		case "len":
+			cond = "false"
+			if k == tags.String {
+				cond = fmt.Sprintf("len(%s) == %d", e, r.Nums[0])
+			}
+		case "min", "max":
+			op := ">="
+			if r.Name == "max" {
+				op = "<="
+			}
+			cond = compareCond(k, e, op, r.Nums[0])
+		case "in":
+			var alts []string
+			if k == tags.String {
+				for _, arg := range r.Args {
+					alts = append(alts, e+" == "+strconv.Quote(arg))
+				}
+			} else {
+				for _, arg := range r.Args {
+					if alt := inCond(k, e, arg); alt != "false" {
+						alts = append(alts, alt)
+					}
+				}
+			}
+			switch len(alts) {
+			case 0:
+				cond = "false"
+			case 1:
+				cond = alts[0]
+			default:
+				cond = strings.Join(alts, " || ")
+			}
+		default:
+			cond = "false"
+			if k == tags.String {
+				cond = fmt.Sprintf("validator.CheckFormat(%q, %s)", r.Name, e)
+			}
+		}
+
+		checks = append(checks, ruleCheck{name: r.Name, cond: cond})
+		if cond == "false" {
+			break
+		}
+	}
+	return checks
+}
+
+// compareCond returns the condition comparing e of kind k with num using op.
+// For a string it compares the length. A negative num is less than any
+// unsigned number: "" then means the condition always holds.
+func compareCond(k tags.Kind, e, op string, num int) string {
+	switch k {
+	case tags.String:
+		if op == "==" {
+			return "false"
+		}
+		return fmt.Sprintf("len(%s) %s %d", e, op, num)
+	case tags.Int:
+		return fmt.Sprintf("int64(%s) %s %d", e, op, num)
+	case tags.Uint:
+		if num < 0 {
+			if op == ">=" {
+				return ""
+			}
+			return "false"
+		}
+		return fmt.Sprintf("uint64(%s) %s %d", e, op, num)
+	case tags.Float:
+		return fmt.Sprintf("float64(%s) %s %d", e, op, num)
+	}
+	return "false"
+}
+
+// inCond returns the condition for e of kind k being equal to arg, an
+// argument of an in rule checked by checkIn.
+func inCond(k tags.Kind, e, arg string) string {
+	value, err := tags.ParseIn(arg, k)
+	if err != nil {
+		return "false"
+	}
+	switch k {
+	case tags.Int:
+		return fmt.Sprintf("int64(%s) == %d", e, value)
+	case tags.Uint:
+		return fmt.Sprintf("uint64(%s) == %d", e, value)
+	case tags.Float:
+		return fmt.Sprintf("float64(%s) == %s", e, strconv.FormatFloat(value.(float64), 'g', -1, 64))
+	case tags.Bool:
+		if value.(bool) {
+			return e
+		}
+		return "!" + e
+	}
+	return "false"
+}
+
+// checkIn returns an error when an argument of an in rule of rules is not a
+// value of kind k.
+func checkIn(rules []tags.Rule, k tags.Kind) error {
+	for _, r := range rules {
+		if r.Name != "in" || k == tags.String {
+			continue
+		}
+		for _, arg := range r.Args {
+			if _, err := tags.ParseIn(arg, k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isZero returns the condition for x of kind k being the zero value, or not
+// being it when zero is false.
+func isZero(k tags.Kind, x string, zero bool) string {
+	switch {
+	case k == tags.Bool && zero:
+		return "!" + x
+	case k == tags.Bool:
+		return x
+	case k == tags.String && zero:
+		return x + ` == ""`
+	case k == tags.String:
+		return x + ` != ""`
+	case zero:
+		return x + " == 0"
+	}
+	return x + " != 0"
+}
+
+func not(c string) string {
+	switch {
+	case c == "false":
+		return "true"
+	case !strings.Contains(c, " "):
+		return "!" + c
+	}
+	return "!(" + c + ")"
+}
+
+func and(a, b string) string {
+	if b == "true" {
+		return a
+	}
+	return a + " && " + b
+}
+
+func or(a, b string) string {
+	if b == "true" {
+		return b
+	}
+	return a + " || " + b
+}
+
+// emitIf writes body guarded by cond, or unguarded when cond always holds.
+func emitIf(w *bytes.Buffer, cond, body string) {
+	if cond == "true" {
+		fmt.Fprintf(w, "\t%s", body)
+		return
+	}
+	fmt.Fprintf(w, "\tif %s {\n\t\t%s\t}\n", cond, body)
+}
+
+// parseRules parses the rules of a validate tag, rejecting the rules that
+// can only be checked with the registries of the validator package.
+func parseRules(cond string) ([]tags.Rule, error) {
+	if cond == "" {
+		return nil, nil
+	}
+
+	rules, err := tags.Parse(cond, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.Warn {
+			return nil, fmt.Errorf("warnings are not supported")
+		}
+		if r.Refs {
+			return nil, fmt.Errorf("rule %s referencing a field is not supported", r.Name)
+		}
+		if r.Name == "password" || r.Name == "regexp" || r.Name == "groups" || r.Name == "default" || r.Name == "in_ci" || r.Name == "enum" || r.Name == "astext" || r.Name == "asnum" || r.Name == "keys" || r.Name == "values" || r.Name == "jsonschema" || r.Name == "if" || r.Name == "within" || r.Name == "card_expiry" || r.Name == "minage" || r.Name == "checksum" || r.Name == "uniquefield" || r.Name == "sumfield" || r.Name == "maxbytes" || r.Name == "notnil" || r.Name == "or" || r.Name == "continue" {
+			return nil, fmt.Errorf("rule %s is not supported", r.Name)
+		}
+	}
+	return rules, nil
+}
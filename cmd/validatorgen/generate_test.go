@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateExample(t *testing.T) {
+	src, err := generate("internal/example", "example_validate.go", []string{"User", "Order"}, "")
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("internal/example/example_validate.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(src), "run go generate in internal/example")
+}
+
+func TestGenerateBuildConstraint(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "t.go"), []byte("package t\n\ntype T struct {\n\tA string `validate:\"min:1\"`\n}\n"), 0o644))
+
+	src, err := generate(dir, "t_validate.go", nil, "js && wasm")
+	require.NoError(t, err)
+	assert.Contains(t, string(src), "DO NOT EDIT.\n\n//go:build js && wasm\n\npackage t\n")
+
+	_, err = generate(dir, "t_validate.go", nil, "js &&")
+	assert.Error(t, err)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{
+			name: "custom rule",
+			src:  "type T struct {\n\tA string `validate:\"unique_email\"`\n}",
+			err:  `T.A: invalid validator syntax: unknown rule "unique_email"`,
+		},
+		{
+			name: "syntax",
+			src:  "type T struct {\n\tA string `validate:\"min\"`\n}",
+			err:  "T.A: invalid validator syntax: rule min needs arguments",
+		},
+		{
+			name: "in argument",
+			src:  "type T struct {\n\tA int `validate:\"in:1,a\"`\n}",
+			err:  `T.A: invalid validator syntax: argument "a" of rule in is not an integer`,
+		},
+		{
+			name: "password",
+			src:  "type T struct {\n\tA string `validate:\"password:strong\"`\n}",
+			err:  "T.A: rule password is not supported",
+		},
+		{
+			name: "groups",
+			src:  "type T struct {\n\tA string `validate:\"required&groups:create\"`\n}",
+			err:  "T.A: rule groups is not supported",
+		},
+		{
+			name: "warning",
+			src:  "type T struct {\n\tA string `validate:\"warn:min:1\"`\n}",
+			err:  "T.A: warnings are not supported",
+		},
+		{
+			name: "unsupported type",
+			src:  "type T struct {\n\tA map[string]int `validate:\"required\"`\n}",
+			err:  "T.A: rules are not supported for type map[string]int",
+		},
+		{
+			name: "rule on struct",
+			src:  "type T struct {\n\tA S `validate:\"min:1\"`\n}\n\ntype S struct{}",
+			err:  "T.A: rule min is not supported for struct fields",
+		},
+		{
+			name: "valuer",
+			src:  "type T struct {\n\tA N `validate:\"min:1\"`\n}\n\ntype N int\n\nfunc (N) Value() (driver.Value, error) { return nil, nil }",
+			err:  "T.A: rules are not supported for type N",
+		},
+		{
+			name: "no tags",
+			src:  "type T struct{ A string }",
+			err:  "no struct types with validate tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "t.go"), []byte("package t\n\n"+tt.src+"\n"), 0o644))
+
+			_, err := generate(dir, "t_validate.go", nil, "")
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
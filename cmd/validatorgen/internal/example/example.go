@@ -0,0 +1,62 @@
+// Package example holds struct types with Validate methods generated by
+// validatorgen. Its tests check that they behave like the reflective
+// validation.
+package example
+
+//go:generate go run github.com/Nadya2002/validator/cmd/validatorgen -type User,Order
+//go:generate go run github.com/Nadya2002/validator/cmd/validatorgen -comments -type Signup -output example_rules.go
+
+type Status string
+
+type Base struct {
+	ID string `validate:"required&ulid"`
+}
+
+type Address struct {
+	Street string `validate:"omitempty&min:3&max:64"`
+	Zip    string `validate:"len:5"`
+}
+
+type User struct {
+	Base
+	Name     string   `validate:"required&min:2&printable_unicode"`
+	Email    string   `validate:"email"`
+	Age      int      `validate:"min:18&max:130"`
+	Level    uint8    `validate:"in:1,2,3"`
+	Score    float64  `validate:"omitempty&max:100"`
+	Ratio    float32  `validate:"in:0.5,1.5"`
+	Status   Status   `validate:"in:active,blocked"`
+	Nickname *string  `validate:"omitempty&no_html"`
+	Invited  *int     `validate:"min:1"`
+	Tags     []string `validate:"omitempty&min:2"`
+	Labels   []string `validate:"min:2&max:4"`
+	Codes    []int    `validate:"required&in:7,8"`
+	Admin    bool     `validate:"required"`
+	Address  Address
+	Previous *Address
+	password string
+	Ignored  string `validate:"-"`
+}
+
+type LineItem struct {
+	SKU      string `validate:"objectid"`
+	Quantity int    `validate:"min:1"`
+}
+
+type Order struct {
+	Buyer  *User `validate:"required&nostructlevel"`
+	Items  []LineItem
+	Extras []*LineItem
+	Notes  Address `validate:"structonly"`
+	secret string  `validate:"len:5"`
+}
+
+// Signup has the rules of its fields in their comments.
+type Signup struct {
+	// validate: required & min:3 & max:32
+	Login string
+	Email string // validate: required & email
+	// validate: omitempty
+	// validate: in:en,ru
+	Locale string
+}
@@ -0,0 +1,103 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+func validUser() User {
+	invited := 3
+	return User{
+		Base:    Base{ID: "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		Name:    "Nadya",
+		Email:   "nadya@example.com",
+		Age:     30,
+		Level:   2,
+		Ratio:   1.5,
+		Status:  "active",
+		Invited: &invited,
+		Codes:   []int{7, 8},
+		Admin:   true,
+		Address: Address{Zip: "12345"},
+	}
+}
+
+func TestGeneratedValidate(t *testing.T) {
+	html, name := "<b>hi</b>", "plain"
+	zero := 0
+
+	tests := []struct {
+		name   string
+		modify func(u *User)
+	}{
+		{name: "valid"},
+		{name: "zero", modify: func(u *User) { *u = User{} }},
+		{name: "bad id", modify: func(u *User) { u.ID = "nope" }},
+		{name: "short name", modify: func(u *User) { u.Name = "N" }},
+		{name: "bad email", modify: func(u *User) { u.Email = "nadya" }},
+		{name: "age", modify: func(u *User) { u.Age, u.Level = 150, 4 }},
+		{name: "score", modify: func(u *User) { u.Score = 100.5 }},
+		{name: "ratio", modify: func(u *User) { u.Ratio = 1 }},
+		{name: "status", modify: func(u *User) { u.Status = "gone" }},
+		{name: "nickname", modify: func(u *User) { u.Nickname = &html }},
+		{name: "valid nickname", modify: func(u *User) { u.Nickname = &name }},
+		{name: "not invited", modify: func(u *User) { u.Invited = nil }},
+		{name: "invited zero", modify: func(u *User) { u.Invited = &zero }},
+		{name: "tags", modify: func(u *User) { u.Tags = []string{"go", "x"} }},
+		{name: "labels", modify: func(u *User) { u.Labels = []string{"toolong", "x"} }},
+		{name: "long label", modify: func(u *User) { u.Labels = []string{"ok", "toolong"} }},
+		{name: "codes", modify: func(u *User) { u.Codes = []int{7, 9} }},
+		{name: "no codes", modify: func(u *User) { u.Codes = nil }},
+		{name: "address", modify: func(u *User) { u.Address = Address{Street: "ab", Zip: "1"} }},
+		{name: "previous", modify: func(u *User) { u.Previous = &Address{Zip: "9"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := validUser()
+			if tt.modify != nil {
+				tt.modify(&u)
+			}
+			assert.Equal(t, validator.Validate(u), u.Validate())
+		})
+	}
+}
+
+func TestGeneratedValidateNested(t *testing.T) {
+	buyer := validUser()
+	buyer.Address.Zip = ""
+
+	orders := []Order{
+		{},
+		{Buyer: &buyer},
+		{
+			Buyer:  &User{},
+			Items:  []LineItem{{SKU: "507f1f77bcf86cd799439011", Quantity: 1}, {SKU: "x"}},
+			Extras: []*LineItem{nil, {Quantity: 2}},
+			Notes:  Address{Zip: "1"},
+		},
+	}
+	for _, o := range orders {
+		err := o.Validate()
+		assert.Error(t, err)
+		assert.Equal(t, validator.Validate(o), err)
+	}
+}
+
+func TestCommentRules(t *testing.T) {
+	assert.NoError(t, validator.Validate(Signup{Login: "nadya", Email: "nadya@example.com"}))
+	assert.NoError(t, validator.Validate(Signup{Login: "nadya", Email: "nadya@example.com", Locale: "ru"}))
+
+	err := validator.Validate(Signup{Login: "na", Email: "nadya", Locale: "de"})
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field())
+	}
+	assert.Equal(t, []string{"Login", "Email", "Locale"}, fields)
+}
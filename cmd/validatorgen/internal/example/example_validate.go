@@ -0,0 +1,168 @@
+// Code generated by validatorgen. DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+
+	"github.com/Nadya2002/validator"
+)
+
+// Validate checks v against the rules in its validate tags.
+func (v User) Validate() error {
+	if errs := v.appendValidationErrors("", nil); len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks v against the rules in its validate tags.
+func (v Order) Validate() error {
+	if errs := v.appendValidationErrors("", nil); len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// appendValidationErrors appends the errors of the fields of v to errs; prefix
+// is the path of v followed by a dot.
+func (v User) appendValidationErrors(prefix string, errs validator.ValidationErrors) validator.ValidationErrors {
+	errs = v.Base.appendValidationErrors(prefix, errs)
+	if v.Name == "" || !(len(v.Name) >= 2 && validator.CheckFormat("printable_unicode", v.Name)) {
+		rule := "printable_unicode"
+		switch {
+		case v.Name == "":
+			rule = "required"
+		case !(len(v.Name) >= 2):
+			rule = "min"
+		}
+		errs = append(errs, validator.RuleError(prefix+"Name", "required&min:2&printable_unicode", rule))
+	}
+	if !(validator.CheckFormat("email", v.Email)) {
+		errs = append(errs, validator.RuleError(prefix+"Email", "email", "email"))
+	}
+	if !(int64(v.Age) >= 18 && int64(v.Age) <= 130) {
+		rule := "max"
+		if !(int64(v.Age) >= 18) {
+			rule = "min"
+		}
+		errs = append(errs, validator.RuleError(prefix+"Age", "min:18&max:130", rule))
+	}
+	if !(uint64(v.Level) == 1 || uint64(v.Level) == 2 || uint64(v.Level) == 3) {
+		errs = append(errs, validator.RuleError(prefix+"Level", "in:1,2,3", "in"))
+	}
+	if v.Score != 0 && !(float64(v.Score) <= 100) {
+		errs = append(errs, validator.RuleError(prefix+"Score", "omitempty&max:100", "max"))
+	}
+	if !(float64(v.Ratio) == 0.5 || float64(v.Ratio) == 1.5) {
+		errs = append(errs, validator.RuleError(prefix+"Ratio", "in:0.5,1.5", "in"))
+	}
+	if !(v.Status == "active" || v.Status == "blocked") {
+		errs = append(errs, validator.RuleError(prefix+"Status", "in:active,blocked", "in"))
+	}
+	if v.Nickname != nil && !(validator.CheckFormat("no_html", *v.Nickname)) {
+		errs = append(errs, validator.RuleError(prefix+"Nickname", "omitempty&no_html", "no_html"))
+	}
+	if v.Invited == nil || !(int64(*v.Invited) >= 1) {
+		errs = append(errs, validator.RuleError(prefix+"Invited", "min:1", "min"))
+	}
+	for _, e := range v.Tags {
+		if !(len(e) >= 2) {
+			errs = append(errs, validator.RuleError(prefix+"Tags", "omitempty&min:2", "min"))
+			break
+		}
+	}
+	for _, e := range v.Labels {
+		if !(len(e) >= 2 && len(e) <= 4) {
+			errs = append(errs, validator.RuleError(prefix+"Labels", "min:2&max:4", func() string {
+				for _, e := range v.Labels {
+					if !(len(e) >= 2) {
+						return "min"
+					}
+				}
+				return "max"
+			}()))
+			break
+		}
+	}
+	if len(v.Codes) == 0 {
+		errs = append(errs, validator.RuleError(prefix+"Codes", "required&in:7,8", "required"))
+	} else {
+		for _, e := range v.Codes {
+			if !(int64(e) == 7 || int64(e) == 8) {
+				errs = append(errs, validator.RuleError(prefix+"Codes", "required&in:7,8", "in"))
+				break
+			}
+		}
+	}
+	if !v.Admin {
+		errs = append(errs, validator.RuleError(prefix+"Admin", "required", "required"))
+	}
+	errs = v.Address.appendValidationErrors(prefix+"Address.", errs)
+	if v.Previous != nil {
+		errs = v.Previous.appendValidationErrors(prefix+"Previous.", errs)
+	}
+	return errs
+}
+
+// appendValidationErrors appends the errors of the fields of v to errs; prefix
+// is the path of v followed by a dot.
+func (v Order) appendValidationErrors(prefix string, errs validator.ValidationErrors) validator.ValidationErrors {
+	if v.Buyer == nil {
+		errs = append(errs, validator.RuleError(prefix+"Buyer", "required&nostructlevel", "required"))
+	}
+	if v.Buyer != nil {
+		errs = v.Buyer.appendValidationErrors(prefix+"Buyer.", errs)
+	}
+	for i := range v.Items {
+		errs = v.Items[i].appendValidationErrors(prefix+"Items["+strconv.Itoa(i)+"].", errs)
+	}
+	for i := range v.Extras {
+		if v.Extras[i] != nil {
+			errs = v.Extras[i].appendValidationErrors(prefix+"Extras["+strconv.Itoa(i)+"].", errs)
+		}
+	}
+	errs = append(errs, validator.ValidationError{Err: validator.ErrValidateForUnexportedFields})
+	return errs
+}
+
+// appendValidationErrors appends the errors of the fields of v to errs; prefix
+// is the path of v followed by a dot.
+func (v Base) appendValidationErrors(prefix string, errs validator.ValidationErrors) validator.ValidationErrors {
+	if v.ID == "" || !(validator.CheckFormat("ulid", v.ID)) {
+		rule := "ulid"
+		if v.ID == "" {
+			rule = "required"
+		}
+		errs = append(errs, validator.RuleError(prefix+"ID", "required&ulid", rule))
+	}
+	return errs
+}
+
+// appendValidationErrors appends the errors of the fields of v to errs; prefix
+// is the path of v followed by a dot.
+func (v Address) appendValidationErrors(prefix string, errs validator.ValidationErrors) validator.ValidationErrors {
+	if v.Street != "" && !(len(v.Street) >= 3 && len(v.Street) <= 64) {
+		rule := "max"
+		if !(len(v.Street) >= 3) {
+			rule = "min"
+		}
+		errs = append(errs, validator.RuleError(prefix+"Street", "omitempty&min:3&max:64", rule))
+	}
+	if !(len(v.Zip) == 5) {
+		errs = append(errs, validator.RuleError(prefix+"Zip", "len:5", "len"))
+	}
+	return errs
+}
+
+// appendValidationErrors appends the errors of the fields of v to errs; prefix
+// is the path of v followed by a dot.
+func (v LineItem) appendValidationErrors(prefix string, errs validator.ValidationErrors) validator.ValidationErrors {
+	if !(validator.CheckFormat("objectid", v.SKU)) {
+		errs = append(errs, validator.RuleError(prefix+"SKU", "objectid", "objectid"))
+	}
+	if !(int64(v.Quantity) >= 1) {
+		errs = append(errs, validator.RuleError(prefix+"Quantity", "min:1", "min"))
+	}
+	return errs
+}
@@ -0,0 +1,107 @@
+// Command validatorgen generates reflection-free Validate methods from the
+// validate tags of struct types, for use with go:generate:
+//
+//	//go:generate go run github.com/Nadya2002/validator/cmd/validatorgen -type User,Order
+//
+// For every listed type it writes
+//
+//	func (v User) Validate() error
+//
+// checking the fields with plain Go comparisons. The methods report the
+// same validator.ValidationErrors as validator.Validate does for the
+// reflective path, including the field paths of nested structs and slice
+// elements. Without -type, all struct types with validate tags in the
+// package get a Validate method.
+//
+// The methods use no reflection themselves: they only call
+// validator.RuleError and validator.CheckFormat. The validator package they
+// import still holds the reflective validation, so they build wherever it
+// does, including WebAssembly with GOOS=js or GOOS=wasip1, e.g. for the same
+// checks in the browser and on the server; TinyGo is not supported. With
+// -tags the output file gets a build constraint; builds it excludes have no
+// Validate methods and validate the structs with validator.Validate:
+//
+//	//go:generate go run github.com/Nadya2002/validator/cmd/validatorgen -type User -tags "js && wasm"
+//
+// Rules added with validator.RegisterRule, the password and regexp rules,
+// struct level validations and types registered with
+// validator.RegisterCustomType are not supported. Validatorgen fails on
+// tags it cannot translate instead of generating code with different
+// semantics. Structs in maps and interfaces, and structs of other packages,
+// are not descended into.
+//
+// With -comments validatorgen reads the rules from the comments of the
+// fields instead, keeping long rules out of the struct tags, and writes an
+// init function registering them with validator.RegisterTypeRules, so the
+// reflective validation checks them as if they were tags:
+//
+//	//go:generate go run github.com/Nadya2002/validator/cmd/validatorgen -comments -type User
+//
+//	type User struct {
+//		// validate: required & min:3 & max:32
+//		Name string
+//	}
+//
+// Every rule is supported in comments. Without -type, all struct types with
+// validate comments in the package get their rules registered.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("validatorgen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of type names; default all types with validate tags")
+	output := flag.String("output", "", "output file name; default <file>_validate.go with go:generate, validate_gen.go otherwise")
+	buildTags := flag.String("tags", "", "build constraint of the output file, e.g. \"js && wasm\"; default none")
+	comments := flag.Bool("comments", false, "register the rules in the validate comments of the fields instead of generating Validate methods")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: validatorgen [-comments] [-type T1,T2] [-output file] [-tags constraint] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		dir = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	outName := *output
+	if outName == "" {
+		outName = "validate_gen.go"
+		if file := os.Getenv("GOFILE"); file != "" {
+			outName = strings.TrimSuffix(file, ".go") + "_validate.go"
+		}
+	}
+	outPath := filepath.Join(dir, outName)
+
+	var names []string
+	if *typeNames != "" {
+		names = strings.Split(*typeNames, ",")
+	}
+
+	gen := generate
+	if *comments {
+		gen = generateRegistrations
+	}
+	src, err := gen(dir, filepath.Base(outPath), names, *buildTags)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(outPath, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
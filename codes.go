@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// Codes of errors not about a rule failing for a value.
+const (
+	// CodeInvalid is the code of values that could not be checked at all,
+	// e.g. because a custom type failed to extract their value.
+	CodeInvalid = "VAL_INVALID"
+	// CodeSyntax is the code of tags with invalid syntax.
+	CodeSyntax = "VAL_SYNTAX"
+	// CodeUnexported is the code of tags on unexported fields.
+	CodeUnexported = "VAL_UNEXPORTED"
+	// CodeNotApplicable is the code of rules that do not apply to the type
+	// of their field.
+	CodeNotApplicable = "VAL_NOT_APPLICABLE"
+	// CodeStruct is the code of errors of struct level validations.
+	CodeStruct = "VAL_STRUCT"
+)
+
+var ruleCodes = map[string]string{}
+
+// RegisterRuleCode sets the code of the errors of the rule name, replacing
+// its default code. It is meant to be called during program
+// initialization, like RegisterRule.
+func RegisterRuleCode(name, code string) error {
+	return register("RegisterRuleCode", func() {
+		ruleCodes[name] = code
+	})
+}
+
+// Rule returns the name of the rule the value failed, e.g. "min" for a
+// field tagged "required&min:3", or "" when the error is not about a rule.
+func (e ValidationError) Rule() string {
+	var fe *fieldError
+	if errors.As(e.Err, &fe) {
+		return fe.rule
+	}
+	return ""
+}
+
+// Code returns a stable, machine-readable code for the error, to key
+// messages and monitoring on. The code of a failed rule is "VAL_" followed
+// by its name in upper case, e.g. "VAL_MIN" or "VAL_EMAIL", unless set with
+// RegisterRuleCode. Other errors have one of the Code constants.
+func (e ValidationError) Code() string {
+	var fe *fieldError
+	switch {
+	case errors.As(e.Err, &fe) && fe.rule != "":
+		if code, ok := registered(ruleCodes, fe.rule); ok {
+			return code
+		}
+		return "VAL_" + strings.ToUpper(fe.rule)
+	case fe != nil:
+		return CodeInvalid
+	case errors.Is(e.Err, ErrInvalidValidatorSyntax):
+		return CodeSyntax
+	case errors.Is(e.Err, ErrValidateForUnexportedFields):
+		return CodeUnexported
+	case errors.Is(e.Err, ErrRuleNotApplicable):
+		return CodeNotApplicable
+	}
+	return CodeStruct
+}
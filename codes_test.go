@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type codesUser struct {
+	Name  string `validate:"required&min:3"`
+	Email string `validate:"email"`
+	Role  string `validate:"in:admin,user"`
+	Team  string `validate:"team"`
+}
+
+type codesBroken struct {
+	Name   string `validate:"min:x"`
+	hidden string `validate:"len:1"`
+}
+
+func TestValidationErrorCode(t *testing.T) {
+	RegisterRule("team", func(ctx context.Context, field reflect.Value, params []string) error {
+		if field.String() != "core" {
+			return errors.New("unknown team")
+		}
+		return nil
+	})
+	RegisterRuleCode("team", "TEAM_UNKNOWN")
+	t.Cleanup(func() {
+		delete(customRules, "team")
+		delete(ruleCodes, "team")
+	})
+
+	err := Validate(codesUser{Name: "ab", Email: "nope", Role: "guest", Team: "ops"})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 4)
+
+	tests := []struct {
+		field string
+		rule  string
+		code  string
+	}{
+		{"Name", "min", "VAL_MIN"},
+		{"Email", "email", "VAL_EMAIL"},
+		{"Role", "in", "VAL_IN"},
+		{"Team", "team", "TEAM_UNKNOWN"},
+	}
+	for i, tt := range tests {
+		assert.Equal(t, tt.field, errs[i].Field())
+		assert.Equal(t, tt.rule, errs[i].Rule())
+		assert.Equal(t, tt.code, errs[i].Code())
+	}
+
+	err = Validate(codesUser{Email: "a@b.c", Role: "user", Team: "core"})
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "required", errs[0].Rule())
+	assert.Equal(t, "VAL_REQUIRED", errs[0].Code())
+}
+
+func TestValidationErrorCodeNotRule(t *testing.T) {
+	err := Validate(codesBroken{})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "", errs[0].Rule())
+	assert.Equal(t, CodeSyntax, errs[0].Code())
+	assert.Equal(t, CodeUnexported, errs[1].Code())
+
+	err = Lint(reflect.TypeOf(struct {
+		Age int `validate:"email"`
+	}{}))
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, CodeNotApplicable, errs[0].Code())
+
+	assert.Equal(t, CodeStruct, ValidationError{Err: errors.New("dates overlap")}.Code())
+}
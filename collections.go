@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// minParallelElems is the number of elements from which a collection is
+// split between goroutines by WithParallelism. Smaller collections are
+// validated faster than goroutines are started.
+const minParallelElems = 256
+
+// validateElems validates the structs held by the elements of the slice,
+// array or map value found at path.
+func (s *validation) validateElems(path fieldPath, value reflect.Value, structLevel bool) {
+	var keys []reflect.Value
+	if value.Kind() == reflect.Map {
+		keys = sortedKeys(value)
+	}
+
+	elem := func(s *validation, i int) {
+		if keys == nil {
+			s.validateElem(path.index(i), value.Index(i), structLevel)
+		} else {
+			s.validateElem(path.key(keys[i]), value.MapIndex(keys[i]), structLevel)
+		}
+	}
+
+	n := value.Len()
+	if s.parallelism <= 1 || n < minParallelElems {
+		for i := 0; i < n && !s.canceled(); i++ {
+			elem(s, i)
+		}
+		return
+	}
+
+	// Every goroutine validates a contiguous range of elements and collects
+	// its own errors, which are merged in order. Nested collections are
+	// validated sequentially to keep the number of goroutines bounded.
+	workers := make([]validation, s.parallelism)
+	size := (n + len(workers) - 1) / len(workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		lo, hi := w*size, (w+1)*size
+		if hi > n {
+			hi = n
+		}
+		workers[w] = validation{config: s.config, ctx: s.ctx, plans: s.plans}
+		workers[w].parallelism = 1
+		// The workers validate the elements below the same structs.
+		workers[w].depth, workers[w].ancestors = s.depth, s.ancestors
+		workers[w].deeper = append([]visit(nil), s.deeper...)
+
+		wg.Add(1)
+		go func(worker *validation, lo, hi int) {
+			defer wg.Done()
+			defer worker.recoverPanic()
+			for i := lo; i < hi && !worker.canceled(); i++ {
+				elem(worker, i)
+			}
+		}(&workers[w], lo, hi)
+	}
+	wg.Wait()
+
+	for _, worker := range workers {
+		for i := range worker.pending {
+			worker.pending[i].at += len(s.errors)
+		}
+		s.errors = append(s.errors, worker.errors...)
+		s.warnings = append(s.warnings, worker.warnings...)
+		s.changes = append(s.changes, worker.changes...)
+		s.pending = append(s.pending, worker.pending...)
+		s.checked += worker.checked
+		if s.err == nil {
+			s.err = worker.err
+		}
+	}
+}
+
+func (s *validation) validateElem(path fieldPath, value reflect.Value, structLevel bool) {
+	if nested, ok := nestedStruct(value); ok {
+		s.validateStruct(path, nested, structLevel)
+	}
+}
+
+// sortedKeys returns the keys of the map m in a deterministic order, so that
+// errors are reported in the same order on every call.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i], keys[j])
+	})
+	return keys
+}
+
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
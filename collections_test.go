@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type lineItem struct {
+	SKU      string `validate:"len:4"`
+	Quantity int    `validate:"min:1"`
+}
+
+func TestValidateCollectionsOfStructs(t *testing.T) {
+	type order struct {
+		Items    []lineItem
+		Pointers []*lineItem
+		Fixed    [2]lineItem
+		ByName   map[string]lineItem
+		Any      []any
+		Skipped  []lineItem `validate:"structonly"`
+		Tags     []string   `validate:"max:3"`
+	}
+
+	err := Validate(order{
+		Items:    []lineItem{{"ABCD", 1}, {"AB", 1}, {"ABCD", 0}},
+		Pointers: []*lineItem{nil, {"ABCD", 0}},
+		Fixed:    [2]lineItem{{"ABCD", 1}, {"ABCDE", 1}},
+		ByName:   map[string]lineItem{"b": {"B", 1}, "a": {"A", 1}, "c": {"ABCD", 1}},
+		Any:      []any{"not a struct", lineItem{"ABCD", -1}},
+		Skipped:  []lineItem{{}},
+		Tags:     []string{"a"},
+	})
+	assert.Error(t, err)
+
+	var got []string
+	for _, e := range err.(ValidationErrors) {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"field: Items[1].SKU not valid for len:4",
+		"field: Items[2].Quantity not valid for min:1",
+		"field: Pointers[1].Quantity not valid for min:1",
+		"field: Fixed[1].SKU not valid for len:4",
+		"field: ByName[a].SKU not valid for len:4",
+		"field: ByName[b].SKU not valid for len:4",
+		"field: Any[1].Quantity not valid for min:1",
+	}, got)
+}
+
+func TestValidateDeepPath(t *testing.T) {
+	type level struct {
+		Name string `validate:"required"`
+		Next *level
+	}
+	root := &level{Name: "0"}
+	current := root
+	for i := 1; i < 12; i++ {
+		current.Next = &level{Name: fmt.Sprint(i)}
+		current = current.Next
+	}
+	current.Name = ""
+
+	err := Validate(*root)
+	assert.Error(t, err)
+	assert.Equal(t, "field: Next.Next.Next.Next.Next.Next.Next.Next.Next.Next.Next.Name not valid for required", err.Error())
+}
+
+func TestWithParallelism(t *testing.T) {
+	type batch struct {
+		Items  []lineItem
+		ByID   map[int]*lineItem
+		Nested []struct {
+			Items []lineItem
+		}
+	}
+
+	b := batch{ByID: map[int]*lineItem{}}
+	for i := 0; i < 10000; i++ {
+		item := lineItem{SKU: "ABCD", Quantity: 1}
+		if i%7 == 0 {
+			item.SKU = "A"
+		}
+		if i%13 == 0 {
+			item.Quantity = 0
+		}
+		b.Items = append(b.Items, item)
+		b.ByID[i] = &item
+	}
+	b.Nested = make([]struct{ Items []lineItem }, 300)
+	b.Nested[299].Items = b.Items[:300]
+
+	want := Validate(b)
+	assert.Error(t, want)
+	assert.Len(t, want.(ValidationErrors), 2*(1429+770)+43+24)
+
+	for _, n := range []int{2, 3, 8, 64} {
+		assert.Equal(t, want, Validate(b, WithParallelism(n)), n)
+	}
+	assert.NoError(t, Validate(batch{Items: b.Items[1:7]}, WithParallelism(4)))
+}
@@ -0,0 +1,125 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// validateHexColor checks that field is a hexadecimal color like "#fff",
+// "#ffff", "#ffffff" or "#ffffff80".
+func validateHexColor(field string) error {
+	hex, ok := strings.CutPrefix(field, "#")
+	if !ok {
+		return ErrFieldNotValid
+	}
+	switch len(hex) {
+	case 3, 4, 6, 8:
+	default:
+		return ErrFieldNotValid
+	}
+	for i := 0; i < len(hex); i++ {
+		if !isHex(hex[i]) {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// validateRGB checks that field is a CSS color like "rgb(255, 0, 128)" or
+// "rgb(100%, 0%, 50%)", with components either all numbers from 0 to 255 or
+// all percentages from 0% to 100%.
+func validateRGB(field string) error {
+	args, ok := colorArgs(field, "rgb", 3)
+	if !ok || !rgbComponents(args) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// validateRGBA checks that field is a CSS color like "rgba(255, 0, 128, 0.5)",
+// the components of rgb followed by an alpha from 0 to 1 or from 0% to 100%.
+func validateRGBA(field string) error {
+	args, ok := colorArgs(field, "rgba", 4)
+	if !ok || !rgbComponents(args[:3]) || !alphaComponent(args[3]) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// validateHSL checks that field is a CSS color like "hsl(120, 100%, 50%)": a
+// hue from 0 to 360 degrees and a saturation and a lightness from 0% to 100%.
+func validateHSL(field string) error {
+	args, ok := colorArgs(field, "hsl", 3)
+	if !ok {
+		return ErrFieldNotValid
+	}
+	hue, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+	if err != nil || hue < 0 || hue > 360 {
+		return ErrFieldNotValid
+	}
+	for _, arg := range args[1:] {
+		if !percent(arg) {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// colorArgs returns the n comma-separated arguments of the CSS function fn
+// in field, like the components of "rgb(255, 0, 0)".
+func colorArgs(field, fn string, n int) ([]string, bool) {
+	inner, ok := strings.CutPrefix(field, fn+"(")
+	if !ok {
+		return nil, false
+	}
+	if inner, ok = strings.CutSuffix(inner, ")"); !ok {
+		return nil, false
+	}
+	args := strings.Split(inner, ",")
+	if len(args) != n {
+		return nil, false
+	}
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+	return args, true
+}
+
+// rgbComponents reports whether args are all numbers from 0 to 255 or all
+// percentages.
+func rgbComponents(args []string) bool {
+	if strings.HasSuffix(args[0], "%") {
+		for _, arg := range args {
+			if !percent(arg) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, arg := range args {
+		if n, err := strconv.Atoi(arg); err != nil || n < 0 || n > 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// alphaComponent reports whether arg is an alpha, a number from 0 to 1 or a
+// percentage.
+func alphaComponent(arg string) bool {
+	if strings.HasSuffix(arg, "%") {
+		return percent(arg)
+	}
+	f, err := strconv.ParseFloat(arg, 64)
+	return err == nil && f >= 0 && f <= 1
+}
+
+// percent reports whether arg is a percentage from 0% to 100%.
+func percent(arg string) bool {
+	number, ok := strings.CutSuffix(arg, "%")
+	if !ok {
+		return false
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	return err == nil && f >= 0 && f <= 100
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateColors(t *testing.T) {
+	tests := []struct {
+		rule    string
+		valid   []string
+		invalid []string
+	}{
+		{
+			rule:    "hexcolor",
+			valid:   []string{"#fff", "#FFF8", "#ff8800", "#Ff880080"},
+			invalid: []string{"", "fff", "#ff", "#fffff", "#ff88001", "#ggg", "# fff"},
+		},
+		{
+			rule:    "rgb",
+			valid:   []string{"rgb(255, 136, 0)", "rgb(0,0,0)", "rgb(100%, 50%, 0%)", "rgb( 1 , 2 , 3 )"},
+			invalid: []string{"", "rgb(256, 0, 0)", "rgb(-1, 0, 0)", "rgb(1, 2)", "rgb(1, 2, 3, 4)", "rgb(100%, 0, 0)", "rgb(101%, 0%, 0%)", "rgb(1.5, 2, 3)", "rgba(1, 2, 3)", "rgb(1, 2, 3"},
+		},
+		{
+			rule:    "rgba",
+			valid:   []string{"rgba(255, 136, 0, 0.5)", "rgba(0, 0, 0, 0)", "rgba(0, 0, 0, 1)", "rgba(10%, 20%, 30%, 50%)"},
+			invalid: []string{"", "rgba(255, 136, 0)", "rgba(255, 136, 0, 1.5)", "rgba(255, 136, 0, -0.1)", "rgba(255, 136, 256, 0.5)", "rgb(1, 2, 3, 0.5)"},
+		},
+		{
+			rule:    "hsl",
+			valid:   []string{"hsl(32, 100%, 50%)", "hsl(0, 0%, 0%)", "hsl(360, 0%, 100%)", "hsl(120deg, 50.5%, 25%)"},
+			invalid: []string{"", "hsl(361, 100%, 50%)", "hsl(-1, 100%, 50%)", "hsl(32, 100, 50)", "hsl(32, 101%, 50%)", "hsl(32, 100%)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			for _, s := range tt.valid {
+				assert.NoError(t, ValidateVar(s, tt.rule), s)
+			}
+			for _, s := range tt.invalid {
+				assert.Error(t, ValidateVar(s, tt.rule), s)
+			}
+			assert.Error(t, ValidateVar(1, tt.rule))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Typed validates values of the struct type T with rules parsed once by
+// Compile, so validation does no tag parsing at all. A Typed is safe for
+// concurrent use; its rules are those registered when it was compiled.
+type Typed[T any] struct {
+	config config
+	plans  map[reflect.Type]*structPlan
+}
+
+// Compile parses the validate tags of the struct type T and of the structs
+// nested in it. Tags that can never be applied, like unexported tagged fields
+// or invalid rule syntax, are reported by Compile instead of by every
+// validation.
+//
+//	users, err := validator.Compile[User]()
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	...
+//	err = users.Validate(u)
+func Compile[T any](opts ...Option) (*Typed[T], error) {
+	typeV := reflect.TypeOf((*T)(nil)).Elem()
+	if typeV.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	t := &Typed[T]{config: newConfig(opts), plans: map[reflect.Type]*structPlan{}}
+
+	var errs ValidationErrors
+	collectPlans(&t.config, typeV, t.plans, &errs)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return t, nil
+}
+
+// Validate validates v like the package level Validate function.
+func (t *Typed[T]) Validate(v T) error {
+	return t.ValidateCtx(context.Background(), v)
+}
+
+// ValidateCtx validates v like the package level ValidateCtx function.
+func (t *Typed[T]) ValidateCtx(ctx context.Context, v T) error {
+	s := validation{config: t.config, ctx: ctx, plans: t.plans}
+	s.validateTop(reflect.ValueOf(v))
+	return s.result()
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type compiledUser struct {
+	Name    string `validate:"required&max:32"`
+	Age     int    `validate:"min:18"`
+	Address *Address
+	Parent  *compiledUser
+}
+
+func TestCompile(t *testing.T) {
+	typed, err := Compile[compiledUser]()
+	assert.NoError(t, err)
+
+	assert.NoError(t, typed.Validate(compiledUser{Name: "Ann", Age: 30}))
+
+	err = typed.Validate(compiledUser{
+		Name:    "Bob",
+		Age:     17,
+		Address: &Address{City: "Moscow", Zip: "1"},
+		Parent:  &compiledUser{Age: 40},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "field: Age not valid for min:18"+
+		"field: Address.Zip not valid for len:6"+
+		"field: Parent.Name not valid for required&max:32", err.Error())
+	assert.Equal(t, err, Validate(compiledUser{
+		Name:    "Bob",
+		Age:     17,
+		Address: &Address{City: "Moscow", Zip: "1"},
+		Parent:  &compiledUser{Age: 40},
+	}))
+}
+
+func TestCompileOptions(t *testing.T) {
+	type user struct {
+		Base
+	}
+
+	typed, err := Compile[user](WithEmbeddedNaming(QualifyEmbedded))
+	assert.NoError(t, err)
+	assert.Equal(t, "field: Base.ID not valid for min:1", typed.Validate(user{Base{Name: "Ann"}}).Error())
+}
+
+func TestCompileErrors(t *testing.T) {
+	type nested struct {
+		Len string `validate:"len:x"`
+	}
+	type broken struct {
+		private string `validate:"len:1"`
+		Nested  nested
+	}
+
+	_, err := Compile[broken]()
+	assert.Error(t, err)
+	errs := err.(ValidationErrors)
+	assert.Len(t, errs, 2)
+	assert.True(t, errors.Is(errs[0].Err, ErrValidateForUnexportedFields))
+	assert.True(t, errors.Is(errs[1].Err, ErrInvalidValidatorSyntax))
+
+	_, err = Compile[string]()
+	assert.ErrorIs(t, err, ErrNotStruct)
+}
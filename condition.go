@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// ConditionFunc reports whether the rules of a field apply, given the struct
+// v holding the field. v is the invalid value for values not read from a
+// struct, like those of ValidateVar.
+type ConditionFunc func(v reflect.Value) bool
+
+var conditions = map[string]ConditionFunc{}
+
+// RegisterCondition makes fn available under name to the if rule, which
+// makes the rules of a field apply only when the condition holds:
+//
+//	validator.RegisterCondition("isPremium", func(v reflect.Value) bool {
+//		return v.FieldByName("Plan").String() == "premium"
+//	})
+//
+//	type Account struct {
+//		Plan    string
+//		Storage int `validate:"if:isPremium&max:1000"`
+//	}
+//
+// Conditions can also compare a field of the struct with a value, like
+// `validate:"if:Currency=='USD'&max:100"` or "if:Age!=0", or check that a
+// field is set, like "if:Email", or empty, like "if:!Email". Paths like
+// "Address.Country" reach the fields of nested structs. A field holds a
+// value when its textual form, as printed by fmt, is the value. Several if
+// rules must all hold. It is meant to be called during program
+// initialization.
+func RegisterCondition(name string, fn ConditionFunc) error {
+	return register("RegisterCondition", func() {
+		conditions[name] = fn
+		resetPlanCache()
+	})
+}
+
+// condition is the parsed argument of an if rule.
+type condition struct {
+	tags.Condition
+	fn ConditionFunc
+}
+
+// parseCondition parses the argument of the if rule.
+func parseCondition(params string) (rule, error) {
+	c, err := tags.ParseCondition(params)
+	if err != nil {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	cond := &condition{Condition: c}
+	if c.Op == "" {
+		cond.fn, _ = registered(conditions, c.Name)
+	}
+	return rule{name: "if", argsStr: []string{strings.TrimSpace(params)}, cond: cond}, nil
+}
+
+// conditionsHold reports whether the conditions of the if rules of
+// validators hold for the struct structV.
+func conditionsHold(validators []rule, structV reflect.Value) bool {
+	for _, validator := range validators {
+		if validator.name == "if" && !validator.cond.holds(structV) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *condition) holds(structV reflect.Value) bool {
+	if c.fn != nil {
+		return c.fn(structV)
+	}
+	if !structV.IsValid() {
+		return false
+	}
+	raw, ok := fieldByKey(structV, c.Name)
+	if !ok {
+		return false
+	}
+	field, err := customValue(raw)
+	if err != nil {
+		return false
+	}
+	switch c.Op {
+	case "":
+		return !isEmpty(raw, field)
+	case "!":
+		return isEmpty(raw, field)
+	}
+	text := ""
+	if field.IsValid() && field.CanInterface() {
+		text = fmt.Sprint(field.Interface())
+	}
+	return (text == c.Value) == (c.Op == "==")
+}
+
+// conditionError returns an error when a condition of validators, the rules
+// of the field name of typeV, names a field typeV does not have.
+func conditionError(typeV reflect.Type, name string, validators []rule) error {
+	for _, validator := range validators {
+		if validator.name != "if" || validator.cond.fn != nil {
+			continue
+		}
+		first, _, _ := strings.Cut(validator.cond.Name, ".")
+		if _, ok := structFieldIndex(typeV, first); !ok {
+			return fmt.Errorf("%w: %s in condition %q of field %s", ErrUnknownField, first, validator.argsStr[0], name)
+		}
+	}
+	return nil
+}
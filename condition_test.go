@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type conditionalPayment struct {
+	Currency string
+	Amount   int      `validate:"if:Currency=='USD'&max:100"`
+	Fee      int      `validate:"if:Currency!=USD&required"`
+	Note     string   `validate:"if:!Coupon&required"`
+	Coupon   *string  `validate:"if:Coupon&len:8"`
+	Storage  int      `validate:"if:isPremium&max:1000"`
+	Plan     string   `json:"plan"`
+	Address  *Address `validate:"if:Address.Street=='Main'&required"`
+}
+
+func TestConditions(t *testing.T) {
+	RegisterCondition("isPremium", func(v reflect.Value) bool {
+		return v.IsValid() && v.FieldByName("Plan").String() == "premium"
+	})
+	t.Cleanup(func() {
+		delete(conditions, "isPremium")
+		resetPlanCache()
+	})
+
+	coupon := "SPRING"
+	tests := []struct {
+		name   string
+		v      conditionalPayment
+		fields []string
+	}{
+		{name: "usd", v: conditionalPayment{Currency: "USD", Amount: 100, Note: "n"}},
+		{name: "usd over", v: conditionalPayment{Currency: "USD", Amount: 101, Note: "n"}, fields: []string{"Amount"}},
+		{name: "eur", v: conditionalPayment{Currency: "EUR", Amount: 500, Fee: 1, Note: "n"}},
+		{name: "eur without fee", v: conditionalPayment{Currency: "EUR", Note: "n"}, fields: []string{"Fee"}},
+		{name: "coupon", v: conditionalPayment{Currency: "USD", Coupon: &coupon}, fields: []string{"Coupon"}},
+		{name: "premium", v: conditionalPayment{Currency: "USD", Note: "n", Storage: 2000, Plan: "premium"}, fields: []string{"Storage"}},
+		{name: "basic", v: conditionalPayment{Currency: "USD", Note: "n", Storage: 2000, Plan: "basic"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.fields, fieldsOf(errs))
+		})
+	}
+
+	assert.NoError(t, ValidateVar(500, "if:Currency=='USD'&max:100"))
+	assert.NoError(t, ValidateMap(map[string]any{"currency": "EUR", "amount": 500.0}, map[string]string{"amount": "if:currency=='USD'&max:100"}))
+	assert.Error(t, ValidateMap(map[string]any{"currency": "USD", "amount": 500.0}, map[string]string{"amount": "if:currency=='USD'&max:100"}))
+}
+
+func TestConditionErrors(t *testing.T) {
+	type unknownField struct {
+		Amount int `validate:"if:Currency=='USD'&max:100"`
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(unknownField{}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+	assert.EqualError(t, errs[0].Err, `unknown field: Currency in condition "Currency=='USD'" of field Amount`)
+
+	for _, rules := range []string{"if:", "if:Currency=", "if:Currency==", "if:A B", "if:Currency==U S"} {
+		require.ErrorAs(t, ValidateVar(1, rules), &errs, rules)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+}
@@ -0,0 +1,136 @@
+// Package configvalidate validates configuration, telling where the
+// offending values come from. Files are decoded and validated with:
+//
+//	cfg, err := configvalidate.DecodeYAML[Config]("config.yaml", data)
+//	// config.yaml:42:7: field: Server.Port not valid for min:1&max:65535
+//
+// The positions of the values are collected while decoding and attached to
+// the validator.ValidationErrors, which keep their fields and rules. For
+// other formats, or to decode with other options, collect the Positions from
+// the decoder's metadata and pass them to Annotate.
+//
+// Config structs populated from environment variables are validated with
+// ValidateEnv, which names the variables instead of the fields in errors.
+package configvalidate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Nadya2002/validator"
+)
+
+// Position is the location of a value in a file. Line and Column start at
+// 1; Column is 0 when unknown.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	s := p.File
+	if p.Line != 0 {
+		if s != "" {
+			s += ":"
+		}
+		s += strconv.Itoa(p.Line)
+		if p.Column != 0 {
+			s += ":" + strconv.Itoa(p.Column)
+		}
+	}
+	return s
+}
+
+// Positions maps the field paths of errors, e.g. "Server.Port" or
+// "Servers[2].Host", to the positions of their values. The empty path is
+// the position of the document itself. Fields of embedded structs are
+// named as with validator.FlattenEmbedded.
+type Positions map[string]Position
+
+// lookup returns the position of the value at path, or of the closest
+// enclosing value found for fields missing from the file.
+func (p Positions) lookup(path string) (Position, bool) {
+	for {
+		if pos, ok := p[path]; ok {
+			return pos, true
+		}
+		if path == "" {
+			return Position{}, false
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			i = 0
+		}
+		path = path[:i]
+	}
+}
+
+// PositionError is a validation error with the position of the value.
+type PositionError struct {
+	Position Position
+	Err      error
+}
+
+func (e *PositionError) Error() string {
+	return e.Position.String() + ": " + e.Err.Error()
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// Annotate adds the positions of the values to the errors in err when it
+// holds validator.ValidationErrors, wrapping each error in a PositionError.
+// Other errors are returned unchanged.
+func Annotate(err error, positions Positions) error {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	annotated := make(validator.ValidationErrors, len(errs))
+	for i, e := range errs {
+		if pos, ok := positions.lookup(e.Field()); ok {
+			e.Err = &PositionError{Position: pos, Err: e.Err}
+		}
+		annotated[i] = e
+	}
+	return annotated
+}
+
+// joinPath returns the path of the field name of the struct at path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// decodedField is a field a key of a document is decoded into: the index
+// of the field for reflect.Type.FieldByIndex and its path relative to the
+// struct, e.g. "Port" or "Limits.Max" for a field of a struct inlined as
+// Limits.
+type decodedField struct {
+	index []int
+	path  string
+}
+
+// embeddedPrefix returns the prefix of the paths of the fields of the
+// struct in f, inlined into the struct whose fields have prefix. Fields
+// promoted from embedded structs are named as if they were declared in the
+// outer struct.
+func embeddedPrefix(prefix string, f reflect.StructField) string {
+	if f.Anonymous {
+		return prefix
+	}
+	return prefix + f.Name + "."
+}
+
+// decodeError adds the file name to errors of decoders.
+func decodeError(file string, err error) error {
+	return fmt.Errorf("%s: %w", file, err)
+}
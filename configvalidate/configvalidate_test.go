@@ -0,0 +1,78 @@
+package configvalidate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+type testConfig struct {
+	Name     string         `yaml:"name" json:"name" validate:"required&min:2"`
+	Server   testServer     `yaml:"server" json:"server"`
+	Backups  []testServer   `yaml:"backups" json:"backups"`
+	Limits   map[string]int `yaml:"limits" json:"limits"`
+	testMeta `yaml:",inline"`
+}
+
+type testServer struct {
+	Host string `yaml:"host" json:"host" validate:"required"`
+	Port int    `yaml:"port" json:"port" validate:"min:1&max:65535"`
+}
+
+type testMeta struct {
+	Owner string `yaml:"owner" json:"owner" validate:"omitempty&min:3"`
+}
+
+func TestPositionString(t *testing.T) {
+	tests := []struct {
+		pos  Position
+		want string
+	}{
+		{pos: Position{File: "config.yaml", Line: 42, Column: 7}, want: "config.yaml:42:7"},
+		{pos: Position{File: "config.yaml", Line: 42}, want: "config.yaml:42"},
+		{pos: Position{File: "config.yaml"}, want: "config.yaml"},
+		{pos: Position{Line: 3, Column: 1}, want: "3:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pos.String())
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	positions := Positions{
+		"":            {File: "c.toml", Line: 1, Column: 1},
+		"Server":      {File: "c.toml", Line: 3, Column: 1},
+		"Server.Port": {File: "c.toml", Line: 5, Column: 8},
+	}
+	err := validator.Validate(testConfig{Server: testServer{Port: 0}})
+	err = Annotate(err, positions)
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"c.toml:1:1: field: Name not valid for required&min:2",
+		"c.toml:3:1: field: Server.Host not valid for required",
+		"c.toml:5:8: field: Server.Port not valid for min:1&max:65535",
+	}, got)
+
+	assert.Equal(t, "Server.Port", errs[2].Field())
+	assert.Equal(t, "min:1&max:65535", errs[2].Rules())
+	assert.ErrorIs(t, errs[2].Err, validator.ErrFieldNotValid)
+	var posErr *PositionError
+	require.ErrorAs(t, errs[2].Err, &posErr)
+	assert.Equal(t, 5, posErr.Position.Line)
+
+	other := errors.New("other")
+	assert.Equal(t, other, Annotate(other, positions))
+	assert.NoError(t, Annotate(nil, positions))
+}
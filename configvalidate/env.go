@@ -0,0 +1,92 @@
+package configvalidate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator"
+)
+
+// EnvError is a validation error of a field populated from an environment
+// variable, named by the env tag of the field.
+type EnvError struct {
+	Var string
+	Err error
+}
+
+func (e *EnvError) Error() string {
+	if rules := (validator.ValidationError{Err: e.Err}).Rules(); rules != "" {
+		return e.Var + " not valid for " + rules
+	}
+	return e.Var + ": " + e.Err.Error()
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateEnv validates the config struct v populated from environment
+// variables, e.g. with github.com/caarlos0/env, and reports the errors of
+// fields with an env tag by the name of the variable:
+//
+//	type Config struct {
+//		DatabaseURL string `env:"DATABASE_URL" validate:"required"`
+//		HTTP        HTTP   `envPrefix:"HTTP_"`
+//	}
+//
+//	type HTTP struct {
+//		Port int `env:"PORT" validate:"min:1&max:65535"` // HTTP_PORT
+//	}
+//
+// The errors are validator.ValidationErrors wrapping an EnvError each,
+// like "DATABASE_URL not valid for required".
+func ValidateEnv(v any, opts ...validator.Option) error {
+	err := validator.Validate(v, opts...)
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	vars := map[string]string{}
+	envVars(vars, t, "", "")
+	named := make(validator.ValidationErrors, len(errs))
+	for i, e := range errs {
+		if name, ok := vars[e.Field()]; ok {
+			e.Err = &EnvError{Var: name, Err: e.Err}
+		}
+		named[i] = e
+	}
+	return named
+}
+
+// envVars maps the paths of the fields of t with env tags to the names of
+// their variables. Fields of nested structs are prefixed by the envPrefix
+// tag of the struct field.
+func envVars(vars map[string]string, t reflect.Type, path, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("env"), ",")
+		switch {
+		case name != "" && name != "-":
+			if f.IsExported() {
+				vars[joinPath(path, f.Name)] = prefix + name
+			}
+		case ft.Kind() == reflect.Struct && (f.IsExported() || f.Anonymous):
+			fieldPath := path
+			if !f.Anonymous {
+				fieldPath = joinPath(path, f.Name)
+			}
+			envVars(vars, ft, fieldPath, prefix+f.Tag.Get("envPrefix"))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package configvalidate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+type envConfig struct {
+	DatabaseURL string   `env:"DATABASE_URL" validate:"required"`
+	HTTP        envHTTP  `envPrefix:"HTTP_"`
+	Admin       *envHTTP `envPrefix:"ADMIN_"`
+	Name        string   `validate:"min:2"`
+	envMeta
+}
+
+type envHTTP struct {
+	Port int `env:"PORT,required" validate:"min:1&max:65535"`
+}
+
+type envMeta struct {
+	Region string `env:"REGION" validate:"in:eu,us"`
+}
+
+func TestValidateEnv(t *testing.T) {
+	err := ValidateEnv(envConfig{Admin: &envHTTP{}, Name: "x", envMeta: envMeta{Region: "asia"}})
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"DATABASE_URL not valid for required",
+		"HTTP_PORT not valid for min:1&max:65535",
+		"ADMIN_PORT not valid for min:1&max:65535",
+		"field: Name not valid for min:2",
+		"REGION not valid for in:eu,us",
+	}, got)
+	assert.Equal(t, "HTTP.Port", errs[1].Field())
+	assert.ErrorIs(t, errs[1].Err, validator.ErrFieldNotValid)
+
+	var envErr *EnvError
+	require.ErrorAs(t, errs[0].Err, &envErr)
+	assert.Equal(t, "DATABASE_URL", envErr.Var)
+
+	assert.NoError(t, ValidateEnv(envConfig{DatabaseURL: "postgres://db", HTTP: envHTTP{Port: 80}, Name: "api", envMeta: envMeta{Region: "eu"}}))
+	assert.ErrorIs(t, ValidateEnv(1), validator.ErrNotStruct)
+
+	var other error = &EnvError{Var: "TOKEN", Err: errors.New("expired")}
+	assert.Equal(t, "TOKEN: expired", other.Error())
+}
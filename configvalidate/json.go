@@ -0,0 +1,175 @@
+package configvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nadya2002/validator"
+)
+
+// DecodeJSON decodes the JSON document data read from file into a T and
+// validates it. Validation errors carry the positions of the values.
+func DecodeJSON[T any](file string, data []byte, opts ...validator.Option) (T, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, decodeError(file, err)
+	}
+	positions, err := JSONPositions(file, data, reflect.TypeOf(v))
+	if err != nil {
+		return v, decodeError(file, err)
+	}
+	return v, Annotate(validator.Validate(&v, opts...), positions)
+}
+
+// JSONPositions returns the positions of the values of the JSON document
+// data decoded into a value of type t.
+func JSONPositions(file string, data []byte, t reflect.Type) (Positions, error) {
+	w := jsonWalker{
+		file:      file,
+		data:      data,
+		dec:       json.NewDecoder(bytes.NewReader(data)),
+		positions: Positions{},
+	}
+	for i, b := range data {
+		if b == '\n' {
+			w.lines = append(w.lines, i+1)
+		}
+	}
+	if err := w.walk(t, ""); err != nil {
+		return nil, err
+	}
+	return w.positions, nil
+}
+
+type jsonWalker struct {
+	file string
+	data []byte
+	dec  *json.Decoder
+	// lines holds the offsets of the starts of the lines after the first.
+	lines     []int
+	positions Positions
+}
+
+// walk records the positions of the next value and its elements. t is nil
+// for values not decoded into anything.
+func (w *jsonWalker) walk(t reflect.Type, path string) error {
+	if t != nil {
+		w.positions[path] = w.position()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+
+	tok, err := w.dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		var fields map[string]decodedField
+		if t != nil && t.Kind() == reflect.Struct {
+			fields = map[string]decodedField{}
+			jsonFields(fields, t, nil, "")
+		}
+		for w.dec.More() {
+			tok, err := w.dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := tok.(string)
+
+			var elemT reflect.Type
+			var elemPath string
+			switch {
+			case fields != nil:
+				if f, ok := lookupJSONField(fields, key); ok {
+					elemT, elemPath = t.FieldByIndex(f.index).Type, joinPath(path, f.path)
+				}
+			case t != nil && t.Kind() == reflect.Map:
+				elemT, elemPath = t.Elem(), path+"["+key+"]"
+			}
+			if err := w.walk(elemT, elemPath); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		var elemT reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			elemT = t.Elem()
+		}
+		for i := 0; w.dec.More(); i++ {
+			if err := w.walk(elemT, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	// The closing delimiter.
+	_, err = w.dec.Token()
+	return err
+}
+
+// position returns the position of the next value. The decoder's offset is
+// at the end of the previous token, before any separator.
+func (w *jsonWalker) position() Position {
+	offset := int(w.dec.InputOffset())
+	for offset < len(w.data) && strings.IndexByte(" \t\r\n,:", w.data[offset]) >= 0 {
+		offset++
+	}
+	line := sort.SearchInts(w.lines, offset+1)
+	start := 0
+	if line > 0 {
+		start = w.lines[line-1]
+	}
+	return Position{File: w.file, Line: line + 1, Column: offset - start + 1}
+}
+
+// jsonFields maps the keys of the fields of t to the fields, as
+// encoding/json decodes them: by the name in the json tag or the field
+// name, with the fields of embedded structs without a name promoted.
+func jsonFields(fields map[string]decodedField, t reflect.Type, index []int, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		fieldIndex := append(index[:len(index):len(index)], i)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			jsonFields(fields, ft, fieldIndex, embeddedPrefix(prefix, f))
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = decodedField{index: fieldIndex, path: prefix + f.Name}
+		}
+	}
+}
+
+// lookupJSONField finds the field for key, preferring an exact match of the
+// name like encoding/json does.
+func lookupJSONField(fields map[string]decodedField, key string) (decodedField, bool) {
+	if f, ok := fields[key]; ok {
+		return f, true
+	}
+	for name, f := range fields {
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return decodedField{}, false
+}
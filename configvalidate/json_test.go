@@ -0,0 +1,58 @@
+package configvalidate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+const testJSON = `{
+  "name": "x",
+  "server": {"host": "localhost", "port": 70000},
+  "backups": [
+    {"host": "b1", "port": 1},
+    {"port": 2}
+  ],
+  "limits": {"conns": 10},
+  "unknown": [1, {"a": 2}],
+  "Owner": "me"
+}`
+
+func TestDecodeJSON(t *testing.T) {
+	cfg, err := DecodeJSON[testConfig]("config.json", []byte(testJSON))
+	assert.Equal(t, "localhost", cfg.Server.Host)
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"config.json:2:11: field: Name not valid for required&min:2",
+		"config.json:3:43: field: Server.Port not valid for min:1&max:65535",
+		"config.json:6:5: field: Backups[1].Host not valid for required",
+		"config.json:10:12: field: Owner not valid for omitempty&min:3",
+	}, got)
+
+	_, err = DecodeJSON[testConfig]("config.json", []byte(`{"name": `))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config.json: unexpected end")
+
+	_, err = DecodeJSON[testConfig]("config.json", []byte(`{"name": "api", "server": {"host": "h", "port": 1}}`))
+	assert.NoError(t, err)
+}
+
+func TestJSONPositions(t *testing.T) {
+	positions, err := JSONPositions("c.json", []byte(testJSON), reflect.TypeOf(testConfig{}))
+	require.NoError(t, err)
+	assert.Equal(t, Position{File: "c.json", Line: 1, Column: 1}, positions[""])
+	assert.Equal(t, Position{File: "c.json", Line: 3, Column: 13}, positions["Server"])
+	assert.Equal(t, Position{File: "c.json", Line: 5, Column: 28}, positions["Backups[0].Port"])
+	assert.Equal(t, Position{File: "c.json", Line: 8, Column: 23}, positions["Limits[conns]"])
+	assert.NotContains(t, positions, "unknown")
+}
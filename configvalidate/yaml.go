@@ -0,0 +1,109 @@
+package configvalidate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Nadya2002/validator"
+)
+
+// DecodeYAML decodes the YAML document data read from file into a T and
+// validates it. Validation errors carry the positions of the values.
+func DecodeYAML[T any](file string, data []byte, opts ...validator.Option) (T, error) {
+	var v T
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return v, decodeError(file, err)
+	}
+	if err := doc.Decode(&v); err != nil {
+		return v, decodeError(file, err)
+	}
+	return v, Annotate(validator.Validate(&v, opts...), YAMLPositions(file, &doc, reflect.TypeOf(v)))
+}
+
+// YAMLPositions returns the positions of the values of a document decoded
+// into a value of type t.
+func YAMLPositions(file string, doc *yaml.Node, t reflect.Type) Positions {
+	positions := Positions{}
+	walkYAML(positions, file, doc, t, "")
+	return positions
+}
+
+func walkYAML(positions Positions, file string, node *yaml.Node, t reflect.Type, path string) {
+	for node.Kind == yaml.DocumentNode || node.Kind == yaml.AliasNode {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		} else if len(node.Content) == 0 {
+			return
+		} else {
+			node = node.Content[0]
+		}
+	}
+	positions[path] = Position{File: file, Line: node.Line, Column: node.Column}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := map[string]decodedField{}
+		yamlFields(fields, t, nil, "")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if f, ok := fields[node.Content[i].Value]; ok {
+				walkYAML(positions, file, node.Content[i+1], t.FieldByIndex(f.index).Type, joinPath(path, f.path))
+			}
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkYAML(positions, file, node.Content[i+1], t.Elem(), path+"["+node.Content[i].Value+"]")
+		}
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			walkYAML(positions, file, item, t.Elem(), path+"["+strconv.Itoa(i)+"]")
+		}
+	}
+}
+
+// yamlFields maps the keys of the fields of t to the fields, as yaml.v3
+// decodes them: by the name in the yaml tag, or the lower-cased field name,
+// with inlined structs contributing their fields.
+func yamlFields(fields map[string]decodedField, t reflect.Type, index []int, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		fieldIndex := append(index[:len(index):len(index)], i)
+		if strings.Contains(","+opts+",", ",inline,") && f.Type.Kind() == reflect.Struct {
+			yamlFields(fields, f.Type, fieldIndex, embeddedPrefix(prefix, f))
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = decodedField{index: fieldIndex, path: prefix + f.Name}
+		}
+	}
+}
+
+// ParseSchemaYAML compiles the validator.Schema in the YAML document data,
+// mapping field names to rules and nesting the fields of nested values:
+//
+//	email: required&email
+//	address:
+//	  zip: len:6
+func ParseSchemaYAML(data []byte) (*validator.Schema, error) {
+	var fields map[string]any
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return validator.NewSchema(fields)
+}
@@ -0,0 +1,80 @@
+package configvalidate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Nadya2002/validator"
+)
+
+const testYAML = `name: x
+server:
+  host: localhost
+  port: 70000
+backups:
+  - host: b1
+    port: 1
+  - port: 2
+limits:
+  conns: 10
+owner: me
+`
+
+func TestDecodeYAML(t *testing.T) {
+	cfg, err := DecodeYAML[testConfig]("config.yaml", []byte(testYAML))
+	assert.Equal(t, "localhost", cfg.Server.Host)
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"config.yaml:1:7: field: Name not valid for required&min:2",
+		"config.yaml:4:9: field: Server.Port not valid for min:1&max:65535",
+		"config.yaml:8:5: field: Backups[1].Host not valid for required",
+		"config.yaml:11:8: field: Owner not valid for omitempty&min:3",
+	}, got)
+
+	_, err = DecodeYAML[testConfig]("config.yaml", []byte("name: [x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config.yaml: yaml:")
+
+	_, err = DecodeYAML[testConfig]("config.yaml", []byte("name: api\nserver: {host: h, port: 1}\n"))
+	assert.NoError(t, err)
+}
+
+func TestYAMLPositions(t *testing.T) {
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(testYAML), &doc))
+	positions := YAMLPositions("c.yaml", &doc, reflect.TypeOf(testConfig{}))
+	assert.Equal(t, Position{File: "c.yaml", Line: 1, Column: 1}, positions[""])
+	assert.Equal(t, Position{File: "c.yaml", Line: 3, Column: 3}, positions["Server"])
+	assert.Equal(t, Position{File: "c.yaml", Line: 7, Column: 11}, positions["Backups[0].Port"])
+	assert.Equal(t, Position{File: "c.yaml", Line: 10, Column: 10}, positions["Limits[conns]"])
+}
+
+func TestParseSchemaYAML(t *testing.T) {
+	schema, err := ParseSchemaYAML([]byte(`name: required&max:3
+server:
+  port: min:1&max:65535
+`))
+	require.NoError(t, err)
+
+	cfg := testConfig{Name: "long"}
+	cfg.Server.Port = 70000
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, schema.Validate(cfg), &errs)
+	assert.Equal(t, []string{"name", "server.port"}, errs.Fields())
+
+	_, err = ParseSchemaYAML([]byte("name: [required]"))
+	assert.ErrorIs(t, err.(validator.ValidationErrors)[0].Err, validator.ErrInvalidValidatorSyntax)
+
+	_, err = ParseSchemaYAML([]byte("name: ["))
+	assert.Error(t, err)
+}
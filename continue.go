@@ -0,0 +1,51 @@
+package validator
+
+import "reflect"
+
+// checkAll applies validators, which have the continue rule, to the field
+// fieldV named name of the struct at path, reporting an error for each rule
+// it does not satisfy, in the order of the tag:
+//
+//	Password string `validate:"continue&min:12&regexp:[0-9]&regexp:[A-Z]"`
+//
+// Without continue, or with bail, a field only reports the first rule it
+// fails and the rules after it are not checked, so expensive rules written
+// last only run for values passing the cheap ones. A field failing required,
+// notnil or asnum, or whose value cannot be read, is not checked further
+// either way. parseValidators rejects tags with both bail and continue.
+func (s *validation) checkAll(path *fieldPath, name, cond string, validators []rule, fieldV reflect.Value) {
+	control := controlRules(validators)
+	rest := validators
+	for {
+		err := s.validateField(rest, fieldV)
+		if err == nil {
+			return
+		}
+		s.errors = append(s.errors, ValidationError{s.fieldError(path, name, cond, validators, err)})
+
+		i := failedRule(rest, s.failed)
+		switch {
+		case i < 0 || s.failed == "required" || s.failed == "notnil" || s.failed == "asnum":
+			return
+		case isExclusive(s.failed) || s.failed == "assert":
+			// Checked before the other rules, which are still to be checked.
+			rest = append(rest[:i:i], rest[i+1:]...)
+		default:
+			rest = append(control[:len(control):len(control)], rest[i+1:]...)
+		}
+	}
+}
+
+// failedRule returns the index of the rule name in validators, which failed,
+// -1 when no rule did, like when the value could not be read.
+func failedRule(validators []rule, name string) int {
+	if name == "" {
+		return -1
+	}
+	for i, validator := range validators {
+		if validator.name == name && !validator.warn {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinue(t *testing.T) {
+	type signup struct {
+		Password string   `validate:"continue&min:12&regexp:[0-9]&regexp:[A-Z]"`
+		Login    string   `validate:"bail&min:3&regexp:^[a-z]+$"`
+		Nickname string   `validate:"omitempty&continue&min:3&max:4"`
+		Email    string   `validate:"continue&required&email"`
+		Tags     []string `validate:"continue&min:2&max:3"`
+	}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(signup{Password: "secret", Login: "A", Tags: []string{"a", "long"}}), &errs)
+	assert.Equal(t, []string{"Password", "Password", "Password", "Login", "Email", "Tags", "Tags"}, fieldsOf(errs))
+	assert.Equal(t, "min", errs[0].Rule())
+	assert.Equal(t, "regexp", errs[1].Rule())
+	assert.Equal(t, "min", errs[3].Rule())
+	assert.Equal(t, "required", errs[4].Rule())
+
+	require.NoError(t, Validate(signup{Password: "Secret123456", Login: "nadya", Email: "n@example.com"}))
+
+	for _, rules := range []string{"bail&continue&min:1", "keys:continue,endkeys", "(continue|min:1)"} {
+		var errs ValidationErrors
+		require.ErrorAs(t, ValidateVar(map[string]int{}, rules), &errs, rules)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+}
+
+func TestContinueRuleOrder(t *testing.T) {
+	var calls []string
+	RegisterRule("remote", func(ctx context.Context, field reflect.Value, params []string) error {
+		calls = append(calls, field.String())
+		return errTaken
+	})
+	t.Cleanup(func() {
+		delete(customRules, "remote")
+		resetPlanCache()
+	})
+
+	type account struct {
+		Count int
+		Name  string `validate:"min:3&remote"`
+		Email string `validate:"continue&min:3&remote&assert:Count>0"`
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(account{Count: 1, Name: "ab", Email: "ab"}), &errs)
+	assert.Equal(t, []string{"ab"}, calls)
+	assert.Equal(t, []string{"Name", "Email", "Email"}, fieldsOf(errs))
+	assert.ErrorIs(t, errs[2].Err, errTaken)
+
+	calls = nil
+	require.ErrorAs(t, Validate(account{Email: "ab"}), &errs)
+	assert.Equal(t, []string{"Name", "Email", "Email", "Email"}, fieldsOf(errs))
+	assert.Equal(t, []string{"min", "assert", "min", "remote"}, []string{errs[0].Rule(), errs[1].Rule(), errs[2].Rule(), errs[3].Rule()})
+	assert.Equal(t, []string{"ab"}, calls)
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cronField describes a field of cron expressions: the range of its values
+// and the names usable instead of them.
+type cronField struct {
+	min, max int
+	names    []string
+	// any reports whether "?" is allowed, for the days of the month and of
+	// the week.
+	any bool
+}
+
+var (
+	cronSeconds = cronField{min: 0, max: 59}
+	cronFields  = []cronField{
+		{min: 0, max: 59},
+		{min: 0, max: 23},
+		{min: 1, max: 31, any: true},
+		{min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+		{min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, any: true},
+	}
+)
+
+// cronMacros are the expressions standing for schedules, like "@daily".
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// validateCron checks that field is a cron expression: five fields for the
+// minutes, hours, days of the month, months and days of the week, like
+// "*/15 9-17 * * MON-FRI", optionally preceded by a field for the seconds,
+// or a macro like "@daily". Fields are lists of values, ranges and "*", each
+// with an optional step, like "0,30" or "1-10/2".
+func validateCron(field string) error {
+	if cronMacros[field] {
+		return nil
+	}
+	parts := strings.Fields(field)
+	fields := cronFields
+	switch len(parts) {
+	case 5:
+	case 6:
+		fields = append([]cronField{cronSeconds}, cronFields...)
+	default:
+		return ErrFieldNotValid
+	}
+	for i, part := range parts {
+		if !fields[i].valid(part) {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// valid reports whether s is a valid value of the field.
+func (f cronField) valid(s string) bool {
+	if f.any && s == "?" {
+		return true
+	}
+	for _, item := range strings.Split(s, ",") {
+		item, step, stepped := strings.Cut(item, "/")
+		if stepped {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 || n > f.max {
+				return false
+			}
+		}
+		if item == "*" {
+			continue
+		}
+		lo, hi, ranged := strings.Cut(item, "-")
+		from, ok := f.value(lo)
+		if !ok {
+			return false
+		}
+		if ranged {
+			to, ok := f.value(hi)
+			if !ok || to < from {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// value returns the value s, a number or a name, stands for.
+func (f cronField) value(s string) (int, bool) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, true
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max || s[0] == '+' {
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/15 9-17 * * MON-FRI",
+		"0 0 1,15 * ?",
+		"30 4 1-10/2 jan-jun 0,7",
+		"0 */5 * * * *",
+		"@daily",
+		" 0  0 * * * ",
+	}
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"? * * * *",
+		"1,,2 * * * *",
+		"+1 * * * *",
+		"* * * FOO *",
+		"@sometimes",
+	}
+	for _, s := range valid {
+		assert.NoError(t, ValidateVar(s, "cron"), s)
+	}
+	for _, s := range invalid {
+		assert.Error(t, ValidateVar(s, "cron"), s)
+	}
+	assert.Error(t, ValidateVar(5, "cron"))
+}
@@ -0,0 +1,273 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// RuleFunc implements a custom rule. It receives the context passed to
+// ValidateCtx, the value to validate and the rule parameters, e.g. ["a", "b"]
+// for `validate:"myrule:a,b"`. A non-nil error marks the value as not valid
+// and can be reached from the resulting ValidationError with errors.Is and
+// errors.As.
+type RuleFunc func(ctx context.Context, field reflect.Value, params []string) error
+
+var customRules = map[string]RuleFunc{}
+
+// RegisterRule makes fn available in tags under name, replacing a built-in
+// rule of the same name. Rules doing I/O, like uniqueness checks against a
+// database, should respect the cancellation of ctx. It is meant to be called
+// during program initialization.
+func RegisterRule(name string, fn RuleFunc) error {
+	return register("RegisterRule", func() {
+		customRules[name] = fn
+		resetPlanCache()
+	})
+}
+
+// RuleCheckFunc implements a custom rule like a RuleFunc, receiving the rule
+// as written in the tag, with the defaults of RegisterRuleDefaults, instead
+// of its parameters, so they can be read as the types they stand for.
+type RuleCheckFunc func(ctx context.Context, field reflect.Value, r Rule) error
+
+// RegisterRuleCheck makes fn available in tags under name like RegisterRule:
+//
+//	validator.RegisterRuleCheck("maxage", func(ctx context.Context, field reflect.Value, r validator.Rule) error {
+//		limit, err := r.Param(0).Duration()
+//		if err != nil {
+//			return err
+//		}
+//		if time.Since(field.Interface().(time.Time)) > limit {
+//			return errTooOld
+//		}
+//		return nil
+//	})
+//
+//	UpdatedAt time.Time `validate:"maxage:24h"`
+func RegisterRuleCheck(name string, fn RuleCheckFunc) error {
+	return register("RegisterRuleCheck", func() {
+		customRules[name] = func(ctx context.Context, field reflect.Value, params []string) error {
+			return fn(ctx, field, Rule{Name: name, Params: params})
+		}
+		resetPlanCache()
+	})
+}
+
+var ruleDefaults = map[string][]string{}
+
+// RegisterRuleDefaults sets the default parameters of the custom or batch
+// rule name, making its parameters optional in tags. Parameters left out or
+// empty take their defaults:
+//
+//	validator.RegisterRuleDefaults("unique", "users", "email")
+//
+//	Email string `validate:"unique"`          // ["users", "email"]
+//	Login string `validate:"unique:,login"`   // ["users", "login"]
+//	Ref   string `validate:"unique:orders"`   // ["orders", "email"]
+//
+// Parameters beyond the defaults are passed as written. It is meant to be
+// called during program initialization, like RegisterRule.
+func RegisterRuleDefaults(name string, params ...string) error {
+	return register("RegisterRuleDefaults", func() {
+		ruleDefaults[name] = params
+		resetPlanCache()
+	})
+}
+
+// withDefaultParams fills the parameters args of the rule name left out or
+// empty with the defaults of RegisterRuleDefaults.
+func withDefaultParams(name string, args []string) []string {
+	defaults, ok := registered(ruleDefaults, name)
+	if !ok || len(defaults) == 0 {
+		return args
+	}
+	params := append([]string(nil), args...)
+	for len(params) < len(defaults) {
+		params = append(params, "")
+	}
+	for i, dflt := range defaults {
+		if strings.TrimSpace(params[i]) == "" {
+			params[i] = dflt
+		}
+	}
+	return params
+}
+
+var aliases = map[string]string{}
+
+// maxAliasDepth limits how deeply aliases may refer to other aliases, which
+// also stops aliases referring to themselves.
+const maxAliasDepth = 8
+
+// RegisterAlias makes name stand for rules in tags, so a composite rule
+// written once can be used across many structs:
+//
+//	validator.RegisterAlias("username", "required&min:3&max:32")
+//
+//	type User struct {
+//		Name string `validate:"username"`
+//	}
+//
+// Rules may contain other aliases. An alias takes precedence over a rule of
+// the same name and, like the rules, is meant to be registered during
+// program initialization.
+func RegisterAlias(name, rules string) error {
+	return register("RegisterAlias", func() {
+		aliases[name] = rules
+		resetPlanCache()
+	})
+}
+
+// BatchRuleFunc implements a rule checking many values at once, e.g. with a
+// single database query. It receives all values of a validation having the
+// rule with the same parameters and returns an error for each of them, nil
+// for the valid ones. A non-nil second result means the values could not be
+// checked at all; the validation then fails with that error.
+type BatchRuleFunc func(ctx context.Context, values []reflect.Value, params []string) ([]error, error)
+
+var batchRules = map[string]BatchRuleFunc{}
+
+// RegisterBatchRule makes fn available in tags under name. The values are
+// collected while the struct is traversed, so a struct with 100 slice
+// elements having the rule leads to a single call of fn. It is meant to be
+// called during program initialization.
+func RegisterBatchRule(name string, fn BatchRuleFunc) error {
+	return register("RegisterBatchRule", func() {
+		batchRules[name] = fn
+		resetPlanCache()
+	})
+}
+
+// pendingCheck is a value waiting for its batch rule. field and cond describe
+// the field holding the value for the error message, at is the number of
+// errors found before the field, to report its error in declaration order.
+type pendingCheck struct {
+	validator rule
+	value     reflect.Value
+	field     string
+	cond      string
+	at        int
+	// fieldRules are all the rules of the field.
+	fieldRules []rule
+	// reportOnly is set for the rules of WithReportOnly, whose violations
+	// are warnings.
+	reportOnly bool
+}
+
+// runBatches calls every batch rule once for all its pending values and
+// reports the fields with values that are not valid.
+func (s *validation) runBatches() {
+	if len(s.pending) == 0 || s.err != nil {
+		return
+	}
+	defer s.recoverPanic()
+
+	var order []string
+	groups := map[string][]int{}
+	for i, check := range s.pending {
+		key := check.validator.name + ":" + strings.Join(check.validator.argsStr, ",")
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	failed := make([]error, len(s.pending))
+	for _, key := range order {
+		checks := groups[key]
+		values := make([]reflect.Value, len(checks))
+		for i, check := range checks {
+			values[i] = s.pending[check].value
+		}
+
+		validator := s.pending[checks[0]].validator
+		errs, err := s.callBatch(validator, values)
+		if err == nil && len(errs) != len(values) {
+			err = fmt.Errorf("validator: batch rule %s returned %d results for %d values", validator.name, len(errs), len(values))
+		}
+		if err != nil {
+			s.err = err
+			return
+		}
+		for i, check := range checks {
+			failed[check] = errs[i]
+		}
+	}
+
+	var errs ValidationErrors
+	reported := map[string]bool{}
+	next := 0
+	for i, check := range s.pending {
+		if failed[i] != nil && check.reportOnly {
+			s.warnings = append(s.warnings, ValidationError{&fieldError{field: check.field, cond: check.cond, rule: check.validator.name, err: s.redacted(check.fieldRules, failed[i]), reportOnly: true}})
+			continue
+		}
+		if failed[i] == nil || reported[check.field] {
+			continue
+		}
+		reported[check.field] = true
+		errs = append(errs, s.errors[next:check.at]...)
+		errs = append(errs, ValidationError{&fieldError{field: check.field, cond: check.cond, rule: check.validator.name, err: s.redacted(check.fieldRules, failed[i])}})
+		next = check.at
+	}
+	if errs != nil {
+		s.errors = append(errs, s.errors[next:]...)
+	}
+	s.pending = nil
+}
+
+// callBatch calls the batch rule of validator for values, in a span of its
+// own when the validation is traced.
+func (s *validation) callBatch(validator rule, values []reflect.Value) ([]error, error) {
+	if s.tracer == nil {
+		return s.runBatch(s.ctx, validator, values)
+	}
+	ctx, span := s.startRuleSpan(validator.name)
+	errs, err := s.runBatch(ctx, validator, values)
+	valid := err == nil
+	for _, e := range errs {
+		valid = valid && e == nil
+	}
+	endRuleSpan(span, valid)
+	return errs, err
+}
+
+// RuleNames returns the names of the rules usable in tags, sorted: the
+// built-in rules, the custom and batch rules and the aliases registered.
+func RuleNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, name := range tags.Builtins() {
+		add(name)
+	}
+	registry.RLock()
+	defer registry.RUnlock()
+	for name := range customRules {
+		add(name)
+	}
+	for name := range batchRules {
+		add(name)
+	}
+	for name := range aliases {
+		add(name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// suggestRule returns the name of the rule closest to the unknown rule name,
+// "" when none is close.
+func suggestRule(name string) string {
+	return tags.Suggest(name, RuleNames())
+}
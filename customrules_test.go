@@ -0,0 +1,266 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey struct{}
+
+var errTaken = errors.New("email is already taken")
+
+func TestRegisterRule(t *testing.T) {
+	RegisterRule("unique_email", func(ctx context.Context, field reflect.Value, params []string) error {
+		taken, _ := ctx.Value(ctxKey{}).(map[string]bool)
+		if taken[field.String()] {
+			return errTaken
+		}
+		return nil
+	})
+	RegisterRule("prefix", func(ctx context.Context, field reflect.Value, params []string) error {
+		for _, p := range params {
+			if strings.HasPrefix(field.String(), p) {
+				return nil
+			}
+		}
+		return ErrFieldNotValid
+	})
+	t.Cleanup(func() {
+		delete(customRules, "unique_email")
+		delete(customRules, "prefix")
+		resetPlanCache()
+	})
+
+	type signup struct {
+		Email string   `validate:"email&unique_email"`
+		Code  string   `validate:"prefix:A-,B-"`
+		Codes []string `validate:"prefix:C-"`
+	}
+	ctx := context.WithValue(context.Background(), ctxKey{}, map[string]bool{"taken@example.com": true})
+
+	assert.NoError(t, ValidateCtx(ctx, signup{Email: "free@example.com", Code: "B-1", Codes: []string{"C-1"}}))
+
+	err := ValidateCtx(ctx, signup{Email: "taken@example.com", Code: "C-1", Codes: []string{"C-1", "D-2"}})
+	assert.Error(t, err)
+	errs := err.(ValidationErrors)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "field: Email not valid for email&unique_email", errs[0].Err.Error())
+	assert.ErrorIs(t, errs[0].Err, errTaken)
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+	assert.ErrorIs(t, errs[1].Err, ErrFieldNotValid)
+
+	assert.NoError(t, ValidateVar("taken@example.com", "unique_email"), "no taken emails without the context")
+	assert.NoError(t, ValidateVar("A-1", "prefix:A-"))
+}
+
+func TestRegisterRuleCheck(t *testing.T) {
+	RegisterRuleCheck("maxwords", func(ctx context.Context, field reflect.Value, r Rule) error {
+		limit, err := r.Param(0).Int()
+		if err != nil {
+			return err
+		}
+		if len(strings.Fields(field.String())) > limit {
+			return ErrFieldNotValid
+		}
+		return nil
+	})
+	RegisterRuleDefaults("maxwords", "2")
+	t.Cleanup(func() {
+		delete(customRules, "maxwords")
+		delete(ruleDefaults, "maxwords")
+		resetPlanCache()
+	})
+
+	assert.NoError(t, ValidateVar("two words", "maxwords"))
+	assert.Error(t, ValidateVar("now three words", "maxwords"))
+	assert.NoError(t, ValidateVar("now three words", "maxwords:3"))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, ValidateVar("words", "maxwords:x"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
+
+func TestRegisterRuleDefaults(t *testing.T) {
+	var got [][]string
+	RegisterRule("unique", func(ctx context.Context, field reflect.Value, params []string) error {
+		got = append(got, params)
+		return nil
+	})
+	RegisterRuleDefaults("unique", "users", "email")
+	t.Cleanup(func() {
+		delete(customRules, "unique")
+		delete(ruleDefaults, "unique")
+		resetPlanCache()
+	})
+
+	for _, rules := range []string{"unique", "unique:", "unique:,login", "unique:orders", "unique:orders,ref,x"} {
+		require.NoError(t, ValidateVar("a", rules))
+	}
+	assert.Equal(t, [][]string{
+		{"users", "email"},
+		{"users", "email"},
+		{"users", "login"},
+		{"orders", "email"},
+		{"orders", "ref", "x"},
+	}, got)
+}
+
+func TestValidateCtxCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	RegisterRule("slow", func(ctx context.Context, field reflect.Value, params []string) error {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return ctx.Err()
+	})
+	t.Cleanup(func() {
+		delete(customRules, "slow")
+		resetPlanCache()
+	})
+
+	type item struct {
+		Name string `validate:"slow"`
+	}
+	type batch struct {
+		Items []item
+	}
+
+	err := ValidateCtx(ctx, batch{Items: make([]item, 10)})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	typed, _ := Compile[batch]()
+	assert.ErrorIs(t, typed.ValidateCtx(ctx, batch{Items: make([]item, 10)}), context.Canceled)
+	assert.Zero(t, calls)
+}
+
+func TestRegisterBatchRule(t *testing.T) {
+	var calls [][]string
+	RegisterBatchRule("unique_login", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		batch := make([]string, len(values))
+		errs := make([]error, len(values))
+		for i, v := range values {
+			batch[i] = v.String()
+			if batch[i] == "taken" {
+				errs[i] = errTaken
+			}
+		}
+		calls = append(calls, batch)
+		return errs, nil
+	})
+	errBackend := errors.New("backend is down")
+	RegisterBatchRule("broken", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		return nil, errBackend
+	})
+	RegisterBatchRule("short", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		return nil, nil
+	})
+	t.Cleanup(func() {
+		delete(batchRules, "unique_login")
+		delete(batchRules, "broken")
+		delete(batchRules, "short")
+		resetPlanCache()
+	})
+
+	type user struct {
+		Login string `validate:"len:5&unique_login"`
+	}
+	type team struct {
+		Owner   user
+		Members []user
+		Logins  []string `validate:"unique_login"`
+	}
+
+	members := make([]user, 100)
+	for i := range members {
+		members[i].Login = "free" + strconv.Itoa(i%10)
+	}
+	members[42].Login = "taken"
+	members[7].Login = "bad"
+
+	err := Validate(team{Owner: user{Login: "owner"}, Members: members, Logins: []string{"taken", "taken"}})
+	assert.Error(t, err)
+	assert.Len(t, calls, 1, "a single call for all values")
+	assert.Len(t, calls[0], 102, "the value failing len is not checked")
+
+	errs := err.(ValidationErrors)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "field: Members[7].Login not valid for len:5&unique_login", errs[0].Err.Error())
+	assert.Equal(t, "field: Members[42].Login not valid for len:5&unique_login", errs[1].Err.Error())
+	assert.ErrorIs(t, errs[1].Err, errTaken)
+	assert.Equal(t, "field: Logins not valid for unique_login", errs[2].Err.Error())
+
+	err = ValidateVar("taken", "unique_login")
+	assert.Error(t, err)
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, errTaken)
+	assert.NoError(t, Field(new(string), Rule{Name: "unique_login"}))
+
+	assert.ErrorIs(t, ValidateVar("x", "broken"), errBackend)
+	assert.ErrorContains(t, ValidateVar("x", "short"), "batch rule short returned 0 results for 1 values")
+}
+
+func TestRegisterAlias(t *testing.T) {
+	RegisterAlias("username", "required&min:3&max:8")
+	RegisterAlias("handle", "username&in:admin,guest,member")
+	RegisterAlias("scoped", "groups:create&required")
+	RegisterAlias("loop", "loop")
+	t.Cleanup(func() {
+		for _, name := range []string{"username", "handle", "scoped", "loop"} {
+			delete(aliases, name)
+		}
+		resetPlanCache()
+	})
+
+	type account struct {
+		Name   string `validate:"username"`
+		Handle string `validate:"handle"`
+		Scope  string `validate:"min:1&scoped"`
+	}
+	assert.NoError(t, Validate(account{Name: "alice", Handle: "guest"}))
+
+	err := Validate(account{Name: "al", Handle: "root"}, WithGroups("create"))
+	require.Error(t, err)
+	var got []string
+	for _, e := range err.(ValidationErrors) {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"field: Name not valid for username",
+		"field: Handle not valid for handle",
+		"field: Scope not valid for min:1&scoped",
+	}, got)
+
+	assert.NoError(t, ValidateVar("alice", "username"))
+	assert.Error(t, ValidateVar("verylongname", " username "))
+	err = ValidateVar("x", "loop")
+	require.Error(t, err)
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax)
+}
+
+func TestRuleNames(t *testing.T) {
+	RegisterRule("even", func(ctx context.Context, field reflect.Value, params []string) error { return nil })
+	RegisterAlias("username", "required&min:3")
+	t.Cleanup(func() {
+		delete(customRules, "even")
+		delete(aliases, "username")
+		resetPlanCache()
+	})
+
+	names := RuleNames()
+	assert.True(t, sort.StringsAreSorted(names))
+	for _, name := range []string{"required", "len", "email", "assert", "even", "username"} {
+		assert.Contains(t, names, name)
+	}
+	assert.NotContains(t, names, "warn")
+}
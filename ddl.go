@@ -0,0 +1,340 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SQLDialect selects the SQL dialect of ExportSQL.
+type SQLDialect int
+
+const (
+	// PostgreSQL writes types like VARCHAR(n), BOOLEAN and BYTEA.
+	PostgreSQL SQLDialect = iota
+	// MySQL writes types like VARCHAR(n), INT UNSIGNED and DATETIME.
+	MySQL
+	// SQLite writes TEXT columns whose lengths are enforced by CHECK
+	// constraints, as SQLite ignores the lengths of types.
+	SQLite
+)
+
+// ExportSQL returns a CREATE TABLE statement for table with a column for
+// each field of the struct type of v, which may also be a pointer to a
+// struct, so the constraints of the database match the rules of the
+// application:
+//
+//	CREATE TABLE "users" (
+//	  "email" VARCHAR(254) NOT NULL CHECK ("email" <> ''),
+//	  "age" BIGINT CHECK ("age" >= 18 AND "age" <= 130),
+//	  "role" TEXT CHECK ("role" IN ('admin', 'user'))
+//	);
+//
+// Columns are named by the db tags of the fields or by their names in snake
+// case, fields tagged db:"-" are left out and the fields of embedded structs
+// are columns of their own. Fields holding slices other than bytes, maps,
+// other structs than time.Time and types implementing driver.Valuer have no
+// column. The rules translated are required, which makes the column NOT
+// NULL and rejects the zero value, omitempty, len, min, max, in and enum;
+// the others are left out, so the table may accept values Validate rejects.
+// Lengths are counted in bytes by Validate but in characters by the
+// database. Tags Lint reports are returned as errors.
+func ExportSQL(table string, v any, dialect SQLDialect) (string, error) {
+	typeV := reflect.TypeOf(v)
+	if err := Lint(typeV); err != nil {
+		return "", err
+	}
+	for typeV.Kind() == reflect.Pointer {
+		typeV = typeV.Elem()
+	}
+
+	var columns []string
+	addColumns(&columns, typeV, dialect)
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);\n", dialect.quote(table), strings.Join(columns, ",\n  ")), nil
+}
+
+// addColumns appends the columns of the fields of the struct type typeV to
+// columns.
+func addColumns(columns *[]string, typeV reflect.Type, dialect SQLDialect) {
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+		name := fieldT.Tag.Get("db")
+		if name == "-" {
+			continue
+		}
+		if fieldT.Anonymous && name == "" {
+			if embedded := derefType(fieldT.Type); embedded.Kind() == reflect.Struct && embedded != timeType {
+				addColumns(columns, embedded, dialect)
+				continue
+			}
+		}
+		if !fieldT.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = snakeCase(fieldT.Name)
+		}
+
+		t := derefType(fieldT.Type)
+		sqlType, kind := dialect.columnType(t)
+		if sqlType == "" {
+			continue
+		}
+		var validators []rule
+		if cond := fieldRules(typeV, fieldT, defaultTagName); cond != "" && cond != "-" {
+			validators, _ = cachedValidators(cond)
+		}
+		column := dialect.quote(name)
+		notNull, checks := columnChecks(column, kind, validators, dialect)
+		if kind == reflect.String && dialect != SQLite {
+			if n, ok := maxLength(validators); ok {
+				sqlType = "VARCHAR(" + strconv.Itoa(n) + ")"
+			}
+		}
+		if isUnsigned(t.Kind()) && dialect != MySQL {
+			checks = append(checks, column+" >= 0")
+		}
+
+		def := column + " " + sqlType
+		if notNull {
+			def += " NOT NULL"
+		}
+		if len(checks) != 0 {
+			def += " CHECK (" + strings.Join(checks, " AND ") + ")"
+		}
+		*columns = append(*columns, def)
+	}
+}
+
+// columnType returns the type of the column holding values of type t, and
+// the kind of the values compared by its rules, "" when there is no column.
+func (d SQLDialect) columnType(t reflect.Type) (string, reflect.Kind) {
+	if t == timeType {
+		switch d {
+		case PostgreSQL:
+			return "TIMESTAMP WITH TIME ZONE", reflect.Struct
+		case MySQL:
+			return "DATETIME", reflect.Struct
+		}
+		return "TIMESTAMP", reflect.Struct
+	}
+	if t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType) {
+		return "", reflect.Invalid
+	}
+
+	kind := t.Kind()
+	unsigned := ""
+	if d == MySQL && isUnsigned(kind) {
+		unsigned = " UNSIGNED"
+	}
+	switch kind {
+	case reflect.String:
+		return "TEXT", kind
+	case reflect.Bool:
+		return "BOOLEAN", kind
+	case reflect.Int8, reflect.Uint8:
+		if d == MySQL {
+			return "TINYINT" + unsigned, kind
+		}
+		return "SMALLINT", kind
+	case reflect.Int16, reflect.Uint16:
+		return "SMALLINT" + unsigned, kind
+	case reflect.Int32, reflect.Uint32:
+		if d == MySQL {
+			return "INT" + unsigned, kind
+		}
+		return "INTEGER", kind
+	case reflect.Int, reflect.Int64:
+		return "BIGINT", kind
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		if d == PostgreSQL {
+			// BIGINT cannot hold the largest values.
+			return "NUMERIC(20)", kind
+		}
+		return "BIGINT" + unsigned, kind
+	case reflect.Float32:
+		return "REAL", kind
+	case reflect.Float64:
+		switch d {
+		case PostgreSQL:
+			return "DOUBLE PRECISION", kind
+		case MySQL:
+			return "DOUBLE", kind
+		}
+		return "REAL", kind
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if d == PostgreSQL {
+				return "BYTEA", reflect.Slice
+			}
+			return "BLOB", reflect.Slice
+		}
+	}
+	return "", reflect.Invalid
+}
+
+// columnChecks returns whether the column of values of kind k is NOT NULL
+// by validators and the conditions of its CHECK constraint.
+func columnChecks(column string, k reflect.Kind, validators []rule, dialect SQLDialect) (notNull bool, checks []string) {
+	if len(validators) != 0 && validators[0].name == "groups" || asText(validators) || asNumber(validators) {
+		// The rules do not always apply, or apply to another form of the
+		// values.
+		return false, nil
+	}
+
+	mode := ""
+	for _, validator := range validators {
+		if validator.name == "required" || validator.name == "omitempty" {
+			mode = validator.name
+			break
+		}
+	}
+	zero := sqlZero(k)
+	for _, validator := range validators {
+		if validator.warn || validator.refs != nil {
+			continue
+		}
+		if check := dialect.ruleCheck(column, k, validator); check != "" {
+			checks = append(checks, check)
+		}
+	}
+	if mode == "omitempty" && len(checks) != 0 && zero != "" {
+		return false, []string{"(" + column + " = " + zero + " OR " + strings.Join(checks, " AND ") + ")"}
+	}
+	if mode == "required" {
+		notNull = true
+		if zero != "" {
+			checks = append([]string{column + " <> " + zero}, checks...)
+		}
+	}
+	return notNull, checks
+}
+
+// ruleCheck returns the condition of the CHECK constraint of column holding
+// values of kind k for validator, "" for none.
+func (d SQLDialect) ruleCheck(column string, k reflect.Kind, validator rule) string {
+	value := column
+	if k == reflect.String {
+		value = d.length(column)
+	} else if !isNumber(k) {
+		return ""
+	}
+	switch validator.name {
+	case "len":
+		if k == reflect.String {
+			return value + " = " + strconv.Itoa(validator.argsInt[0])
+		}
+	case "min":
+		return value + " >= " + strconv.Itoa(validator.argsInt[0])
+	case "max":
+		if k != reflect.String || d == SQLite {
+			return value + " <= " + strconv.Itoa(validator.argsInt[0])
+		}
+	case "in", "enum":
+		var values []string
+		if validator.enum != nil {
+			for _, v := range validator.enum {
+				values = append(values, sqlLiteral(v.Interface()))
+			}
+		} else {
+			for _, arg := range validator.argsStr {
+				if k == reflect.String {
+					values = append(values, sqlLiteral(arg))
+				} else if _, err := strconv.ParseFloat(strings.TrimSpace(arg), 64); err == nil {
+					values = append(values, strings.TrimSpace(arg))
+				}
+			}
+		}
+		if len(values) == 0 {
+			return "FALSE"
+		}
+		return column + " IN (" + strings.Join(values, ", ") + ")"
+	}
+	return ""
+}
+
+// length returns the expression of the length of the string in column.
+func (d SQLDialect) length(column string) string {
+	switch d {
+	case PostgreSQL, MySQL:
+		return "CHAR_LENGTH(" + column + ")"
+	}
+	return "LENGTH(" + column + ")"
+}
+
+// quote returns name quoted as an identifier.
+func (d SQLDialect) quote(name string) string {
+	if d == MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// maxLength returns the largest length of a string allowed by the len and
+// max rules of validators.
+func maxLength(validators []rule) (n int, ok bool) {
+	if len(validators) != 0 && validators[0].name == "groups" || asText(validators) || asNumber(validators) {
+		return 0, false
+	}
+	for _, validator := range validators {
+		if (validator.name == "len" || validator.name == "max") && !validator.warn && validator.refs == nil && (!ok || validator.argsInt[0] < n) {
+			n, ok = validator.argsInt[0], true
+		}
+	}
+	return n, ok && n > 0
+}
+
+// sqlZero returns the SQL literal of the zero value of kind k, "" when it
+// is not a literal.
+func sqlZero(k reflect.Kind) string {
+	switch {
+	case k == reflect.String:
+		return "''"
+	case k == reflect.Bool:
+		return "FALSE"
+	case isNumber(k):
+		return "0"
+	}
+	return ""
+}
+
+// sqlLiteral returns value, a string, number or bool, as an SQL literal.
+func sqlLiteral(value any) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v))
+	}
+	return fmt.Sprint(value)
+}
+
+// isNumber reports whether k is the kind of integers or floats.
+func isNumber(k reflect.Kind) bool {
+	return isScalar(k) && k != reflect.String && k != reflect.Bool
+}
+
+// isUnsigned reports whether k is the kind of unsigned integers.
+func isUnsigned(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// snakeCase returns the Go name name in snake case, e.g. "user_id" for
+// "UserID".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
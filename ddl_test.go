@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ddlBase struct {
+	ID      uint64 `validate:"required"`
+	Created time.Time
+}
+
+type ddlUser struct {
+	ddlBase
+	Email    string  `validate:"required&email&max:254"`
+	Code     string  `validate:"len:5"`
+	Nickname *string `validate:"omitempty&min:3&max:20"`
+	Age      int     `validate:"min:18&max:130"`
+	Role     string  `validate:"in:admin,user,o'neil"`
+	Score    float64 `validate:"in:0.5,1.5"`
+	Active   bool    `validate:"required"`
+	Avatar   []byte
+	UserURL  string `db:"url" validate:"warn:max:10"`
+	Tags     []string
+	Address  ddlAddress
+	Secret   string `db:"-"`
+	Amount   string `validate:"asnum&min:10"`
+}
+
+type ddlAddress struct {
+	City string
+}
+
+func TestExportSQL(t *testing.T) {
+	sql, err := ExportSQL("users", &ddlUser{}, PostgreSQL)
+	require.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE "users" (
+  "id" NUMERIC(20) NOT NULL CHECK ("id" <> 0 AND "id" >= 0),
+  "created" TIMESTAMP WITH TIME ZONE,
+  "email" VARCHAR(254) NOT NULL CHECK ("email" <> ''),
+  "code" VARCHAR(5) CHECK (CHAR_LENGTH("code") = 5),
+  "nickname" VARCHAR(20) CHECK (("nickname" = '' OR CHAR_LENGTH("nickname") >= 3)),
+  "age" BIGINT CHECK ("age" >= 18 AND "age" <= 130),
+  "role" TEXT CHECK ("role" IN ('admin', 'user', 'o''neil')),
+  "score" DOUBLE PRECISION CHECK ("score" IN (0.5, 1.5)),
+  "active" BOOLEAN NOT NULL CHECK ("active" <> FALSE),
+  "avatar" BYTEA,
+  "url" TEXT,
+  "amount" TEXT
+);
+`, sql)
+
+	_, err = ExportSQL("users", 1, PostgreSQL)
+	assert.ErrorIs(t, err, ErrNotStruct)
+	_, err = ExportSQL("broken", struct {
+		A string `validate:"min:x"`
+	}{}, PostgreSQL)
+	assert.Error(t, err)
+}
+
+func TestExportSQLDialects(t *testing.T) {
+	type account struct {
+		UserID uint32 `validate:"required"`
+		Name   string `validate:"min:2&max:40"`
+		At     time.Time
+	}
+
+	sql, err := ExportSQL("accounts", account{}, MySQL)
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE `accounts` (\n"+
+		"  `user_id` INT UNSIGNED NOT NULL CHECK (`user_id` <> 0),\n"+
+		"  `name` VARCHAR(40) CHECK (CHAR_LENGTH(`name`) >= 2),\n"+
+		"  `at` DATETIME\n"+
+		");\n", sql)
+
+	sql, err = ExportSQL("accounts", account{}, SQLite)
+	require.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE "accounts" (
+  "user_id" INTEGER NOT NULL CHECK ("user_id" <> 0 AND "user_id" >= 0),
+  "name" TEXT CHECK (LENGTH("name") >= 2 AND LENGTH("name") <= 40),
+  "at" TIMESTAMP
+);
+`, sql)
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"HTTPProxy": "http_proxy",
+		"Name2":     "name2",
+		"createdAt": "created_at",
+	} {
+		assert.Equal(t, want, snakeCase(name), name)
+	}
+}
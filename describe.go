@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Describe returns the rules of the fields of the struct v, or of the struct
+// v points to, as parsed from their tags, e.g. for documentation generators
+// or admin UIs. Fields of nested structs are described with dotted paths
+// like "Address.Zip", fields of the structs in slices, arrays and maps like
+// "Items.SKU". Aliases are replaced by their rules.
+//
+// The rules of keys and values have the rules they apply as parameters, a
+// warning, like "warn:max:500", is a rule named warn with the rule as its
+// parameter, and a group of alternatives, like "(email|len:0)", is a rule
+// named or with the alternatives as parameters. Tags that cannot be applied
+// are reported like by Compile.
+func Describe(v any, opts ...Option) ([]FieldRules, error) {
+	described, err := describeFields(v, newConfig(opts))
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]FieldRules, len(described))
+	for i, f := range described {
+		fields[i] = FieldRules{name: f.name, typ: f.typ, rules: describeRules(f.validators)}
+	}
+	return fields, nil
+}
+
+// describedField is a tagged field found by describeFields.
+type describedField struct {
+	name       string
+	typ        reflect.Type
+	validators []rule
+}
+
+// describeFields returns the tagged fields of the struct v, or of the struct
+// v points to, and of the structs nested in it.
+func describeFields(v any, c config) ([]describedField, error) {
+	typeV := reflect.TypeOf(v)
+	if typeV != nil && typeV.Kind() == reflect.Pointer {
+		typeV = typeV.Elem()
+	}
+	if typeV == nil || typeV.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	d := describer{config: c, visiting: map[reflect.Type]bool{}}
+	d.describe("", typeV)
+	if len(d.errs) != 0 {
+		return nil, d.errs
+	}
+	return d.fields, nil
+}
+
+// Name returns the path of the field, e.g. "Address.Zip".
+func (f FieldRules) Name() string {
+	return f.name
+}
+
+// Type returns the type of the field described by Describe, nil for rules
+// built by F.
+func (f FieldRules) Type() reflect.Type {
+	return f.typ
+}
+
+// Rules returns the rules of the field.
+func (f FieldRules) Rules() []Rule {
+	return f.rules
+}
+
+type describer struct {
+	config
+	fields []describedField
+	errs   ValidationErrors
+	// visiting holds the struct types being described, as types may refer
+	// to themselves.
+	visiting map[reflect.Type]bool
+}
+
+// describe adds the fields of the struct type typeV at the path prefix.
+func (d *describer) describe(prefix string, typeV reflect.Type) {
+	if d.visiting[typeV] {
+		return
+	}
+	d.visiting[typeV] = true
+	defer delete(d.visiting, typeV)
+
+	plan := d.cachedPlan(typeV)
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		fieldT := typeV.Field(f.index).Type
+		path := joinPath(prefix, f.name)
+
+		if f.tagged && !d.skips(f) {
+			if err := d.fieldErr(f); err != nil {
+				d.errs = append(d.errs, ValidationError{err})
+			} else {
+				d.fields = append(d.fields, describedField{name: path, typ: fieldT, validators: f.validators})
+			}
+		}
+
+		if !f.descend {
+			continue
+		}
+		if f.elems {
+			fieldT = fieldT.Elem()
+		}
+		nestedT := derefType(fieldT)
+		if !describesNested(nestedT) || !d.recurses(nestedT) {
+			continue
+		}
+		if f.anonymous && !f.elems && d.embeddedNaming == FlattenEmbedded {
+			path = prefix
+		}
+		d.describe(path, nestedT)
+	}
+}
+
+// describesNested reports whether the fields of values of type t are
+// validated, like nestedStruct does for values.
+func describesNested(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.Implements(valuerType) || t.Implements(openerType) {
+		return false
+	}
+	_, custom := registered(customTypes, t)
+	_, number := registered(numberTypes, t)
+	return !custom && !number
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// describeRules converts parsed rules back to the rules they were parsed
+// from.
+func describeRules(validators []rule) []Rule {
+	rules := make([]Rule, len(validators))
+	for i, validator := range validators {
+		rules[i] = describeRule(validator)
+	}
+	return rules
+}
+
+func describeRule(validator rule) Rule {
+	if validator.warn {
+		validator.warn = false
+		return Rule{Name: "warn", Params: []string{describeRule(validator).String()}}
+	}
+	if validator.each != nil {
+		params := make([]string, len(validator.each))
+		for i, each := range validator.each {
+			params[i] = describeRule(each).String()
+		}
+		return Rule{Name: validator.name, Params: params}
+	}
+	if validator.alternatives != nil {
+		params := make([]string, len(validator.alternatives))
+		for i, alternative := range validator.alternatives {
+			rules := describeRules(alternative)
+			parts := make([]string, len(rules))
+			for j, r := range rules {
+				parts[j] = r.String()
+			}
+			params[i] = strings.Join(parts, "&")
+		}
+		return Rule{Name: validator.name, Params: params}
+	}
+	return Rule{Name: validator.name, Params: validator.argsStr}
+}
@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describedItem struct {
+	SKU string `validate:"len:8"`
+}
+
+type describedOrder struct {
+	Base
+	ID     string          `validate:"required&regexp:^[a-z,]+$"`
+	Note   string          `validate:"warn:max:500"`
+	Codes  map[string]int  `validate:"keys:len:2&in:RU,EN,endkeys&values:min:0"`
+	Items  []describedItem `validate:"min:1"`
+	Parent *describedOrder
+	Labels map[string]string
+	Secret string `validate:"-"`
+}
+
+func TestDescribe(t *testing.T) {
+	fields, err := Describe(&describedOrder{})
+	require.NoError(t, err)
+
+	var rules []string
+	for _, f := range fields {
+		var strs []string
+		for _, r := range f.Rules() {
+			strs = append(strs, r.String())
+		}
+		rules = append(rules, f.Name()+" "+f.Type().String()+" "+strings.Join(strs, "&"))
+	}
+	assert.Equal(t, []string{
+		"ID int min:1",
+		"Name string required",
+		"ID string required&regexp:^[a-z,]+$",
+		"Note string warn:max:500",
+		"Codes map[string]int keys:len:2,in:RU,EN&values:min:0",
+		"Items []validator.describedItem min:1",
+		"Items.SKU string len:8",
+	}, rules)
+
+	assert.Equal(t, Rule{Name: "keys", Params: []string{"len:2", "in:RU,EN"}}, fields[4].Rules()[0])
+
+	qualified, err := Describe(describedOrder{}, WithEmbeddedNaming(QualifyEmbedded))
+	require.NoError(t, err)
+	assert.Equal(t, "Base.ID", qualified[0].Name())
+}
+
+func TestDescribeAliases(t *testing.T) {
+	RegisterAlias("describedname", "required&max:32")
+	t.Cleanup(func() {
+		delete(aliases, "describedname")
+		resetPlanCache()
+	})
+
+	fields, err := Describe(struct {
+		Name string `validate:"describedname"`
+	}{})
+	require.NoError(t, err)
+	require.Len(t, fields, 1)
+	assert.Equal(t, []Rule{{Name: "required"}, {Name: "max", Params: []string{"32"}}}, fields[0].Rules())
+	assert.Equal(t, reflect.TypeOf(""), fields[0].Type())
+}
+
+func TestDescribeErrors(t *testing.T) {
+	_, err := Describe(42)
+	assert.ErrorIs(t, err, ErrNotStruct)
+
+	_, err = Describe(struct {
+		Name string `validate:"max:x"`
+	}{})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
+
+func TestRuleParam(t *testing.T) {
+	r := Rule{Name: "window", Params: []string{" 18", "1.5", "true", "90s", "$Start", "x"}}
+	n, err := r.Param(0).Int()
+	require.NoError(t, err)
+	assert.Equal(t, 18, n)
+	f, err := r.Param(1).Float()
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, f)
+	b, err := r.Param(2).Bool()
+	require.NoError(t, err)
+	assert.True(t, b)
+	d, err := r.Param(3).Duration()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+	field, ok := r.Param(4).Field()
+	assert.True(t, ok)
+	assert.Equal(t, "Start", field)
+
+	_, ok = r.Param(5).Field()
+	assert.False(t, ok)
+	_, err = r.Param(5).Int()
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+	assert.EqualError(t, err, `invalid validator syntax: parameter "x" is not an integer`)
+	_, err = r.Param(5).Duration()
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+	assert.Equal(t, Param(""), r.Param(6))
+	assert.Equal(t, Param(""), r.Param(-1))
+	assert.Equal(t, "18", r.Param(0).String())
+}
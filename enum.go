@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+var enums = map[string][]reflect.Value{}
+
+// RegisterEnum makes values available under name to the enum rule, which
+// accepts only these values, so the valid set is kept next to the constants
+// of an enum type:
+//
+//	type Status string
+//
+//	const (
+//		Active  Status = "active"
+//		Blocked Status = "blocked"
+//	)
+//
+//	func init() {
+//		validator.RegisterEnum("status", Active, Blocked)
+//	}
+//
+//	type User struct {
+//		Status Status `validate:"required&enum:status"`
+//	}
+//
+// Values are compared by their underlying kind, so a plain string field can
+// be checked against the constants of Status too. It is meant to be called
+// during program initialization.
+func RegisterEnum[T comparable](name string, values ...T) error {
+	set := make([]reflect.Value, len(values))
+	for i, v := range values {
+		set[i] = reflect.ValueOf(v)
+	}
+	return register("RegisterEnum", func() {
+		enums[name] = set
+		resetPlanCache()
+	})
+}
+
+// parseEnum parses the argument of the enum rule, the name of a registered
+// enum.
+func parseEnum(params string) (rule, error) {
+	name := strings.TrimSpace(params)
+	values, ok := registered(enums, name)
+	if !ok {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	return rule{name: "enum", argsStr: []string{name}, enum: values}, nil
+}
+
+// validateEnum reports whether field is one of values.
+func validateEnum(field reflect.Value, values []reflect.Value) error {
+	for _, v := range values {
+		if enumEqual(field, v) {
+			return nil
+		}
+	}
+	return ErrFieldNotValid
+}
+
+// enumEqual reports whether a and b are equal values of the same kind of
+// strings, integers, unsigned integers, floats or bools, or equal values of
+// the same type otherwise.
+func enumEqual(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() || kindClass(a.Kind()) != kindClass(b.Kind()) {
+		return false
+	}
+	switch kindClass(a.Kind()) {
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Int:
+		return a.Int() == b.Int()
+	case reflect.Uint:
+		return a.Uint() == b.Uint()
+	case reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	}
+	return a.Type() == b.Type() && a.CanInterface() && a.Interface() == b.Interface()
+}
+
+// kindClass maps the kinds of integers, unsigned integers and floats of any
+// size to Int, Uint and Float64.
+func kindClass(kind reflect.Kind) reflect.Kind {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Uint
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return kind
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type enumStatus string
+
+const (
+	enumActive  enumStatus = "active"
+	enumBlocked enumStatus = "blocked"
+)
+
+type enumLevel uint8
+
+func TestRegisterEnum(t *testing.T) {
+	RegisterEnum("status", enumActive, enumBlocked)
+	RegisterEnum("level", enumLevel(1), enumLevel(2))
+	t.Cleanup(func() {
+		delete(enums, "status")
+		delete(enums, "level")
+		resetPlanCache()
+	})
+
+	type account struct {
+		Status   enumStatus   `validate:"required&enum:status"`
+		Raw      string       `validate:"omitempty&enum: status"`
+		History  []enumStatus `validate:"enum:status"`
+		Level    *enumLevel   `validate:"omitempty&enum:level"`
+		Priority int          `validate:"omitempty&enum:level"`
+	}
+
+	level := enumLevel(2)
+	require.NoError(t, Validate(account{Status: enumActive, Raw: "blocked", History: []enumStatus{enumBlocked}, Level: &level}))
+
+	tests := []struct {
+		name  string
+		value account
+		field string
+	}{
+		{"unknown value", account{Status: "deleted"}, "Status"},
+		{"plain string", account{Status: enumActive, Raw: "Active"}, "Raw"},
+		{"slice element", account{Status: enumActive, History: []enumStatus{enumActive, "x"}}, "History"},
+		{"pointer", account{Status: enumActive, Level: new(enumLevel)}, "Level"},
+		{"other kind", account{Status: enumActive, Priority: 1}, "Priority"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value)
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.field, errs[0].Field())
+			assert.Equal(t, "VAL_ENUM", errs[0].Code())
+		})
+	}
+
+	err := ValidateVar("active", "enum:unknown")
+	require.Error(t, err)
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax)
+
+	err = Lint(reflect.TypeOf(account{}))
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "Priority: rule does not apply to the field type: enum on int")
+
+	schema, err := ExportJSONSchema(struct {
+		Status enumStatus `json:"status" validate:"enum:status"`
+	}{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {"status": {"type": "string", "enum": ["active", "blocked"]}}
+	}`, string(schema))
+}
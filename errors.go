@@ -0,0 +1,315 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sort sorts the errors by the paths of their fields, e.g. to compare them
+// with golden files regardless of the order the fields are declared in.
+// Segments of paths are compared by name and then by index, numerically
+// for slices and arrays, so "Items[2]" comes before "Items[10]". Errors not
+// about a field come first and errors of the same field keep their order.
+func (v ValidationErrors) Sort() {
+	fields := make([]string, len(v))
+	for i, e := range v {
+		fields[i] = e.Field()
+	}
+	sort.Stable(byField{errs: v, fields: fields})
+}
+
+type byField struct {
+	errs   ValidationErrors
+	fields []string
+}
+
+func (b byField) Len() int { return len(b.errs) }
+
+func (b byField) Swap(i, j int) {
+	b.errs[i], b.errs[j] = b.errs[j], b.errs[i]
+	b.fields[i], b.fields[j] = b.fields[j], b.fields[i]
+}
+
+func (b byField) Less(i, j int) bool {
+	return comparePaths(b.fields[i], b.fields[j]) < 0
+}
+
+// comparePaths compares the field paths a and b segment by segment.
+func comparePaths(a, b string) int {
+	for a != "" && b != "" {
+		var segA, segB string
+		segA, a, _ = strings.Cut(a, ".")
+		segB, b, _ = strings.Cut(b, ".")
+		if c := compareSegments(segA, segB); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// compareSegments compares the segments a and b of paths, like "Items[2]".
+func compareSegments(a, b string) int {
+	nameA, indexA, _ := strings.Cut(a, "[")
+	nameB, indexB, _ := strings.Cut(b, "[")
+	if c := strings.Compare(nameA, nameB); c != 0 {
+		return c
+	}
+	indexA, indexB = strings.TrimSuffix(indexA, "]"), strings.TrimSuffix(indexB, "]")
+	numA, errA := strconv.Atoi(indexA)
+	numB, errB := strconv.Atoi(indexB)
+	if errA == nil && errB == nil {
+		return numA - numB
+	}
+	return strings.Compare(indexA, indexB)
+}
+
+// Filter returns the errors keep reports true for, in the same order, or nil
+// when there are none.
+//
+//	required := errs.Filter(func(e validator.ValidationError) bool {
+//		return e.Rule() == "required"
+//	})
+func (v ValidationErrors) Filter(keep func(ValidationError) bool) ValidationErrors {
+	var kept ValidationErrors
+	for _, e := range v {
+		if keep(e) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// Fields returns the paths of the fields that are not valid, each once, in
+// the order of the errors. Errors not about a field are left out.
+func (v ValidationErrors) Fields() []string {
+	var fields []string
+	seen := map[string]bool{}
+	for _, e := range v {
+		field := e.Field()
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// configErrors are the errors about the rules of a value rather than the
+// value itself.
+var configErrors = []error{
+	ErrNotStruct,
+	ErrInvalidValidatorSyntax,
+	ErrValidateForUnexportedFields,
+	ErrRuleNotApplicable,
+	ErrUnknownField,
+	ErrPanic,
+}
+
+// isConfigError reports whether err is about the rules rather than the
+// value.
+func isConfigError(err error) bool {
+	for _, target := range configErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConfig reports whether e is about the rules rather than the value, like
+// rules with invalid syntax, rules on unexported fields, rules that cannot
+// apply to the type of their field or unknown fields of a Builder or Schema.
+// Such errors are bugs of the program, not of its input. Rules that cannot
+// apply fail like invalid values unless WithStrictRules is used.
+func (e ValidationError) IsConfig() bool {
+	return isConfigError(e.Err)
+}
+
+// Split splits the errors into the errors about the values and the errors
+// about the rules, see ValidationError.IsConfig, keeping their order.
+func (v ValidationErrors) Split() (data, config ValidationErrors) {
+	for _, e := range v {
+		if e.IsConfig() {
+			config = append(config, e)
+		} else {
+			data = append(data, e)
+		}
+	}
+	return data, config
+}
+
+// SplitErrors splits an error returned by Validate or the other validating
+// functions into an error about the input, dataErr, and an error about the
+// rules or the program, configErr, so that a service can answer 400 to the
+// first and 500 to the second:
+//
+//	dataErr, configErr := validator.SplitErrors(validator.Validate(req))
+//	switch {
+//	case configErr != nil:
+//		return http.StatusInternalServerError
+//	case dataErr != nil:
+//		return http.StatusBadRequest
+//	}
+//
+// ValidationErrors are split with Split, each side being nil when empty.
+// Other errors, like ErrNotStruct or a recovered panic, are configErr when
+// they wrap one of the errors IsConfig reports, and dataErr otherwise, like
+// the error of a done context, ErrMaxDepth or ErrTooLarge.
+func SplitErrors(err error) (dataErr, configErr error) {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		if isConfigError(err) {
+			return nil, err
+		}
+		return err, nil
+	}
+	data, config := errs.Split()
+	if len(data) != 0 {
+		dataErr = data
+	}
+	if len(config) != 0 {
+		configErr = config
+	}
+	return dataErr, configErr
+}
+
+// Join merges the errors of several validations into one, e.g. of the
+// header, the body and the path parameters of a request, telling them apart
+// with Prefix:
+//
+//	err := validator.Join(
+//		validator.Prefix("header", validator.Validate(header)),
+//		validator.Prefix("body", validator.Validate(body)),
+//	)
+//
+// The ValidationErrors of errs are concatenated in order into a single
+// ValidationErrors, nil errors are skipped and Join returns nil when all
+// are. Other errors, like the error of a done context, are joined after it
+// with errors.Join, so errors.Is and errors.As still find them.
+func Join(errs ...error) error {
+	var merged ValidationErrors
+	var others []error
+	for _, err := range errs {
+		if verrs, ok := err.(ValidationErrors); ok {
+			merged = append(merged, verrs...)
+		} else if err != nil {
+			others = append(others, err)
+		}
+	}
+	if len(others) == 0 {
+		if len(merged) == 0 {
+			return nil
+		}
+		return merged
+	}
+	if len(merged) != 0 {
+		others = append([]error{merged}, others...)
+	}
+	return errors.Join(others...)
+}
+
+// Prefix returns err with path prepended to the paths of the fields of its
+// ValidationErrors, e.g. "body.Name" for the field Name with the path
+// "body", or "items[2]" for the field "[2]" of a validated slice. Errors not
+// about a field, and err itself when it is not a ValidationErrors, are
+// wrapped with a message starting with path. Prefix returns nil for a nil
+// err.
+func Prefix(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	prefixed := make(ValidationErrors, len(errs))
+	for i, e := range errs {
+		fe, ok := e.Err.(*fieldError)
+		if !ok {
+			prefixed[i] = ValidationError{fmt.Errorf("%s: %w", path, e.Err)}
+			continue
+		}
+		moved := *fe
+		switch {
+		case fe.field == "":
+			moved.field = path
+		case path != "" && !strings.HasPrefix(fe.field, "["):
+			moved.field = path + "." + fe.field
+		default:
+			moved.field = path + fe.field
+		}
+		prefixed[i] = ValidationError{&moved}
+	}
+	return prefixed
+}
+
+// SyntaxError tells which rule of a tag cannot be parsed. It wraps
+// ErrInvalidValidatorSyntax, so errors.Is finds that, and is reached with
+// errors.As:
+//
+//	var syntax *validator.SyntaxError
+//	if errors.As(errs[0].Err, &syntax) {
+//		log.Printf("fix rule %q of %s", syntax.Rule, syntax.Field)
+//	}
+type SyntaxError struct {
+	// Field is the name of the struct field with the tag, "" for rules not
+	// read from a tag, like those of ValidateVar.
+	Field string
+	// Rule is the rule as written, e.g. "min:x" in "required&min:x", and
+	// Offset the index of its first byte in the rules. A rule of an alias is
+	// reported as the alias, a rule of a keys group as the whole group.
+	Rule   string
+	Offset int
+	// Reason tells what is wrong when it is not the rule itself, like
+	// "unclosed parenthesis" for a group of alternatives or "unknown rule".
+	Reason string
+	// Suggestion is the name of the rule closest to an unknown rule, like
+	// "len" for "lenght", "" when none is close.
+	Suggestion string
+}
+
+func (e *SyntaxError) Error() string {
+	msg := fmt.Sprintf("%v: rule %q at offset %d", ErrInvalidValidatorSyntax, e.Rule, e.Offset)
+	if e.Field != "" {
+		msg += " of field " + e.Field
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+// unknownRuleError is the error of a rule neither built in nor registered.
+type unknownRuleError struct {
+	name string
+}
+
+func (e *unknownRuleError) Error() string {
+	return fmt.Sprintf("%v: unknown rule %q", ErrInvalidValidatorSyntax, e.name)
+}
+
+func (e *unknownRuleError) Unwrap() error {
+	return ErrInvalidValidatorSyntax
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return ErrInvalidValidatorSyntax
+}
+
+// withField returns err with the name of the field reading the rules when
+// it is a SyntaxError.
+func withField(err error, field string) error {
+	if syntax, ok := err.(*SyntaxError); ok {
+		named := *syntax
+		named.Field = field
+		return &named
+	}
+	return err
+}
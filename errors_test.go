@@ -0,0 +1,269 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldErrors(fields ...string) ValidationErrors {
+	errs := make(ValidationErrors, len(fields))
+	for i, field := range fields {
+		if field == "" {
+			errs[i] = ValidationError{ErrInvalidValidatorSyntax}
+			continue
+		}
+		errs[i] = ValidationError{&fieldError{field: field, cond: "required", rule: "required"}}
+	}
+	return errs
+}
+
+func TestValidationErrorsSort(t *testing.T) {
+	errs := fieldErrors("Name", "Items[10].SKU", "", "Items[2].SKU", "Address.Zip", "Items[2]", "Address", "ByID[b]", "ByID[a]")
+	errs.Sort()
+	assert.Equal(t, []string{"", "Address", "Address.Zip", "ByID[a]", "ByID[b]", "Items[2]", "Items[2].SKU", "Items[10].SKU", "Name"}, fieldsOf(errs))
+
+	stable := ValidationErrors{
+		{&fieldError{field: "B", rule: "min"}},
+		{&fieldError{field: "A", rule: "max"}},
+		{&fieldError{field: "B", rule: "max"}},
+	}
+	stable.Sort()
+	assert.Equal(t, []string{"A", "B", "B"}, fieldsOf(stable))
+	assert.Equal(t, "min", stable[1].Rule())
+	assert.Equal(t, "max", stable[2].Rule())
+}
+
+func TestValidationErrorsFilter(t *testing.T) {
+	errs := fieldErrors("Name", "", "Email")
+	kept := errs.Filter(func(e ValidationError) bool {
+		return e.Field() != ""
+	})
+	assert.Equal(t, []string{"Name", "Email"}, fieldsOf(kept))
+	assert.Nil(t, errs.Filter(func(ValidationError) bool { return false }))
+}
+
+func TestValidationErrorsFields(t *testing.T) {
+	assert.Equal(t, []string{"Name", "Email"}, fieldErrors("Name", "", "Email", "Name").Fields())
+	assert.Nil(t, ValidationErrors(nil).Fields())
+}
+
+func TestJoinPrefix(t *testing.T) {
+	type header struct {
+		Token string `validate:"required"`
+	}
+	type item struct {
+		SKU string `validate:"required"`
+	}
+	type body struct {
+		Name  string `validate:"required&min:2"`
+		Items []item
+		notes string `validate:"max:1"`
+	}
+
+	err := Join(
+		Prefix("header", Validate(header{})),
+		Prefix("body", Validate(body{Name: "a", Items: []item{{}}, notes: "ab"})),
+		Prefix("items", Validate([]item{{SKU: "a"}, {}})),
+		Prefix("query", nil),
+	)
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{"header.Token", "body.Name", "body.Items[0].SKU", "", "items[1].SKU"}, fieldsOf(errs))
+	assert.Equal(t, "min", errs[1].Rule())
+	assert.Equal(t, "required&min:2", errs[1].Rules())
+	assert.ErrorIs(t, errs[1].Err, ErrFieldNotValid)
+	assert.EqualError(t, errs[1].Err, "field: body.Name not valid for required&min:2")
+	require.Len(t, errs, 5)
+	assert.EqualError(t, errs[3].Err, "body: validation for unexported field is not allowed")
+	assert.ErrorIs(t, errs[3].Err, ErrValidateForUnexportedFields)
+
+	assert.NoError(t, Join())
+	assert.NoError(t, Join(nil, Prefix("a", nil)))
+	assert.Equal(t, []string{"Token"}, fieldsOf(Join(nil, Validate(header{})).(ValidationErrors)))
+	assert.Equal(t, []string{"x"}, fieldsOf(Prefix("x", ValidateVar("", "required")).(ValidationErrors)))
+
+	err = Join(Validate(header{}), Prefix("body", Validate(42)), context.Canceled)
+	assert.ErrorIs(t, err, ErrNotStruct)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.EqualError(t, err, "field: Token not valid for required\nbody: wrong argument given, should be a struct\ncontext canceled")
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{"Token"}, fieldsOf(errs))
+}
+
+func TestValidationErrorsOrder(t *testing.T) {
+	errTaken := errors.New("taken")
+	RegisterBatchRule("free", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		errs := make([]error, len(values))
+		for i, v := range values {
+			if v.String() == "taken" {
+				errs[i] = errTaken
+			}
+		}
+		return errs, nil
+	})
+	t.Cleanup(func() {
+		delete(batchRules, "free")
+		resetPlanCache()
+	})
+
+	type member struct {
+		Login string `validate:"free"`
+		Name  string `validate:"required"`
+	}
+	type team struct {
+		Owner   string `validate:"free"`
+		Members []member
+		Title   string `validate:"required"`
+	}
+
+	v := team{Owner: "taken", Members: make([]member, 600)}
+	for i := range v.Members {
+		v.Members[i].Name = "x"
+	}
+	v.Members[3].Login = "taken"
+	v.Members[400].Name = ""
+	v.Members[500].Login = "taken"
+
+	want := []string{"Owner", "Members[3].Login", "Members[400].Name", "Members[500].Login", "Title"}
+	for _, n := range []int{1, 4} {
+		err := Validate(v, WithParallelism(n))
+		var errs ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, want, fieldsOf(errs), n)
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	RegisterAlias("brokenalias", "required&min:x")
+	t.Cleanup(func() {
+		delete(aliases, "brokenalias")
+		resetPlanCache()
+	})
+
+	tests := []struct {
+		tag    string
+		rule   string
+		offset int
+	}{
+		{tag: "required&min:x", rule: "min:x", offset: 9},
+		{tag: "len:x", rule: "len:x", offset: 0},
+		{tag: "required& max:y &email", rule: "max:y", offset: 10},
+		{tag: "min:1&brokenalias", rule: "brokenalias", offset: 6},
+		{tag: "min:1&keys:len:2&len:y,endkeys&max:1", rule: "keys:len:2&len:y,endkeys", offset: 6},
+		{tag: `in:"a,b"&regexp:(`, rule: "regexp:(", offset: 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			_, err := parseValidators(tt.tag)
+			var syntax *SyntaxError
+			require.ErrorAs(t, err, &syntax)
+			assert.Equal(t, tt.rule, syntax.Rule)
+			assert.Equal(t, tt.offset, syntax.Offset)
+			assert.Equal(t, tt.rule, tt.tag[syntax.Offset:syntax.Offset+len(syntax.Rule)])
+			assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+		})
+	}
+
+	err := Validate(struct {
+		Name string `validate:"required&min:x"`
+	}{})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var syntax *SyntaxError
+	require.ErrorAs(t, errs[0].Err, &syntax)
+	assert.Equal(t, SyntaxError{Field: "Name", Rule: "min:x", Offset: 9}, *syntax)
+	assert.EqualError(t, syntax, `invalid validator syntax: rule "min:x" at offset 9 of field Name`)
+	assert.Equal(t, CodeSyntax, errs[0].Code())
+}
+
+func TestSyntaxErrorSuggestion(t *testing.T) {
+	RegisterAlias("username", "required&min:3")
+	t.Cleanup(func() {
+		delete(aliases, "username")
+		resetPlanCache()
+	})
+
+	tests := []struct {
+		tag        string
+		rule       string
+		suggestion string
+	}{
+		{tag: "required&lenght", rule: "lenght", suggestion: "len"},
+		{tag: "emial", rule: "emial", suggestion: "email"},
+		{tag: "usernmae", rule: "usernmae", suggestion: "username"},
+		{tag: "(email|ulidd)", rule: "ulidd", suggestion: "ulid"},
+		{tag: "frobnicate", rule: "frobnicate"},
+		{tag: "required&mni:3", rule: "mni:3", suggestion: "min"},
+		{tag: "lenght:3", rule: "lenght:3", suggestion: "len"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			_, err := parseValidators(tt.tag)
+			var syntax *SyntaxError
+			require.ErrorAs(t, err, &syntax)
+			assert.Equal(t, tt.rule, syntax.Rule)
+			assert.Equal(t, "unknown rule", syntax.Reason)
+			assert.Equal(t, tt.suggestion, syntax.Suggestion)
+		})
+	}
+
+	err := Validate(struct {
+		Size string `validate:"lenght"`
+	}{})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.EqualError(t, errs[0].Err, `invalid validator syntax: rule "lenght" at offset 0 of field Size: unknown rule, did you mean "len"?`)
+	require.ErrorAs(t, ValidateVar("Ann", "mni:3"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+	assert.True(t, errs[0].IsConfig())
+
+	_, err = parseValidators("min")
+	var syntax *SyntaxError
+	require.ErrorAs(t, err, &syntax)
+	assert.Empty(t, syntax.Reason, "built-in rule without arguments")
+}
+
+func TestSplitErrors(t *testing.T) {
+	type account struct {
+		Name  string `validate:"required"`
+		Age   int    `validate:"omitempty&email"`
+		Login string `validate:"min:x"`
+	}
+	err := Validate(account{}, WithStrictRules())
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	data, config := errs.Split()
+	assert.Equal(t, []string{"Name"}, fieldsOf(data))
+	require.Len(t, config, 2)
+	assert.ErrorIs(t, config[0].Err, ErrRuleNotApplicable)
+	assert.ErrorIs(t, config[1].Err, ErrInvalidValidatorSyntax)
+
+	dataErr, configErr := SplitErrors(err)
+	assert.Equal(t, data, dataErr)
+	assert.Equal(t, config, configErr)
+
+	dataErr, configErr = SplitErrors(Validate(account{Name: "Ann", Login: "x"}))
+	assert.NoError(t, dataErr)
+	assert.Error(t, configErr)
+
+	dataErr, configErr = SplitErrors(ValidateVar("", "required"))
+	assert.Error(t, dataErr)
+	assert.NoError(t, configErr)
+
+	dataErr, configErr = SplitErrors(Validate(42))
+	assert.NoError(t, dataErr)
+	assert.ErrorIs(t, configErr, ErrNotStruct)
+
+	dataErr, configErr = SplitErrors(context.Canceled)
+	assert.ErrorIs(t, dataErr, context.Canceled)
+	assert.NoError(t, configErr)
+
+	dataErr, configErr = SplitErrors(nil)
+	assert.NoError(t, dataErr)
+	assert.NoError(t, configErr)
+}
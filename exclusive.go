@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// parseExclusive parses the excluded_with and exactly_one_of rules, whose
+// arguments are the paths of the fields set along with the field, or
+// instead of it:
+//
+//	type Payment struct {
+//		CardToken   string `validate:"exactly_one_of:BankAccount"`
+//		BankAccount string `validate:"excluded_with:CardToken"`
+//		Coupon      string `validate:"excluded_with:Gift.Code,Discount"`
+//	}
+//
+// A field excluded_with other fields must be empty when any of them is set.
+// A field exactly_one_of other fields requires exactly one of it and them to
+// be set. Like required, a field is set when it is not empty.
+func parseExclusive(name, params string) (rule, error) {
+	args := tags.SplitArgs(params)
+	for i, arg := range args {
+		if args[i] = strings.TrimSpace(arg); !tags.IsFieldPath(args[i]) {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+	}
+	refs := make([]string, len(args))
+	copy(refs, args)
+	return rule{name: name, argsStr: args, argsInt: make([]int, len(args)), refs: refs}, nil
+}
+
+// isExclusive reports whether the rule name compares whether a field is set
+// with whether other fields are.
+func isExclusive(name string) bool {
+	return name == "excluded_with" || name == "exactly_one_of"
+}
+
+// refSet returns 1 when the field at path in root is set, 0 otherwise, the
+// value of the argument of an exclusive rule referencing it.
+func refSet(root reflect.Value, path string) (int, error) {
+	raw, ok := reflect.Value{}, false
+	if root.IsValid() {
+		raw, ok = fieldByKey(root, path)
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s referenced by a rule", ErrUnknownField, path)
+	}
+	field, err := customValue(raw)
+	if err != nil || isEmpty(raw, field) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// exclusiveHolds reports whether the exclusive rule validator, with the
+// referenced fields resolved by withRefs, holds for a field that is empty
+// or set.
+func exclusiveHolds(validator rule, empty bool) bool {
+	set := 0
+	for _, n := range validator.argsInt {
+		set += n
+	}
+	if validator.name == "excluded_with" {
+		return empty || set == 0
+	}
+	if !empty {
+		set++
+	}
+	return set == 1
+}
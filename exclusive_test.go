@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExclusive(t *testing.T) {
+	type gift struct {
+		Code string
+	}
+	type payment struct {
+		CardToken   string  `validate:"exactly_one_of:BankAccount,Wallet"`
+		BankAccount string  `validate:"omitempty&len:8"`
+		Wallet      *string `validate:"omitempty&excluded_with:BankAccount"`
+		Coupon      string  `validate:"excluded_with:Gift.Code, Discount"`
+		Discount    int
+		Gift        *gift
+	}
+	wallet := ""
+
+	tests := []struct {
+		name   string
+		value  payment
+		fields []string
+	}{
+		{"card", payment{CardToken: "tok"}, nil},
+		{"bank", payment{BankAccount: "12345678"}, nil},
+		{"wallet", payment{Wallet: &wallet}, nil},
+		{"none", payment{}, []string{"CardToken"}},
+		{"two", payment{CardToken: "tok", BankAccount: "12345678"}, []string{"CardToken"}},
+		{"three", payment{CardToken: "tok", BankAccount: "12345678", Wallet: &wallet}, []string{"CardToken", "Wallet"}},
+		{"excluded", payment{BankAccount: "12345678", Wallet: &wallet}, []string{"CardToken", "Wallet"}},
+		{"nested", payment{CardToken: "tok", Coupon: "x", Gift: &gift{Code: "g"}}, []string{"Coupon"}},
+		{"nil pointer", payment{CardToken: "tok", Coupon: "x", Gift: nil}, nil},
+		{"second", payment{CardToken: "tok", Coupon: "x", Discount: 5}, []string{"Coupon"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.True(t, errors.As(err, &errs), err)
+			assert.Equal(t, tt.fields, fieldsOf(errs))
+			assert.Contains(t, []string{"excluded_with", "exactly_one_of"}, errs[0].Rule())
+		})
+	}
+
+	syntax := []any{
+		struct {
+			A string `validate:"excluded_with:B"`
+		}{},
+		struct {
+			A string `validate:"exactly_one_of:"`
+		}{},
+		struct {
+			A string `validate:"(exactly_one_of:A|min:1)"`
+		}{},
+		struct {
+			A string `validate:"warn:excluded_with:A"`
+		}{},
+		struct {
+			A map[string]string `validate:"values:excluded_with:A"`
+		}{},
+	}
+	for _, v := range syntax {
+		var errs ValidationErrors
+		require.True(t, errors.As(Validate(v), &errs))
+		_, config := SplitErrors(errs)
+		assert.Error(t, config)
+	}
+
+	var errs ValidationErrors
+	require.True(t, errors.As(ValidateVar("a", "excluded_with:B"), &errs))
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+}
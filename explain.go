@@ -0,0 +1,320 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// explanations holds the phrases rules are explained with, by locale.
+var explanations = map[string]map[string]string{
+	"en": {
+		"field":             "Field",
+		"type":              "Type",
+		"rules":             "Rules",
+		"required":          "required",
+		"notnil":            "not nil",
+		"excluded_with":     "empty when any of %s is set",
+		"exactly_one_of":    "exactly one of it and %s set",
+		"assert":            "such that %s",
+		"omitempty":         "optional",
+		"between":           "between %s and %s",
+		"min":               "at least %s",
+		"max":               "at most %s",
+		"between_len":       "between %s and %s bytes long",
+		"min_len":           "at least %s bytes long",
+		"max_len":           "at most %s bytes long",
+		"len":               "exactly %s bytes long",
+		"in":                "one of %s",
+		"in_ci":             "one of %s, ignoring case",
+		"email":             "must be a valid address",
+		"ulid":              "must be a ULID",
+		"objectid":          "must be a MongoDB ObjectID",
+		"file":              "must be an existing file",
+		"dir":               "must be an existing directory",
+		"filepath":          "must be a valid file path",
+		"no_html":           "must not contain HTML",
+		"printable_unicode": "must contain only printable characters",
+		"urlencoded":        "must be percent-encoded",
+		"slug":              "must be a slug, like my-post-1",
+		"dns_label":         "must be a DNS label, like my-app",
+		"k8s_quantity":      "must be a Kubernetes quantity, like 500Mi",
+		"k8s_label":         "must be a Kubernetes label, like app.kubernetes.io/name",
+		"hexcolor":          "must be a hex color, like #ff8800",
+		"rgb":               "must be an rgb color, like rgb(255, 136, 0)",
+		"rgba":              "must be an rgba color, like rgba(255, 136, 0, 0.5)",
+		"hsl":               "must be an hsl color, like hsl(32, 100%, 50%)",
+		"cron":              "must be a cron expression, like */15 * * * *",
+		"mimetype":          "must be a media type, like image/png",
+		"charset":           "must be a character set, like utf-8",
+		"card_expiry":       "must be a card expiry date, like 09/27, not in the past",
+		"cvv":               "must be a card security code of %s digits",
+		"password":          "must be a strong password",
+		"regexp":            "must match %s",
+		"maxsize":           "at most %s in size",
+		"maxbytes":          "at most %s bytes in total",
+		"mime":              "of type %s",
+		"ext":               "with extension %s",
+		"jsonschema":        "a valid JSON document of %s",
+		"within":            "within %s of now",
+		"minage":            "a birth date at least %s years ago",
+		"checksum":          "with the %s checksum %s",
+		"asnum":             "a number",
+		"uniquefield":       "with unique %s",
+		"sumfield":          "with %s summing to %s",
+		"or":                " or ",
+		"if":                "only if %s",
+		"default":           "defaults to %s",
+		"groups":            "only in groups %s",
+		"keys":              "keys: %s",
+		"values":            "values: %s",
+		"warn":              "recommended: %s",
+	},
+	"ru": {
+		"field":             "Поле",
+		"type":              "Тип",
+		"rules":             "Правила",
+		"required":          "обязательно",
+		"notnil":            "не nil",
+		"excluded_with":     "пусто, если задано любое из %s",
+		"exactly_one_of":    "задано ровно одно из него и %s",
+		"assert":            "при условии %s",
+		"omitempty":         "необязательно",
+		"between":           "от %s до %s",
+		"min":               "не меньше %s",
+		"max":               "не больше %s",
+		"between_len":       "длиной от %s до %s байт",
+		"min_len":           "длиной не меньше %s байт",
+		"max_len":           "длиной не больше %s байт",
+		"len":               "длиной ровно %s байт",
+		"in":                "одно из значений %s",
+		"in_ci":             "одно из значений %s без учёта регистра",
+		"email":             "корректный адрес электронной почты",
+		"ulid":              "ULID",
+		"objectid":          "ObjectID MongoDB",
+		"file":              "путь к существующему файлу",
+		"dir":               "путь к существующему каталогу",
+		"filepath":          "корректный путь к файлу",
+		"no_html":           "без HTML",
+		"printable_unicode": "только печатаемые символы",
+		"urlencoded":        "в процентной кодировке",
+		"slug":              "слаг, например my-post-1",
+		"dns_label":         "метка DNS, например my-app",
+		"k8s_quantity":      "количество Kubernetes, например 500Mi",
+		"k8s_label":         "метка Kubernetes, например app.kubernetes.io/name",
+		"hexcolor":          "цвет в шестнадцатеричном виде, например #ff8800",
+		"rgb":               "цвет rgb, например rgb(255, 136, 0)",
+		"rgba":              "цвет rgba, например rgba(255, 136, 0, 0.5)",
+		"hsl":               "цвет hsl, например hsl(32, 100%, 50%)",
+		"cron":              "выражение cron, например */15 * * * *",
+		"mimetype":          "тип содержимого, например image/png",
+		"charset":           "кодировка, например utf-8",
+		"card_expiry":       "срок действия карты, например 09/27, не в прошлом",
+		"cvv":               "код безопасности карты из %s цифр",
+		"password":          "надёжный пароль",
+		"regexp":            "соответствует %s",
+		"maxsize":           "размером не больше %s",
+		"maxbytes":          "всего не больше %s байт",
+		"mime":              "типа %s",
+		"ext":               "с расширением %s",
+		"jsonschema":        "корректный JSON-документ %s",
+		"within":            "не дальше %s от текущего момента",
+		"minage":            "дата рождения не меньше %s лет назад",
+		"checksum":          "с контрольной суммой %s %s",
+		"asnum":             "число",
+		"uniquefield":       "с уникальными %s",
+		"sumfield":          "с суммой %s %s",
+		"or":                " или ",
+		"if":                "только если %s",
+		"default":           "по умолчанию %s",
+		"groups":            "только в группах %s",
+		"keys":              "ключи: %s",
+		"values":            "значения: %s",
+		"warn":              "рекомендуется: %s",
+	},
+}
+
+// ExplainRules explains the rules of the fields of the struct v in words, a
+// field per line, e.g. for API documentation or the help of a command:
+//
+//	Email — required, must be a valid address
+//	Age — between 18 and 120
+//
+// Fields are named like by Describe. locale selects the language, "en" or
+// "ru", and falls back to English for others; regional variants like
+// "ru-RU" are explained in their language. Custom rules are written as in
+// tags.
+func ExplainRules(v any, locale string) (string, error) {
+	fields, _, err := explainFields(v, locale)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, f := range fields {
+		fmt.Fprintf(&sb, "%s — %s\n", f.name, f.text)
+	}
+	return sb.String(), nil
+}
+
+// ExplainRulesMarkdown explains the rules like ExplainRules as a Markdown
+// table with the name, type and rules of every field.
+func ExplainRulesMarkdown(v any, locale string) (string, error) {
+	fields, phrases, err := explainFields(v, locale)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "| %s | %s | %s |\n| --- | --- | --- |\n", phrases["field"], phrases["type"], phrases["rules"])
+	for _, f := range fields {
+		fmt.Fprintf(&sb, "| %s | `%s` | %s |\n", markdownCell(f.name), f.typ, markdownCell(f.text))
+	}
+	return sb.String(), nil
+}
+
+type explainedField struct {
+	name string
+	typ  reflect.Type
+	text string
+}
+
+func explainFields(v any, locale string) ([]explainedField, map[string]string, error) {
+	described, err := describeFields(v, config{})
+	if err != nil {
+		return nil, nil, err
+	}
+	phrases := localePhrases(locale)
+	fields := make([]explainedField, len(described))
+	for i, f := range described {
+		t := ruleType(f.typ)
+		if asText(f.validators) {
+			t = reflect.TypeOf("")
+		}
+		if asNumber(f.validators) {
+			t = reflect.TypeOf(0.0)
+		}
+		fields[i] = explainedField{name: f.name, typ: f.typ, text: explainRules(phrases, f.validators, t)}
+	}
+	return fields, phrases, nil
+}
+
+// localePhrases returns the phrases of locale.
+func localePhrases(locale string) map[string]string {
+	locale = strings.ToLower(locale)
+	if phrases, ok := explanations[locale]; ok {
+		return phrases
+	}
+	if lang, _, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-"); found {
+		if phrases, ok := explanations[lang]; ok {
+			return phrases
+		}
+	}
+	return explanations["en"]
+}
+
+// explainRules explains validators applied to values of type t.
+func explainRules(phrases map[string]string, validators []rule, t reflect.Type) string {
+	var parts []string
+	var minArg, maxArg string
+	for _, validator := range validators {
+		if !validator.warn && validator.custom == nil && validator.batch == nil {
+			switch validator.name {
+			case "min":
+				minArg = validator.argsStr[0]
+			case "max":
+				maxArg = validator.argsStr[0]
+			}
+		}
+	}
+
+	suffix := ""
+	if t.Kind() == reflect.String {
+		suffix = "_len"
+	}
+	for _, validator := range validators {
+		if !validator.warn && validator.custom == nil && validator.batch == nil && minArg != "" && maxArg != "" {
+			switch validator.name {
+			case "min":
+				parts = append(parts, fmt.Sprintf(phrases["between"+suffix], strings.TrimSpace(minArg), strings.TrimSpace(maxArg)))
+				continue
+			case "max":
+				continue
+			}
+		}
+		if text := explainRule(phrases, validator, t, suffix); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// explainRule explains a single rule, or returns "" for rules that only
+// control how fields are validated.
+func explainRule(phrases map[string]string, validator rule, t reflect.Type, suffix string) string {
+	if validator.warn {
+		validator.warn = false
+		return fmt.Sprintf(phrases["warn"], explainRule(phrases, validator, t, suffix))
+	}
+	if validator.custom != nil || validator.batch != nil {
+		return describeRule(validator).String()
+	}
+
+	args := make([]string, len(validator.argsStr))
+	for i, arg := range validator.argsStr {
+		args[i] = strings.TrimSpace(arg)
+	}
+	switch validator.name {
+	case "astext", "structonly", "nostructlevel", "sensitive", "bail", "continue":
+		return ""
+	case "min", "max":
+		return fmt.Sprintf(phrases[validator.name+suffix], args[0])
+	case "enum":
+		args = args[:0]
+		for _, value := range validator.enum {
+			args = append(args, fmt.Sprint(value.Interface()))
+		}
+		return fmt.Sprintf(phrases["in"], strings.Join(args, ", "))
+	case "keys", "values":
+		elemT := t
+		if t.Kind() == reflect.Map {
+			if elemT = t.Elem(); validator.name == "keys" {
+				elemT = t.Key()
+			}
+		}
+		if asText(validator.each) {
+			elemT = reflect.TypeOf("")
+		}
+		if asNumber(validator.each) {
+			elemT = reflect.TypeOf(0.0)
+		}
+		return fmt.Sprintf(phrases[validator.name], explainRules(phrases, validator.each, derefType(elemT)))
+	case "password":
+		return phrases["password"]
+	case "maxbytes":
+		return fmt.Sprintf(phrases["maxbytes"], strconv.Itoa(validator.argsInt[0]))
+	case "checksum":
+		algorithm, sum, _ := strings.Cut(args[0], ":")
+		return fmt.Sprintf(phrases["checksum"], strings.TrimSpace(algorithm), strings.TrimSpace(sum))
+	case "sumfield":
+		return fmt.Sprintf(phrases["sumfield"], args[0], explainRules(phrases, validator.each, reflect.TypeOf(0.0)))
+	case "or":
+		alternatives := make([]string, len(validator.alternatives))
+		for i, alternative := range validator.alternatives {
+			alternatives[i] = explainRules(phrases, alternative, t)
+		}
+		return strings.Join(alternatives, phrases["or"])
+	}
+	phrase, ok := phrases[validator.name]
+	if !ok {
+		return describeRule(validator).String()
+	}
+	if strings.Contains(phrase, "%s") {
+		return fmt.Sprintf(phrase, strings.Join(args, ", "))
+	}
+	return phrase
+}
+
+// markdownCell escapes s for a cell of a Markdown table.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
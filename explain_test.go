@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type explainedSignup struct {
+	Email  string            `validate:"required&email"`
+	Age    int               `validate:"min:18&max:120"`
+	Login  string            `validate:"min:3&max:32&regexp:^[a-z|]+$"`
+	Bio    string            `validate:"omitempty&warn:max:500"`
+	Lang   string            `validate:"in:ru,en"`
+	Labels map[string]string `validate:"keys:len:2,endkeys&values:max:64"`
+	Items  []describedItem
+}
+
+func TestExplainRules(t *testing.T) {
+	text, err := ExplainRules(explainedSignup{}, "en")
+	require.NoError(t, err)
+	assert.Equal(t, `Email — required, must be a valid address
+Age — between 18 and 120
+Login — between 3 and 32 bytes long, must match ^[a-z|]+$
+Bio — optional, recommended: at most 500 bytes long
+Lang — one of ru, en
+Labels — keys: exactly 2 bytes long, values: at most 64 bytes long
+Items.SKU — exactly 8 bytes long
+`, text)
+
+	ru, err := ExplainRules(&explainedSignup{}, "ru-RU")
+	require.NoError(t, err)
+	assert.Contains(t, ru, "Email — обязательно, корректный адрес электронной почты\n")
+	assert.Contains(t, ru, "Age — от 18 до 120\n")
+
+	fallback, err := ExplainRules(explainedSignup{}, "de")
+	require.NoError(t, err)
+	assert.Equal(t, text, fallback)
+
+	_, err = ExplainRules(42, "en")
+	assert.ErrorIs(t, err, ErrNotStruct)
+}
+
+func TestExplainRulesMarkdown(t *testing.T) {
+	RegisterRule("explained", func(ctx context.Context, field reflect.Value, params []string) error {
+		return nil
+	})
+	t.Cleanup(func() {
+		delete(customRules, "explained")
+		resetPlanCache()
+	})
+
+	table, err := ExplainRulesMarkdown(struct {
+		Login string `validate:"min:3&regexp:^[a-z|]+$&explained:a,b"`
+		Port  *int   `validate:"max:65535"`
+	}{}, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "| Field | Type | Rules |\n"+
+		"| --- | --- | --- |\n"+
+		"| Login | `string` | at least 3 bytes long, must match ^[a-z\\|]+$, explained:a,b |\n"+
+		"| Port | `*int` | at most 65535 |\n", table)
+}
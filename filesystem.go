@@ -0,0 +1,27 @@
+package validator
+
+import (
+	"os"
+	"strings"
+)
+
+// validatePath checks field against one of the file system validators:
+// "file" requires an existing regular file, "dir" an existing directory and
+// "filepath" only a syntactically valid path that does not have to exist.
+func validatePath(name string, field string) error {
+	if field == "" || strings.IndexByte(field, 0) >= 0 {
+		return ErrFieldNotValid
+	}
+	if name == "filepath" {
+		return nil
+	}
+
+	info, err := os.Stat(field)
+	if err != nil {
+		return ErrFieldNotValid
+	}
+	if name == "file" && !info.Mode().IsRegular() || name == "dir" && !info.IsDir() {
+		return ErrFieldNotValid
+	}
+	return nil
+}
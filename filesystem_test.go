@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePaths(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("port: 80"), 0o600))
+	missing := filepath.Join(dir, "missing.yaml")
+
+	tests := []struct {
+		name    string
+		v       any
+		wantErr int
+	}{
+		{
+			name: "existing paths",
+			v: struct {
+				File     string   `validate:"file"`
+				Dir      string   `validate:"dir"`
+				Path     string   `validate:"filepath"`
+				Relative string   `validate:"filepath"`
+				Dirs     []string `validate:"dir"`
+			}{
+				File:     file,
+				Dir:      dir,
+				Path:     missing,
+				Relative: "testdata/fixture.json",
+				Dirs:     []string{dir, os.TempDir()},
+			},
+		},
+		{
+			name: "wrong paths",
+			v: struct {
+				FileIsDir   string `validate:"file"`
+				FileMissing string `validate:"file"`
+				DirIsFile   string `validate:"dir"`
+				DirMissing  string `validate:"dir"`
+				PathEmpty   string `validate:"filepath"`
+				PathNul     string `validate:"filepath"`
+				NotString   int    `validate:"file"`
+			}{
+				FileIsDir:   dir,
+				FileMissing: missing,
+				DirIsFile:   file,
+				DirMissing:  missing,
+				PathEmpty:   "",
+				PathNul:     "conf\x00ig",
+			},
+			wantErr: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package validator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Fingerprint returns a hash of the rules of the fields of the struct v, or
+// of the struct v points to, as described by Describe, so tests can pin the
+// validation schema of a type and fail when a rule changes unnoticed:
+//
+//	func TestOrderRules(t *testing.T) {
+//		got, err := validator.Fingerprint(Order{})
+//		require.NoError(t, err)
+//		assert.Equal(t, "5d41402abc4b2a76b9719d911017c592...", got, "rules of Order changed, update the fingerprint")
+//	}
+//
+// The hash covers the paths, types and rules of the fields in declaration
+// order, with aliases replaced by their rules, so renaming an alias keeps it
+// while changing what it stands for does not. Spaces around the rules of a
+// tag are ignored, those within arguments are not. It is the same across
+// runs and platforms for the same rules; Describe tells which changed.
+func Fingerprint(v any) (string, error) {
+	fields, err := Describe(v)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, f := range fields {
+		b.WriteString(f.Name())
+		b.WriteByte(' ')
+		b.WriteString(f.Type().String())
+		for i, r := range f.Rules() {
+			if i == 0 {
+				b.WriteByte(' ')
+			} else {
+				b.WriteByte('&')
+			}
+			b.WriteString(r.String())
+		}
+		b.WriteByte('\n')
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:]), nil
+}
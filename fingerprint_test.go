@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	RegisterAlias("quantity", "min:1&max:100")
+	t.Cleanup(func() {
+		delete(aliases, "quantity")
+		resetPlanCache()
+	})
+
+	type address struct {
+		Zip string `validate:"len:5"`
+	}
+	type order struct {
+		ID      string `validate:"required&ulid"`
+		Qty     int    `validate:"min:1&max:100"`
+		Address address
+	}
+	type spaced struct {
+		ID      string `validate:" required & ulid"`
+		Qty     int    `validate:"min:1& max:100"`
+		Address address
+	}
+	type aliased struct {
+		ID      string `validate:"required&ulid"`
+		Qty     int    `validate:"quantity"`
+		Address address
+	}
+	type changed struct {
+		ID      string `validate:"required&ulid"`
+		Qty     int    `validate:"min:1&max:99"`
+		Address address
+	}
+	type retyped struct {
+		ID      string `validate:"required&ulid"`
+		Qty     int64  `validate:"min:1&max:100"`
+		Address address
+	}
+
+	got, err := Fingerprint(order{})
+	require.NoError(t, err)
+	assert.Len(t, got, 64)
+	again, err := Fingerprint(&order{Qty: 5})
+	require.NoError(t, err)
+	assert.Equal(t, got, again, "values do not matter")
+
+	for name, v := range map[string]any{"spaced": spaced{}, "aliased": aliased{}} {
+		other, err := Fingerprint(v)
+		require.NoError(t, err)
+		assert.Equal(t, got, other, name)
+	}
+	for name, v := range map[string]any{"changed": changed{}, "retyped": retyped{}} {
+		other, err := Fingerprint(v)
+		require.NoError(t, err)
+		assert.NotEqual(t, got, other, name)
+	}
+
+	_, err = Fingerprint(1)
+	assert.ErrorIs(t, err, ErrNotStruct)
+	_, err = Fingerprint(struct {
+		A string `validate:"min:x"`
+	}{})
+	assert.Error(t, err)
+}
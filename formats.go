@@ -0,0 +1,262 @@
+package validator
+
+import (
+	"mime"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// validateULID checks that field is a canonical 26 character ULID encoded with
+// Crockford's base32 alphabet. Lowercase letters are accepted.
+func validateULID(field string) error {
+	if len(field) != 26 {
+		return ErrFieldNotValid
+	}
+	// 26 base32 characters hold 130 bits, the first one may only carry 3 of them.
+	if field[0] > '7' {
+		return ErrFieldNotValid
+	}
+	for i := 0; i < len(field); i++ {
+		if !isCrockford(field[i]) {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+func isCrockford(c byte) bool {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	for i := 0; i < len(crockfordAlphabet); i++ {
+		if crockfordAlphabet[i] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// validateObjectID checks that field is a MongoDB ObjectID in its usual
+// 24 digit hexadecimal form.
+func validateObjectID(field string) error {
+	if len(field) != 24 {
+		return ErrFieldNotValid
+	}
+	for i := 0; i < len(field); i++ {
+		if !isHex(field[i]) {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// validateEmail checks that field is a bare address like "user@example.com":
+// a dot-atom local part as defined by RFC 5322 and a domain name. Display
+// names, angle brackets, quoted local parts and address literals are
+// rejected.
+func validateEmail(field string) error {
+	at := strings.LastIndexByte(field, '@')
+	if at < 0 {
+		return ErrFieldNotValid
+	}
+	local, domain := field[:at], field[at+1:]
+	if len(local) > 64 || !isDotAtom(local, isAtext) || len(domain) > 253 || !isDotAtom(domain, isLabelChar) {
+		return ErrFieldNotValid
+	}
+
+	for rest, found := domain, true; found; {
+		var label string
+		label, rest, found = strings.Cut(rest, ".")
+		if len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// isDotAtom reports whether s is made of non-empty runs of characters accepted
+// by valid separated by single dots.
+func isDotAtom(s string, valid func(c byte) bool) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' || strings.Contains(s, "..") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '.' && !valid(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isAtext(c byte) bool {
+	return isAlnum(c) || strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) >= 0
+}
+
+func isLabelChar(c byte) bool {
+	return isAlnum(c) || c == '-'
+}
+
+// htmlRegexp matches anything that a browser could treat as markup: opening,
+// closing and self-closing tags, comments, doctypes and unterminated script
+// tags.
+var htmlRegexp = regexp.MustCompile(`(?i)</?[a-z!?][^>]*>|<script`)
+
+// validateNoHTML rejects strings containing HTML tags or script content.
+// Plain "<" and ">" characters, as in "a < b > c", are allowed.
+func validateNoHTML(field string) error {
+	if htmlRegexp.MatchString(field) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// validatePrintableUnicode rejects strings with invalid UTF-8 or with non
+// printable runes. Tabs and line breaks are allowed since they are common in
+// user written text.
+func validatePrintableUnicode(field string) error {
+	if !utf8.ValidString(field) {
+		return ErrFieldNotValid
+	}
+	for _, r := range field {
+		if !unicode.IsPrint(r) && r != '\t' && r != '\n' && r != '\r' {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// validateURLEncoded checks that field is percent-encoded like the query
+// values escaped by url.QueryEscape: unreserved characters, "+" for spaces
+// and "%" followed by two hexadecimal digits. Other characters, including
+// the delimiters of URLs like "/", "?", "&" and "=", must be escaped.
+func validateURLEncoded(field string) error {
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+		switch {
+		case c == '%':
+			if i+2 >= len(field) || !isHex(field[i+1]) || !isHex(field[i+2]) {
+				return ErrFieldNotValid
+			}
+			i += 2
+		case !isAlnum(c) && strings.IndexByte("-._~+", c) < 0:
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// validateSlug checks that field is a slug like "my-post-1": words of
+// lowercase ASCII letters and digits separated by single hyphens.
+func validateSlug(field string) error {
+	if field == "" || field[0] == '-' || field[len(field)-1] == '-' || strings.Contains(field, "--") {
+		return ErrFieldNotValid
+	}
+	for i := 0; i < len(field); i++ {
+		if !isLowerAlnum(field[i]) && field[i] != '-' {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+// validateDNSLabel checks that field is a DNS label as defined by RFC 1123,
+// the form of most Kubernetes names: 1 to 63 lowercase ASCII letters,
+// digits and hyphens, starting and ending with a letter or a digit.
+func validateDNSLabel(field string) error {
+	if field == "" || len(field) > 63 || field[0] == '-' || field[len(field)-1] == '-' {
+		return ErrFieldNotValid
+	}
+	for i := 0; i < len(field); i++ {
+		if !isLowerAlnum(field[i]) && field[i] != '-' {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
+
+func isLowerAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z'
+}
+
+// k8sQuantityRegexp matches the quantities of Kubernetes resources, like
+// "500Mi", "2", "0.5" or "100m": a decimal number with a binary suffix,
+// a decimal suffix or an exponent.
+var k8sQuantityRegexp = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([KMGTPE]i|[numkMGTPE]|[eE][+-]?[0-9]+)?$`)
+
+// validateK8sQuantity checks that field is a Kubernetes resource quantity.
+func validateK8sQuantity(field string) error {
+	if !k8sQuantityRegexp.MatchString(field) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// k8sLabelNameRegexp matches the names of Kubernetes labels: alphanumeric
+// characters, "-", "_" and ".", starting and ending with an alphanumeric one.
+var k8sLabelNameRegexp = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+// validateK8sLabel checks that field is a Kubernetes label key, like
+// "app.kubernetes.io/name" or "tier": a name of at most 63 characters with an
+// optional prefix, a DNS subdomain of at most 253 characters followed by "/".
+// Non-empty label values are names too.
+func validateK8sLabel(field string) error {
+	prefix, name, ok := strings.Cut(field, "/")
+	if !ok {
+		prefix, name = "", field
+	} else if prefix == "" || len(prefix) > 253 {
+		return ErrFieldNotValid
+	}
+	if prefix != "" {
+		for _, label := range strings.Split(prefix, ".") {
+			if validateDNSLabel(label) != nil {
+				return ErrFieldNotValid
+			}
+		}
+	}
+	if len(name) > 63 || !k8sLabelNameRegexp.MatchString(name) {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// validateMIMEType checks that field is a media type like "image/png" or
+// "text/html; charset=utf-8", as parsed by mime.ParseMediaType, with both a
+// type and a subtype.
+func validateMIMEType(field string) error {
+	mediaType, _, err := mime.ParseMediaType(field)
+	if err != nil {
+		return ErrFieldNotValid
+	}
+	typ, subtype, ok := strings.Cut(mediaType, "/")
+	if !ok || typ == "" || subtype == "" {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// validateCharset checks that field is the name of a character set like
+// "utf-8" or "ISO-8859-1", made of the at most 40 characters allowed in the
+// names registered with IANA by RFC 2978.
+func validateCharset(field string) error {
+	if field == "" || len(field) > 40 {
+		return ErrFieldNotValid
+	}
+	for i := 0; i < len(field); i++ {
+		if !isAlnum(field[i]) && strings.IndexByte("!#$%&'+-^_`{}~", field[i]) < 0 {
+			return ErrFieldNotValid
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIdentifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		wantErr int
+	}{
+		{
+			name: "valid identifiers",
+			v: struct {
+				ULID      string   `validate:"ulid"`
+				ULIDLower string   `validate:"ulid"`
+				ObjectID  string   `validate:"objectid"`
+				ULIDs     []string `validate:"ulid"`
+			}{
+				ULID:      "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+				ULIDLower: "01arz3ndektsv4rrffq69g5fav",
+				ObjectID:  "507f1f77bcf86cd799439011",
+				ULIDs:     []string{"01BX5ZZKBKACTAV9WEVGEMMVRZ", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+			},
+		},
+		{
+			name: "wrong identifiers",
+			v: struct {
+				ULIDShort    string `validate:"ulid"`
+				ULIDAlphabet string `validate:"ulid"`
+				ULIDOverflow string `validate:"ulid"`
+				ObjectIDLen  string `validate:"objectid"`
+				ObjectIDHex  string `validate:"objectid"`
+				NotString    int    `validate:"objectid"`
+			}{
+				ULIDShort:    "01ARZ3NDEKTSV4RRFFQ69G5FA",
+				ULIDAlphabet: "01ARZ3NDEKTSV4RRFFQ69G5FAU",
+				ULIDOverflow: "8ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+				ObjectIDLen:  "507f1f77bcf86cd79943901",
+				ObjectIDHex:  "507f1f77bcf86cd79943901z",
+			},
+			wantErr: 6,
+		},
+		{
+			name: "identifiers do not take arguments",
+			v: struct {
+				ULID     string `validate:"ulid:1"`
+				ObjectID string `validate:"objectid:"`
+				Len      string `validate:"len"`
+			}{},
+			wantErr: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateTextContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		wantErr int
+	}{
+		{
+			name: "safe text",
+			v: struct {
+				Plain     string   `validate:"no_html"`
+				Compare   string   `validate:"no_html"`
+				Printable string   `validate:"printable_unicode"`
+				Multiline string   `validate:"printable_unicode&no_html"`
+				Comments  []string `validate:"no_html"`
+			}{
+				Plain:     "Hello, world!",
+				Compare:   "a < b > c",
+				Printable: "Привет, 世界 🙂",
+				Multiline: "first line\n\tsecond line\r\n",
+				Comments:  []string{"nice", "5 > 3"},
+			},
+		},
+		{
+			name: "unsafe text",
+			v: struct {
+				Tag        string `validate:"no_html"`
+				Closing    string `validate:"no_html"`
+				Script     string `validate:"no_html"`
+				Comment    string `validate:"no_html"`
+				Control    string `validate:"printable_unicode"`
+				InvalidUTF string `validate:"printable_unicode"`
+				NotString  int    `validate:"no_html"`
+			}{
+				Tag:        `<img src=x onerror="alert(1)">`,
+				Closing:    "bold</b>",
+				Script:     "<SCRIPT src=//evil.example",
+				Comment:    "<!-- hidden -->",
+				Control:    "bell\a",
+				InvalidUTF: "\xff\xfe",
+			},
+			wantErr: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateStringFormats(t *testing.T) {
+	tests := []struct {
+		rule    string
+		valid   []string
+		invalid []string
+	}{
+		{
+			rule:    "urlencoded",
+			valid:   []string{"", "hello", "hello+world", "a%20b%2Fc", "%E4%B8%96", "-._~"},
+			invalid: []string{"a b", "a/b", "q?x=1&y=2", "100%", "%2", "%zz", "世界"},
+		},
+		{
+			rule:    "slug",
+			valid:   []string{"post", "my-post-1", "2024-review"},
+			invalid: []string{"", "-post", "post-", "my--post", "My-Post", "my_post", "my post"},
+		},
+		{
+			rule:    "dns_label",
+			valid:   []string{"a", "my-app", "app-1", "1app", strings.Repeat("a", 63)},
+			invalid: []string{"", "-app", "app-", "My-App", "my.app", "my_app", strings.Repeat("a", 64)},
+		},
+		{
+			rule:    "k8s_quantity",
+			valid:   []string{"2", "500Mi", "1.5Gi", "100m", "0.5", ".5", "1e3", "-1k", "+2E"},
+			invalid: []string{"", "Mi", "500MB", "1.2.3", "1 Gi", "5mi", "1e"},
+		},
+		{
+			rule:    "k8s_label",
+			valid:   []string{"tier", "app.kubernetes.io/name", "example.com/My_Label.1", "a"},
+			invalid: []string{"", "/name", "-tier", "tier.", "Example.com/name", "a/b/c", "example.com/", strings.Repeat("a", 64)},
+		},
+		{
+			rule:    "mimetype",
+			valid:   []string{"image/png", "text/html; charset=utf-8", "application/vnd.api+json", "Text/Plain"},
+			invalid: []string{"", "text", "text/", "/plain", "text/html; charset", "image png"},
+		},
+		{
+			rule:    "charset",
+			valid:   []string{"utf-8", "ISO-8859-1", "windows-1251", "x-user_defined"},
+			invalid: []string{"", "utf 8", "utf/8", "кои8", strings.Repeat("a", 41)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			for _, s := range tt.valid {
+				assert.NoError(t, ValidateVar(s, tt.rule), s)
+			}
+			for _, s := range tt.invalid {
+				assert.Error(t, ValidateVar(s, tt.rule), s)
+			}
+			assert.Error(t, ValidateVar(1, tt.rule))
+		})
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	valid := []string{"user@example.com", "first.last+tag@sub.example.org", "u@localhost"}
+	for _, email := range valid {
+		assert.NoError(t, ValidateVar(email, "email"), email)
+	}
+
+	invalid := []string{"", "user", "user@", "@example.com", "User <user@example.com>", "a b@example.com"}
+	for _, email := range invalid {
+		assert.Error(t, ValidateVar(email, "email"), email)
+	}
+	assert.Error(t, ValidateVar(42, "email"))
+}
+
+func TestValidateRegexp(t *testing.T) {
+	assert.NoError(t, ValidateVar("AB-12", `regexp:^[A-Z]{2}-\d{2,3}$`))
+	assert.NoError(t, ValidateVar([]string{"AB-123"}, `regexp:^[A-Z]{2}-\d{2,3}$`))
+	assert.Error(t, ValidateVar("ab-12", `regexp:^[A-Z]{2}-\d{2,3}$`))
+	assert.Error(t, ValidateVar(12, `regexp:^\d+$`))
+
+	err := ValidateVar("a", "regexp:[")
+	assert.Error(t, err)
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax)
+}
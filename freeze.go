@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrFrozen is wrapped by the errors of registrations after Freeze.
+var ErrFrozen = errors.New("registrations are frozen")
+
+var frozen, mustRegister atomic.Bool
+
+// registry guards the registries, written by RegisterRule, SetTagSyntax and
+// the other registration functions and read by the validations, so values
+// may be validated while rules are registered in other goroutines.
+var registry sync.RWMutex
+
+// Freeze ends the registrations: RegisterRule, RegisterCustomType,
+// SetTagSyntax and the other functions changing how values are validated
+// return an error wrapping ErrFrozen once it is called, so a registration
+// made late, while values are being validated, is noticed:
+//
+//	func main() {
+//		validator.Freeze()
+//		...
+//	}
+//
+// Validators, Typed validators and Schemas never change once created and are
+// safe for concurrent use, frozen or not.
+func Freeze() {
+	frozen.Store(true)
+}
+
+// register calls set, changing the registries for the registration fn,
+// unless Freeze was called.
+func register(fn string, set func()) error {
+	registry.Lock()
+	defer registry.Unlock()
+	if frozen.Load() {
+		return fmt.Errorf("validator: %s after Freeze: %w", fn, ErrFrozen)
+	}
+	set()
+	return nil
+}
+
+// registered returns the value registered at key in the registry m.
+func registered[K comparable, V any](m map[K]V, key K) (V, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	value, ok := m[key]
+	return value, ok
+}
+
+// MustRegister makes the use of a struct type whose tags can never be
+// applied, like `validate:"min:x"`, panic with an error wrapping their
+// ValidationErrors, whether by Validate, Compile or WarmUp, instead of every
+// validation of the type reporting them. Broken tags then stop the tests or
+// the start of the program at the first use of the type:
+//
+//	func main() {
+//		validator.MustRegister()
+//		validator.WarmUp(User{}, Order{})
+//		...
+//	}
+//
+// Describe and Lint still report such tags as errors. Like Freeze it is meant to be called during program initialization.
+func MustRegister() {
+	mustRegister.Store(true)
+}
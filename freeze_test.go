@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	t.Cleanup(func() { frozen.Store(false) })
+
+	type user struct {
+		Name string `validate:"min:3"`
+	}
+	users, err := Compile[user]()
+	require.NoError(t, err)
+
+	Freeze()
+	assert.NoError(t, Validate(user{Name: "Ann"}))
+	assert.Error(t, users.Validate(user{Name: "An"}))
+
+	for name, register := range map[string]func() error{
+		"RegisterRule": func() error {
+			return RegisterRule("frozen", func(ctx context.Context, field reflect.Value, params []string) error { return nil })
+		},
+		"RegisterAlias":        func() error { return RegisterAlias("frozen", "min:1") },
+		"RegisterEnum":         func() error { return RegisterEnum("frozen", 1, 2) },
+		"RegisterErrorMessage": func() error { return RegisterErrorMessage("min", func(FailedField) string { return "" }) },
+		"SetTagSyntax":         func() error { return SetTagSyntax(DefaultSyntax) },
+		"RegisterZeroChecker":  func() error { return RegisterZeroChecker(func(reflect.Value) bool { return false }, struct{}{}) },
+		"RegisterChecksum":     func() error { return RegisterChecksum("frozen", func(data []byte) []byte { return nil }) },
+		"RegisterRuleCheck": func() error {
+			return RegisterRuleCheck("frozen", func(ctx context.Context, field reflect.Value, r Rule) error { return nil })
+		},
+		"RegisterJSONSchema": func() error {
+			return RegisterJSONSchema("frozen", func(r Rule, t reflect.Type) map[string]any { return nil })
+		},
+		"RegisterRuleLimits": func() error { return RegisterRuleLimits("frozen", RuleLimits{TTL: time.Minute}) },
+	} {
+		err := register()
+		assert.ErrorIs(t, err, ErrFrozen, name)
+		assert.EqualError(t, err, "validator: "+name+" after Freeze: registrations are frozen", name)
+	}
+	assert.NotContains(t, aliases, "frozen")
+}
+
+func TestRegisterWhileValidating(t *testing.T) {
+	t.Cleanup(func() {
+		delete(customRules, "concurrent")
+		delete(aliases, "concurrent_alias")
+		resetPlanCache()
+	})
+
+	type user struct {
+		Name string `validate:"min:3"`
+		Tag  string `validate:"omitempty&concurrent"`
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = Validate(user{Name: "Ann", Tag: "a"})
+				_ = ValidateVar("a", "concurrent_alias")
+				_ = RuleNames()
+			}
+		}()
+	}
+	for j := 0; j < 50; j++ {
+		require.NoError(t, RegisterRule("concurrent", func(ctx context.Context, field reflect.Value, params []string) error { return nil }))
+		require.NoError(t, RegisterAlias("concurrent_alias", "min:1"))
+		require.NoError(t, SetTagSyntax(DefaultSyntax))
+	}
+	wg.Wait()
+	assert.NoError(t, Validate(user{Name: "Ann", Tag: "a"}))
+}
+
+func TestMustRegister(t *testing.T) {
+	t.Cleanup(func() { mustRegister.Store(false) })
+
+	type valid struct {
+		Name string `validate:"min:3"`
+	}
+	type broken struct {
+		Name string `validate:"min:x"`
+		Age  int    `validate:"max:y"`
+	}
+	type nested struct {
+		Broken []broken
+	}
+
+	MustRegister()
+	assert.Error(t, Validate(valid{}))
+	_, err := Compile[valid]()
+	assert.NoError(t, err)
+
+	const msg = `validator: invalid tags of validator.broken: invalid validator syntax: rule "min:x" at offset 0 of field Nameinvalid validator syntax: rule "max:y" at offset 0 of field Age`
+	assert.PanicsWithError(t, msg, func() { _ = Validate(broken{}) })
+	assert.PanicsWithError(t, msg, func() { _ = Validate(nested{Broken: []broken{{}}}) })
+	assert.PanicsWithError(t, msg, func() { _, _ = Compile[nested]() })
+	assert.PanicsWithError(t, msg, func() { _ = WarmUp(nested{}) })
+	assert.NotPanics(t, func() { _ = Validate(nested{}) })
+	_, err = Describe(broken{})
+	assert.Error(t, err)
+
+	mustRegister.Store(false)
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(broken{}), &errs)
+	assert.Len(t, errs, 2)
+}
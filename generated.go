@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// The functions in this file back the Validate methods emitted by
+// cmd/validatorgen, so generated code reports the same errors as the
+// reflective validation.
+
+// FieldError returns the error reported when the field at path holding
+// value is not valid for the rules cond, e.g.
+// FieldError("Address.Zip", "len:5", v.Address.Zip). The value tells which
+// of the rules failed.
+func FieldError(path, cond string, value any) ValidationError {
+	s := validation{ctx: context.Background()}
+	if validators, err := cachedValidators(cond); err == nil {
+		s.validateField(validators, reflect.ValueOf(value))
+	}
+	return ValidationError{&fieldError{field: path, cond: cond, rule: s.failed, err: ErrFieldNotValid}}
+}
+
+// RuleError returns the error reported when the field at path is not valid
+// for the rules cond, rule being the one that failed, e.g.
+// RuleError("Address.Zip", "len:5", "len"). Unlike FieldError it takes the
+// failed rule instead of finding it by checking a value, so generated code
+// calling it and CheckFormat uses no reflection.
+func RuleError(path, cond, rule string) ValidationError {
+	return ValidationError{&fieldError{field: path, cond: cond, rule: rule, err: ErrFieldNotValid}}
+}
+
+// CheckFormat reports whether s satisfies the built-in string rule name:
+// ulid, objectid, email, no_html, printable_unicode, urlencoded, slug,
+// dns_label, k8s_quantity, k8s_label, hexcolor, rgb, rgba, hsl, cron,
+// mimetype, charset, file, dir or filepath.
+// It reports false for any other name.
+func CheckFormat(name, s string) bool {
+	var err error
+	switch name {
+	case "ulid":
+		err = validateULID(s)
+	case "objectid":
+		err = validateObjectID(s)
+	case "email":
+		err = validateEmail(s)
+	case "no_html":
+		err = validateNoHTML(s)
+	case "printable_unicode":
+		err = validatePrintableUnicode(s)
+	case "urlencoded":
+		err = validateURLEncoded(s)
+	case "slug":
+		err = validateSlug(s)
+	case "dns_label":
+		err = validateDNSLabel(s)
+	case "k8s_quantity":
+		err = validateK8sQuantity(s)
+	case "k8s_label":
+		err = validateK8sLabel(s)
+	case "hexcolor":
+		err = validateHexColor(s)
+	case "rgb":
+		err = validateRGB(s)
+	case "rgba":
+		err = validateRGBA(s)
+	case "hsl":
+		err = validateHSL(s)
+	case "cron":
+		err = validateCron(s)
+	case "mimetype":
+		err = validateMIMEType(s)
+	case "charset":
+		err = validateCharset(s)
+	case "file", "dir", "filepath":
+		err = validatePath(name, s)
+	default:
+		return false
+	}
+	return err == nil
+}
@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldError(t *testing.T) {
+	err := FieldError("Address.Zip", "len:5", "1")
+	assert.Equal(t, "field: Address.Zip not valid for len:5", err.Err.Error())
+	assert.ErrorIs(t, err.Err, ErrFieldNotValid)
+	assert.Equal(t, "len", err.Rule())
+
+	err = FieldError("Name", "required&min:2", "")
+	assert.Equal(t, "required", err.Rule())
+	err = FieldError("Name", "required&min:2", "a")
+	assert.Equal(t, "VAL_MIN", err.Code())
+}
+
+func TestRuleError(t *testing.T) {
+	err := RuleError("Address.Zip", "len:5", "len")
+	assert.Equal(t, FieldError("Address.Zip", "len:5", "1"), err)
+	assert.ErrorIs(t, err.Err, ErrFieldNotValid)
+	assert.Equal(t, "VAL_MIN", RuleError("Name", "required&min:2", "min").Code())
+}
+
+func TestCheckFormat(t *testing.T) {
+	assert.True(t, CheckFormat("email", "user@example.com"))
+	assert.False(t, CheckFormat("email", "user"))
+	assert.True(t, CheckFormat("objectid", "507f1f77bcf86cd799439011"))
+	assert.True(t, CheckFormat("slug", "my-post-1"))
+	assert.False(t, CheckFormat("dns_label", "My_App"))
+	assert.True(t, CheckFormat("dir", "."))
+	assert.False(t, CheckFormat("min", "abc"), "not a format rule")
+}
@@ -0,0 +1,85 @@
+// Package gqlvalidate enforces rules on the input fields and arguments of a
+// GraphQL schema served by gqlgen, written in a directive:
+//
+//	directive @validate(rules: String!) on INPUT_FIELD_DEFINITION | ARGUMENT_DEFINITION
+//
+//	input NewUser {
+//		name: String! @validate(rules: "min:3&max:32")
+//		email: String @validate(rules: "omitempty&email")
+//	}
+//
+// The directive is implemented by Directive in the generated configuration:
+//
+//	cfg := generated.Config{Resolvers: resolvers}
+//	cfg.Directives.Validate = func(ctx context.Context, obj any, next graphql.Resolver, rules string) (any, error) {
+//		return gqlvalidate.Directive(ctx, obj, next, rules)
+//	}
+//
+// gqlgen reports the errors of input fields at their paths, like
+// ["createUser", "input", "name"], with the code and the rule of the
+// violation in the extensions of the error. The package does not depend on
+// gqlgen.
+package gqlvalidate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nadya2002/validator"
+)
+
+// Error is a violation of the rules of an input field. It implements the
+// ExtendedError interface of gqlgen, so the default error presenter adds
+// its code and rule to the extensions of the GraphQL error:
+//
+//	{"message": "value not valid for min:3", "path": ["createUser", "input", "name"],
+//	 "extensions": {"code": "VAL_MIN", "rule": "min"}}
+type Error struct {
+	Message string
+	// Rule is the name of the rule the value failed and Code its code, see
+	// validator.ValidationError.Code.
+	Rule string
+	Code string
+	// Err is the error reported by the validator.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Extensions returns the extensions of the GraphQL error.
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code, "rule": e.Rule}
+}
+
+// Directive implements the @validate directive: it resolves the value of the
+// input field or argument with next and checks it against rules, written in
+// the syntax of the validate tag. A nil value, for an optional field that is
+// not set, is an empty value. A value that is not valid is reported with an
+// *Error; rules that cannot be parsed or applied are reported as they are,
+// as they are a bug of the schema rather than of the request.
+func Directive(ctx context.Context, obj any, next func(context.Context) (any, error), rules string) (any, error) {
+	value, err := next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dataErr, configErr := validator.SplitErrors(validator.ValidateVar(value, rules))
+	if configErr != nil {
+		return nil, configErr
+	}
+	var errs validator.ValidationErrors
+	if errors.As(dataErr, &errs) {
+		e := errs[0]
+		return nil, &Error{Message: e.Err.Error(), Rule: e.Rule(), Code: e.Code(), Err: e.Err}
+	}
+	if dataErr != nil {
+		return nil, dataErr
+	}
+	return value, nil
+}
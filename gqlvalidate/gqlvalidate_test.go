@@ -0,0 +1,46 @@
+package gqlvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+func resolved(value any) func(context.Context) (any, error) {
+	return func(context.Context) (any, error) { return value, nil }
+}
+
+func TestDirective(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := Directive(ctx, nil, resolved("Ann"), "min:3&max:32")
+	require.NoError(t, err)
+	assert.Equal(t, "Ann", value)
+
+	_, err = Directive(ctx, nil, resolved("An"), "min:3&max:32")
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, "value not valid for min:3&max:32", e.Error())
+	assert.Equal(t, map[string]any{"code": "VAL_MIN", "rule": "min"}, e.Extensions())
+	assert.ErrorIs(t, err, validator.ErrFieldNotValid)
+
+	var email *string
+	value, err = Directive(ctx, nil, resolved(email), "omitempty&email")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, err = Directive(ctx, nil, resolved(5), "min:x")
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.ErrorIs(t, errs[0].Err, validator.ErrInvalidValidatorSyntax)
+	assert.False(t, errors.As(err, &e))
+
+	errResolve := errors.New("resolve")
+	_, err = Directive(ctx, nil, func(context.Context) (any, error) { return nil, errResolve }, "required")
+	assert.ErrorIs(t, err, errResolve)
+}
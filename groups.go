@@ -0,0 +1,37 @@
+package validator
+
+// WithGroups selects the groups of rules to apply. Rules of a tag with a
+// groups rule, like `validate:"required&groups:create,update"`, only apply
+// when one of their groups is selected; rules of tags without one always
+// apply. This lets a struct have different rules for different operations:
+//
+//	type User struct {
+//		ID   int    `validate:"required&groups:update"`
+//		Name string `validate:"required&min:2&groups:create"`
+//		Bio  string `validate:"max:500"`
+//	}
+//
+// Without groups only the rules of tags without a groups rule are applied.
+func WithGroups(groups ...string) Option {
+	return func(c *config) {
+		c.groups = groups
+	}
+}
+
+// ValidateGroup validates v like Validate, with the rules of the given groups
+// selected as by WithGroups.
+func ValidateGroup(v any, groups ...string) error {
+	return Validate(v, WithGroups(groups...))
+}
+
+// inGroups reports whether one of groups is selected.
+func (c *config) inGroups(groups []string) bool {
+	for _, group := range groups {
+		for _, selected := range c.groups {
+			if group == selected {
+				return true
+			}
+		}
+	}
+	return false
+}
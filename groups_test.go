@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type groupsUser struct {
+	ID   int    `validate:"required&groups:update,patch"`
+	Name string `validate:"groups:create&required&min:2"`
+	Bio  string `validate:"max:5"`
+}
+
+func TestValidateGroup(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   groupsUser
+		groups []string
+		want   []string
+	}{
+		{
+			name: "no groups",
+			user: groupsUser{Bio: "too long"},
+			want: []string{"field: Bio not valid for max:5"},
+		},
+		{
+			name:   "create",
+			user:   groupsUser{Bio: "too long"},
+			groups: []string{"create"},
+			want:   []string{"field: Name not valid for groups:create&required&min:2", "field: Bio not valid for max:5"},
+		},
+		{
+			name:   "update",
+			user:   groupsUser{Name: "a"},
+			groups: []string{"update"},
+			want:   []string{"field: ID not valid for required&groups:update,patch"},
+		},
+		{
+			name:   "several groups",
+			user:   groupsUser{Name: "a"},
+			groups: []string{"patch", "create"},
+			want: []string{
+				"field: ID not valid for required&groups:update,patch",
+				"field: Name not valid for groups:create&required&min:2",
+			},
+		},
+		{
+			name:   "unknown group",
+			user:   groupsUser{},
+			groups: []string{"delete"},
+		},
+		{
+			name:   "valid",
+			user:   groupsUser{ID: 1, Name: "ab"},
+			groups: []string{"create", "update"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGroup(tt.user, tt.groups...)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var got []string
+			for _, e := range err.(ValidationErrors) {
+				got = append(got, e.Err.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGroupsSyntax(t *testing.T) {
+	for _, rules := range []string{"required&groups", "required&groups:a,,b"} {
+		err := ValidateVar("", rules)
+		assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+	assert.NoError(t, ValidateVar("", "required&groups:create"))
+
+	err := Validate(struct {
+		Name string `validate:"required&groups:create"`
+	}{}, WithGroups("create"))
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrFieldNotValid)
+}
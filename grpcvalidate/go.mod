@@ -0,0 +1,25 @@
+module github.com/Nadya2002/validator/grpcvalidate
+
+go 1.20
+
+require (
+	github.com/Nadya2002/validator v0.0.0
+	github.com/stretchr/testify v1.8.2
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20231002182017-d307bd883b97
+	google.golang.org/grpc v1.60.1
+	google.golang.org/protobuf v1.31.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.16.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/text v0.13.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Builds against the validator in this repository until a release of it
+// with the APIs used here is tagged.
+replace github.com/Nadya2002/validator => ../
@@ -0,0 +1,120 @@
+// Package grpcvalidate provides gRPC server interceptors validating request
+// messages before they reach the handlers:
+//
+//	srv := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(grpcvalidate.UnaryServerInterceptor()),
+//		grpc.ChainStreamInterceptor(grpcvalidate.StreamServerInterceptor()),
+//	)
+//
+// Messages implementing interface{ Validate() error }, like wrappers with
+// methods generated by validatorgen, are validated by that method; other
+// structs, like proto-generated messages with validate tags injected or
+// rules registered with validator.RegisterTypeRules, by
+// validator.ValidateCtx, see RegisterWrapperTypes. Invalid messages are rejected with
+// codes.InvalidArgument and an errdetails.BadRequest listing the field
+// violations in the status details.
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Nadya2002/validator"
+)
+
+// UnaryServerInterceptor returns an interceptor validating the request of
+// every unary call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validate(ctx, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor validating every message
+// received on a stream; RecvMsg fails for messages that are not valid.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(s.Context(), m)
+}
+
+// validate validates msg and converts the errors to a gRPC status.
+func validate(ctx context.Context, msg any) error {
+	var err error
+	if v, ok := msg.(interface{ Validate() error }); ok {
+		err = v.Validate()
+		var errs validator.ValidationErrors
+		if err != nil && !errors.As(err, &errs) {
+			// The message rejected itself for reasons of its own.
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	} else {
+		value := reflect.ValueOf(msg)
+		for value.Kind() == reflect.Pointer && !value.IsNil() {
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return nil
+		}
+		if value.CanAddr() {
+			// Lets default rules fill in the fields of the message.
+			value = value.Addr()
+		}
+		err = validator.ValidateCtx(ctx, value.Interface())
+	}
+	if err == nil {
+		return nil
+	}
+	return toStatus(err)
+}
+
+// toStatus returns codes.InvalidArgument with the field violations for
+// ValidationErrors reporting values that are not valid, and codes.Internal
+// for any other error, like tags that cannot be applied.
+func toStatus(err error) error {
+	if ctxErr := status.FromContextError(err); ctxErr.Code() != codes.Unknown {
+		return ctxErr.Err()
+	}
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(errs))
+	for _, e := range errs {
+		if !errors.Is(e.Err, validator.ErrFieldNotValid) {
+			return status.Error(codes.Internal, err.Error())
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       e.Field(),
+			Description: e.Err.Error(),
+		})
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, "validation failed").WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
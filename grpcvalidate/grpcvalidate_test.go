@@ -0,0 +1,89 @@
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type createUser struct {
+	Email string `validate:"required&email"`
+	Age   int    `validate:"min:18"`
+}
+
+type signed struct{ valid bool }
+
+func (s *signed) Validate() error {
+	if !s.valid {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	intercept := UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	resp, err := intercept(context.Background(), &createUser{Email: "a@example.com", Age: 20}, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = intercept(context.Background(), &createUser{Email: "a", Age: 20}, &grpc.UnaryServerInfo{}, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest := st.Details()[0].(*errdetails.BadRequest)
+	require.Len(t, badRequest.FieldViolations, 1)
+	assert.Equal(t, "Email", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "field: Email not valid for required&email", badRequest.FieldViolations[0].Description)
+
+	_, err = intercept(context.Background(), &signed{}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "bad signature", status.Convert(err).Message())
+	_, err = intercept(context.Background(), &signed{valid: true}, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+
+	_, err = intercept(context.Background(), "not a struct", &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+
+	type broken struct {
+		age int `validate:"min:1"`
+	}
+	_, err = intercept(context.Background(), &broken{}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	msgs []createUser
+}
+
+func (s *fakeStream) Context() context.Context { return context.Background() }
+
+func (s *fakeStream) RecvMsg(m any) error {
+	*m.(*createUser), s.msgs = s.msgs[0], s.msgs[1:]
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	stream := &fakeStream{msgs: []createUser{{Email: "a@example.com", Age: 30}, {Email: "a@example.com", Age: 3}}}
+
+	var errs []error
+	err := StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{}, func(srv any, ss grpc.ServerStream) error {
+		for i := 0; i < 2; i++ {
+			var msg createUser
+			errs = append(errs, ss.RecvMsg(&msg))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.NoError(t, errs[0])
+	assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+}
@@ -0,0 +1,43 @@
+package grpcvalidate
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/Nadya2002/validator"
+)
+
+// RegisterWrapperTypes makes the validator check fields of the wrapper types
+// of wrapperspb, like *wrapperspb.StringValue, through the value they wrap:
+// a nil wrapper is an empty value, so it fails required and skips the rules
+// after omitempty, and the other rules apply to the wrapped value:
+//
+//	validator.RegisterTypeRules(map[string]string{
+//		"Nickname": "omitempty&min:3",
+//	}, pb.UpdateUserRequest{})
+//
+// Rules cannot be written in the tags of messages generated by protoc, so
+// they are registered with validator.RegisterTypeRules, including for the
+// wrapper types of the fields of a oneof, which are validated as nested
+// structs like "Contact.Phone". The unexported fields protoc generates for
+// the internal state of messages are not validated. It is meant to be called
+// during program initialization.
+func RegisterWrapperTypes() {
+	validator.RegisterCustomType(wrappedValue,
+		wrapperspb.DoubleValue{},
+		wrapperspb.FloatValue{},
+		wrapperspb.Int64Value{},
+		wrapperspb.UInt64Value{},
+		wrapperspb.Int32Value{},
+		wrapperspb.UInt32Value{},
+		wrapperspb.BoolValue{},
+		wrapperspb.StringValue{},
+		wrapperspb.BytesValue{},
+	)
+}
+
+// wrappedValue returns the value wrapped by a wrapper message.
+func wrappedValue(field reflect.Value) (any, error) {
+	return field.FieldByName("Value").Interface(), nil
+}
@@ -0,0 +1,40 @@
+package grpcvalidate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/Nadya2002/validator"
+)
+
+type updateUser struct {
+	Nickname *wrapperspb.StringValue `validate:"omitempty&min:3"`
+	Age      *wrapperspb.Int32Value  `validate:"required&min:18"`
+}
+
+func TestRegisterWrapperTypes(t *testing.T) {
+	RegisterWrapperTypes()
+
+	assert.NoError(t, validator.Validate(updateUser{Age: wrapperspb.Int32(20)}))
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, validator.Validate(updateUser{Nickname: wrapperspb.String("ab"), Age: wrapperspb.Int32(17)}), &errs)
+	assert.Equal(t, []string{"Nickname", "Age"}, errs.Fields())
+
+	require.ErrorAs(t, validator.Validate(updateUser{}), &errs)
+	assert.Equal(t, []string{"Age"}, errs.Fields())
+}
+
+func TestRegisterTypeRulesMessage(t *testing.T) {
+	validator.RegisterTypeRules(map[string]string{"Field": "required", "Description": "max:5"}, errdetails.BadRequest_FieldViolation{})
+
+	assert.NoError(t, validator.Validate(&errdetails.BadRequest_FieldViolation{Field: "email"}))
+
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, validator.Validate(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Description: "too long"}}}), &errs)
+	assert.Equal(t, []string{"FieldViolations[0].Field", "FieldViolations[0].Description"}, errs.Fields())
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"reflect"
+	"time"
+)
+
+// Hooks observes validations, e.g. to export metrics on which rules fail
+// most often and how long validation takes. Hooks are called once a
+// validation is done, from the goroutine that started it, and must be safe
+// for concurrent use when validations run concurrently.
+type Hooks interface {
+	// OnValidate is called for every validation with the type of the
+	// validated value, nil for ValidateVar with a nil value, the time the
+	// validation took and the number of errors it found.
+	OnValidate(t reflect.Type, d time.Duration, errCount int)
+	// OnRuleFail is called for every field that is not valid, before
+	// OnValidate, with the path of the field and the name of the rule it
+	// failed, see ValidationError.Rule. It is called for the violations of
+	// the rules of WithReportOnly too.
+	OnRuleFail(field, rule string)
+}
+
+// WithHooks makes the validation report to h.
+func WithHooks(h Hooks) Option {
+	return func(c *config) {
+		c.hooks = h
+	}
+}
+
+// begin starts the timeout, timing and tracing of the validation of value.
+func (s *validation) begin(value reflect.Value) {
+	s.startTimeout()
+	if s.hooks == nil && s.tracer == nil && s.logErrors == nil {
+		return
+	}
+	if value.IsValid() {
+		s.typ = value.Type()
+	}
+	s.start = time.Now()
+	s.startSpan(s.typ)
+}
+
+// report calls the hooks, logs the errors and ends the span once the
+// validation is done.
+func (s *validation) report() {
+	s.endSpan()
+	if s.logErrors != nil && (len(s.errors) != 0 || s.err != nil) {
+		s.logErrors(s.ctx, s.typ, s.errors, s.err)
+	}
+	if s.hooks == nil {
+		return
+	}
+	for _, e := range s.errors {
+		if fe, ok := e.Err.(*fieldError); ok {
+			s.hooks.OnRuleFail(fe.field, fe.rule)
+		}
+	}
+	for _, e := range s.warnings {
+		if fe, ok := e.Err.(*fieldError); ok && fe.reportOnly {
+			s.hooks.OnRuleFail(fe.field, fe.rule)
+		}
+	}
+	s.hooks.OnValidate(s.typ, time.Since(s.start), len(s.errors))
+}
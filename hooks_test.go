@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedValidation struct {
+	typ      reflect.Type
+	errCount int
+}
+
+type recordingHooks struct {
+	validations []recordedValidation
+	failures    []string
+}
+
+func (h *recordingHooks) OnValidate(t reflect.Type, d time.Duration, errCount int) {
+	h.validations = append(h.validations, recordedValidation{typ: t, errCount: errCount})
+}
+
+func (h *recordingHooks) OnRuleFail(field, rule string) {
+	h.failures = append(h.failures, field+":"+rule)
+}
+
+func TestWithHooks(t *testing.T) {
+	hooks := &recordingHooks{}
+	v := New(WithHooks(hooks))
+
+	assert.NoError(t, v.Validate(compiledUser{Name: "Ann", Age: 30}))
+	assert.Error(t, v.Validate(&compiledUser{Age: 17}))
+	assert.Error(t, v.ValidateVar("", "required"))
+	assert.Error(t, v.ValidateVar(nil, "required"))
+
+	userType := reflect.TypeOf(compiledUser{})
+	assert.Equal(t, []recordedValidation{
+		{typ: userType},
+		{typ: userType, errCount: 2},
+		{typ: reflect.TypeOf(""), errCount: 1},
+		{errCount: 1},
+	}, hooks.validations)
+	assert.Equal(t, []string{"Name:required", "Age:min", ":required", ":required"}, hooks.failures)
+
+	typed, err := Compile[compiledUser](WithHooks(hooks))
+	assert.NoError(t, err)
+	assert.NoError(t, typed.Validate(compiledUser{Name: "Ann", Age: 30}))
+	assert.Len(t, hooks.validations, 5)
+}
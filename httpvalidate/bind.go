@@ -0,0 +1,230 @@
+package httpvalidate
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Nadya2002/validator"
+)
+
+// ParamError is the error of a single query or form parameter.
+type ParamError struct {
+	Param string
+	// Rules are the validate rules the value does not satisfy, "" when the
+	// value could not be parsed at all.
+	Rules string
+	Err   error
+}
+
+func (e ParamError) Error() string {
+	if e.Rules != "" {
+		return "param " + e.Param + " not valid for " + e.Rules
+	}
+	return "param " + e.Param + ": " + e.Err.Error()
+}
+
+func (e ParamError) Unwrap() error { return e.Err }
+
+// ParamErrors are the errors of the parameters of a request, in the order
+// of the struct fields.
+type ParamErrors []ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// BindQuery maps query parameters to the fields of a T, which must be a
+// struct, and validates it. A field is bound to the parameter named by its
+// query tag, or to the parameter with its own name without a tag; fields
+// tagged with query:"-" are skipped. Slices take all values of a parameter,
+// other fields the first one. Strings, bools, numbers, pointers to them and
+// encoding.TextUnmarshaler implementations like time.Time are supported.
+//
+//	type search struct {
+//		Query string   `query:"q" validate:"required&max:100"`
+//		Page  int      `query:"page" validate:"min:1"`
+//		Tags  []string `query:"tag"`
+//	}
+//
+//	s, err := httpvalidate.BindQuery[search](r.URL.Query())
+//
+// Values that cannot be parsed are reported with an *Error with status 400,
+// values failing validation with status 422. Its Err holds ParamErrors
+// keyed by the parameter names.
+func BindQuery[T any](values url.Values) (T, error) {
+	return bind[T](values, "query")
+}
+
+// BindForm is BindQuery for form values, e.g. r.PostForm after
+// r.ParseForm, bound using form tags.
+func BindForm[T any](values url.Values) (T, error) {
+	return bind[T](values, "form")
+}
+
+func bind[T any](values url.Values, tagKey string) (T, error) {
+	var v T
+	valueV := reflect.ValueOf(&v).Elem()
+	if valueV.Kind() != reflect.Struct {
+		return v, validator.ErrNotStruct
+	}
+
+	params := map[string]string{}
+	var errs ParamErrors
+	if err := bindStruct(valueV, values, tagKey, params, &errs); err != nil {
+		return v, err
+	}
+	if len(errs) != 0 {
+		return v, &Error{Status: http.StatusBadRequest, Err: errs}
+	}
+
+	err := validator.Validate(&v)
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return v, err
+	}
+	for _, e := range verrs {
+		if !errors.Is(e.Err, validator.ErrFieldNotValid) {
+			return v, err
+		}
+		param, ok := params[e.Field()]
+		if !ok {
+			param = e.Field()
+		}
+		errs = append(errs, ParamError{Param: param, Rules: e.Rules(), Err: e.Err})
+	}
+	return v, &Error{Status: http.StatusUnprocessableEntity, Err: errs}
+}
+
+// bindStruct sets the fields of the struct valueV, recording the parameter
+// of every field path in params. Embedded structs are flattened like the
+// validator names their fields.
+func bindStruct(valueV reflect.Value, values url.Values, tagKey string, params map[string]string, errs *ParamErrors) error {
+	typeV := valueV.Type()
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+		tag, hasTag := fieldT.Tag.Lookup(tagKey)
+		if tag == "-" {
+			continue
+		}
+		if fieldT.Anonymous && !hasTag && fieldT.Type.Kind() == reflect.Struct {
+			if err := bindStruct(valueV.Field(i), values, tagKey, params, errs); err != nil {
+				return err
+			}
+			continue
+		}
+		if !fieldT.IsExported() {
+			continue
+		}
+
+		name := fieldT.Name
+		if tag != "" {
+			name = tag
+		}
+		params[fieldT.Name] = name
+
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		if !canBind(fieldT.Type) {
+			if hasTag {
+				return fmt.Errorf("httpvalidate: cannot bind field %s of type %s", fieldT.Name, fieldT.Type)
+			}
+			continue
+		}
+		if err := setField(valueV.Field(i), raw); err != nil {
+			*errs = append(*errs, ParamError{Param: name, Err: err})
+		}
+	}
+	return nil
+}
+
+func canBind(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func setField(field reflect.Value, raw []string) error {
+	if field.Kind() == reflect.Slice && !field.Addr().Type().Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(field.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			if err := setValue(slice.Index(i), s); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setValue(field, raw[0])
+}
+
+func setValue(field reflect.Value, s string) error {
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), s); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.New("not a bool")
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("not an integer")
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("not an unsigned integer")
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return errors.New("not a number")
+		}
+		field.SetFloat(n)
+	}
+	return nil
+}
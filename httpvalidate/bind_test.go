@@ -0,0 +1,97 @@
+package httpvalidate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paging struct {
+	Page  int  `query:"page" form:"p" validate:"min:1"`
+	Limit *int `query:"limit" validate:"omitempty&max:100"`
+}
+
+type search struct {
+	paging
+	Query  string    `query:"q" validate:"required&max:10"`
+	Tags   []string  `query:"tag" validate:"omitempty&min:2"`
+	Exact  bool      `query:"exact"`
+	Since  time.Time `query:"since"`
+	Ignore string    `query:"-" validate:"required"`
+}
+
+func TestBindQuery(t *testing.T) {
+	values, err := url.ParseQuery("q=go&page=2&limit=50&tag=ab&tag=cd&exact=true&since=2024-01-02T03:04:05Z&Ignore=x")
+	require.NoError(t, err)
+
+	type plain struct {
+		Query string `query:"q" validate:"required"`
+		Page  int
+	}
+	p, err := BindQuery[plain](values)
+	require.NoError(t, err)
+	assert.Equal(t, plain{Query: "go"}, p, "no tag means the field name")
+
+	values.Set("Ignore", "")
+	s, err := BindQuery[search](values)
+	var reqErr *Error
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, reqErr.Status)
+	assert.Equal(t, ParamErrors{{Param: "Ignore", Rules: "required", Err: reqErr.Err.(ParamErrors)[0].Err}}, reqErr.Err)
+
+	assert.Equal(t, 2, s.Page)
+	assert.Equal(t, 50, *s.Limit)
+	assert.Equal(t, "go", s.Query)
+	assert.Equal(t, []string{"ab", "cd"}, s.Tags)
+	assert.True(t, s.Exact)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), s.Since)
+}
+
+func TestBindQueryErrors(t *testing.T) {
+	values, err := url.ParseQuery("q=golang-generics&page=0&limit=500&tag=a")
+	require.NoError(t, err)
+
+	type search struct {
+		paging
+		Query string   `query:"q" validate:"required&max:10"`
+		Tags  []string `query:"tag" validate:"omitempty&min:2"`
+	}
+	_, err = BindQuery[search](values)
+	var reqErr *Error
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, reqErr.Status)
+	assert.EqualError(t, reqErr.Err, "param page not valid for min:1; param limit not valid for omitempty&max:100; "+
+		"param q not valid for required&max:10; param tag not valid for omitempty&min:2")
+
+	values.Set("page", "one")
+	_, err = BindQuery[search](values)
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, http.StatusBadRequest, reqErr.Status)
+	assert.EqualError(t, reqErr.Err, "param page: not an integer")
+
+	w := httptest.NewRecorder()
+	WriteError(w, err)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"invalid parameters","fields":[{"field":"page","rules":"","message":"param page: not an integer"}]}`, w.Body.String())
+
+	type unsupported struct {
+		M map[string]string `query:"m"`
+	}
+	_, err = BindQuery[unsupported](url.Values{"m": {"x"}})
+	assert.EqualError(t, err, "httpvalidate: cannot bind field M of type map[string]string")
+}
+
+func TestBindForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.PostForm = url.Values{"p": {"3"}}
+
+	p, err := BindForm[paging](r.PostForm)
+	require.NoError(t, err)
+	assert.Equal(t, 3, p.Page)
+}
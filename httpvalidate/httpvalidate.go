@@ -0,0 +1,156 @@
+// Package httpvalidate decodes and validates JSON request bodies, answering
+// invalid requests with a structured error response:
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//		u, err := httpvalidate.Decode[User](r)
+//		if err != nil {
+//			httpvalidate.WriteError(w, err)
+//			return
+//		}
+//		...
+//	}
+//
+// Or, with the middleware doing both:
+//
+//	mux.Handle("/users", httpvalidate.Middleware[User](http.HandlerFunc(createUser)))
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//		u, _ := httpvalidate.FromContext[User](r.Context())
+//		...
+//	}
+package httpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Nadya2002/validator"
+)
+
+// Error is returned for requests that cannot be accepted. Status is the
+// HTTP status to answer with: 415 for a body that is not JSON, 400 for a
+// body that cannot be decoded and 422 for a value failing validation, in
+// which case Err holds the validator.ValidationErrors.
+type Error struct {
+	Status int
+	Err    error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Decode decodes the JSON body of r into a T, which must be a struct, and
+// validates it. Invalid requests are reported with an *Error. Other errors,
+// like tags that cannot be applied, are returned as they are, as they are
+// not the fault of the client.
+func Decode[T any](r *http.Request) (T, error) {
+	var v T
+
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			return v, &Error{Status: http.StatusUnsupportedMediaType, Err: fmt.Errorf("unsupported content type %q", ct)}
+		}
+	}
+
+	if r.Body == nil {
+		return v, &Error{Status: http.StatusBadRequest, Err: errors.New("request body is empty")}
+	}
+	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("request body is empty")
+		}
+		return v, &Error{Status: http.StatusBadRequest, Err: err}
+	}
+
+	err := validator.ValidateCtx(r.Context(), &v)
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return v, err
+	}
+	for _, e := range errs {
+		if !errors.Is(e.Err, validator.ErrFieldNotValid) {
+			return v, err
+		}
+	}
+	return v, &Error{Status: http.StatusUnprocessableEntity, Err: errs}
+}
+
+// Response is the body WriteError answers with.
+type Response struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes a field of a request body that is not valid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rules   string `json:"rules"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// WriteError answers with the status of an *Error and a JSON Response
+// listing the fields, or the parameters for BindQuery and BindForm, that are
+// not valid. Any other error is answered with 500 and a generic message.
+func WriteError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	resp := Response{Error: http.StatusText(status)}
+
+	var reqErr *Error
+	if errors.As(err, &reqErr) {
+		status = reqErr.Status
+		resp.Error = reqErr.Error()
+
+		var errs validator.ValidationErrors
+		var params ParamErrors
+		switch {
+		case errors.As(reqErr.Err, &errs):
+			resp.Error = "validation failed"
+			for _, e := range errs {
+				resp.Fields = append(resp.Fields, FieldError{Field: e.Field(), Rules: e.Rules(), Code: e.Code(), Message: e.Err.Error()})
+			}
+		case errors.As(reqErr.Err, &params):
+			resp.Error = "invalid parameters"
+			if reqErr.Status == http.StatusUnprocessableEntity {
+				resp.Error = "validation failed"
+			}
+			for _, e := range params {
+				resp.Fields = append(resp.Fields, FieldError{Field: e.Param, Rules: e.Rules, Message: e.Error()})
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+type valueKey[T any] struct{}
+
+// Middleware decodes and validates the body of every request like Decode
+// and passes the value on to next in the request context, see FromContext.
+// Invalid requests are answered with WriteError and do not reach next.
+func Middleware[T any](next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, err := Decode[T](r)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), valueKey[T]{}, v)))
+	})
+}
+
+// FromContext returns the value decoded by Middleware[T].
+func FromContext[T any](ctx context.Context) (T, bool) {
+	v, ok := ctx.Value(valueKey[T]{}).(T)
+	return v, ok
+}
@@ -0,0 +1,94 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+type signup struct {
+	Email string `json:"email" validate:"required&email"`
+	Age   int    `json:"age" validate:"min:18"`
+}
+
+func TestDecode(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a@example.com","age":20}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	v, err := Decode[signup](r)
+	require.NoError(t, err)
+	assert.Equal(t, signup{Email: "a@example.com", Age: 20}, v)
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		status      int
+	}{
+		{name: "invalid", body: `{"email":"a","age":20}`, status: http.StatusUnprocessableEntity},
+		{name: "malformed", body: `{"email":`, status: http.StatusBadRequest},
+		{name: "empty", body: ``, status: http.StatusBadRequest},
+		{name: "not json", body: `email=a`, contentType: "application/x-www-form-urlencoded", status: http.StatusUnsupportedMediaType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			_, err := Decode[signup](r)
+
+			var reqErr *Error
+			require.True(t, errors.As(err, &reqErr))
+			assert.Equal(t, tt.status, reqErr.Status)
+		})
+	}
+
+	type broken struct {
+		name string `validate:"required"`
+	}
+	_, err = Decode[broken](httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+	assert.ErrorIs(t, err.(validator.ValidationErrors)[0].Err, validator.ErrValidateForUnexportedFields)
+}
+
+func TestMiddleware(t *testing.T) {
+	var got signup
+	h := Middleware[signup](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext[signup](r.Context())
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a@example.com","age":30}`)))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, signup{Email: "a@example.com", Age: 30}, got)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"a","age":3}`)))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var resp Response
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, Response{
+		Error: "validation failed",
+		Fields: []FieldError{
+			{Field: "Email", Rules: "required&email", Code: "VAL_EMAIL", Message: "field: Email not valid for required&email"},
+			{Field: "Age", Rules: "min:18", Code: "VAL_MIN", Message: "field: Age not valid for min:18"},
+		},
+	}, resp)
+}
+
+func TestWriteError(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, errors.New("database is down"))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"Internal Server Error"}`, w.Body.String())
+}
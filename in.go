@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// typeIn parses the arguments of the in rules of validators, the rules of
+// the field name of type t, as values of the type they apply to when it is a
+// number or a bool type, so they are compared like the values of an enum:
+//
+//	Ratio   float64 `validate:"in:0.5,1.5"`
+//	Enabled bool    `validate:"in:true"`
+//	Level   int8    `validate:"in:1,2,3"`
+//
+// An argument that is not a value of the type, like "a" or "300" for the
+// int8, is reported with an error wrapping ErrInvalidValidatorSyntax instead
+// of never matching. Types only known at run time, like those of interfaces
+// and registered custom types, keep their arguments as written.
+func typeIn(validators []rule, t reflect.Type, name string) error {
+	if asText(validators) || asNumber(validators) {
+		// The rules apply to a textual form or to a number.
+		return nil
+	}
+	elemT := ruleType(t)
+	kind, known := ruleKind(t)
+	if _, number := registered(numberTypes, elemT); number {
+		known = false
+	}
+	for i := range validators {
+		validator := &validators[i]
+		if validator.custom != nil || validator.batch != nil {
+			continue
+		}
+		switch validator.name {
+		case "in":
+			switch kindClass(kind) {
+			case reflect.Int, reflect.Uint, reflect.Float64, reflect.Bool:
+			default:
+				continue
+			}
+			if !known {
+				continue
+			}
+			values := make([]reflect.Value, len(validator.argsStr))
+			for j, arg := range validator.argsStr {
+				value, _, err := parseLiteral(strings.TrimSpace(arg), elemT)
+				if err != nil && name == "" {
+					return fmt.Errorf("%w: argument %q of rule in is not a value of %s", ErrInvalidValidatorSyntax, strings.TrimSpace(arg), elemT)
+				}
+				if err != nil {
+					return fmt.Errorf("%w: argument %q of rule in on field %s is not a value of %s", ErrInvalidValidatorSyntax, strings.TrimSpace(arg), name, elemT)
+				}
+				values[j] = value
+			}
+			validator.enum = values
+		case "keys", "values":
+			if elemT.Kind() != reflect.Map {
+				continue
+			}
+			mapT := elemT.Elem()
+			if validator.name == "keys" {
+				mapT = elemT.Key()
+			}
+			if err := typeIn(validator.each, mapT, name); err != nil {
+				return err
+			}
+		case "or":
+			for _, alternative := range validator.alternatives {
+				if err := typeIn(alternative, t, name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// hasIn reports whether validators, or the rules nested in them, include an
+// in rule.
+func hasIn(validators []rule) bool {
+	for _, validator := range validators {
+		if validator.name == "in" || hasIn(validator.each) {
+			return true
+		}
+		for _, alternative := range validator.alternatives {
+			if hasIn(alternative) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyRules returns a copy of validators and of the rules nested in them,
+// which typeIn can change without changing validators.
+func copyRules(validators []rule) []rule {
+	copied := make([]rule, len(validators))
+	copy(copied, validators)
+	for i, validator := range copied {
+		if validator.each != nil {
+			copied[i].each = copyRules(validator.each)
+		}
+		if validator.alternatives != nil {
+			alternatives := make([][]rule, len(validator.alternatives))
+			for j, alternative := range validator.alternatives {
+				alternatives[j] = copyRules(alternative)
+			}
+			copied[i].alternatives = alternatives
+		}
+	}
+	return copied
+}
+
+// validateInNumber reports whether field, a number or a bool whose type is
+// only known at run time, is equal to one of the arguments of the in rule
+// validator. Integers are compared with the arguments that are integers,
+// other values with the arguments parsed as values of their type.
+func validateInNumber(field reflect.Value, validator rule) error {
+	switch kindClass(field.Kind()) {
+	case reflect.Float64, reflect.Bool:
+		for _, arg := range validator.argsStr {
+			if value, _, err := parseLiteral(strings.TrimSpace(arg), field.Type()); err == nil && enumEqual(field, value) {
+				return nil
+			}
+		}
+		return ErrFieldNotValid
+	}
+	for i, v := range validator.argsInt {
+		if validator.notInts != nil && validator.notInts[i] {
+			continue
+		}
+		if c, ok := compareNumber(field, v); ok && c == 0 {
+			return nil
+		}
+	}
+	return ErrFieldNotValid
+}
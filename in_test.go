@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTypedIn(t *testing.T) {
+	type settings struct {
+		Ratio   float64            `validate:"in:0.5, 1.5"`
+		Small   float32            `validate:"in:0.1"`
+		Enabled bool               `validate:"in:true"`
+		Level   int8               `validate:"in:-1,2"`
+		Port    *uint16            `validate:"omitempty&in:80,443"`
+		Codes   []int              `validate:"in:7,8"`
+		Weights map[string]float64 `validate:"values:in:0.25,0.75"`
+		Mode    int                `validate:"in:1|in:3"`
+		Any     any                `validate:"in:0.5,true"`
+	}
+
+	port := uint16(443)
+	valid := settings{Ratio: 1.5, Small: 0.1, Enabled: true, Level: -1, Port: &port, Codes: []int{8}, Weights: map[string]float64{"a": 0.25}, Mode: 3, Any: 0.5}
+	require.NoError(t, Validate(valid))
+
+	tests := []struct {
+		name   string
+		change func(*settings)
+		rule   string
+	}{
+		{"float", func(s *settings) { s.Ratio = 1 }, "in"},
+		{"bool", func(s *settings) { s.Enabled = false }, "in"},
+		{"int8", func(s *settings) { s.Level = 1 }, "in"},
+		{"uint", func(s *settings) { p := uint16(8080); s.Port = &p }, "in"},
+		{"slice", func(s *settings) { s.Codes = []int{7, 9} }, "in"},
+		{"map values", func(s *settings) { s.Weights = map[string]float64{"a": 0.5} }, "in"},
+		{"alternatives", func(s *settings) { s.Mode = 2 }, "or"},
+		{"interface", func(s *settings) { s.Any = 1.5 }, "in"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid
+			tt.change(&s)
+			var errs ValidationErrors
+			require.ErrorAs(t, Validate(s), &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.rule, errs[0].Rule())
+		})
+	}
+	valid.Any = true
+	assert.NoError(t, Validate(valid))
+
+	syntax := []struct {
+		name  string
+		value any
+		err   string
+	}{
+		{"mixed", struct {
+			A int `validate:"in:1,a"`
+		}{}, `invalid validator syntax: argument "a" of rule in on field A is not a value of int`},
+		{"overflow", struct {
+			A []int8 `validate:"in:300"`
+		}{}, `invalid validator syntax: argument "300" of rule in on field A is not a value of int8`},
+		{"negative uint", struct {
+			A uint `validate:"in:-1"`
+		}{}, `invalid validator syntax: argument "-1" of rule in on field A is not a value of uint`},
+		{"bool", struct {
+			A bool `validate:"in:yes"`
+		}{}, `invalid validator syntax: argument "yes" of rule in on field A is not a value of bool`},
+		{"map keys", struct {
+			A map[int]string `validate:"keys:in:x,endkeys"`
+		}{}, `invalid validator syntax: argument "x" of rule in on field A is not a value of int`},
+	}
+	for _, tt := range syntax {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationErrors
+			require.ErrorAs(t, Validate(tt.value), &errs)
+			assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+			assert.EqualError(t, errs[0].Err, tt.err)
+		})
+	}
+
+	assert.NoError(t, ValidateVar(1.5, "in:0.5,1.5"))
+	assert.NoError(t, ValidateVar(false, "in:false"))
+	assert.Error(t, ValidateVar(true, "in:false,0"))
+	assert.NoError(t, ValidateVar(int8(2), "in:1,2"))
+	assert.NoError(t, ValidateVar("a", "in:a,2"))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, ValidateVar(3, "in:a,3"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+	assert.EqualError(t, errs[0].Err, `invalid validator syntax: argument "a" of rule in is not a value of int`)
+	require.ErrorAs(t, ValidateVar([]int8{1}, "in:1,300"), &errs)
+	assert.EqualError(t, errs[0].Err, `invalid validator syntax: argument "300" of rule in is not a value of int8`)
+
+	require.ErrorAs(t, ValidateMap(map[string]any{"level": 2.0}, map[string]string{"level": "in:1,x"}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+	assert.EqualError(t, errs[0].Err, `invalid validator syntax: argument "x" of rule in on field level is not a value of float64`)
+	assert.NoError(t, ValidateMap(map[string]any{"level": 2.0}, map[string]string{"level": "in:1,2"}))
+}
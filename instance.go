@@ -0,0 +1,36 @@
+package validator
+
+import "context"
+
+// Validator validates values with options of its own, for code that needs
+// a configuration differing from the package level functions, e.g. rules in
+// tags with another key:
+//
+//	v := validator.New(validator.WithTagName("valid"))
+//	err := v.Validate(u)
+//
+// A Validator is safe for concurrent use.
+type Validator struct {
+	config config
+}
+
+// New returns a Validator applying opts to every validation.
+func New(opts ...Option) *Validator {
+	return &Validator{config: newConfig(opts)}
+}
+
+// Validate validates value like the package level Validate function.
+func (v *Validator) Validate(value any) error {
+	return validateCtx(context.Background(), value, v.config)
+}
+
+// ValidateCtx validates value like the package level ValidateCtx function.
+func (v *Validator) ValidateCtx(ctx context.Context, value any) error {
+	return validateCtx(ctx, value, v.config)
+}
+
+// ValidateVar validates value against rules like the package level
+// ValidateVar function.
+func (v *Validator) ValidateVar(value any, rules string) error {
+	return validateVar(value, rules, v.config)
+}
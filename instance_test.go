@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type instanceUser struct {
+	Name    string          `valid:"required&min:2" validate:"len:10"`
+	Address instanceAddress `valid:"required"`
+}
+
+type instanceAddress struct {
+	Zip string `valid:"len:5"`
+}
+
+func TestNew(t *testing.T) {
+	v := New(WithTagName("valid"))
+	assert.NoError(t, v.Validate(instanceUser{Name: "al", Address: instanceAddress{Zip: "12345"}}))
+
+	err := v.Validate(&instanceUser{Name: "a", Address: instanceAddress{Zip: "1"}})
+	require.Error(t, err)
+	var got []string
+	for _, e := range err.(ValidationErrors) {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"field: Name not valid for required&min:2",
+		"field: Address.Zip not valid for len:5",
+	}, got)
+
+	// The default key is unaffected.
+	err = Validate(instanceUser{Name: "al"})
+	require.Error(t, err)
+	assert.Equal(t, "field: Name not valid for len:10", err.Error())
+	err = Validate(instanceUser{Name: "al"}, WithTagName("valid"))
+	require.Error(t, err)
+	assert.Equal(t, "Address", err.(ValidationErrors)[0].Field())
+
+	assert.NoError(t, New(WithTagName("validate")).Validate(instanceUser{Name: "0123456789"}))
+	assert.ErrorIs(t, v.Validate(1), ErrNotStruct)
+
+	v = New(WithGroups("create"))
+	assert.Error(t, v.ValidateVar("", "required&groups:create"))
+	assert.NoError(t, ValidateVar("", "required&groups:create"))
+}
+
+func TestCompileWithTagName(t *testing.T) {
+	users, err := Compile[instanceUser](WithTagName("valid"))
+	require.NoError(t, err)
+	assert.Error(t, users.Validate(instanceUser{Name: "al", Address: instanceAddress{Zip: "1"}}))
+	assert.NoError(t, users.Validate(instanceUser{Name: "al", Address: instanceAddress{Zip: "12345"}}))
+}
@@ -0,0 +1,238 @@
+package tags
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Assertion is the argument of an assert rule, like "Total==Subtotal+Tax":
+// a comparison of two arithmetic expressions over the numeric fields of a
+// struct. Op is one of "==", "!=", "<", "<=", ">" and ">=".
+type Assertion struct {
+	Left, Right *Arith
+	Op          string
+}
+
+// Arith is an arithmetic expression. Op is "+", "-", "*" or "/" for an
+// operation on X and Y, "neg" for the negation of X, and "" for the number
+// Num or, when Field is set, the field at the path Field.
+type Arith struct {
+	Op    string
+	Num   float64
+	Field string
+	X, Y  *Arith
+}
+
+// ParseAssertion parses the argument s of an assert rule. Expressions are
+// made of numbers, paths of fields like "Lines.Total", the operators "+",
+// "-", "*" and "/", and parentheses.
+func ParseAssertion(s string) (Assertion, error) {
+	p := arithParser{s: s}
+	left, err := p.sum()
+	if err != nil {
+		return Assertion{}, err
+	}
+	a := Assertion{Left: left}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(s[p.pos:], op) {
+			a.Op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if a.Op == "" {
+		return Assertion{}, p.errorf("expected a comparison")
+	}
+	if a.Right, err = p.sum(); err != nil {
+		return Assertion{}, err
+	}
+	if p.skipSpaces(); p.pos < len(s) {
+		return Assertion{}, p.errorf("unexpected %q", s[p.pos:])
+	}
+	return a, nil
+}
+
+// Fields returns the paths of the fields the assertion reads, in the order
+// they appear, each once.
+func (a Assertion) Fields() []string {
+	var fields []string
+	seen := map[string]bool{}
+	var walk func(e *Arith)
+	walk = func(e *Arith) {
+		if e == nil {
+			return
+		}
+		if e.Field != "" && !seen[e.Field] {
+			seen[e.Field] = true
+			fields = append(fields, e.Field)
+		}
+		walk(e.X)
+		walk(e.Y)
+	}
+	walk(a.Left)
+	walk(a.Right)
+	return fields
+}
+
+// Holds reports whether the assertion holds with the fields read by value.
+// Numbers are equal when they differ by no more than a billionth of the
+// larger one, so 0.1+0.2 equals 0.3. Divisions by zero never hold.
+func (a Assertion) Holds(value func(field string) float64) bool {
+	x, okX := a.Left.Eval(value)
+	y, okY := a.Right.Eval(value)
+	if !okX || !okY {
+		return false
+	}
+	equal := math.Abs(x-y) <= 1e-9*math.Max(1, math.Max(math.Abs(x), math.Abs(y)))
+	switch a.Op {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	case "<":
+		return x < y && !equal
+	case "<=":
+		return x < y || equal
+	case ">":
+		return x > y && !equal
+	}
+	return x > y || equal
+}
+
+// Eval returns the value of e with the fields read by value, false for a
+// division by zero.
+func (e *Arith) Eval(value func(field string) float64) (float64, bool) {
+	switch e.Op {
+	case "":
+		if e.Field != "" {
+			return value(e.Field), true
+		}
+		return e.Num, true
+	case "neg":
+		x, ok := e.X.Eval(value)
+		return -x, ok
+	}
+	x, okX := e.X.Eval(value)
+	y, okY := e.Y.Eval(value)
+	if !okX || !okY {
+		return 0, false
+	}
+	switch e.Op {
+	case "+":
+		return x + y, true
+	case "-":
+		return x - y, true
+	case "*":
+		return x * y, true
+	}
+	if y == 0 {
+		return 0, false
+	}
+	return x / y, true
+}
+
+type arithParser struct {
+	s     string
+	pos   int
+	depth int
+}
+
+func (p *arithParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: invalid assertion %q: %s", ErrSyntax, p.s, fmt.Sprintf(format, args...))
+}
+
+func (p *arithParser) skipSpaces() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// sum parses terms separated by "+" and "-".
+func (p *arithParser) sum() (*Arith, error) {
+	x, err := p.product()
+	for err == nil {
+		p.skipSpaces()
+		if p.pos == len(p.s) || p.s[p.pos] != '+' && p.s[p.pos] != '-' {
+			return x, nil
+		}
+		op := p.s[p.pos : p.pos+1]
+		p.pos++
+		var y *Arith
+		if y, err = p.product(); err == nil {
+			x = &Arith{Op: op, X: x, Y: y}
+		}
+	}
+	return nil, err
+}
+
+// product parses factors separated by "*" and "/".
+func (p *arithParser) product() (*Arith, error) {
+	x, err := p.factor()
+	for err == nil {
+		p.skipSpaces()
+		if p.pos == len(p.s) || p.s[p.pos] != '*' && p.s[p.pos] != '/' {
+			return x, nil
+		}
+		op := p.s[p.pos : p.pos+1]
+		p.pos++
+		var y *Arith
+		if y, err = p.factor(); err == nil {
+			x = &Arith{Op: op, X: x, Y: y}
+		}
+	}
+	return nil, err
+}
+
+// factor parses a number, a field, a negation or an expression in
+// parentheses.
+func (p *arithParser) factor() (*Arith, error) {
+	p.skipSpaces()
+	if p.pos == len(p.s) {
+		return nil, p.errorf("unexpected end")
+	}
+	switch c := p.s[p.pos]; {
+	case c == '-':
+		p.pos++
+		x, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return &Arith{Op: "neg", X: x}, nil
+	case c == '(':
+		if p.depth++; p.depth > maxDepth {
+			return nil, p.errorf("too deeply nested")
+		}
+		p.pos++
+		x, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.skipSpaces(); p.pos == len(p.s) || p.s[p.pos] != ')' {
+			return nil, p.errorf("unbalanced parenthesis")
+		}
+		p.pos++
+		p.depth--
+		return x, nil
+	case c >= '0' && c <= '9' || c == '.':
+		start := p.pos
+		for p.pos < len(p.s) && (p.s[p.pos] >= '0' && p.s[p.pos] <= '9' || p.s[p.pos] == '.') {
+			p.pos++
+		}
+		num, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", p.s[start:p.pos])
+		}
+		return &Arith{Num: num}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte(" +-*/()=!<>", p.s[p.pos]) < 0 {
+		p.pos++
+	}
+	if field := p.s[start:p.pos]; IsFieldPath(field) {
+		return &Arith{Field: field}, nil
+	}
+	return nil, p.errorf("invalid field %q", p.s[start:p.pos])
+}
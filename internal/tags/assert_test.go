@@ -0,0 +1,44 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAssertion(t *testing.T) {
+	values := map[string]float64{"Total": 130, "Subtotal": 100, "Tax": 20, "Shipping.Cost": 5, "Zero": 0}
+	value := func(field string) float64 { return values[field] }
+	tests := []struct {
+		s      string
+		fields []string
+		holds  bool
+	}{
+		{s: "Total==Subtotal+Tax", fields: []string{"Total", "Subtotal", "Tax"}},
+		{s: "Total == Subtotal + Tax + 2 * Shipping.Cost", fields: []string{"Total", "Subtotal", "Tax", "Shipping.Cost"}, holds: true},
+		{s: "Total-Tax==(Subtotal+Shipping.Cost)*2-100", fields: []string{"Total", "Tax", "Subtotal", "Shipping.Cost"}, holds: true},
+		{s: "Subtotal/4==25", fields: []string{"Subtotal"}, holds: true},
+		{s: "Subtotal-Tax-Tax==60", fields: []string{"Subtotal", "Tax"}, holds: true},
+		{s: "-Tax<0", fields: []string{"Tax"}, holds: true},
+		{s: "Tax*Tax>=400", fields: []string{"Tax"}, holds: true},
+		{s: "Tax!=Tax", fields: []string{"Tax"}},
+		{s: "0.1+0.2==0.3", holds: true},
+		{s: "0.1+0.2<=0.3", holds: true},
+		{s: "0.1+0.2>0.3"},
+		{s: "Tax/Zero==Tax/Zero", fields: []string{"Tax", "Zero"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			a, err := ParseAssertion(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.fields, a.Fields())
+			assert.Equal(t, tt.holds, a.Holds(value))
+		})
+	}
+
+	for _, s := range []string{"", "Total", "Total==", "==Tax", "Total=Tax", "Total==Tax)", "(Total==Tax", "Total==1.2.3", "Total==Tax+", "Total==$Tax", "Total==Tax Tax", "Total==1x"} {
+		_, err := ParseAssertion(s)
+		assert.ErrorIs(t, err, ErrSyntax, s)
+	}
+}
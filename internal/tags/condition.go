@@ -0,0 +1,74 @@
+package tags
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Condition is the argument of an if rule, like "Currency=='USD'": the
+// rules of a field apply only when it holds. Op is "==" or "!=" for a
+// comparison of the field Name with Value, "" for a Name that is either a
+// registered condition or a field that must not be empty, and "!" for a
+// field that must be empty.
+type Condition struct {
+	Name  string
+	Op    string
+	Value string
+}
+
+// ParseCondition parses the argument s of an if rule. Names are field names
+// or dotted paths of fields. Values are quoted with single or double quotes,
+// or written as they are when they hold no spaces or quotes, like numbers.
+func ParseCondition(s string) (Condition, error) {
+	s = strings.TrimSpace(s)
+	var c Condition
+	eq, ne := strings.Index(s, "=="), strings.Index(s, "!=")
+	switch {
+	case eq >= 0 && (ne < 0 || eq < ne):
+		c = Condition{Name: s[:eq], Op: "==", Value: s[eq+2:]}
+	case ne >= 0:
+		c = Condition{Name: s[:ne], Op: "!=", Value: s[ne+2:]}
+	case strings.HasPrefix(s, "!"):
+		c = Condition{Name: s[1:], Op: "!"}
+	default:
+		c = Condition{Name: s}
+	}
+
+	c.Name = strings.TrimSpace(c.Name)
+	if !IsFieldPath(c.Name) {
+		return Condition{}, fmt.Errorf("%w: invalid condition %q", ErrSyntax, s)
+	}
+	if c.Op == "==" || c.Op == "!=" {
+		value := strings.TrimSpace(c.Value)
+		switch {
+		case len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0]:
+			value = value[1 : len(value)-1]
+		case value == "" || strings.ContainsAny(value, " '\"=!"):
+			return Condition{}, fmt.Errorf("%w: invalid value in condition %q", ErrSyntax, s)
+		}
+		c.Value = value
+	}
+	return c, nil
+}
+
+// IsFieldPath reports whether s is a field name or a dotted path of field
+// names, like "Address.Zip".
+func IsFieldPath(s string) bool {
+	for _, name := range strings.Split(s, ".") {
+		if !isIdent(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// isIdent reports whether s is a Go identifier.
+func isIdent(s string) bool {
+	for i, r := range s {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
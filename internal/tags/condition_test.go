@@ -0,0 +1,33 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		cond string
+		want Condition
+	}{
+		{cond: "Currency=='USD'", want: Condition{Name: "Currency", Op: "==", Value: "USD"}},
+		{cond: ` Currency == "a b" `, want: Condition{Name: "Currency", Op: "==", Value: "a b"}},
+		{cond: "Age!=0", want: Condition{Name: "Age", Op: "!=", Value: "0"}},
+		{cond: "Note!='a==b'", want: Condition{Name: "Note", Op: "!=", Value: "a==b"}},
+		{cond: "isPremium", want: Condition{Name: "isPremium"}},
+		{cond: "!Address.Zip", want: Condition{Name: "Address.Zip", Op: "!"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cond, func(t *testing.T) {
+			got, err := ParseCondition(tt.cond)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, cond := range []string{"", "!", "A=B", "A==", "A==a b", "A=='b", "1A", "A..B", "A==B==C"} {
+		_, err := ParseCondition(cond)
+		assert.ErrorIs(t, err, ErrSyntax, cond)
+	}
+}
@@ -0,0 +1,98 @@
+package tags
+
+import "strings"
+
+// Cut slices s around the first sep that is neither escaped nor quoted,
+// like strings.Cut. Arguments of rules may contain the separators of a tag
+// when they are quoted, like the arguments of `in:"a,b","c:d"`, or when the
+// separators are escaped by a backslash, like in `in:a\,b`. In struct tags
+// the backslash is doubled, as the tag is a Go string itself.
+func Cut(s string, sep byte) (before, after string, found bool) {
+	if i := index(s, sep); i >= 0 {
+		return s[:i], s[i+1:], true
+	}
+	return s, "", false
+}
+
+// SplitArgs splits the arguments of a rule at the commas that are neither
+// escaped nor quoted, and removes the quotes and escapes of the arguments.
+func SplitArgs(params string) []string {
+	var args []string
+	for {
+		arg, rest, found := Cut(params, ',')
+		args = append(args, Unescape(arg))
+		if !found {
+			return args
+		}
+		params = rest
+	}
+}
+
+// Unescape removes the quotes around a quoted argument and the backslashes
+// escaping separators, quotes and backslashes. Other backslashes are kept,
+// so arguments written before escaping was supported keep their meaning.
+func Unescape(arg string) string {
+	if !strings.ContainsAny(arg, `\"`) {
+		return arg
+	}
+	if quoted := strings.TrimSpace(arg); len(quoted) >= 2 && quoted[0] == '"' && closingQuote(quoted) == len(quoted)-1 {
+		arg = quoted[1 : len(quoted)-1]
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] == '\\' && i+1 < len(arg) && isEscaped(arg[i+1]) {
+			i++
+		}
+		sb.WriteByte(arg[i])
+	}
+	return sb.String()
+}
+
+// closingQuote returns the index of the quote closing the quoted argument
+// s, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && isEscaped(s[i+1]):
+			i++
+		case s[i] == '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// index returns the index of the first sep in s that is neither escaped nor
+// quoted, or -1. A quote only opens a quoted argument at the start of the
+// argument, so quotes inside arguments like regular expressions are kept.
+func index(s string, sep byte) int {
+	quoted, start := false, true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isEscaped(s[i+1]):
+			i++
+			start = false
+		case c == '"' && (quoted || start):
+			quoted, start = !quoted, false
+		case quoted:
+		case c == sep:
+			return i
+		case c == ':' || c == ',' || c == '&':
+			start = true
+		case c != ' ':
+			start = false
+		}
+	}
+	return -1
+}
+
+// isEscaped reports whether a backslash before c escapes it.
+func isEscaped(c byte) bool {
+	switch c {
+	case ',', '&', ':', '"', '\\', '|', '(', ')':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCut(t *testing.T) {
+	tests := []struct {
+		s, before, after string
+		found            bool
+	}{
+		{`min:1&max:2`, `min:1`, `max:2`, true},
+		{`in:"a&b"&max:2`, `in:"a&b"`, `max:2`, true},
+		{`in:a\&b&max:2`, `in:a\&b`, `max:2`, true},
+		{`regexp:^a"&b$`, `regexp:^a"`, `b$`, true},
+		{`in:"a&b`, `in:"a&b`, ``, false},
+	}
+	for _, tt := range tests {
+		before, after, found := Cut(tt.s, '&')
+		assert.Equal(t, tt.before, before, tt.s)
+		assert.Equal(t, tt.after, after, tt.s)
+		assert.Equal(t, tt.found, found, tt.s)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		params string
+		want   []string
+	}{
+		{`a,b`, []string{"a", "b"}},
+		{`"a,b","c:d"`, []string{"a,b", "c:d"}},
+		{` "a,b" ,c`, []string{"a,b", "c"}},
+		{`a\,b,c\:d,e\&f`, []string{"a,b", "c:d", "e&f"}},
+		{`"say \"hi\"",a\\b`, []string{`say "hi"`, `a\b`}},
+		{`a\b,""`, []string{`a\b`, ""}},
+		{`a"b,c`, []string{`a"b`, "c"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SplitArgs(tt.params), tt.params)
+	}
+}
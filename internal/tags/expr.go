@@ -0,0 +1,250 @@
+package tags
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expr is a rule of a tag or a group of alternatives. Rule is the text as
+// written, spaces included, and Offset the index of its first byte in the
+// tag. Any holds the alternatives of a group like "(email|len:0)", each
+// made of rules that must all hold, of which one must.
+type Expr struct {
+	Rule   string
+	Offset int
+	Any    [][]Expr
+}
+
+// GroupError reports a tag whose parentheses do not match, at the index
+// Offset of the tag. It wraps ErrSyntax.
+type GroupError struct {
+	Offset int
+	Reason string
+}
+
+func (e *GroupError) Error() string {
+	return fmt.Sprintf("%v: %s at offset %d", ErrSyntax, e.Reason, e.Offset)
+}
+
+func (e *GroupError) Unwrap() error {
+	return ErrSyntax
+}
+
+// ParseExpr splits tag into the rules that must all hold. Rules are
+// separated by "&", alternatives by "|", which binds less tightly, and
+// parentheses group them:
+//
+//	required&(email|len:0)
+//	min:3&max:5|len:0
+//
+// The second tag holds for values of 3 to 5 bytes or empty ones. A group
+// with a single alternative, like "(min:1&max:2)", adds its rules to the
+// rules around it. The separators in the arguments of a rule are escaped,
+// quoted or enclosed in parentheses of the rule itself, like those of
+// "values:(min:1|max:-1)". The "|" of a regexp belong to its pattern, which
+// runs up to the next "&" or to the parenthesis closing its group.
+func ParseExpr(tag string) ([]Expr, error) {
+	p := exprParser{tag: tag}
+	alternatives, err := p.alternatives()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(tag) {
+		return nil, &GroupError{Offset: p.pos, Reason: "unbalanced parenthesis"}
+	}
+	if len(alternatives) == 1 {
+		return alternatives[0], nil
+	}
+	return []Expr{{Rule: tag, Any: alternatives}}, nil
+}
+
+// maxDepth limits how deeply groups may be nested, so malformed tags cannot
+// exhaust the stack.
+const maxDepth = 16
+
+type exprParser struct {
+	tag string
+	pos int
+	// depth is the number of groups enclosing pos.
+	depth int
+}
+
+// alternatives parses the alternatives at pos, up to the end of the tag or
+// of the enclosing group.
+func (p *exprParser) alternatives() ([][]Expr, error) {
+	var alternatives [][]Expr
+	for {
+		all, err := p.all()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, all)
+		if !p.next('|') {
+			return alternatives, nil
+		}
+	}
+}
+
+// all parses the rules and groups separated by "&" at pos.
+func (p *exprParser) all() ([]Expr, error) {
+	var all []Expr
+	for {
+		p.skipSpaces()
+		if p.pos < len(p.tag) && p.tag[p.pos] == '(' {
+			start := p.pos
+			if p.depth == maxDepth {
+				return nil, &GroupError{Offset: start, Reason: "parentheses nested too deeply"}
+			}
+			p.pos++
+			p.depth++
+			alternatives, err := p.alternatives()
+			if err != nil {
+				return nil, err
+			}
+			if !p.next(')') {
+				return nil, &GroupError{Offset: start, Reason: "unclosed parenthesis"}
+			}
+			p.depth--
+			if len(alternatives) == 1 {
+				all = append(all, alternatives[0]...)
+			} else {
+				all = append(all, Expr{Rule: p.tag[start:p.pos], Offset: start, Any: alternatives})
+			}
+			if p.skipSpaces(); p.pos < len(p.tag) && !strings.ContainsRune("&|)", rune(p.tag[p.pos])) {
+				return nil, &GroupError{Offset: p.pos, Reason: "missing & or | after parenthesis"}
+			}
+		} else {
+			end, err := p.ruleEnd()
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, Expr{Rule: p.tag[p.pos:end], Offset: p.pos})
+			p.pos = end
+		}
+		if !p.next('&') {
+			return all, nil
+		}
+	}
+}
+
+// ruleEnd returns the end of the rule at pos: the first "&" or "|", or the
+// ")" closing the enclosing group, that is neither escaped, quoted nor
+// enclosed in parentheses of the rule itself.
+func (p *exprParser) ruleEnd() (int, error) {
+	s := p.tag
+	if isRegexp(s[p.pos:]) {
+		return p.patternEnd(), nil
+	}
+	quoted, start := false, true
+	depth, open := 0, 0
+	for i := p.pos; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isEscaped(s[i+1]):
+			i++
+			start = false
+		case c == '"' && (quoted || start):
+			quoted, start = !quoted, false
+		case quoted:
+		case c == '(':
+			if depth == 0 {
+				open = i
+			}
+			depth++
+			start = true
+		case c == ')' && depth > 0:
+			depth--
+			start = false
+		case depth == 0 && (c == '&' || c == '|' || c == ')' && p.depth > 0):
+			return i, nil
+		case c == ':' || c == ',' || c == '&' || c == '|':
+			start = true
+		case c != ' ':
+			start = false
+		}
+	}
+	if depth > 0 {
+		return 0, &GroupError{Offset: open, Reason: "unclosed parenthesis"}
+	}
+	return len(s), nil
+}
+
+// patternEnd returns the end of the regexp rule at pos, like ruleEnd but
+// reading its pattern as a regular expression: "|" is part of it, "&" ends
+// it even within parentheses, as it always did, and the parentheses of
+// escapes like `\(` and of character classes like "[(]" do not count.
+func (p *exprParser) patternEnd() int {
+	s := p.tag
+	quoted, start, class := false, true, false
+	depth := 0
+	for i := p.pos; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			start = false
+		case c == '"' && (quoted || start):
+			quoted, start = !quoted, false
+		case quoted:
+		case class:
+			class = c != ']'
+		case c == '&':
+			return i
+		case c == '[':
+			class = true
+			if i+1 < len(s) && s[i+1] == '^' {
+				i++
+			}
+			if i+1 < len(s) && s[i+1] == ']' {
+				i++
+			}
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ')' && p.depth > 0:
+			return i
+		case c == ':' || c == ',':
+			start = true
+		case c != ' ':
+			start = false
+		}
+	}
+	return len(s)
+}
+
+// next skips spaces and the byte c at pos, reporting whether there is one.
+func (p *exprParser) next(c byte) bool {
+	if p.skipSpaces(); p.pos < len(p.tag) && p.tag[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.tag) && p.tag[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// isRegexp reports whether the rule starting s is a regexp, possibly a
+// warning or applied to keys or values.
+func isRegexp(s string) bool {
+	for {
+		// Only the name of the rule is read, up to its colon.
+		i := strings.IndexAny(s, ":&|(")
+		if i < 0 || s[i] != ':' {
+			return false
+		}
+		name, rest := s[:i], s[i+1:]
+		switch strings.TrimSpace(name) {
+		case "warn", "keys", "values":
+			s = rest
+		case "regexp":
+			return true
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tags
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want []Expr
+	}{
+		{"required&min:3", []Expr{{Rule: "required"}, {Rule: "min:3", Offset: 9}}},
+		{"email|len:0", []Expr{{Rule: "email|len:0", Any: [][]Expr{{{Rule: "email"}}, {{Rule: "len:0", Offset: 6}}}}}},
+		{"required&(email | len:0)", []Expr{
+			{Rule: "required"},
+			{Rule: "(email | len:0)", Offset: 9, Any: [][]Expr{{{Rule: "email ", Offset: 10}}, {{Rule: "len:0", Offset: 18}}}},
+		}},
+		{"min:3&max:5|len:0", []Expr{{Rule: "min:3&max:5|len:0", Any: [][]Expr{
+			{{Rule: "min:3"}, {Rule: "max:5", Offset: 6}},
+			{{Rule: "len:0", Offset: 12}},
+		}}}},
+		{"(min:1&max:2)&email", []Expr{{Rule: "min:1", Offset: 1}, {Rule: "max:2", Offset: 7}, {Rule: "email", Offset: 14}}},
+		{"values:(min:1|max:-1)&in:(a),b", []Expr{{Rule: "values:(min:1|max:-1)"}, {Rule: "in:(a),b", Offset: 22}}},
+		{`in:a\|b,"c|d"`, []Expr{{Rule: `in:a\|b,"c|d"`}}},
+		{"regexp:^(a|b)$|x&len:3", []Expr{{Rule: "regexp:^(a|b)$|x"}, {Rule: "len:3", Offset: 17}}},
+		{`(len:0|regexp:[(]\))`, []Expr{{Rule: `(len:0|regexp:[(]\))`, Any: [][]Expr{{{Rule: "len:0", Offset: 1}}, {{Rule: `regexp:[(]\)`, Offset: 7}}}}}},
+		{"in:a)", []Expr{{Rule: "in:a)"}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseExpr(tt.tag)
+		require.NoError(t, err, tt.tag)
+		assert.Equal(t, tt.want, got, tt.tag)
+	}
+
+	for tag, offset := range map[string]int{
+		"(email|len:0":     0,
+		"required&(email":  9,
+		"(email)x":         7,
+		"(email))":         7,
+		"in:(a&min:1":      3,
+		"min:1&(a|(b|c)&d": 6,
+		strings.Repeat("(", 17) + "a" + strings.Repeat(")", 17): 16,
+	} {
+		_, err := ParseExpr(tag)
+		var group *GroupError
+		require.ErrorAs(t, err, &group, tag)
+		assert.Equal(t, offset, group.Offset, tag)
+		assert.ErrorIs(t, err, ErrSyntax, tag)
+	}
+}
+
+func FuzzParseExpr(f *testing.F) {
+	for _, tag := range []string{
+		"required&min:3",
+		"email|len:0",
+		"required&(email|len:0)",
+		"keys:len:2&in:RU,EN,endkeys&values:(min:0|max:-1)",
+		`in:"a|b",c\&d&regexp:^(a|[)])$`,
+		"warn:max:10&if:Kind=='x'&(min:1&max:2|len:0)",
+		"((a))|(",
+	} {
+		f.Add(tag)
+	}
+	f.Fuzz(func(t *testing.T, tag string) {
+		exprs, err := ParseExpr(tag)
+		if err != nil {
+			return
+		}
+		var check func(exprs []Expr)
+		check = func(exprs []Expr) {
+			for _, e := range exprs {
+				if e.Any == nil && tag[e.Offset:e.Offset+len(e.Rule)] != e.Rule {
+					t.Fatalf("rule %q at offset %d of %q", e.Rule, e.Offset, tag)
+				}
+				for _, alternative := range e.Any {
+					check(alternative)
+				}
+			}
+		}
+		check(exprs)
+		_, _ = Parse(tag, nil)
+	})
+}
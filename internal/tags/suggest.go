@@ -0,0 +1,61 @@
+package tags
+
+// Suggest returns the name of names closest to the unknown rule name, for
+// errors to ask "did you mean len?" of "lenght", or "" when none is close.
+// Names are close when a third of the bytes of name at most, and at least
+// one, must be inserted, deleted, replaced or swapped with the next one to
+// spell them, or half of them for names starting with the same byte, as
+// typos rarely hit the first one. Ties go to the first of names.
+func Suggest(name string, names []string) string {
+	best, bestDistance := "", len(name)+1
+	for _, candidate := range names {
+		limit := len(name) / 3
+		if candidate != "" && name != "" && candidate[0] == name[0] {
+			limit = len(name) / 2
+		}
+		if limit < 1 {
+			limit = 1
+		}
+		if d := distance(name, candidate); d <= limit && d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// distance returns the optimal string alignment distance of a and b: the
+// number of bytes inserted, deleted, replaced or swapped with the next one
+// to turn a into b, none of them edited twice.
+func distance(a, b string) int {
+	// rows[i][j] is the distance of a[:i] and b[:j].
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := rows[i-1][j-1] + cost
+			if del := rows[i-1][j] + 1; del < d {
+				d = del
+			}
+			if ins := rows[i][j-1] + 1; ins < d {
+				d = ins
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				if swap := rows[i-2][j-2] + 1; swap < d {
+					d = swap
+				}
+			}
+			rows[i][j] = d
+		}
+	}
+	return rows[len(a)][len(b)]
+}
@@ -0,0 +1,38 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "lenght", want: "len"},
+		{name: "mni", want: "min"},
+		{name: "emial", want: "email"},
+		{name: "requried", want: "required"},
+		{name: "omitemtpy", want: "omitempty"},
+		{name: "strong"},
+		{name: "x"},
+		{name: ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Suggest(tt.name, Builtins()), tt.name)
+	}
+	assert.Equal(t, "ab", Suggest("abc", []string{"ab", "bc"}), "ties go to the first name")
+
+	_, err := Parse("required&emial", nil)
+	assert.EqualError(t, err, `invalid validator syntax: unknown rule "emial", did you mean "email"?`)
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("min", "min"))
+	assert.Equal(t, 1, distance("mni", "min"))
+	assert.Equal(t, 3, distance("lenght", "len"))
+	assert.Equal(t, 3, distance("", "abc"))
+	assert.Equal(t, 3, distance("kitten", "sitting"))
+}
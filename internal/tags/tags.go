@@ -0,0 +1,547 @@
+// Package tags parses validate tags like the validator package does, for
+// tools working on source code like validatorgen and the tagcheck analyzer.
+// It only knows the built-in rules; rules registered at run time are passed
+// in by name.
+//
+// The grammar of tags, with spaces around names ignored:
+//
+//	tag    = all { "|" all } .
+//	all    = term { "&" term } .
+//	term   = rule | "(" tag ")" .
+//	rule   = [ "warn:" ] name [ ":" params ] | keys | values .
+//	keys   = "keys:" rule { "&" rule } ( ",endkeys" | "&endkeys" ) .
+//	values = "values:" rule .
+//	params = arg { "," arg } .
+//	arg    = { char | `\` any } | `"` { char } `"` .
+//
+// where char is any byte but the separators "&", "|" and ",", or but `"`
+// within quotes; see ParseExpr for parentheses in params. Rules like
+// required take no params and reject them, rules like min require them;
+// custom rules take any number, so their params are optional. The params of
+// regexp and default are a single argument, commas included.
+package tags
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrSyntax is returned for rules the validator package would reject with
+// validator.ErrInvalidValidatorSyntax.
+var ErrSyntax = errors.New("invalid validator syntax")
+
+// Rule is a parsed rule of a validate tag. Nums holds the numeric arguments
+// of len, min, max and in, with 0 for an argument of in that is not a number.
+type Rule struct {
+	Name string
+	Args []string
+	Nums []int
+	// Warn is set for rules reporting warnings, like "warn:max:100".
+	Warn bool
+	// Rules are the rules of a keys group or a values rule, applied to the
+	// keys or values of a map, like "keys:len:2,endkeys&values:min:0".
+	Rules []Rule
+	// Refs is set when an argument references a field, like "max:$Limit".
+	// Its number is 0.
+	Refs bool
+	// Any holds the alternatives of a rule named or, parsed from a group
+	// like "(email|len:0)", of which one must hold.
+	Any [][]Rule
+}
+
+// Kind classifies the types of values rules are applied to.
+type Kind int
+
+const (
+	Other Kind = iota
+	String
+	Int
+	Uint
+	Float
+	Bool
+	Map
+)
+
+// noArgs lists the built-in rules written without a colon.
+var noArgs = map[string]bool{
+	"required":      true,
+	"notnil":        true,
+	"omitempty":     true,
+	"structonly":    true,
+	"nostructlevel": true,
+	"astext":        true,
+	"asnum":         true,
+	"sensitive":     true,
+	"bail":          true,
+	"continue":      true,
+
+	"ulid":     true,
+	"objectid": true,
+	"file":     true,
+	"dir":      true,
+	"filepath": true,
+
+	"email":             true,
+	"no_html":           true,
+	"printable_unicode": true,
+
+	"urlencoded": true,
+	"slug":       true,
+	"dns_label":  true,
+
+	"k8s_quantity": true,
+	"k8s_label":    true,
+
+	"hexcolor": true,
+	"rgb":      true,
+	"rgba":     true,
+	"hsl":      true,
+
+	"cron": true,
+
+	"mimetype": true,
+	"charset":  true,
+
+	"card_expiry": true,
+}
+
+// passwordKeys are the requirements of a password rule like
+// "password:min=12,digit=1".
+var passwordKeys = map[string]bool{"min": true, "upper": true, "lower": true, "digit": true, "symbol": true}
+
+// Parse parses the rules of a validate tag. custom reports whether a name
+// that is not built in is a rule registered at run time; it may be nil.
+// Unknown rules are reported with an error wrapping ErrSyntax.
+func Parse(tag string, custom func(name string) bool) ([]Rule, error) {
+	exprs, err := ParseExpr(tag)
+	if err != nil {
+		return nil, err
+	}
+	return parseExprs(exprs, custom)
+}
+
+// parseExprs parses the rules of exprs, which must all hold.
+func parseExprs(exprs []Expr, custom func(name string) bool) ([]Rule, error) {
+	var rules []Rule
+	for i := 0; i < len(exprs); i++ {
+		if exprs[i].Any != nil {
+			r, err := parseAny(exprs[i].Any, custom)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, r)
+			continue
+		}
+
+		get := exprs[i].Rule
+		switch name, params, _ := strings.Cut(get, ":"); strings.TrimSpace(name) {
+		case "keys":
+			conds := []string{params}
+			for _, e := range exprs[i+1:] {
+				if e.Any != nil {
+					break
+				}
+				conds = append(conds, e.Rule)
+			}
+			group, n, err := keysGroup(conds)
+			if err != nil {
+				return nil, err
+			}
+			nested, err := parseEach(group, custom)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, Rule{Name: "keys", Rules: nested})
+			i += n - 1
+			continue
+		case "values":
+			nested, err := parseEach(params, custom)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, Rule{Name: "values", Rules: nested})
+			continue
+		}
+
+		warn := false
+		if name, params, _ := strings.Cut(get, ":"); strings.TrimSpace(name) == "warn" {
+			warn, get = true, params
+		}
+		name, params, found := strings.Cut(get, ":")
+		name = strings.TrimSpace(name)
+		r := Rule{Name: name, Warn: warn}
+		if warn && !canWarn(name) {
+			return nil, fmt.Errorf("%w: rule %s cannot be a warning", ErrSyntax, name)
+		}
+
+		switch {
+		case custom != nil && custom(name):
+			if found {
+				r.Args = SplitArgs(params)
+			}
+		case noArgs[name]:
+			if found {
+				return nil, fmt.Errorf("%w: rule %s takes no arguments", ErrSyntax, name)
+			}
+		case !IsBuiltin(name):
+			if suggestion := Suggest(name, Builtins()); suggestion != "" {
+				return nil, fmt.Errorf("%w: unknown rule %q, did you mean %q?", ErrSyntax, name, suggestion)
+			}
+			return nil, fmt.Errorf("%w: unknown rule %q", ErrSyntax, name)
+		case !found:
+			return nil, fmt.Errorf("%w: rule %s needs arguments", ErrSyntax, name)
+		case name == "default":
+			// The value is checked against the type of the field by the
+			// validator package.
+			r.Args = []string{Unescape(params)}
+		case name == "regexp":
+			r.Args = []string{params}
+			if _, err := regexp.Compile(params); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrSyntax, err)
+			}
+		case name == "groups":
+			r.Args = SplitArgs(params)
+			for i, group := range r.Args {
+				if r.Args[i] = strings.TrimSpace(group); r.Args[i] == "" {
+					return nil, fmt.Errorf("%w: empty group in %q", ErrSyntax, get)
+				}
+			}
+		case name == "in_ci":
+			r.Args = SplitArgs(params)
+			for i, arg := range r.Args {
+				r.Args[i] = strings.TrimSpace(arg)
+			}
+			if params == "" {
+				r.Args = nil
+			}
+		case name == "enum":
+			// Enums are registered at run time, like custom rules.
+			r.Args = []string{strings.TrimSpace(params)}
+			if r.Args[0] == "" {
+				return nil, fmt.Errorf("%w: rule enum needs the name of an enum", ErrSyntax)
+			}
+		case name == "if":
+			r.Args = []string{strings.TrimSpace(params)}
+			if _, err := ParseCondition(params); err != nil {
+				return nil, err
+			}
+		case name == "jsonschema":
+			// Payload types are registered at run time, like enums.
+			r.Args = []string{strings.TrimSpace(params)}
+			if r.Args[0] == "" {
+				return nil, fmt.Errorf("%w: rule jsonschema needs the name of a payload type", ErrSyntax)
+			}
+		case name == "within":
+			r.Args = []string{params}
+			if d, err := time.ParseDuration(strings.TrimSpace(params)); err != nil || d <= 0 {
+				return nil, fmt.Errorf("%w: invalid duration %q", ErrSyntax, params)
+			}
+		case name == "uniquefield" || name == "sumfield":
+			if err := parseAggregate(&r, params); err != nil {
+				return nil, err
+			}
+		case name == "excluded_with" || name == "exactly_one_of":
+			r.Args = SplitArgs(params)
+			for i, arg := range r.Args {
+				if r.Args[i] = strings.TrimSpace(arg); !IsFieldPath(r.Args[i]) {
+					return nil, fmt.Errorf("%w: invalid field %q of rule %s", ErrSyntax, arg, name)
+				}
+			}
+			r.Refs = true
+		case name == "mime" || name == "ext":
+			r.Args = SplitArgs(params)
+		case name == "minage":
+			r.Args = []string{params}
+			num, err := strconv.Atoi(strings.TrimSpace(params))
+			if err != nil || num < 0 {
+				return nil, fmt.Errorf("%w: argument %q of rule minage is not a number of years", ErrSyntax, params)
+			}
+			r.Nums = []int{num}
+		case name == "assert":
+			r.Args = []string{strings.TrimSpace(params)}
+			if _, err := ParseAssertion(params); err != nil {
+				return nil, err
+			}
+			r.Refs = true
+		case name == "checksum":
+			// Algorithms are registered at run time, like enums.
+			r.Args = []string{params}
+			algorithm, sum, ok := strings.Cut(strings.TrimSpace(params), ":")
+			sum = strings.TrimSpace(sum)
+			if ref, isRef := strings.CutPrefix(sum, "$"); isRef && IsFieldPath(ref) {
+				r.Refs = true
+			} else if _, err := hex.DecodeString(sum); err != nil || sum == "" {
+				ok = false
+			}
+			if !ok || strings.TrimSpace(algorithm) == "" {
+				return nil, fmt.Errorf("%w: rule checksum needs an algorithm and a checksum, like crc32:414fa339", ErrSyntax)
+			}
+		case name == "cvv":
+			r.Args = SplitArgs(params)
+			for _, arg := range r.Args {
+				num, err := strconv.Atoi(strings.TrimSpace(arg))
+				if err != nil || num < 1 {
+					return nil, fmt.Errorf("%w: argument %q of rule cvv is not a number of digits", ErrSyntax, arg)
+				}
+				r.Nums = append(r.Nums, num)
+			}
+		case name == "maxsize" || name == "maxbytes":
+			r.Args = []string{params}
+			if !isSize(strings.TrimSpace(params)) {
+				return nil, fmt.Errorf("%w: invalid size %q", ErrSyntax, params)
+			}
+		case name == "password":
+			r.Args = strings.Split(params, ",")
+			if err := checkPassword(strings.TrimSpace(params)); err != nil {
+				return nil, err
+			}
+		default:
+			r.Args = SplitArgs(params)
+			for _, arg := range r.Args {
+				if ref, ok := strings.CutPrefix(strings.TrimSpace(arg), "$"); ok && (name == "len" || name == "min" || name == "max") && IsFieldPath(ref) {
+					r.Refs = true
+					r.Nums = append(r.Nums, 0)
+					continue
+				}
+				num, err := strconv.Atoi(strings.TrimSpace(arg))
+				if err != nil && name != "in" {
+					return nil, fmt.Errorf("%w: argument %q of rule %s is not a number", ErrSyntax, arg, name)
+				}
+				r.Nums = append(r.Nums, num)
+			}
+			if name == "in" && params == "" {
+				r.Args = nil
+			}
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// parseAny parses the alternatives of a group into a rule named or. Like in
+// keys and values, rules controlling how a field is validated and warnings
+// cannot be alternatives.
+func parseAny(alternatives [][]Expr, custom func(name string) bool) (Rule, error) {
+	r := Rule{Name: "or"}
+	for _, alternative := range alternatives {
+		nested, err := parseExprs(alternative, custom)
+		if err != nil {
+			return Rule{}, err
+		}
+		for _, n := range nested {
+			if n.Warn || !canWarn(n.Name) && n.Name != "omitempty" {
+				return Rule{}, fmt.Errorf("%w: rule %s cannot be an alternative", ErrSyntax, n.Name)
+			}
+		}
+		r.Any = append(r.Any, nested)
+	}
+	return r, nil
+}
+
+// keysGroup returns the rules of a keys group, starting with the rule after
+// "keys:" in conds, and the number of elements of conds up to endkeys.
+func keysGroup(conds []string) (group string, n int, err error) {
+	var rules []string
+	for n < len(conds) {
+		cond := strings.TrimSpace(conds[n])
+		n++
+		if cond == "endkeys" {
+			break
+		}
+		if cond, ok := strings.CutSuffix(cond, ",endkeys"); ok {
+			rules = append(rules, cond)
+			break
+		}
+		if n == len(conds) {
+			return "", 0, fmt.Errorf("%w: keys without endkeys", ErrSyntax)
+		}
+		rules = append(rules, cond)
+	}
+	if len(rules) == 0 {
+		return "", 0, fmt.Errorf("%w: keys without rules", ErrSyntax)
+	}
+	return strings.Join(rules, "&"), n, nil
+}
+
+// parseEach parses the rules of a keys group or a values rule.
+func parseEach(tag string, custom func(name string) bool) ([]Rule, error) {
+	rules, err := Parse(tag, custom)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.Warn || !canWarn(r.Name) && r.Name != "omitempty" {
+			return nil, fmt.Errorf("%w: rule %s cannot apply to keys or values", ErrSyntax, r.Name)
+		}
+	}
+	return rules, nil
+}
+
+// canWarn reports whether the rule name can be a warning: rules controlling
+// how a field is validated cannot.
+func canWarn(name string) bool {
+	switch name {
+	case "warn", "omitempty", "groups", "default", "structonly", "nostructlevel", "astext", "asnum", "sensitive", "bail", "continue", "if", "excluded_with", "exactly_one_of", "assert":
+		return false
+	}
+	return true
+}
+
+// parseAggregate parses the arguments of the uniquefield and sumfield rules
+// into r: paths of fields and, for sumfield, a field and min and max bounds
+// of its sum, like "Amount,max:1000".
+func parseAggregate(r *Rule, params string) error {
+	r.Args = SplitArgs(params)
+	for i, arg := range r.Args {
+		r.Args[i] = strings.TrimSpace(arg)
+		if r.Name == "uniquefield" || i == 0 {
+			if !IsFieldPath(r.Args[i]) {
+				return fmt.Errorf("%w: invalid field %q of rule %s", ErrSyntax, arg, r.Name)
+			}
+			continue
+		}
+		bound, value, _ := strings.Cut(r.Args[i], ":")
+		ref, isRef := strings.CutPrefix(strings.TrimSpace(value), "$")
+		_, err := strconv.Atoi(strings.TrimSpace(value))
+		if bound = strings.TrimSpace(bound); bound != "min" && bound != "max" || err != nil && !(isRef && IsFieldPath(ref)) {
+			return fmt.Errorf("%w: invalid bound %q of rule sumfield", ErrSyntax, arg)
+		}
+		r.Refs = r.Refs || isRef
+	}
+	if r.Name == "sumfield" && len(r.Args) < 2 {
+		return fmt.Errorf("%w: rule sumfield needs a field and bounds", ErrSyntax)
+	}
+	return nil
+}
+
+// checkPassword checks the parameters of a password rule: the name of a
+// policy, which is registered at run time, or key=value requirements.
+func checkPassword(params string) error {
+	if !strings.Contains(params, "=") {
+		if params == "" || strings.Contains(params, ",") {
+			return fmt.Errorf("%w: invalid password policy %q", ErrSyntax, params)
+		}
+		return nil
+	}
+
+	for _, arg := range strings.Split(params, ",") {
+		key, value, found := strings.Cut(arg, "=")
+		num, err := strconv.Atoi(strings.TrimSpace(value))
+		if !found || err != nil || num < 0 || !passwordKeys[strings.TrimSpace(key)] {
+			return fmt.Errorf("%w: invalid password requirement %q", ErrSyntax, arg)
+		}
+	}
+	return nil
+}
+
+// isSize reports whether s is the argument of maxsize and maxbytes, a
+// number of bytes with an optional unit like "5MB".
+func isSize(s string) bool {
+	upper := strings.ToUpper(s)
+	for _, unit := range []string{"KB", "MB", "GB", "B"} {
+		if len(upper) > len(unit) && strings.HasSuffix(upper, unit) {
+			s = strings.TrimSpace(s[:len(s)-len(unit)])
+			break
+		}
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0
+}
+
+// ParseIn parses the argument arg of an in rule for a value of kind k: as an
+// int64, a uint64, a float64 or a bool, or as the string itself for other
+// kinds.
+func ParseIn(arg string, k Kind) (any, error) {
+	s := strings.TrimSpace(arg)
+	var value any
+	var err error
+	switch k {
+	case Int:
+		value, err = strconv.ParseInt(s, 10, 64)
+	case Uint:
+		value, err = strconv.ParseUint(s, 10, 64)
+	case Float:
+		value, err = strconv.ParseFloat(s, 64)
+	case Bool:
+		value, err = strconv.ParseBool(s)
+	default:
+		return arg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: argument %q of rule in is not %s", ErrSyntax, s, kindNames[k])
+	}
+	return value, nil
+}
+
+var kindNames = map[Kind]string{Int: "an integer", Uint: "an unsigned integer", Float: "a number", Bool: "a bool"}
+
+// withArgs lists the built-in rules written with a colon and arguments.
+var withArgs = map[string]bool{
+	"len": true, "min": true, "max": true, "in": true, "in_ci": true, "enum": true,
+	"jsonschema": true, "if": true, "within": true, "password": true, "regexp": true,
+	"maxsize": true, "maxbytes": true, "mime": true, "ext": true, "groups": true,
+	"default": true, "keys": true, "values": true, "uniquefield": true, "sumfield": true,
+	"excluded_with": true, "exactly_one_of": true, "cvv": true, "minage": true,
+	"checksum": true, "assert": true,
+}
+
+// IsBuiltin reports whether name is a built-in rule.
+func IsBuiltin(name string) bool {
+	return withArgs[name] || noArgs[name]
+}
+
+// Builtins returns the names of the built-in rules, sorted.
+func Builtins() []string {
+	names := make([]string, 0, len(withArgs)+len(noArgs))
+	for name := range withArgs {
+		names = append(names, name)
+	}
+	for name := range noArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Accepts reports whether a value of kind k can satisfy the built-in rule
+// name at all, e.g. email can never be satisfied by an int.
+func Accepts(name string, k Kind) bool {
+	switch name {
+	case "required", "notnil", "excluded_with", "exactly_one_of", "assert", "omitempty", "structonly", "nostructlevel", "groups", "default", "astext", "asnum", "sensitive", "bail", "continue", "if", "or":
+		return true
+	case "min", "max":
+		return k == String || k == Int || k == Uint || k == Float
+	case "in":
+		return k != Other && k != Map
+	case "enum":
+		return k != Other && k != Map
+	case "jsonschema":
+		// It applies to json.RawMessage too, which is not a basic kind.
+		return k == String || k == Other
+	case "maxsize", "mime", "ext":
+		// They apply to *multipart.FileHeader, which is never a basic kind.
+		return false
+	case "within", "uniquefield", "sumfield":
+		// They apply to time.Time and to slices of structs.
+		return k == Other
+	case "minage":
+		// It applies to time.Time and to date strings.
+		return k == String || k == Other
+	case "maxbytes":
+		// It applies to byte slices, whose elements are uints, and to
+		// structs.
+		return k == String || k == Uint || k == Map || k == Other
+	case "checksum":
+		// It applies to byte slices, whose elements are uints.
+		return k == String || k == Uint
+	case "keys", "values":
+		return k == Map
+	}
+	return k == String
+}
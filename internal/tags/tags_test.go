@@ -0,0 +1,138 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	rules, err := Parse("required&min:2&in:a, b&unique:x", func(name string) bool { return name == "unique" })
+	require.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Name: "required"},
+		{Name: "min", Args: []string{"2"}, Nums: []int{2}},
+		{Name: "in", Args: []string{"a", " b"}, Nums: []int{0, 0}},
+		{Name: "unique", Args: []string{"x"}},
+	}, rules)
+
+	rules, err = Parse("required&groups:create, update", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create", "update"}, rules[1].Args)
+
+	rules, err = Parse("default:a,b&min:1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a,b"}, rules[0].Args)
+
+	rules, err = Parse("required&warn:max:100", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "max", Args: []string{"100"}, Nums: []int{100}, Warn: true}, rules[1])
+
+	rules, err = Parse("required&keys:len:2&in:RU,EN,endkeys&values:min:0", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Name: "required"},
+		{Name: "keys", Rules: []Rule{
+			{Name: "len", Args: []string{"2"}, Nums: []int{2}},
+			{Name: "in", Args: []string{"RU", "EN"}, Nums: []int{0, 0}},
+		}},
+		{Name: "values", Rules: []Rule{{Name: "min", Args: []string{"0"}, Nums: []int{0}}}},
+	}, rules)
+
+	rules, err = Parse("keys:len:2&endkeys&min:1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "min", rules[1].Name)
+
+	rules, err = Parse("in_ci:RU, en", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "in_ci", Args: []string{"RU", "en"}}, rules[0])
+
+	rules, err = Parse(`in:"a,b",c\:d&default:x\&y`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a,b", "c:d"}, rules[0].Args)
+	assert.Equal(t, []string{"x&y"}, rules[1].Args)
+
+	rules, err = Parse("enum: status", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "enum", Args: []string{"status"}}, rules[0])
+
+	rules, err = Parse("jsonschema:user_created", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "jsonschema", Args: []string{"user_created"}}, rules[0])
+
+	rules, err = Parse("max:$Limits.Max&in:$x", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "max", Args: []string{"$Limits.Max"}, Nums: []int{0}, Refs: true}, rules[0])
+	assert.False(t, rules[1].Refs)
+
+	rules, err = Parse("required&(email|len:0&warn:max:3)", nil)
+	require.Error(t, err)
+	rules, err = Parse("required&(email|omitempty&len:2)", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "or", Any: [][]Rule{
+		{{Name: "email"}},
+		{{Name: "omitempty"}, {Name: "len", Args: []string{"2"}, Nums: []int{2}}},
+	}}, rules[1])
+
+	rules, err = Parse("uniquefield:OrderID, Position&sumfield:Amount,max:$Limit", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OrderID", "Position"}, rules[0].Args)
+	assert.Equal(t, Rule{Name: "sumfield", Args: []string{"Amount", "max:$Limit"}, Refs: true}, rules[1])
+
+	rules, err = Parse("exactly_one_of:Card, Bank.IBAN", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "exactly_one_of", Args: []string{"Card", "Bank.IBAN"}, Refs: true}, rules[0])
+
+	rules, err = Parse("card_expiry&cvv:3,4", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "cvv", Args: []string{"3", "4"}, Nums: []int{3, 4}}, rules[1])
+
+	rules, err = Parse("checksum:sha256:$Digest", nil)
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Name: "checksum", Args: []string{"sha256:$Digest"}, Refs: true}, rules[0])
+
+	rules, err = Parse("in:", nil)
+	require.NoError(t, err)
+	assert.Nil(t, rules[0].Args)
+
+	for _, tag := range []string{"min", "min:", "len:x", "email:1", "unknown", "password:", "password:min=x", "password:a,b", "maxsize:5TB", "regexp:[", "groups:", "groups:a,,b", "warn:omitempty", "warn:min", "keys:len:2", "keys:endkeys", "keys:groups:a,endkeys", "values:warn:min:1", "values:email:1", "endkeys", "enum:", "enum", "jsonschema: ", "astext:1", "warn:astext", "sensitive:1", "warn:sensitive", "max:$1", "min:$", "within:", "within:1x", "within:-1h", "uniquefield:", "uniquefield:1x", "sumfield:Amount", "sumfield:Amount,len:1", "sumfield:Amount,max:x", "maxbytes:", "maxbytes:1TB", "excluded_with:", "excluded_with:1x", "warn:exactly_one_of:A", "values:excluded_with:A", "cvv", "cvv:x", "cvv:0", "card_expiry:1", "minage", "minage:x", "minage:-1", "checksum:crc32", "checksum::414f", "checksum:crc32:xyz", "checksum:crc32:$"} {
+		_, err := Parse(tag, nil)
+		assert.ErrorIs(t, err, ErrSyntax, tag)
+	}
+	_, err = Parse("password:strong", nil)
+	assert.NoError(t, err)
+}
+
+func TestAccepts(t *testing.T) {
+	assert.True(t, Accepts("min", Float))
+	assert.True(t, Accepts("required", Other))
+	assert.False(t, Accepts("email", Int))
+	assert.False(t, Accepts("max", Bool))
+	assert.True(t, Accepts("keys", Map))
+	assert.False(t, Accepts("values", String))
+	assert.False(t, Accepts("jsonschema", Int))
+	assert.True(t, Accepts("in", Bool))
+	assert.True(t, Accepts("maxbytes", Uint))
+	assert.False(t, Accepts("maxbytes", Float))
+}
+
+func TestParseIn(t *testing.T) {
+	value, err := ParseIn(" -3", Int)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-3), value)
+	value, err = ParseIn("0.5", Float)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, value)
+	value, err = ParseIn("true", Bool)
+	require.NoError(t, err)
+	assert.Equal(t, true, value)
+	value, err = ParseIn(" a", String)
+	require.NoError(t, err)
+	assert.Equal(t, " a", value)
+
+	_, err = ParseIn("-1", Uint)
+	assert.EqualError(t, err, `invalid validator syntax: argument "-1" of rule in is not an unsigned integer`)
+	_, err = ParseIn("a", Int)
+	assert.ErrorIs(t, err, ErrSyntax)
+}
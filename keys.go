@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// cutKeys cuts the rules of a keys group off a tag. The rules of a map
+// field apply to the map itself, the rules of a keys group to every key of
+// it, and a rule prefixed by values to every value:
+//
+//	Quotas map[string]int `validate:"keys:len:2&in:RU,EN,DE,endkeys&values:min:0"`
+//
+// A group runs from "keys:" to "endkeys", written after its last rule
+// following a comma or as a rule of its own, like "keys:len:2&endkeys".
+// first is the rule after "keys:" and rest the rules after it. cutKeys
+// returns the rules of the group and the number of the rules of rest it
+// takes, all of them when endkeys is missing.
+func cutKeys(first string, rest []tags.Expr) (rules string, n int, err error) {
+	var group []string
+	for cond := first; ; {
+		cond = strings.TrimSpace(cond)
+		if cond == "endkeys" {
+			break
+		}
+		if cond, ok := strings.CutSuffix(cond, ",endkeys"); ok {
+			group = append(group, cond)
+			break
+		}
+		if n == len(rest) || rest[n].Any != nil {
+			return "", len(rest), ErrInvalidValidatorSyntax
+		}
+		group = append(group, cond)
+		cond = rest[n].Rule
+		n++
+	}
+	if len(group) == 0 {
+		return "", n, ErrInvalidValidatorSyntax
+	}
+	return strings.Join(group, "&"), n, nil
+}
+
+// maxNesting limits how deeply keys, values and or rules may be nested in
+// each other, like in "values:values:min:1", so malformed tags can neither
+// exhaust the stack nor take long to parse.
+const maxNesting = 16
+
+// parseEach parses the rules of a keys group or of a values rule into a rule
+// named name. Rules controlling how a field is validated and warnings do not
+// apply to keys and values.
+func parseEach(name, rules string, depth, nesting int) (rule, error) {
+	if nesting == maxNesting {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	each, err := appendValidators(nil, rules, depth, nesting+1)
+	if err != nil {
+		return rule{}, err
+	}
+	for _, validator := range each {
+		switch validator.name {
+		case "groups", "default", "structonly", "nostructlevel", "bail", "continue", "excluded_with", "exactly_one_of", "assert":
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+		if validator.warn {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+	}
+	moveToFront(each, "asnum")
+	moveToFront(each, "astext")
+	return rule{name: name, each: each}, nil
+}
+
+// validateMap applies the rules of the keys or values rule validator to the
+// keys or values of the map m, in the order of the keys.
+func (s *validation) validateMap(validator rule, m reflect.Value) error {
+	if m.Len() == 0 {
+		return nil
+	}
+	for _, key := range sortedKeys(m) {
+		value := key
+		if validator.name == "values" {
+			value = m.MapIndex(key)
+		}
+		if err := s.validateField(validator.each, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
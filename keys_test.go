@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CountryCode string
+
+type keysQuotas struct {
+	Quotas map[CountryCode]int    `validate:"required&keys:len:2&in:RU,EN,DE,endkeys&values:min:0"`
+	Labels map[string][]string    `validate:"keys:min:1&endkeys&values:required&values:max:3"`
+	Nested map[string]map[int]int `validate:"values:keys:min:1,endkeys"`
+}
+
+func TestValidateKeys(t *testing.T) {
+	valid := keysQuotas{
+		Quotas: map[CountryCode]int{"RU": 10, "EN": 0},
+		Labels: map[string][]string{"a": {"x", "yz"}},
+		Nested: map[string]map[int]int{"a": {1: 0}},
+	}
+	require.NoError(t, Validate(valid))
+
+	tests := []struct {
+		name  string
+		value keysQuotas
+		field string
+		rule  string
+	}{
+		{"missing map", keysQuotas{}, "Quotas", "required"},
+		{"key length", keysQuotas{Quotas: map[CountryCode]int{"RUS": 1}}, "Quotas", "len"},
+		{"key not in", keysQuotas{Quotas: map[CountryCode]int{"FR": 1}}, "Quotas", "in"},
+		{"value", keysQuotas{Quotas: map[CountryCode]int{"DE": -1}}, "Quotas", "min"},
+		{"empty key", keysQuotas{Quotas: valid.Quotas, Labels: map[string][]string{"": {"x"}}}, "Labels", "min"},
+		{"empty value", keysQuotas{Quotas: valid.Quotas, Labels: map[string][]string{"a": nil}}, "Labels", "required"},
+		{"value element", keysQuotas{Quotas: valid.Quotas, Labels: map[string][]string{"a": {"long"}}}, "Labels", "max"},
+		{"nested map", keysQuotas{Quotas: valid.Quotas, Nested: map[string]map[int]int{"a": {0: 1}}}, "Nested", "min"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value)
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.field, errs[0].Field())
+			assert.Equal(t, tt.rule, errs[0].Rule())
+		})
+	}
+}
+
+func TestValidateKeysSyntax(t *testing.T) {
+	for _, rules := range []string{"keys:len:2", "keys:endkeys", "keys:groups:a,endkeys", "values", "values:warn:min:1", "values:default:1", "endkeys"} {
+		err := ValidateVar(map[string]int{}, rules)
+		require.Error(t, err, rules)
+		assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+
+	assert.NoError(t, ValidateVar(map[string]int{"ab": 1}, "keys:len:2&endkeys&required"))
+	assert.Error(t, ValidateVar([]int{1}, "values:min:0"))
+
+	err := Lint(reflect.TypeOf(struct {
+		ByID map[int]string `validate:"keys:email,endkeys&values:min:1"`
+		IDs  []int          `validate:"keys:min:1,endkeys"`
+	}{}))
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0].Err, ErrRuleNotApplicable)
+	assert.ErrorContains(t, errs[0].Err, "email on int")
+	assert.ErrorContains(t, errs[1].Err, "keys on []int")
+}
@@ -0,0 +1,198 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var ErrRuleNotApplicable = errors.New("rule does not apply to the field type")
+
+// CheckStruct parses the validate tags of the struct type T and of the
+// structs nested in it, and checks that every rule can apply to the type of
+// its field, so services can fail fast at startup on typo'd tags:
+//
+//	if err := validator.CheckStruct[User](); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Every problem is reported as a ValidationError wrapping
+// ErrInvalidValidatorSyntax, ErrValidateForUnexportedFields or
+// ErrRuleNotApplicable and naming the field, like "User.Age".
+func CheckStruct[T any]() error {
+	return Lint(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// Lint is CheckStruct for a type only known at run time. Pointers to structs
+// are accepted too.
+func Lint(typeV reflect.Type) error {
+	for typeV != nil && typeV.Kind() == reflect.Pointer {
+		typeV = typeV.Elem()
+	}
+	if typeV == nil || typeV.Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+
+	var errs ValidationErrors
+	lintStruct(typeV, map[reflect.Type]bool{}, &errs)
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+func lintStruct(typeV reflect.Type, seen map[reflect.Type]bool, errs *ValidationErrors) {
+	if seen[typeV] {
+		return
+	}
+	seen[typeV] = true
+
+	plan := cachedPlan(typeV)
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		fieldT := typeV.Field(f.index).Type
+		name := typeV.Name() + "." + f.name
+
+		if err := (&config{}).fieldErr(f); err != nil {
+			*errs = append(*errs, ValidationError{fmt.Errorf("%s: %w", name, err)})
+		} else {
+			lintRules(name, fieldT, f.validators, errs)
+		}
+
+		if !f.descend {
+			continue
+		}
+		if f.elems {
+			fieldT = fieldT.Elem()
+		}
+		for fieldT.Kind() == reflect.Pointer {
+			fieldT = fieldT.Elem()
+		}
+		if fieldT.Kind() == reflect.Struct {
+			lintStruct(fieldT, seen, errs)
+		}
+	}
+}
+
+// strictError returns the first error of the rules of the field name of
+// type fieldT that cannot apply to it.
+func strictError(name string, fieldT reflect.Type, validators []rule) error {
+	var errs ValidationErrors
+	lintRules(name, fieldT, validators, &errs)
+	if len(errs) != 0 {
+		return errs[0].Err
+	}
+	return nil
+}
+
+// lintRules checks that validators can apply to the field name of type
+// fieldT, and the rules of keys and values rules to the keys and values of
+// the map.
+func lintRules(name string, fieldT reflect.Type, validators []rule, errs *ValidationErrors) {
+	kind, ok := ruleKind(fieldT)
+	if asText(validators) {
+		// The rules apply to the textual form.
+		if !hasText(derefType(fieldT)) && !hasText(ruleType(fieldT)) {
+			*errs = append(*errs, ValidationError{fmt.Errorf("%s: %w", name, errNoText(fieldT))})
+			return
+		}
+		kind, ok = reflect.String, true
+	}
+	if !ok {
+		return
+	}
+	if asNumber(validators) {
+		// The rules apply to the number the text holds.
+		if kind != reflect.String {
+			*errs = append(*errs, ValidationError{fmt.Errorf("%s: %w: asnum on %s", name, ErrRuleNotApplicable, fieldT)})
+			return
+		}
+		kind = reflect.Float64
+	}
+	for _, validator := range validators {
+		switch {
+		case !validator.appliesTo(kind):
+			*errs = append(*errs, ValidationError{fmt.Errorf("%s: %w: %s on %s", name, ErrRuleNotApplicable, validator.name, fieldT)})
+		case validator.name == "keys":
+			lintRules(name, ruleType(fieldT).Key(), validator.each, errs)
+		case validator.name == "values":
+			lintRules(name, ruleType(fieldT).Elem(), validator.each, errs)
+		case validator.name == "or":
+			for _, alternative := range validator.alternatives {
+				lintRules(name, fieldT, alternative, errs)
+			}
+		}
+	}
+}
+
+// ruleKind returns the kind of the values the rules of a field of type t are
+// applied to: the elements of a slice, the values pointers point to. ok is
+// false when the kind is only known at run time, for interfaces, registered
+// custom types and driver.Valuer and Opener implementations. Registered numeric types
+// are checked like floats.
+func ruleKind(t reflect.Type) (kind reflect.Kind, ok bool) {
+	t = ruleType(t)
+	if _, number := registered(numberTypes, t); number {
+		return reflect.Float64, true
+	}
+	if _, custom := registered(customTypes, t); custom || t.Kind() == reflect.Interface {
+		return reflect.Invalid, false
+	}
+	if t.Implements(valuerType) || reflect.PointerTo(t).Implements(valuerType) || t.Implements(openerType) {
+		return reflect.Invalid, false
+	}
+	return t.Kind(), true
+}
+
+// ruleType returns the type of the values the rules of a field of type t
+// are applied to.
+func ruleType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+	return t
+}
+
+// appliesTo reports whether a value of the given kind can satisfy the rule
+// at all. Custom rules decide for themselves.
+func (v rule) appliesTo(kind reflect.Kind) bool {
+	if v.custom != nil || v.batch != nil {
+		return true
+	}
+
+	switch v.name {
+	case "required", "notnil", "excluded_with", "exactly_one_of", "assert", "omitempty", "structonly", "nostructlevel", "groups", "default", "astext", "asnum", "sensitive", "bail", "continue", "if", "or":
+		return true
+	case "min", "max", "in":
+		switch kind {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			return true
+		case reflect.Bool:
+			return v.name == "in"
+		}
+		return false
+	case "maxsize", "mime", "ext", "within", "uniquefield", "sumfield":
+		return kind == reflect.Struct
+	case "minage":
+		return kind == reflect.String || kind == reflect.Struct
+	case "keys", "values":
+		return kind == reflect.Map
+	case "jsonschema", "checksum":
+		return kind == reflect.String || kind == reflect.Slice
+	case "maxbytes":
+		// Byte slices are checked as slices of uint8.
+		return kind == reflect.String || kind == reflect.Uint8 || kind == reflect.Struct || kind == reflect.Map || kind == reflect.Array
+	case "enum":
+		return len(v.enum) == 0 || kindClass(v.enum[0].Kind()) == kindClass(kind)
+	}
+	return kind == reflect.String
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lintAddress struct {
+	Zip  int    `validate:"len:5"`
+	City string `validate:"min"`
+}
+
+type lintUser struct {
+	Name    string         `validate:"required&min:2"`
+	Age     *int           `validate:"min:18&email"`
+	Tags    []string       `validate:"in:a,b"`
+	Flags   []bool         `validate:"max:1"`
+	Note    sql.NullString `validate:"max:10"`
+	Shape   Shape          `validate:"required&min:1"`
+	secret  string         `validate:"len:5"`
+	Home    lintAddress
+	Offices []*lintAddress
+}
+
+func TestCheckStruct(t *testing.T) {
+	assert.NoError(t, CheckStruct[Address]())
+	assert.ErrorIs(t, CheckStruct[int](), ErrNotStruct)
+	assert.ErrorIs(t, Lint(nil), ErrNotStruct)
+
+	err := CheckStruct[lintUser]()
+	require.Error(t, err)
+	assert.Equal(t, err, Lint(reflect.TypeOf(&lintUser{})))
+
+	errs := err.(ValidationErrors)
+	require.Len(t, errs, 5)
+	assert.EqualError(t, errs[0].Err, "lintUser.Age: rule does not apply to the field type: email on *int")
+	assert.ErrorIs(t, errs[0].Err, ErrRuleNotApplicable)
+	assert.EqualError(t, errs[1].Err, "lintUser.Flags: rule does not apply to the field type: max on []bool")
+	assert.ErrorIs(t, errs[2].Err, ErrValidateForUnexportedFields)
+	assert.EqualError(t, errs[3].Err, "lintAddress.Zip: rule does not apply to the field type: len on int")
+	assert.EqualError(t, errs[4].Err, `lintAddress.City: invalid validator syntax: rule "min" at offset 0 of field City`)
+	assert.ErrorIs(t, errs[4].Err, ErrInvalidValidatorSyntax)
+}
+
+func TestWithStrictRules(t *testing.T) {
+	type account struct {
+		Age   int            `validate:"omitempty&email"`
+		Name  string         `validate:"required&mni:3"`
+		Login string         `validate:"required&min:3"`
+		Tags  map[string]int `validate:"keys:len:2,endkeys&values:email"`
+	}
+	v := account{Name: "Ann", Login: "x", Tags: map[string]int{"ru": 1}}
+
+	// Unknown rules are syntax errors with or without strict rules.
+	const unknown = `invalid validator syntax: rule "mni:3" at offset 9 of field Name: unknown rule, did you mean "min"?`
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(v), &errs)
+	require.Len(t, errs, 3)
+	assert.EqualError(t, errs[0].Err, unknown)
+	assert.Equal(t, CodeSyntax, errs[0].Code())
+	assert.Equal(t, []string{"Login", "Tags"}, fieldsOf(errs[1:]))
+
+	require.ErrorAs(t, Validate(v, WithStrictRules()), &errs)
+	require.Len(t, errs, 4)
+	assert.EqualError(t, errs[0].Err, "account.Age: rule does not apply to the field type: email on int")
+	assert.Equal(t, CodeNotApplicable, errs[0].Code())
+	assert.EqualError(t, errs[1].Err, unknown)
+	assert.Equal(t, "Login", errs[2].Field())
+	assert.ErrorIs(t, errs[3].Err, ErrRuleNotApplicable)
+
+	_, err := Compile[account](WithStrictRules())
+	require.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 3)
+	_, err = Compile[account]()
+	require.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,46 @@
+//go:build go1.21
+
+package validator
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+)
+
+// WithLogger logs the errors of the validation to logger, one record per
+// error with the attributes type, field, rule, code and error:
+//
+//	v := validator.New(validator.WithLogger(slog.Default(), slog.LevelInfo))
+//
+// Values that are not valid are logged at level, errors of the rules, like
+// tags with invalid syntax, and errors stopping the validation, like a
+// recovered panic, at slog.LevelError. Combined with WithRedactedErrors
+// the records tell no values. It requires Go 1.21.
+func WithLogger(logger *slog.Logger, level slog.Level) Option {
+	return func(c *config) {
+		c.logErrors = func(ctx context.Context, t reflect.Type, errs ValidationErrors, err error) {
+			typ := ""
+			if t != nil {
+				typ = t.String()
+			}
+			for _, e := range errs {
+				errLevel := level
+				if e.IsConfig() {
+					errLevel = slog.LevelError
+				}
+				logger.LogAttrs(ctx, errLevel, "validation failed",
+					slog.String("type", typ),
+					slog.String("field", e.Field()),
+					slog.String("rule", e.Rule()),
+					slog.String("code", e.Code()),
+					slog.String("error", e.Err.Error()))
+			}
+			if err != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "validation stopped",
+					slog.String("type", typ),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+}
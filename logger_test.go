@@ -0,0 +1,48 @@
+//go:build go1.21
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+
+	type signup struct {
+		Name  string `validate:"required"`
+		Email string `validate:"min:x"`
+	}
+	assert.NoError(t, Validate(Base{ID: 1, Name: "ok"}, WithLogger(logger, slog.LevelWarn)))
+	assert.Empty(t, buf.String())
+
+	assert.Error(t, Validate(signup{}, WithLogger(logger, slog.LevelWarn)))
+	assert.Equal(t, []string{
+		`level=WARN msg="validation failed" type=validator.signup field=Name rule=required code=VAL_REQUIRED error="field: Name not valid for required"`,
+		`level=ERROR msg="validation failed" type=validator.signup field="" rule="" code=VAL_SYNTAX error="invalid validator syntax: rule \"min:x\" at offset 0 of field Email"`,
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+
+	buf.Reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, ValidateCtx(ctx, signup{}, WithLogger(logger, slog.LevelInfo)))
+	assert.Equal(t, "level=ERROR msg=\"validation stopped\" type=validator.signup error=\"context canceled\"\n", buf.String())
+
+	buf.Reset()
+	assert.Error(t, New(WithLogger(logger, slog.LevelInfo)).ValidateVar("", "required"))
+	assert.Equal(t, "level=INFO msg=\"validation failed\" type=string field=\"\" rule=required code=VAL_REQUIRED error=\"value not valid for required\"\n", buf.String())
+}
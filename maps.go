@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidateMap validates the values of a loosely typed payload, like a JSON
+// object decoded into a map[string]any, against rules by key written in
+// the syntax of the validate tag:
+//
+//	err := validator.ValidateMap(payload, map[string]string{
+//		"event":      "required&in:created,deleted",
+//		"user.email": "required&email",
+//		"user.age":   "omitempty&min:18",
+//	})
+//
+// Dotted keys address the values of nested maps. A missing value is empty,
+// so it fails required and skips the rules after omitempty. Numbers decoded
+// by encoding/json are float64 and checked like any other number. Errors are
+// reported for the keys, in the order of the keys.
+func ValidateMap(data map[string]any, rules map[string]string) error {
+	keys := make([]string, 0, len(rules))
+	for key := range rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	s := validation{ctx: context.Background()}
+	for _, key := range keys {
+		validators, err := cachedValidators(rules[key])
+		if err != nil {
+			s.errors = append(s.errors, ValidationError{err})
+			continue
+		}
+		s.checkVar(key, rules[key], validators, reflect.ValueOf(data), reflect.ValueOf(lookupKey(data, key)))
+	}
+	return s.result()
+}
+
+// lookupKey returns the value of data at key, which is either a key of data
+// or a dotted path of keys of nested maps, or nil when there is none.
+func lookupKey(data map[string]any, key string) any {
+	if v, ok := data[key]; ok {
+		return v
+	}
+	for {
+		name, rest, found := strings.Cut(key, ".")
+		v, ok := data[name]
+		if !ok || !found {
+			return v
+		}
+		if data, ok = v.(map[string]any); !ok {
+			return nil
+		}
+		key = rest
+	}
+}
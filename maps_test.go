@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMap(t *testing.T) {
+	rules := map[string]string{
+		"event":      "required&in:created,deleted",
+		"user.email": "required&email",
+		"user.age":   "omitempty&min:18",
+		"tags":       "max:8",
+		"source":     "omitempty&len:3",
+	}
+
+	var valid map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"event": "created",
+		"user": {"email": "ann@example.com", "age": 30},
+		"tags": ["go", "json"]
+	}`), &valid))
+	assert.NoError(t, ValidateMap(valid, rules))
+
+	var invalid map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"event": "updated",
+		"user": {"age": 17},
+		"tags": ["a-very-long-tag"],
+		"source": "api"
+	}`), &invalid))
+	var errs ValidationErrors
+	require.ErrorAs(t, ValidateMap(invalid, rules), &errs)
+	assert.Equal(t, []string{"event", "tags", "user.age", "user.email"}, fieldsOf(errs))
+	assert.Equal(t, "required", errs[3].Rule())
+
+	require.ErrorAs(t, ValidateMap(map[string]any{"user": "ann"}, map[string]string{"user.email": "required"}), &errs)
+	assert.Equal(t, []string{"user.email"}, fieldsOf(errs))
+
+	assert.NoError(t, ValidateMap(map[string]any{"a.b": 5}, map[string]string{"a.b": "min:1"}))
+	assert.NoError(t, ValidateMap(nil, nil))
+
+	require.ErrorAs(t, ValidateMap(nil, map[string]string{"a": "max:x"}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrTooLarge stops the validation of a value holding more bytes than
+// WithMaxBytes allows.
+var ErrTooLarge = errors.New("value too large")
+
+// WithMaxBytes stops the validation with an error wrapping ErrTooLarge when
+// the validated value holds more than n bytes of strings and byte slices,
+// before any rule runs, so absurdly large inputs are rejected without
+// spending time on them. The fields of a single value are limited by the
+// maxbytes rule:
+//
+//	Comment string   `validate:"maxbytes:4KB"`
+//	Items   []Item   `validate:"maxbytes:1MB"`
+//
+// Bytes are counted like by maxbytes, n = 0 for no limit.
+func WithMaxBytes(n int) Option {
+	return func(c *config) {
+		c.maxBytes = n
+	}
+}
+
+// validateMaxBytes applies the rule maxbytes:N, which limits the bytes of
+// the strings and byte slices the field holds, in its fields, elements, keys
+// and values, and behind pointers and interfaces. Bytes are counted whatever
+// they encode, so a string of N runes may not fit in maxbytes:N, unlike in
+// max:N. The argument takes the units of maxsize, like "64KB".
+func validateMaxBytes(field reflect.Value, limit int) error {
+	if byteSize(field, limit) > limit {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// byteSize returns the number of bytes of the strings and byte slices v
+// holds, counting no further once there are more than limit. Values
+// referenced more than once are counted once, so do values referencing
+// themselves.
+func byteSize(v reflect.Value, limit int) int {
+	c := byteCounter{limit: limit}
+	if v.Kind() == reflect.Struct && v.CanAddr() {
+		// Pointers it holds may reference the struct itself.
+		c.first(v.Addr())
+	}
+	c.add(v)
+	return c.n
+}
+
+type byteCounter struct {
+	limit int
+	n     int
+	// seen holds the pointers, maps and slices already counted.
+	seen map[visit]bool
+}
+
+func (c *byteCounter) add(v reflect.Value) {
+	if c.n > c.limit {
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		c.n += v.Len()
+	case reflect.Pointer:
+		if !v.IsNil() && c.first(v) {
+			c.add(v.Elem())
+		}
+	case reflect.Map:
+		if v.IsNil() || !c.first(v) {
+			return
+		}
+		for iter := v.MapRange(); iter.Next() && c.n <= c.limit; {
+			c.add(iter.Key())
+			c.add(iter.Value())
+		}
+	case reflect.Slice:
+		// Slices of bytes and numbers cannot reference themselves.
+		if plain(v.Type().Elem().Kind()) || c.first(v) {
+			c.addElems(v)
+		}
+	case reflect.Array:
+		c.addElems(v)
+	case reflect.Struct:
+		for i := 0; i < v.NumField() && c.n <= c.limit; i++ {
+			c.add(v.Field(i))
+		}
+	case reflect.Interface:
+		c.add(v.Elem())
+	}
+}
+
+// addElems counts the bytes of the elements of the slice or array v.
+func (c *byteCounter) addElems(v reflect.Value) {
+	switch kind := v.Type().Elem().Kind(); {
+	case kind == reflect.Uint8:
+		c.n += v.Len()
+	case plain(kind):
+		// Numbers hold no bytes of text.
+	default:
+		for i := 0; i < v.Len() && c.n <= c.limit; i++ {
+			c.add(v.Index(i))
+		}
+	}
+}
+
+// plain reports whether kind is the kind of booleans and numbers.
+func plain(kind reflect.Kind) bool {
+	return kind >= reflect.Bool && kind <= reflect.Complex128
+}
+
+// first reports whether the pointer, map or slice v is counted for the first
+// time, and records it.
+func (c *byteCounter) first(v reflect.Value) bool {
+	key := visit{addr: v.Pointer(), typ: v.Type()}
+	if c.seen[key] {
+		return false
+	}
+	if c.seen == nil {
+		c.seen = map[visit]bool{}
+	}
+	c.seen[key] = true
+	return true
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMaxBytes(t *testing.T) {
+	type item struct {
+		Name string
+		Tags []string
+		Qty  int
+	}
+	type request struct {
+		Name    string            `validate:"maxbytes:4"`
+		Data    []byte            `validate:"maxbytes:1KB"`
+		Items   []item            `validate:"maxbytes:10"`
+		Labels  map[string]string `validate:"maxbytes:6"`
+		Item    *item             `validate:"maxbytes:5"`
+		Comment string            `validate:"warn:maxbytes:3"`
+	}
+
+	valid := request{
+		Name:   "ёж",
+		Data:   make([]byte, 1024),
+		Items:  []item{{Name: "ab", Tags: []string{"cd"}, Qty: 1 << 20}, {Name: "efgh"}},
+		Labels: map[string]string{"ab": "cd", "e": "f"},
+		Item:   &item{Name: "abc", Tags: []string{"d", "e"}},
+	}
+	result := ValidateResult(valid)
+	require.NoError(t, result.Err())
+	assert.Empty(t, result.Warnings())
+
+	tests := []struct {
+		name   string
+		change func(*request)
+	}{
+		{"runes", func(r *request) { r.Name = "ёжи" }},
+		{"bytes", func(r *request) { r.Data = append(r.Data, 0) }},
+		{"slice of structs", func(r *request) { r.Items = append(r.Items, item{Name: "ijk"}) }},
+		{"map keys", func(r *request) { r.Labels = map[string]string{"abcdefg": ""} }},
+		{"pointer", func(r *request) { r.Item = &item{Name: "abc", Tags: []string{"def"}} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.change(&r)
+			var errs ValidationErrors
+			require.ErrorAs(t, Validate(r), &errs)
+			require.Len(t, errs, 1)
+			assert.Equal(t, "maxbytes", errs[0].Rule())
+		})
+	}
+
+	valid.Comment = "abcd"
+	result = ValidateResult(valid)
+	require.NoError(t, result.Err())
+	assert.Equal(t, []string{"Comment"}, fieldsOf(result.Warnings()))
+
+	type node struct {
+		Name string
+		Next *node
+		Any  any
+	}
+	cycle := &node{Name: "ab"}
+	cycle.Next, cycle.Any = cycle, []any{cycle, map[string]any{"c": "d"}}
+	assert.NoError(t, ValidateVar(cycle, "maxbytes:4"))
+	assert.Error(t, ValidateVar(cycle, "maxbytes:3"))
+	assert.NoError(t, ValidateVar([2]string{"a", "b"}, "maxbytes:2"))
+
+	for _, rules := range []string{"maxbytes", "maxbytes:", "maxbytes:-1", "maxbytes:1TB"} {
+		var errs ValidationErrors
+		require.ErrorAs(t, ValidateVar("", rules), &errs, rules)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Lint(reflect.TypeOf(struct {
+		Age int `validate:"maxbytes:1"`
+	}{})), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrRuleNotApplicable)
+
+	explained, err := ExplainRules(struct {
+		Body string `validate:"maxbytes:2KB"`
+	}{}, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "Body — at most 2048 bytes in total\n", explained)
+}
+
+func TestWithMaxBytes(t *testing.T) {
+	type payload struct {
+		Name  string `validate:"required"`
+		Notes []string
+	}
+	assert.NoError(t, Validate(payload{Name: "a", Notes: []string{"bc"}}, WithMaxBytes(3)))
+
+	err := Validate([]payload{{Name: strings.Repeat("a", 100)}}, WithMaxBytes(99))
+	assert.ErrorIs(t, err, ErrTooLarge)
+	assert.EqualError(t, err, "value too large: more than 99 bytes")
+	dataErr, configErr := SplitErrors(err)
+	assert.Equal(t, err, dataErr)
+	assert.NoError(t, configErr)
+
+	users, err := Compile[payload](WithMaxBytes(1))
+	require.NoError(t, err)
+	assert.ErrorIs(t, users.Validate(payload{Name: "ab"}), ErrTooLarge)
+	assert.NoError(t, Validate(payload{Name: strings.Repeat("a", 100)}, WithMaxBytes(0)))
+}
@@ -0,0 +1,79 @@
+package validator
+
+// FailedField describes a field that is not valid to the functions building
+// error messages, see RegisterErrorMessage.
+type FailedField struct {
+	// Field is the path of the field, e.g. "Address.Zip", or "" for a value
+	// validated by ValidateVar.
+	Field string
+	// Rules are the rules of the field as written in the tag and Rule the
+	// name of the rule that failed.
+	Rules string
+	Rule  string
+	// Params are the parameters of the rule that failed, e.g. ["3"] for
+	// "min:3".
+	Params []string
+	// Err is the error returned by a custom rule, nil for built-in rules.
+	Err error
+}
+
+var errorMessages = map[string]func(FailedField) string{}
+
+// RegisterErrorMessage makes fn build the messages of the errors of the rule
+// name, instead of "field: Name not valid for min:3":
+//
+//	validator.RegisterErrorMessage("min", func(e validator.FailedField) string {
+//		if e.Params[0] == "1" {
+//			return e.Field + " must have at least 1 character"
+//		}
+//		return e.Field + " must have at least " + e.Params[0] + " characters"
+//	})
+//
+// Errors keep their fields, rules and codes. It is meant to be called during
+// program initialization, like RegisterRule.
+func RegisterErrorMessage(name string, fn func(FailedField) string) error {
+	return register("RegisterErrorMessage", func() {
+		errorMessages[name] = fn
+	})
+}
+
+// message returns the registered message of e, if any.
+func (e *fieldError) message() (string, bool) {
+	fn, ok := registered(errorMessages, e.rule)
+	if !ok {
+		return "", false
+	}
+	fe := FailedField{Field: e.field, Rules: e.cond, Rule: e.rule}
+	if e.err != ErrFieldNotValid {
+		fe.Err = e.err
+	}
+	if validators, err := cachedValidators(e.cond); err == nil {
+		fe.Params = failedParams(validators, e.rule)
+	}
+	return fn(fe), true
+}
+
+// failedParams returns the parameters of the rule name in validators,
+// preferring rules that are not warnings and looking into the rules of keys
+// and values too.
+func failedParams(validators []rule, name string) []string {
+	var warning []string
+	for _, validator := range validators {
+		switch {
+		case validator.name != name:
+		case !validator.warn:
+			return validator.argsStr
+		case warning == nil:
+			warning = validator.argsStr
+		}
+	}
+	if warning != nil {
+		return warning
+	}
+	for _, validator := range validators {
+		if params := failedParams(validator.each, name); params != nil {
+			return params
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterErrorMessage(t *testing.T) {
+	var got []FailedField
+	RegisterErrorMessage("min", func(e FailedField) string {
+		got = append(got, e)
+		if e.Params[0] == "1" {
+			return e.Field + " must have at least 1 character"
+		}
+		return e.Field + " must have at least " + e.Params[0] + " characters"
+	})
+	errTaken := errors.New("taken")
+	RegisterRule("messagefree", func(ctx context.Context, field reflect.Value, params []string) error {
+		return errTaken
+	})
+	RegisterErrorMessage("messagefree", func(e FailedField) string {
+		return e.Field + " is " + e.Err.Error()
+	})
+	t.Cleanup(func() {
+		delete(errorMessages, "min")
+		delete(errorMessages, "messagefree")
+		delete(customRules, "messagefree")
+		resetPlanCache()
+	})
+
+	type user struct {
+		Name  string            `validate:"required&min:3"`
+		Code  string            `validate:"min:1"`
+		Login string            `validate:"messagefree"`
+		Tags  map[string]string `validate:"keys:min:2,endkeys"`
+		Email string            `validate:"email"`
+	}
+	err := Validate(user{Name: "An", Tags: map[string]string{"x": ""}})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"Name must have at least 3 characters",
+		"Code must have at least 1 character",
+		"Login is taken",
+		"Tags must have at least 2 characters",
+		"field: Email not valid for email",
+	}, messages)
+	assert.Equal(t, FailedField{Field: "Name", Rules: "required&min:3", Rule: "min", Params: []string{"3"}}, got[0])
+	assert.Equal(t, "VAL_MIN", errs[0].Code())
+
+	assert.True(t, strings.HasPrefix(ValidateVar("", "min:2").Error(), " must have at least 2"))
+}
@@ -0,0 +1,141 @@
+// Package metrics collects metrics of validations through validator.Hooks
+// and exposes them in the Prometheus text format, without depending on a
+// Prometheus client:
+//
+//	collector := metrics.NewCollector()
+//	v := validator.New(validator.WithHooks(collector))
+//	http.Handle("/metrics", collector)
+//
+// The exported metrics are
+//
+//	validator_validations_total{type="..."}
+//	validator_validation_errors_total{type="..."}
+//	validator_validation_duration_seconds_sum{type="..."}
+//	validator_validation_duration_seconds_count{type="..."}
+//	validator_rule_failures_total{field="...",rule="..."}
+//
+// The durations are a summary without quantiles, so their mean over time is
+// the rate of the sum divided by the rate of the count. Field paths are
+// reported with the indexes of slices and maps replaced by "[]", so the
+// number of series does not grow with the data.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collector counts validations and rule failures. It implements
+// validator.Hooks and http.Handler.
+type Collector struct {
+	mu       sync.Mutex
+	types    map[string]*typeStats
+	failures map[failure]uint64
+}
+
+type typeStats struct {
+	validations, errors uint64
+	duration            time.Duration
+}
+
+type failure struct {
+	field, rule string
+}
+
+// NewCollector returns a collector without metrics.
+func NewCollector() *Collector {
+	return &Collector{types: map[string]*typeStats{}, failures: map[failure]uint64{}}
+}
+
+// OnValidate counts a validation of a value of type t.
+func (c *Collector) OnValidate(t reflect.Type, d time.Duration, errCount int) {
+	name := ""
+	if t != nil {
+		name = t.String()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats, ok := c.types[name]
+	if !ok {
+		stats = &typeStats{}
+		c.types[name] = stats
+	}
+	stats.validations++
+	stats.errors += uint64(errCount)
+	stats.duration += d
+}
+
+var indexes = regexp.MustCompile(`\[[^\]]*\]`)
+
+// OnRuleFail counts a failure of rule for field.
+func (c *Collector) OnRuleFail(field, rule string) {
+	f := failure{field: indexes.ReplaceAllString(field, "[]"), rule: rule}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures[f]++
+}
+
+// WriteTo writes the metrics to w in the Prometheus text format, sorted by
+// their labels.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+
+	c.mu.Lock()
+	names := make([]string, 0, len(c.types))
+	for name := range c.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	failures := make([]failure, 0, len(c.failures))
+	for f := range c.failures {
+		failures = append(failures, f)
+	}
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].field != failures[j].field {
+			return failures[i].field < failures[j].field
+		}
+		return failures[i].rule < failures[j].rule
+	})
+
+	sb.WriteString("# TYPE validator_validations_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "validator_validations_total{type=%s} %d\n", label(name), c.types[name].validations)
+	}
+	sb.WriteString("# TYPE validator_validation_errors_total counter\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "validator_validation_errors_total{type=%s} %d\n", label(name), c.types[name].errors)
+	}
+	sb.WriteString("# TYPE validator_validation_duration_seconds summary\n")
+	for _, name := range names {
+		fmt.Fprintf(&sb, "validator_validation_duration_seconds_sum{type=%s} %g\n", label(name), c.types[name].duration.Seconds())
+		fmt.Fprintf(&sb, "validator_validation_duration_seconds_count{type=%s} %d\n", label(name), c.types[name].validations)
+	}
+	sb.WriteString("# TYPE validator_rule_failures_total counter\n")
+	for _, f := range failures {
+		fmt.Fprintf(&sb, "validator_rule_failures_total{field=%s,rule=%s} %d\n", label(f.field), label(f.rule), c.failures[f])
+	}
+	c.mu.Unlock()
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// ServeHTTP answers with the metrics, so the collector can be scraped.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	c.WriteTo(w)
+}
+
+// label quotes a label value, escaping backslashes, quotes and newlines.
+func label(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
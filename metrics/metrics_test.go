@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+type user struct {
+	Name  string `validate:"required"`
+	Items []item
+}
+
+type item struct {
+	SKU string `validate:"len:4"`
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	c.OnValidate(reflect.TypeOf(user{}), 2*time.Second, 3)
+	c.OnValidate(reflect.TypeOf(user{}), time.Second, 0)
+	c.OnValidate(nil, 500*time.Millisecond, 1)
+	c.OnRuleFail("Items[1].SKU", "len")
+	c.OnRuleFail("Items[10].SKU", "len")
+	c.OnRuleFail(`Tags[a"b]`, `in`)
+	c.OnRuleFail("Name", "required")
+
+	var sb strings.Builder
+	_, err := c.WriteTo(&sb)
+	require.NoError(t, err)
+	assert.Equal(t, `# TYPE validator_validations_total counter
+validator_validations_total{type=""} 1
+validator_validations_total{type="metrics.user"} 2
+# TYPE validator_validation_errors_total counter
+validator_validation_errors_total{type=""} 1
+validator_validation_errors_total{type="metrics.user"} 3
+# TYPE validator_validation_duration_seconds summary
+validator_validation_duration_seconds_sum{type=""} 0.5
+validator_validation_duration_seconds_count{type=""} 1
+validator_validation_duration_seconds_sum{type="metrics.user"} 3
+validator_validation_duration_seconds_count{type="metrics.user"} 2
+# TYPE validator_rule_failures_total counter
+validator_rule_failures_total{field="Items[].SKU",rule="len"} 2
+validator_rule_failures_total{field="Name",rule="required"} 1
+validator_rule_failures_total{field="Tags[]",rule="in"} 1
+`, sb.String())
+}
+
+func TestCollectorHooks(t *testing.T) {
+	c := NewCollector()
+	v := validator.New(validator.WithHooks(c))
+	assert.Error(t, v.Validate(user{Items: []item{{SKU: "1"}}}))
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, `validator_validations_total{type="metrics.user"} 1`)
+	assert.Contains(t, body, `validator_validation_errors_total{type="metrics.user"} 2`)
+	assert.Contains(t, body, `validator_validation_duration_seconds_sum{type="metrics.user"} `)
+	assert.Contains(t, body, `validator_validation_duration_seconds_count{type="metrics.user"} 1`)
+	assert.Contains(t, body, `validator_rule_failures_total{field="Items[].SKU",rule="len"} 1`)
+	assert.Contains(t, body, `validator_rule_failures_total{field="Name",rule="required"} 1`)
+}
@@ -0,0 +1,374 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// modifier is a parsed rule of a mod tag.
+type modifier struct {
+	name string
+	// n is the argument of truncate, value the parsed argument of default.
+	n     int
+	value reflect.Value
+}
+
+// modPlan is the parsed form of the mod tags of a struct type.
+type modPlan struct {
+	fields []modField
+}
+
+type modField struct {
+	index     int
+	name      string
+	anonymous bool
+	mods      []modifier
+	err       error
+	// descend is set when the field may hold structs with mod tags of their
+	// own, elems when they are the elements of a slice or an array.
+	descend bool
+	elems   bool
+}
+
+// modCache maps a struct type to its *modPlan.
+var modCache sync.Map
+
+func cachedModPlan(typeV reflect.Type) *modPlan {
+	if plan, ok := modCache.Load(typeV); ok {
+		return plan.(*modPlan)
+	}
+	plan, _ := modCache.LoadOrStore(typeV, newModPlan(typeV))
+	return plan.(*modPlan)
+}
+
+func newModPlan(typeV reflect.Type) *modPlan {
+	plan := &modPlan{}
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+		f := modField{index: i, name: fieldT.Name, anonymous: fieldT.Anonymous}
+
+		if tag := fieldT.Tag.Get("mod"); tag != "" {
+			if !fieldT.IsExported() {
+				f.err = ErrValidateForUnexportedFields
+			} else {
+				f.mods, f.err = parseModifiers(tag, fieldT.Type)
+			}
+			if f.err != nil {
+				f.err = fmt.Errorf("field %s: %w", fieldT.Name, f.err)
+			}
+		}
+
+		t := fieldT.Type
+		f.elems = t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+		if f.elems {
+			t = t.Elem()
+		}
+		f.descend = (fieldT.IsExported() || fieldT.Anonymous) && mayHoldStruct(t)
+		if f.mods != nil || f.err != nil || f.descend {
+			plan.fields = append(plan.fields, f)
+		}
+	}
+	return plan
+}
+
+// parseModifiers parses a mod tag like "trim&lower&default:guest" for a
+// field of type t, written in the syntax of SetTagSyntax, e.g.
+// "trim,lower,default=guest" in PlaygroundSyntax. String modifiers also
+// apply to the elements of string slices and to the values of string
+// pointers.
+func parseModifiers(tag string, t reflect.Type) ([]modifier, error) {
+	t = derefType(t)
+	elemT := t
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		elemT = t.Elem()
+	}
+
+	syntax := currentSyntax()
+	var mods []modifier
+	for _, get := range strings.Split(tag, syntax.RuleSeparator) {
+		name, params, found := strings.Cut(get, syntax.Assign)
+		name = strings.TrimSpace(name)
+		m := modifier{name: name}
+
+		switch name {
+		case "trim", "lower", "upper", "squish":
+			if found {
+				return nil, fmt.Errorf("%w: modifier %s takes no arguments", ErrInvalidValidatorSyntax, name)
+			}
+			if elemT.Kind() != reflect.String {
+				return nil, fmt.Errorf("%w: modifier %s on %s", ErrRuleNotApplicable, name, t)
+			}
+		case "truncate":
+			n, err := strconv.Atoi(strings.TrimSpace(params))
+			if !found || err != nil || n < 0 {
+				return nil, fmt.Errorf("%w: invalid length %q of modifier truncate", ErrInvalidValidatorSyntax, params)
+			}
+			if elemT.Kind() != reflect.String {
+				return nil, fmt.Errorf("%w: modifier %s on %s", ErrRuleNotApplicable, name, t)
+			}
+			m.n = n
+		case "default":
+			if !found {
+				return nil, fmt.Errorf("%w: modifier default needs a value", ErrInvalidValidatorSyntax)
+			}
+			value, err := parseDefault(params, t)
+			if err != nil {
+				return nil, err
+			}
+			m.value = value
+		default:
+			return nil, fmt.Errorf("%w: unknown modifier %q", ErrInvalidValidatorSyntax, name)
+		}
+		mods = append(mods, m)
+	}
+	return mods, nil
+}
+
+// parseDefault parses the argument of a default modifier as a value of t.
+func parseDefault(s string, t reflect.Type) (reflect.Value, error) {
+	value, ok, err := parseLiteral(s, t)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: modifier default on %s", ErrRuleNotApplicable, t)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: invalid default %q for %s", ErrInvalidValidatorSyntax, s, t)
+	}
+	return value, nil
+}
+
+// parseLiteral parses s as a value of t, a string, bool or number type. ok is
+// false for other types.
+func parseLiteral(s string, t reflect.Type) (value reflect.Value, ok bool, err error) {
+	value = reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		value.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, t.Bits())
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, t.Bits())
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(s, t.Bits())
+		value.SetFloat(f)
+	default:
+		return reflect.Value{}, false, nil
+	}
+	return value, true, err
+}
+
+// Modify normalizes the fields of the struct v points to as their mod tags
+// say, before validation:
+//
+//	type User struct {
+//		Email string `mod:"trim&lower" validate:"email"`
+//		Name  string `mod:"squish&truncate:50"`
+//		Role  string `mod:"default:user" validate:"in:user,admin"`
+//	}
+//
+// The modifiers run in order: trim removes leading and trailing white
+// space, lower and upper change the case, squish also collapses inner white
+// space to single spaces, truncate:N cuts strings to N characters and
+// default:x sets a zero value to x, and a nil pointer to a new x. Fields
+// of nested structs and of the elements of slices and arrays are modified
+// too; map values are not, as they cannot be changed in place.
+//
+// Modify returns ErrNotStruct when v is not a non-nil pointer to a struct,
+// and an error wrapping ErrInvalidValidatorSyntax, ErrRuleNotApplicable or
+// ErrValidateForUnexportedFields for a mod tag that cannot be applied.
+func Modify(v any) error {
+	valueV := reflect.ValueOf(v)
+	if valueV.Kind() != reflect.Pointer || valueV.IsNil() || valueV.Elem().Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	return modifyStruct(valueV.Elem(), "", nil)
+}
+
+// ModifyAndValidate normalizes the struct v points to like Modify and
+// validates it.
+func ModifyAndValidate(v any, opts ...Option) error {
+	if err := Modify(v); err != nil {
+		return err
+	}
+	return Validate(reflect.ValueOf(v).Elem().Interface(), opts...)
+}
+
+// modifyStruct applies the mod tags of the struct valueV found at path,
+// appending the changes they make to changes unless it is nil.
+func modifyStruct(valueV reflect.Value, path string, changes *[]FieldChange) error {
+	var errs []error
+	for _, f := range cachedModPlan(valueV.Type()).fields {
+		if f.err != nil {
+			errs = append(errs, f.err)
+			continue
+		}
+		fieldV := valueV.Field(f.index)
+		name := path
+		if !f.anonymous {
+			name = joinPath(path, f.name)
+		}
+		if f.mods != nil {
+			applyModifiers(f.mods, fieldV, name, changes)
+		}
+		if !f.descend {
+			continue
+		}
+		if !f.elems {
+			fieldV = nestedModStruct(fieldV)
+			if fieldV.IsValid() {
+				errs = append(errs, modifyStruct(fieldV, name, changes))
+			}
+			continue
+		}
+		for i := 0; i < fieldV.Len(); i++ {
+			if elem := nestedModStruct(fieldV.Index(i)); elem.IsValid() {
+				errs = append(errs, modifyStruct(elem, indexPath(name, i), changes))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// nestedModStruct returns the settable struct held by field, if any.
+func nestedModStruct(field reflect.Value) reflect.Value {
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return reflect.Value{}
+		}
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Struct || !field.CanSet() {
+		return reflect.Value{}
+	}
+	return field
+}
+
+// applyModifiers applies mods to field, the field at path, appending the
+// changes they make to changes unless it is nil.
+func applyModifiers(mods []modifier, field reflect.Value, path string, changes *[]FieldChange) {
+	for _, m := range mods {
+		if m.name == "default" {
+			if changes != nil && unset(field) {
+				old := changedValue(field)
+				setDefault(field, m.value)
+				*changes = append(*changes, FieldChange{Field: path, Modifier: m.name, Old: old, New: changedValue(field)})
+				continue
+			}
+			setDefault(field, m.value)
+			continue
+		}
+
+		value := field
+		for value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				break
+			}
+			value = value.Elem()
+		}
+		switch value.Kind() {
+		case reflect.String:
+			modifyValue(m, value, path, changes)
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				modifyValue(m, value.Index(i), indexPath(path, i), changes)
+			}
+		}
+	}
+}
+
+// modifyValue applies the string modifier m to value, the string at path,
+// appending the change to changes unless it is nil.
+func modifyValue(m modifier, value reflect.Value, path string, changes *[]FieldChange) {
+	old := value.String()
+	modified := modifyString(m, old)
+	if modified == old {
+		return
+	}
+	value.SetString(modified)
+	if changes != nil {
+		*changes = append(*changes, FieldChange{Field: path, Modifier: m.name, Old: old, New: modified})
+	}
+}
+
+// withDefault returns field with the default value set when it is zero.
+// Fields that cannot be set, e.g. of structs not passed by pointer, are
+// validated as if they held the default value.
+func withDefault(field, value reflect.Value) reflect.Value {
+	if !unset(field) {
+		return field
+	}
+	if !field.CanSet() {
+		field = reflect.New(field.Type()).Elem()
+	}
+	setDefault(field, value)
+	return field
+}
+
+// unset reports whether field takes a default value: it is a nil pointer or
+// the zero value.
+func unset(field reflect.Value) bool {
+	if field.Kind() == reflect.Pointer {
+		return field.IsNil()
+	}
+	return field.IsZero()
+}
+
+// derefType returns the type t points to, through any number of pointers.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// setDefault sets field to value when it is zero. Pointers are set to a
+// new value when nil, as a pointer to zero is an explicit value.
+func setDefault(field, value reflect.Value) {
+	if field.Kind() != reflect.Pointer {
+		if field.IsZero() {
+			field.Set(value)
+		}
+		return
+	}
+	for ; field.Kind() == reflect.Pointer; field = field.Elem() {
+		if !field.IsNil() {
+			return
+		}
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	field.Set(value)
+}
+
+func modifyString(m modifier, s string) string {
+	switch m.name {
+	case "trim":
+		return strings.TrimSpace(s)
+	case "lower":
+		return strings.ToLower(s)
+	case "upper":
+		return strings.ToUpper(s)
+	case "squish":
+		return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+	case "truncate":
+		for i := range s {
+			if m.n == 0 {
+				return s[:i]
+			}
+			m.n--
+		}
+	}
+	return s
+}
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type modAddress struct {
+	City string `mod:"squish&upper"`
+}
+
+type modUser struct {
+	Email    string   `mod:"trim&lower" validate:"email"`
+	Name     string   `mod:"squish&truncate:5"`
+	Role     string   `mod:"default:user" validate:"in:user,admin"`
+	Nick     *string  `mod:"trim&default:anon"`
+	Limit    *int     `mod:"default:10"`
+	Retries  int      `mod:"default:3"`
+	Tags     []string `mod:"trim&lower"`
+	Home     modAddress
+	Work     *modAddress
+	Previous []modAddress
+}
+
+func TestModify(t *testing.T) {
+	nick, zero := "  neo ", 0
+	u := modUser{
+		Email:    "  Alice@Example.COM ",
+		Name:     "  Ann \t  Marie  Smith ",
+		Tags:     []string{" Go ", "RUST"},
+		Home:     modAddress{City: " new   york "},
+		Work:     &modAddress{City: "paris"},
+		Previous: []modAddress{{City: "oslo"}},
+		Nick:     &nick,
+	}
+	require.NoError(t, Modify(&u))
+
+	assert.Equal(t, "alice@example.com", u.Email)
+	assert.Equal(t, "Ann M", u.Name)
+	assert.Equal(t, "user", u.Role)
+	assert.Equal(t, "neo", *u.Nick)
+	assert.Equal(t, 10, *u.Limit)
+	assert.Equal(t, 3, u.Retries)
+	assert.Equal(t, []string{"go", "rust"}, u.Tags)
+	assert.Equal(t, "NEW YORK", u.Home.City)
+	assert.Equal(t, "PARIS", u.Work.City)
+	assert.Equal(t, "OSLO", u.Previous[0].City)
+
+	u = modUser{Role: "admin", Limit: &zero}
+	require.NoError(t, Modify(&u))
+	assert.Equal(t, "admin", u.Role)
+	assert.Equal(t, 0, *u.Limit)
+	assert.Equal(t, "anon", *u.Nick)
+}
+
+func TestModifyAndValidate(t *testing.T) {
+	u := modUser{Email: " BOB@EXAMPLE.COM"}
+	assert.NoError(t, ModifyAndValidate(&u))
+
+	u = modUser{Email: "bob", Role: "root"}
+	err := ModifyAndValidate(&u)
+	require.Error(t, err)
+	assert.Len(t, err.(ValidationErrors), 2)
+
+	assert.ErrorIs(t, ModifyAndValidate(u), ErrNotStruct)
+}
+
+func TestModifyTagSyntax(t *testing.T) {
+	require.NoError(t, SetTagSyntax(PlaygroundSyntax))
+	t.Cleanup(func() { require.NoError(t, SetTagSyntax(DefaultSyntax)) })
+
+	type account struct {
+		Email string `mod:"trim,lower"`
+		Role  string `mod:"trim,default=guest"`
+	}
+	a := account{Email: " Bob@Example.COM "}
+	require.NoError(t, Modify(&a))
+	assert.Equal(t, "bob@example.com", a.Email)
+	assert.Equal(t, "guest", a.Role)
+
+	u := modUser{}
+	assert.ErrorIs(t, Modify(&u), ErrInvalidValidatorSyntax)
+}
+
+func TestModifyErrors(t *testing.T) {
+	var nilUser *modUser
+	assert.ErrorIs(t, Modify(modUser{}), ErrNotStruct)
+	assert.ErrorIs(t, Modify(nilUser), ErrNotStruct)
+
+	tests := []struct {
+		name string
+		v    any
+		want error
+	}{
+		{name: "unknown", v: &struct {
+			A string `mod:"reverse"`
+		}{}, want: ErrInvalidValidatorSyntax},
+		{name: "truncate", v: &struct {
+			A string `mod:"truncate:x"`
+		}{}, want: ErrInvalidValidatorSyntax},
+		{name: "arguments", v: &struct {
+			A string `mod:"trim:1"`
+		}{}, want: ErrInvalidValidatorSyntax},
+		{name: "default", v: &struct {
+			A int `mod:"default:x"`
+		}{}, want: ErrInvalidValidatorSyntax},
+		{name: "not a string", v: &struct {
+			A int `mod:"trim"`
+		}{}, want: ErrRuleNotApplicable},
+		{name: "default slice", v: &struct {
+			A []int `mod:"default:1"`
+		}{}, want: ErrRuleNotApplicable},
+		{name: "unexported", v: &struct {
+			a string `mod:"trim"`
+		}{}, want: ErrValidateForUnexportedFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, Modify(tt.v), tt.want)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"mime"
+	"mime/multipart"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+// sizeUnits are the units accepted by maxsize, in powers of 1024.
+var sizeUnits = []struct {
+	suffix string
+	size   int
+}{
+	{"KB", 1 << 10},
+	{"MB", 1 << 20},
+	{"GB", 1 << 30},
+	{"B", 1},
+}
+
+// parseSize parses the argument of maxsize: a number of bytes with an
+// optional unit, e.g. "512", "100KB" or "5MB".
+func parseSize(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	unit := 1
+	for _, u := range sizeUnits {
+		if len(s) > len(u.suffix) && strings.EqualFold(s[len(s)-len(u.suffix):], u.suffix) {
+			s, unit = strings.TrimSpace(s[:len(s)-len(u.suffix)]), u.size
+			break
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > int(^uint(0)>>1)/unit {
+		return 0, ErrInvalidValidatorSyntax
+	}
+	return n * unit, nil
+}
+
+// validateUpload applies the upload rules to a file of a multipart form:
+// maxsize limits its size, mime its declared content type and ext the
+// extension of its file name. The content type is the one sent by the
+// client; type wildcards like "image/*" are accepted.
+func validateUpload(validator rule, field reflect.Value) error {
+	if !field.IsValid() || field.Type() != fileHeaderType || !field.CanInterface() {
+		return ErrFieldNotValid
+	}
+	fh := field.Interface().(multipart.FileHeader)
+
+	switch validator.name {
+	case "maxsize":
+		if fh.Size <= int64(validator.argsInt[0]) {
+			return nil
+		}
+	case "mime":
+		mediaType, _, err := mime.ParseMediaType(fh.Header.Get("Content-Type"))
+		if err != nil {
+			return ErrFieldNotValid
+		}
+		for _, arg := range validator.argsStr {
+			arg = strings.ToLower(strings.TrimSpace(arg))
+			if strings.EqualFold(arg, mediaType) || strings.HasSuffix(arg, "/*") && strings.HasPrefix(mediaType, arg[:len(arg)-1]) {
+				return nil
+			}
+		}
+	case "ext":
+		ext := filepath.Ext(fh.Filename)
+		for _, arg := range validator.argsStr {
+			if ext != "" && strings.EqualFold(strings.TrimSpace(arg), ext) {
+				return nil
+			}
+		}
+	}
+	return ErrFieldNotValid
+}
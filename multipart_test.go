@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upload(name, contentType string, size int64) *multipart.FileHeader {
+	return &multipart.FileHeader{
+		Filename: name,
+		Header:   textproto.MIMEHeader{"Content-Type": {contentType}},
+		Size:     size,
+	}
+}
+
+func TestValidateUpload(t *testing.T) {
+	type form struct {
+		Avatar  *multipart.FileHeader   `validate:"required&maxsize:1MB&mime:image/png,image/jpeg&ext:.png,.jpg"`
+		Scans   []*multipart.FileHeader `validate:"omitempty&mime:image/*,application/pdf"`
+		Archive multipart.FileHeader    `validate:"maxsize:100"`
+	}
+
+	valid := form{
+		Avatar: upload("me.PNG", "image/png", 1<<20),
+		Scans:  []*multipart.FileHeader{upload("a.pdf", "application/pdf", 10), upload("b.tiff", "image/tiff; x=y", 10)},
+	}
+	assert.NoError(t, Validate(valid))
+	assert.NoError(t, CheckStruct[form]())
+
+	tests := []struct {
+		name string
+		f    func(f *form)
+	}{
+		{name: "missing", f: func(f *form) { f.Avatar = nil }},
+		{name: "too large", f: func(f *form) { f.Avatar.Size = 1<<20 + 1 }},
+		{name: "type", f: func(f *form) { f.Avatar.Header.Set("Content-Type", "image/gif") }},
+		{name: "no type", f: func(f *form) { f.Avatar.Header.Del("Content-Type") }},
+		{name: "extension", f: func(f *form) { f.Avatar.Filename = "me.gif" }},
+		{name: "no extension", f: func(f *form) { f.Avatar.Filename = "me" }},
+		{name: "scan", f: func(f *form) { f.Scans = append(f.Scans, upload("c.txt", "text/plain", 1)) }},
+		{name: "archive", f: func(f *form) { f.Archive.Size = 101 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := form{Avatar: upload("me.png", "image/png", 10)}
+			tt.f(&f)
+			err := Validate(f)
+			require.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), 1)
+		})
+	}
+
+	assert.Error(t, ValidateVar("me.png", "ext:.png"), "only for uploads")
+	for _, size := range []string{"", "MB", "-1", "1TB", "1.5MB"} {
+		assert.Error(t, ValidateVar(upload("a", "b", 0), "maxsize:"+size), size)
+	}
+	assert.NoError(t, ValidateVar(upload("a", "b", 2048), "maxsize: 2 kb"))
+	assert.NoError(t, ValidateVar(upload("a.png", "Image/PNG", 1), "mime:Image/*"))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(struct {
+		F *multipart.FileHeader `validate:"maxsize:1"`
+		A any                   `validate:"maxsize:0"`
+	}{}), &errs)
+	assert.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+	require.ErrorAs(t, ValidateVar(nil, "mime:image/png"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+}
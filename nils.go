@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isNil reports whether the field raw is nil, for the notnil rule: a nil
+// pointer, map, slice, channel or func, or an interface holding nil or a nil
+// value. Empty maps and slices are not nil.
+func isNil(raw reflect.Value) bool {
+	for raw.Kind() == reflect.Interface && !raw.IsNil() {
+		raw = raw.Elem()
+	}
+	switch raw.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return raw.IsNil()
+	}
+	return false
+}
+
+// nilOnly reports whether values of kind k, channels and funcs, only support
+// the rules checking whether they are nil.
+func nilOnly(k reflect.Kind) bool {
+	return k == reflect.Chan || k == reflect.Func
+}
+
+// notNilCheck returns the first of validators that does not check whether a
+// value is nil nor controls how it is validated, "" when there is none.
+// Custom rules decide for themselves.
+func notNilCheck(validators []rule) string {
+	for _, validator := range validators {
+		if validator.custom != nil || validator.batch != nil {
+			continue
+		}
+		switch validator.name {
+		case "required", "notnil", "assert", "omitempty", "structonly", "nostructlevel", "groups", "sensitive", "bail", "continue", "if":
+		case "or":
+			for _, alternative := range validator.alternatives {
+				if name := notNilCheck(alternative); name != "" {
+					return name
+				}
+			}
+		default:
+			return validator.name
+		}
+	}
+	return ""
+}
+
+// nilOnlyError returns an error when validators, the rules of the field name
+// of type fieldT, apply other rules than nil checks to channels or funcs,
+// which would otherwise fail for every value.
+func nilOnlyError(fieldT reflect.Type, name string, validators []rule) error {
+	if !nilOnly(ruleType(fieldT).Kind()) {
+		return nil
+	}
+	if rule := notNilCheck(validators); rule != "" {
+		return fmt.Errorf("%w: %s on field %s of type %s, only required and notnil apply", ErrRuleNotApplicable, rule, name, fieldT)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNotNil(t *testing.T) {
+	type handlers struct {
+		Done    chan struct{}     `validate:"required"`
+		OnError func(error)       `validate:"notnil"`
+		Hooks   []func()          `validate:"notnil"`
+		Labels  map[string]string `validate:"notnil"`
+		Next    *handlers         `validate:"notnil"`
+		Any     any               `validate:"notnil"`
+		Quit    chan bool         `validate:"omitempty"`
+	}
+
+	valid := handlers{
+		Done:    make(chan struct{}),
+		OnError: func(error) {},
+		Hooks:   []func(){},
+		Labels:  map[string]string{},
+		Next:    &handlers{},
+		Any:     0,
+	}
+	var errs ValidationErrors
+	require.True(t, errors.As(Validate(valid), &errs))
+	assert.Equal(t, []string{"Next.Done", "Next.OnError", "Next.Hooks", "Next.Labels", "Next.Next", "Next.Any"}, fieldsOf(errs))
+	for _, e := range errs {
+		assert.ErrorIs(t, e.Err, ErrFieldNotValid)
+	}
+
+	valid.Next = nil
+	require.True(t, errors.As(Validate(valid), &errs))
+	assert.Equal(t, []string{"Next"}, fieldsOf(errs))
+	assert.Equal(t, "notnil", errs[0].Rule())
+
+	valid.Any = (*int)(nil)
+	require.True(t, errors.As(Validate(valid), &errs))
+	assert.Equal(t, []string{"Next", "Any"}, fieldsOf(errs))
+
+	assert.NoError(t, ValidateVar(func() {}, "required&notnil"))
+	assert.Error(t, ValidateVar((func())(nil), "notnil"))
+	assert.NoError(t, ValidateVar([]int{}, "notnil&min:1"))
+	assert.Error(t, ValidateVar([]int(nil), "notnil"))
+}
+
+func TestNilOnlyRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		err   string
+	}{
+		{"chan", struct {
+			C chan int `validate:"required&min:1"`
+		}{}, "rule does not apply to the field type: min on field C of type chan int, only required and notnil apply"},
+		{"func", struct {
+			F func() `validate:"omitempty&email"`
+		}{}, "rule does not apply to the field type: email on field F of type func(), only required and notnil apply"},
+		{"slice", struct {
+			F []func() `validate:"notnil&len:2"`
+		}{}, "rule does not apply to the field type: len on field F of type []func(), only required and notnil apply"},
+		{"alternatives", struct {
+			C *chan int `validate:"(required|max:2)"`
+		}{}, "rule does not apply to the field type: max on field C of type *chan int, only required and notnil apply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationErrors
+			require.True(t, errors.As(Validate(tt.value), &errs))
+			require.Len(t, errs, 1)
+			assert.ErrorIs(t, errs[0].Err, ErrRuleNotApplicable)
+			assert.EqualError(t, errs[0].Err, tt.err)
+			_, config := SplitErrors(errs)
+			assert.Error(t, config)
+		})
+	}
+
+	err := ValidateVar(make(chan int), "min:1")
+	var errs ValidationErrors
+	require.True(t, errors.As(err, &errs))
+	assert.ErrorIs(t, errs[0].Err, ErrRuleNotApplicable)
+	assert.Equal(t, "min", errs[0].Rule())
+	_, config := SplitErrors(err)
+	assert.Error(t, config)
+
+	var anyF any = func() {}
+	assert.Error(t, ValidateVar(anyF, "max:3"))
+	assert.NoError(t, ValidateVar(anyF, "required"))
+}
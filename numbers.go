@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"math/big"
+	"reflect"
+)
+
+// NumberFunc compares field, a value of a numeric type without a numeric
+// kind, with num, the argument of a min, max or in rule. It returns -1, 0 or
+// +1 when field is less than, equal to or greater than num.
+type NumberFunc func(field reflect.Value, num int) int
+
+// numberTypes holds the comparators of numeric types, keyed by the type of
+// the values, not of pointers to them. math/big types are supported out of
+// the box.
+var numberTypes = map[reflect.Type]NumberFunc{
+	reflect.TypeOf(big.Int{}):   compareBigInt,
+	reflect.TypeOf(big.Float{}): compareBigFloat,
+	reflect.TypeOf(big.Rat{}):   compareBigRat,
+}
+
+// RegisterNumberType registers fn as the comparator of each of types, so
+// min, max and in work on numbers that must not be rounded to float64, like
+// decimal types of financial code:
+//
+//	validator.RegisterNumberType(func(field reflect.Value, num int) int {
+//		return field.Interface().(decimal.Decimal).Cmp(decimal.NewFromInt(int64(num)))
+//	}, decimal.Decimal{})
+//
+//	type Payment struct {
+//		Amount decimal.Decimal `validate:"min:1&max:10000"`
+//	}
+//
+// Pointers to the types are compared through the values they point to.
+// math/big.Int, big.Float and big.Rat are registered already. It is meant
+// to be called during program initialization.
+func RegisterNumberType(fn NumberFunc, types ...any) error {
+	return register("RegisterNumberType", func() {
+		for _, t := range types {
+			numberTypes[reflect.TypeOf(t)] = fn
+		}
+	})
+}
+
+func compareBigInt(field reflect.Value, num int) int {
+	x := bigValue[big.Int](field)
+	if !x.IsInt64() {
+		return x.Sign()
+	}
+	return compare(x.Int64(), int64(num))
+}
+
+func compareBigFloat(field reflect.Value, num int) int {
+	f, acc := bigValue[big.Float](field).Float64()
+	if c := compare(f, float64(num)); c != 0 {
+		return c
+	}
+	// f is x rounded, so x lies on the side of num it was rounded from.
+	switch acc {
+	case big.Below:
+		return 1
+	case big.Above:
+		return -1
+	}
+	return 0
+}
+
+func compareBigRat(field reflect.Value, num int) int {
+	return bigValue[big.Rat](field).Cmp(new(big.Rat).SetInt64(int64(num)))
+}
+
+// bigValue returns a pointer to the math/big value field, as their methods
+// have pointer receivers.
+func bigValue[T any](field reflect.Value) *T {
+	if field.CanAddr() {
+		return field.Addr().Interface().(*T)
+	}
+	v := field.Interface().(T)
+	return &v
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Decimal struct {
+	units int64
+	exp   int
+}
+
+func TestValidateBigNumbers(t *testing.T) {
+	huge, _ := new(big.Int).SetString("1000000000000000000000000", 10)
+	above, _ := new(big.Float).SetPrec(200).SetString("5.000000000000000000000001")
+	below, _ := new(big.Float).SetPrec(200).SetString("4.999999999999999999999999")
+
+	type payment struct {
+		Amount  *big.Int   `validate:"min:1&max:100"`
+		Fee     big.Int    `validate:"in:0,5"`
+		Rate    *big.Float `validate:"max:5"`
+		Share   *big.Rat   `validate:"min:0&max:1"`
+		Missing *big.Int   `validate:"omitempty&min:1"`
+	}
+
+	tests := []struct {
+		name   string
+		v      payment
+		fields []string
+	}{
+		{
+			name: "valid",
+			v:    payment{Amount: big.NewInt(100), Fee: *big.NewInt(5), Rate: big.NewFloat(5), Share: big.NewRat(1, 3)},
+		},
+		{
+			name:   "out of range",
+			v:      payment{Amount: huge, Fee: *big.NewInt(1), Rate: above, Share: big.NewRat(4, 3)},
+			fields: []string{"Amount", "Fee", "Rate", "Share"},
+		},
+		{
+			name:   "below the limits",
+			v:      payment{Amount: new(big.Int).Neg(huge), Rate: below, Share: big.NewRat(-1, 3)},
+			fields: []string{"Amount", "Share"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.True(t, errors.As(err, &errs), err)
+			assert.Equal(t, tt.fields, fieldsOf(errs))
+		})
+	}
+
+	assert.NoError(t, ValidateVar(*big.NewInt(3), "min:3"))
+	assert.Error(t, ValidateVar(big.NewFloat(2.5), "min:3"))
+}
+
+func TestRegisterNumberType(t *testing.T) {
+	decimalType := reflect.TypeOf(Decimal{})
+	RegisterNumberType(func(field reflect.Value, num int) int {
+		d := field.Interface().(Decimal)
+		x := new(big.Rat).SetInt64(d.units)
+		x.Quo(x, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.exp)), nil)))
+		return x.Cmp(new(big.Rat).SetInt64(int64(num)))
+	}, Decimal{})
+	t.Cleanup(func() { delete(numberTypes, decimalType) })
+
+	type order struct {
+		Total Decimal `validate:"min:1&max:10"`
+	}
+	assert.NoError(t, Validate(order{Total: Decimal{units: 1000, exp: 3}}))
+	assert.Error(t, Validate(order{Total: Decimal{units: 999, exp: 3}}))
+	assert.Error(t, Validate(order{Total: Decimal{units: 10001, exp: 3}}))
+
+	assert.NoError(t, Lint(reflect.TypeOf(order{})))
+	assert.Error(t, Lint(reflect.TypeOf(struct {
+		Total Decimal `validate:"email"`
+	}{})))
+}
@@ -0,0 +1,46 @@
+package validator
+
+import "reflect"
+
+// Opener is implemented by optional wrapper types, like Option[T] or
+// Maybe[T] of functional-style libraries, whose fields are validated through
+// the value they hold:
+//
+//	type Option[T any] struct {
+//		value T
+//		ok    bool
+//	}
+//
+//	func (o Option[T]) ValidateValue() (any, bool) { return o.value, o.ok }
+//
+//	type Profile struct {
+//		Nickname Option[string] `validate:"required&min:3"`
+//		Address  Option[Address]
+//	}
+//
+// An absent value is an empty one, so it fails required and skips the rules
+// after omitempty, and a present one is set like a pointer that is not nil,
+// even when it is a zero value. The rules apply to the value held, and the
+// fields of a struct held are validated like those of a nested struct.
+type Opener interface {
+	// ValidateValue returns the value held and whether one is present.
+	ValidateValue() (value any, present bool)
+}
+
+var openerType = reflect.TypeOf((*Opener)(nil)).Elem()
+
+// open returns the value held by field when its type implements Opener, and
+// whether field is one. The value is invalid when none is present.
+func open(field reflect.Value) (held reflect.Value, present, ok bool) {
+	if !field.Type().Implements(openerType) || !field.CanInterface() {
+		return reflect.Value{}, false, false
+	}
+	if (field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface) && field.IsNil() {
+		return reflect.Value{}, false, true
+	}
+	v, present := field.Interface().(Opener).ValidateValue()
+	if !present {
+		return reflect.Value{}, false, true
+	}
+	return reflect.ValueOf(v), true, true
+}
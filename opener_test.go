@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type option[T any] struct {
+	value T
+	ok    bool
+}
+
+func some[T any](v T) option[T] { return option[T]{value: v, ok: true} }
+
+func (o option[T]) ValidateValue() (any, bool) { return o.value, o.ok }
+
+type openerAddress struct {
+	Zip string `validate:"len:5"`
+}
+
+type openerProfile struct {
+	Nickname option[string]        `validate:"required&min:3"`
+	Age      option[int]           `validate:"omitempty&min:18"`
+	Score    option[int]           `validate:"required"`
+	Tags     []option[string]      `validate:"min:2"`
+	Address  option[openerAddress] `validate:"omitempty"`
+	Home     *option[string]       `validate:"omitempty&len:2"`
+}
+
+func TestOpener(t *testing.T) {
+	valid := openerProfile{
+		Nickname: some("nadya"),
+		Score:    some(0),
+		Tags:     []option[string]{some("go")},
+		Address:  some(openerAddress{Zip: "12345"}),
+	}
+	require.NoError(t, Validate(valid))
+	require.NoError(t, Lint(reflect.TypeOf(valid)))
+
+	home := some("abc")
+	invalid := openerProfile{
+		Nickname: some("na"),
+		Age:      some(16),
+		Tags:     []option[string]{some("g")},
+		Address:  some(openerAddress{Zip: "1"}),
+		Home:     &home,
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(invalid), &errs)
+	assert.Equal(t, []string{"Nickname", "Age", "Score", "Tags", "Address.Zip", "Home"}, fieldsOf(errs))
+
+	var missing ValidationErrors
+	require.ErrorAs(t, Validate(openerProfile{Score: some(1)}), &missing)
+	assert.Equal(t, []string{"Nickname"}, fieldsOf(missing))
+}
@@ -0,0 +1,248 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Option configures a single Validate call.
+type Option func(*config)
+
+type config struct {
+	embeddedNaming EmbeddedNaming
+	unexported     UnexportedPolicy
+	parallelism    int
+	groups         []string
+	fields         *fieldFilter
+	// tagName is the key of the tags holding the rules and tagPlans the
+	// cache of their plans, both unset for the default key.
+	tagName  string
+	tagPlans *sync.Map
+	// maxDepth limits the nesting of structs, maxBytes the bytes of the
+	// validated value, 0 for no limit.
+	maxDepth int
+	maxBytes int
+	// recurseOnly holds the only struct types whose fields are validated
+	// below the validated value when set, noRecurse those whose fields never
+	// are.
+	recurseOnly   map[reflect.Type]bool
+	noRecurse     map[reflect.Type]bool
+	recoverPanics bool
+	hooks         Hooks
+	tracer        Tracer
+	strictRules   bool
+	redact        bool
+	// reportOnly holds the names of the rules of WithReportOnly.
+	reportOnly map[string]bool
+	// overrides replace the rules of fields, see WithOverrides.
+	overrides []fieldOverride
+	// clock returns the current time for rules like within, time.Now if
+	// unset.
+	clock func() time.Time
+	// timeout limits the whole validation, ruleTimeout every call of a
+	// custom or batch rule, unless ruleTimeouts has one for its name.
+	timeout      time.Duration
+	ruleTimeout  time.Duration
+	ruleTimeouts map[string]time.Duration
+	// logErrors logs the errors of a validation of a value of type t, or
+	// the error err that stopped it, see WithLogger.
+	logErrors func(ctx context.Context, t reflect.Type, errs ValidationErrors, err error)
+}
+
+// newConfig applies opts to the default configuration. The configuration only
+// escapes to the heap when options are given, so validation without options
+// does not allocate.
+func newConfig(opts []Option) config {
+	if len(opts) == 0 {
+		return config{}
+	}
+	c := new(config)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return *c
+}
+
+// EmbeddedNaming selects how fields promoted from embedded structs are named
+// in errors.
+type EmbeddedNaming int
+
+const (
+	// FlattenEmbedded names promoted fields as if they were declared in the
+	// outer struct, e.g. "Name".
+	FlattenEmbedded EmbeddedNaming = iota
+	// QualifyEmbedded prefixes promoted fields with the embedded type name,
+	// e.g. "Base.Name".
+	QualifyEmbedded
+)
+
+// WithEmbeddedNaming selects how fields of embedded structs are named in
+// errors. The default is FlattenEmbedded.
+func WithEmbeddedNaming(naming EmbeddedNaming) Option {
+	return func(c *config) {
+		c.embeddedNaming = naming
+	}
+}
+
+// UnexportedPolicy selects what happens to unexported fields with rules in
+// their tags, which cannot be read like exported fields.
+type UnexportedPolicy int
+
+const (
+	// ErrorUnexported reports the fields with an error wrapping
+	// ErrValidateForUnexportedFields.
+	ErrorUnexported UnexportedPolicy = iota
+	// SkipUnexported ignores their rules, for tags meant for other tools.
+	SkipUnexported
+	// ValidateUnexportedUnsafe reads them with package unsafe and applies
+	// their rules like to exported fields. A struct that cannot be
+	// addressed, like one passed by value, is copied for every such field;
+	// pass pointers to avoid it.
+	ValidateUnexportedUnsafe
+)
+
+// WithUnexportedPolicy selects what happens to unexported fields with rules.
+// The default is ErrorUnexported.
+func WithUnexportedPolicy(policy UnexportedPolicy) Option {
+	return func(c *config) {
+		c.unexported = policy
+	}
+}
+
+// WithParallelism validates the elements of large slices, arrays and maps of
+// structs with up to n goroutines. Errors are reported in the same order as
+// without parallelism. Custom types and struct level validations must be safe
+// for concurrent use to be combined with it.
+func WithParallelism(n int) Option {
+	return func(c *config) {
+		c.parallelism = n
+	}
+}
+
+// WithTagName reads the rules from struct tags with the key name instead of
+// validate, e.g. `valid:"required"` for WithTagName("valid"), so the package
+// can be used alongside other libraries reading validate tags.
+func WithTagName(name string) Option {
+	return func(c *config) {
+		if name == defaultTagName {
+			c.tagName, c.tagPlans = "", nil
+			return
+		}
+		c.tagName, c.tagPlans = name, tagPlanCache(name)
+	}
+}
+
+// WithMaxDepth stops the validation with an error wrapping ErrMaxDepth when
+// structs are nested more than n levels deep, counting the validated struct
+// as the first level. Values referencing themselves through pointers are
+// validated only once either way.
+func WithMaxDepth(n int) Option {
+	return func(c *config) {
+		c.maxDepth = n
+	}
+}
+
+// WithRecurseOnly validates the fields of the structs nested in the validated
+// value only when their type is one of types, so that the fields of other
+// types, like those of third-party libraries, are never walked. Pointers to
+// the types are accepted too:
+//
+//	err := validator.Validate(order, validator.WithRecurseOnly(Address{}, (*Item)(nil)))
+//
+// The rules of the fields holding the other structs still apply, as do the
+// rules of the validated struct, or of the structs of the validated
+// collection. The types of several WithRecurseOnly options add up.
+func WithRecurseOnly(types ...any) Option {
+	return func(c *config) {
+		c.recurseOnly = addTypes(c.recurseOnly, types)
+	}
+}
+
+// WithNoRecurse never validates the fields of the structs of types nested in
+// the validated value, nor their struct level validations, e.g. the internals
+// of ORM models or of generated protobuf messages:
+//
+//	err := validator.Validate(user, validator.WithNoRecurse(gorm.Model{}))
+//
+// Pointers to the types are accepted too. It takes precedence over
+// WithRecurseOnly.
+func WithNoRecurse(types ...any) Option {
+	return func(c *config) {
+		c.noRecurse = addTypes(c.noRecurse, types)
+	}
+}
+
+// addTypes adds the struct types of values, or the types they point to, to
+// set.
+func addTypes(set map[reflect.Type]bool, values []any) map[reflect.Type]bool {
+	if set == nil {
+		set = make(map[reflect.Type]bool, len(values))
+	}
+	for _, v := range values {
+		if t := reflect.TypeOf(v); t != nil {
+			set[derefType(t)] = true
+		}
+	}
+	return set
+}
+
+// recurses reports whether the fields of the structs of type t nested in the
+// validated value are validated.
+func (c *config) recurses(t reflect.Type) bool {
+	if c.noRecurse[t] {
+		return false
+	}
+	return c.recurseOnly == nil || c.recurseOnly[t]
+}
+
+// WithPanicRecovery stops the validation with an error wrapping ErrPanic
+// instead of panicking when a custom rule, a custom type or a struct level
+// validation panics, or when a value cannot be inspected by reflection.
+func WithPanicRecovery() Option {
+	return func(c *config) {
+		c.recoverPanics = true
+	}
+}
+
+// WithStrictRules reports fields whose tags have rules that cannot apply to
+// the type of the field, like email on an int, with an error wrapping
+// ErrRuleNotApplicable, instead of validating them. Without it such rules
+// fail for every value they are applied to, which reports a bug in the tags
+// as invalid data, and are never noticed for empty values skipped by
+// omitempty. Rules that cannot apply are reported like by Lint; unknown
+// rules are syntax errors with or without it.
+func WithStrictRules() Option {
+	return func(c *config) {
+		c.strictRules = true
+	}
+}
+
+// WithRedactedErrors hides the messages of the errors of all fields that may
+// tell their values, for validation errors to be logged safely, like the
+// sensitive rule does for a single field:
+//
+//	Password string `validate:"sensitive&required&strong"`
+//
+// The errors of built-in rules never hold values. The errors returned by
+// custom rules, custom types and decoded payloads are kept for errors.Is,
+// but their messages, also passed to the functions of
+// RegisterErrorMessage, are replaced with "value redacted" and errors.As no
+// longer reaches them.
+func WithRedactedErrors() Option {
+	return func(c *config) {
+		c.redact = true
+	}
+}
+
+// WithClock reads the current time from clock instead of time.Now for rules
+// comparing times with it, like within, so tests can freeze time:
+//
+//	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+//	err := validator.Validate(v, validator.WithClock(func() time.Time { return now }))
+func WithClock(clock func() time.Time) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"reflect"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// parseOr parses the alternatives of a group like "(email|len:0)", the
+// rules of get separated by "|", into a rule named or: a value satisfies it
+// when it satisfies all the rules of one of the alternatives. Rules
+// controlling how a field is validated, warnings and batch rules, which
+// check values later, cannot be alternatives; required and omitempty
+// apply to the alternative they are part of.
+func parseOr(get string, alternatives [][]tags.Expr, depth, nesting int) (rule, error) {
+	if nesting == maxNesting {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	or := rule{name: "or", alternatives: make([][]rule, len(alternatives))}
+	for i, alternative := range alternatives {
+		validators, err := appendExprs(nil, get, alternative, depth, nesting+1)
+		if err != nil {
+			return rule{}, err
+		}
+		for _, validator := range validators {
+			switch validator.name {
+			case "groups", "default", "structonly", "nostructlevel", "astext", "asnum", "sensitive", "bail", "continue", "if", "excluded_with", "exactly_one_of", "assert":
+				return rule{}, ErrInvalidValidatorSyntax
+			}
+			if validator.warn || validator.batch != nil {
+				return rule{}, ErrInvalidValidatorSyntax
+			}
+		}
+		or.alternatives[i] = validators
+	}
+	return or, nil
+}
+
+// validateOr applies the alternatives of an or rule to field, which is valid
+// when it satisfies one of them.
+func (s *validation) validateOr(alternatives [][]rule, field reflect.Value) error {
+	for _, alternative := range alternatives {
+		if s.validateField(alternative, field) == nil {
+			return nil
+		}
+	}
+	return ErrFieldNotValid
+}
@@ -0,0 +1,59 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOr(t *testing.T) {
+	type contact struct {
+		Email  string         `validate:"required&(email|regexp:^\\+[0-9]+$)"`
+		Nick   string         `validate:"min:3&max:5|len:0"`
+		Scores map[string]int `validate:"values:(min:1|max:-1)"`
+	}
+	assert.NoError(t, Validate(contact{Email: "a@b.co", Scores: map[string]int{"a": 2, "b": -3}}))
+	assert.NoError(t, Validate(contact{Email: "+123", Nick: "abcd"}))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(contact{Email: "x", Nick: "ab", Scores: map[string]int{"a": 0}}), &errs)
+	assert.Equal(t, []string{"Email", "Nick", "Scores"}, fieldsOf(errs))
+	assert.Equal(t, "or", errs[0].Rule())
+	assert.Equal(t, "or", errs[1].Rule())
+
+	require.ErrorAs(t, Validate(contact{}), &errs)
+	assert.Equal(t, []string{"Email"}, fieldsOf(errs))
+	assert.Equal(t, "required", errs[0].Rule())
+
+	assert.NoError(t, ValidateVar("", "(required&email|len:0)"))
+	assert.NoError(t, ValidateVar("a", "(len:1)&(min:1&max:1)"))
+	assert.Error(t, ValidateVar("ab", "len:1|len:3"))
+	assert.NoError(t, ValidateVar("a|b", `in:a\|b,c`))
+	assert.NoError(t, ValidateVar("ab", `regexp:^(a|b)+$`))
+}
+
+func TestValidateOrSyntax(t *testing.T) {
+	tests := []struct {
+		tag    string
+		rule   string
+		offset int
+		reason string
+	}{
+		{tag: "required&(email", rule: "(email", offset: 9, reason: "unclosed parenthesis"},
+		{tag: "(email|len:0))", rule: ")", offset: 13, reason: "unbalanced parenthesis"},
+		{tag: "(email|len:0)min:1", rule: "min:1", offset: 13, reason: "missing & or | after parenthesis"},
+		{tag: "required&(email|len:x)", rule: "len:x", offset: 16},
+		{tag: "(email|warn:len:0)", rule: "(email|warn:len:0)", offset: 0},
+		{tag: "(email|default:a)", rule: "(email|default:a)", offset: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			_, err := parseValidators(tt.tag)
+			var syntax *SyntaxError
+			require.ErrorAs(t, err, &syntax)
+			assert.Equal(t, SyntaxError{Rule: tt.rule, Offset: tt.offset, Reason: tt.reason}, *syntax)
+			assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+		})
+	}
+}
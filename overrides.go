@@ -0,0 +1,116 @@
+package validator
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fieldOverride replaces the rules of the field name of the structs found at
+// the path parent, split like the paths of a fieldFilter.
+type fieldOverride struct {
+	parent []string
+	name   string
+	rules  string
+}
+
+// WithOverrides replaces the rules of the fields at the paths of overrides,
+// as reported in errors, with the rules they map to, e.g. to apply the limits
+// of the plan of a tenant on top of the tags of the struct:
+//
+//	limits := map[string]string{"Name": "required&max:200", "Tags": "max:50"}
+//	err := validator.Validate(profile, validator.WithOverrides(limits))
+//
+// Paths without indexes like "Items.Name" stand for the field of every
+// element of a slice or a map. The rules of a field without tag are added,
+// an empty string removes all of them. Paths naming no field are ignored.
+// When several paths name a field, like "Items.Name" and "Items[2].Name", the
+// one with the index applies, and the last of several WithOverrides options.
+func WithOverrides(overrides map[string]string) Option {
+	paths := make([]string, 0, len(overrides))
+	for path := range overrides {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return func(c *config) {
+		for _, path := range paths {
+			o := fieldOverride{name: path, rules: overrides[path]}
+			if i := strings.LastIndexByte(path, '.'); i >= 0 {
+				o.parent, o.name = strings.Split(path[:i], "."), path[i+1:]
+			}
+			c.overrides = append(c.overrides, o)
+		}
+	}
+}
+
+// overlayKey identifies a plan with overridden fields in overlayCache.
+type overlayKey struct {
+	typ reflect.Type
+	// rules holds the tag key and the overridden fields with their rules.
+	rules string
+}
+
+// overlayCache maps an overlayKey to its *structPlan, so the rules of
+// WithOverrides are parsed once however many values they apply to.
+var overlayCache sync.Map
+
+// overlaid returns plan, the plan of the struct type typeV found at path, with
+// the fields overridden by WithOverrides validated by their rules instead.
+func (s *validation) overlaid(path *fieldPath, typeV reflect.Type, plan *structPlan) *structPlan {
+	var segments []string
+	if p := path.String(); p != "" {
+		segments = strings.Split(p, ".")
+	}
+	var rules map[string]string
+	for _, o := range s.overrides {
+		if covers, _ := matchSegments(o.parent, segments); covers && len(o.parent) == len(segments) {
+			if rules == nil {
+				rules = map[string]string{}
+			}
+			rules[o.name] = o.rules
+		}
+	}
+	if rules == nil {
+		return plan
+	}
+
+	key := overlayKey{typ: typeV, rules: s.tagName}
+	for i := 0; i < typeV.NumField(); i++ {
+		if r, ok := rules[typeV.Field(i).Name]; ok {
+			key.rules += "\x00" + typeV.Field(i).Name + "\x00" + r
+		}
+	}
+	if overlay, ok := overlayCache.Load(key); ok {
+		return overlay.(*structPlan)
+	}
+	overlay, _ := overlayCache.LoadOrStore(key, newOverlay(typeV, plan, rules))
+	return overlay.(*structPlan)
+}
+
+// newOverlay returns a copy of plan, the plan of the struct type typeV, with
+// the fields named in rules validated by their rules.
+func newOverlay(typeV reflect.Type, plan *structPlan, rules map[string]string) *structPlan {
+	overlay := &structPlan{}
+	next := 0
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+		planned := next < len(plan.fields) && plan.fields[next].index == i
+		if planned {
+			next++
+		}
+		f := fieldPlan{}
+		if r, ok := rules[fieldT.Name]; ok {
+			f = newFieldPlan(typeV, fieldT, r)
+		} else if planned {
+			f = plan.fields[next-1]
+		}
+		if f.tagged || f.descend {
+			overlay.fields = append(overlay.fields, f)
+		}
+		if f.err != nil {
+			overlay.errs = append(overlay.errs, ValidationError{f.err})
+		}
+	}
+	return overlay
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOverrides(t *testing.T) {
+	type item struct {
+		Name string `validate:"max:5"`
+	}
+	type profile struct {
+		Name   string `validate:"required&max:5"`
+		Bio    string
+		Secret string `validate:"-"`
+		Items  []item
+	}
+	long := profile{Name: "Annabel", Bio: "x", Items: []item{{Name: "longer"}, {Name: "longest"}}}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(long), &errs)
+	assert.Equal(t, []string{"Name", "Items[0].Name", "Items[1].Name"}, fieldsOf(errs))
+
+	pro := WithOverrides(map[string]string{"Name": "required&max:10", "Items.Name": "max:10", "Items[1].Name": "max:6"})
+	require.ErrorAs(t, Validate(long, pro), &errs)
+	assert.Equal(t, []string{"Items[1].Name"}, fieldsOf(errs))
+	assert.Equal(t, "max:6", errs[0].Rules())
+	require.ErrorAs(t, Validate(profile{}, pro), &errs)
+	assert.Equal(t, []string{"Name"}, fieldsOf(errs))
+
+	tight := WithOverrides(map[string]string{"Bio": "min:2", "Secret": "required", "Name": ""})
+	require.ErrorAs(t, Validate(long, tight), &errs)
+	assert.Equal(t, []string{"Bio", "Secret", "Items[0].Name", "Items[1].Name"}, fieldsOf(errs))
+	assert.NoError(t, Validate(long, tight, WithOverrides(map[string]string{"Bio": "", "Secret": "", "Items.Name": ""})))
+	assert.NoError(t, Validate(long, WithOverrides(map[string]string{"Missing": "required", "Items.Missing": "required"}), pro, WithOverrides(map[string]string{"Items[1].Name": ""})))
+
+	require.ErrorAs(t, Validate(long, WithOverrides(map[string]string{"Name": "max:x"})), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+
+	require.ErrorAs(t, Validate(long), &errs)
+	assert.Len(t, errs, 3)
+}
@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldFilter selects the fields of a partial validation by their paths as
+// reported in errors, e.g. "Address.Zip". A path without an index like
+// "Items.Name" stands for the field of all elements, "Items[2].Name" for the
+// field of a single one.
+type fieldFilter struct {
+	paths  [][]string
+	except bool
+}
+
+func newFieldFilter(paths []string, except bool) *fieldFilter {
+	f := &fieldFilter{except: except}
+	for _, path := range paths {
+		f.paths = append(f.paths, strings.Split(path, "."))
+	}
+	return f
+}
+
+// match reports whether the rules of the field or struct at path apply and
+// whether the fields of a struct held by it are worth visiting.
+func (f *fieldFilter) match(path string) (apply, descend bool) {
+	segments := strings.Split(path, ".")
+	if path == "" {
+		segments = nil
+	}
+
+	for _, pattern := range f.paths {
+		covers, within := matchSegments(pattern, segments)
+		if f.except && covers {
+			return false, false
+		}
+		if !f.except {
+			apply = apply || covers
+			descend = descend || covers || within
+		}
+	}
+	if f.except {
+		return true, true
+	}
+	return apply, descend
+}
+
+// matchSegments reports whether pattern names path or one of the structs
+// holding it, and whether path holds the field named by pattern.
+func matchSegments(pattern, path []string) (covers, within bool) {
+	n := len(pattern)
+	if len(path) < n {
+		n = len(path)
+	}
+	for i := 0; i < n; i++ {
+		if !matchSegment(pattern[i], path[i]) {
+			return false, false
+		}
+	}
+	return len(pattern) <= len(path), len(pattern) > len(path)
+}
+
+// matchSegment reports whether the segments a and b name the same field,
+// ignoring an index missing in one of them.
+func matchSegment(a, b string) bool {
+	aName, aIndex, aFound := strings.Cut(a, "[")
+	bName, bIndex, bFound := strings.Cut(b, "[")
+	return aName == bName && (!aFound || !bFound || aIndex == bIndex)
+}
+
+// WithFields restricts the validation to the fields with the given paths,
+// as reported in errors, and the fields nested in them. The struct level
+// validations of the structs at the paths run as well.
+func WithFields(paths ...string) Option {
+	return func(c *config) {
+		c.fields = newFieldFilter(paths, false)
+	}
+}
+
+// WithoutFields excludes the fields with the given paths, as reported in
+// errors, and the fields nested in them from the validation.
+func WithoutFields(paths ...string) Option {
+	return func(c *config) {
+		c.fields = newFieldFilter(paths, true)
+	}
+}
+
+// ValidateFields validates only the fields of v with the given paths, like
+// "Email" or "Address.Zip", e.g. the fields present in a PATCH request.
+// Paths without indexes select the field of every element of a slice or a
+// map, like "Items.Name".
+func ValidateFields(v any, paths ...string) error {
+	return Validate(v, WithFields(paths...))
+}
+
+// ValidateExcept validates all fields of v but the ones with the given
+// paths, selected like for ValidateFields.
+func ValidateExcept(v any, paths ...string) error {
+	return Validate(v, WithoutFields(paths...))
+}
+
+// ValidateChanged validates only the fields of after whose values differ
+// from before, e.g. for an update that should not be blocked by untouched
+// legacy data. Elements of slices of equal length are compared one by one,
+// so a changed element is validated on its own; other slices, maps and
+// values are compared as a whole with reflect.DeepEqual. A value replaced as
+// a whole, like a nil pointer becoming a struct, is validated entirely. A
+// WithFields or WithoutFields option in opts is replaced.
+func ValidateChanged[T any](before, after T, opts ...Option) error {
+	c := newConfig(opts)
+	var changed []string
+	diffValues(&changed, c.embeddedNaming, "", reflect.ValueOf(before), reflect.ValueOf(after))
+	fields := WithFields(changed...)
+	for _, path := range changed {
+		if path == "" {
+			// WithFields("") matches no field.
+			fields = func(c *config) { c.fields = nil }
+			break
+		}
+	}
+	return Validate(after, append(opts[:len(opts):len(opts)], fields)...)
+}
+
+// diffValues appends the paths of the values that differ between a and b,
+// both found at path, to changed.
+func diffValues(changed *[]string, naming EmbeddedNaming, path string, a, b reflect.Value) {
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		// A nil interface, or values of different types held by interfaces.
+		if a.IsValid() || b.IsValid() {
+			*changed = append(*changed, path)
+		}
+		return
+	}
+	for a.Kind() == reflect.Pointer || a.Kind() == reflect.Interface {
+		if a.IsNil() || b.IsNil() || a.Elem().Type() != b.Elem().Type() {
+			if !equalValues(a, b) {
+				*changed = append(*changed, path)
+			}
+			return
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+
+	switch {
+	case a.Kind() == reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			if !f.IsExported() && !f.Anonymous {
+				continue
+			}
+			fieldPath := joinFieldPath(path, f.Name)
+			if f.Anonymous && naming == FlattenEmbedded {
+				fieldPath = path
+			}
+			diffValues(changed, naming, fieldPath, a.Field(i), b.Field(i))
+		}
+	case (a.Kind() == reflect.Slice || a.Kind() == reflect.Array) && a.Len() == b.Len() && path != "":
+		for i := 0; i < a.Len(); i++ {
+			diffValues(changed, naming, path+"["+strconv.Itoa(i)+"]", a.Index(i), b.Index(i))
+		}
+	default:
+		if !equalValues(a, b) {
+			*changed = append(*changed, path)
+		}
+	}
+}
+
+// equalValues reports whether a and b of the same type are deeply equal
+// like reflect.DeepEqual does, but also for values of unexported fields,
+// which cannot be turned back into interfaces.
+func equalValues(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Kind() == reflect.Pointer && a.Pointer() == b.Pointer() {
+			return true
+		}
+		return a.Elem().Type() == b.Elem().Type() && equalValues(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValues(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() {
+			return false
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValues(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() || a.Len() != b.Len() {
+			return false
+		}
+		for iter := a.MapRange(); iter.Next(); {
+			other := b.MapIndex(iter.Key())
+			if !other.IsValid() || !equalValues(iter.Value(), other) {
+				return false
+			}
+		}
+		return true
+	}
+	// Channels, functions and unsafe pointers are equal when identical.
+	return a.IsNil() && b.IsNil() || a.Kind() != reflect.Func && a.Pointer() == b.Pointer()
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,227 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type partialAddress struct {
+	Street string `validate:"required"`
+	Zip    string `validate:"len:5"`
+}
+
+type partialItem struct {
+	Name string `validate:"required"`
+	Qty  int    `validate:"min:1"`
+}
+
+type partialBase struct {
+	ID int `validate:"min:1"`
+}
+
+type partialUser struct {
+	partialBase
+	Email   string          `validate:"email"`
+	Name    string          `validate:"min:2"`
+	Address partialAddress  `validate:"required"`
+	Items   []partialItem   `validate:"required"`
+	Work    *partialAddress `validate:"required"`
+}
+
+func TestValidateFields(t *testing.T) {
+	user := partialUser{
+		Email:   "nope",
+		Name:    "a",
+		Address: partialAddress{Zip: "1"},
+		Items:   []partialItem{{Name: "a"}, {Qty: 2}},
+	}
+
+	tests := []struct {
+		name   string
+		paths  []string
+		except bool
+		want   []string
+	}{
+		{
+			name:  "fields",
+			paths: []string{"Email", "Address.Zip"},
+			want:  []string{"Email", "Address.Zip"},
+		},
+		{
+			name:  "nested struct",
+			paths: []string{"Address"},
+			want:  []string{"Address.Street", "Address.Zip"},
+		},
+		{
+			name:  "all elements",
+			paths: []string{"Items.Qty"},
+			want:  []string{"Items[0].Qty"},
+		},
+		{
+			name:  "single element",
+			paths: []string{"Items[1]"},
+			want:  []string{"Items[1].Name"},
+		},
+		{
+			name:  "embedded",
+			paths: []string{"ID", "Work"},
+			want:  []string{"ID", "Work"},
+		},
+		{
+			name:  "unknown",
+			paths: []string{"Phone"},
+		},
+		{
+			name:   "except",
+			paths:  []string{"Email", "Items", "Address.Street", "ID"},
+			except: true,
+			want:   []string{"Name", "Address.Zip", "Work"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.except {
+				err = ValidateExcept(user, tt.paths...)
+			} else {
+				err = ValidateFields(user, tt.paths...)
+			}
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var fields []string
+			for _, e := range err.(ValidationErrors) {
+				fields = append(fields, e.Field())
+			}
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestValidateFieldsStructLevel(t *testing.T) {
+	errBroken := errors.New("broken")
+	RegisterStructValidation(func(v reflect.Value) error {
+		return errBroken
+	}, partialAddress{})
+	t.Cleanup(func() {
+		delete(structValidations, reflect.TypeOf(partialAddress{}))
+	})
+
+	user := partialUser{
+		Address: partialAddress{Street: "Main", Zip: "12345"},
+		Items:   []partialItem{{Name: "a", Qty: 1}},
+	}
+	err := ValidateFields(user, "Address")
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, errBroken)
+	assert.NoError(t, ValidateFields(user, "Address.Zip"))
+	assert.NoError(t, ValidateExcept(user, "Address", "Work", "ID", "Email", "Name"))
+}
+
+func TestValidateChanged(t *testing.T) {
+	legacy := partialUser{
+		partialBase: partialBase{ID: 1},
+		Email:       "legacy",
+		Name:        "a",
+		Address:     partialAddress{Street: "Main", Zip: "1"},
+		Items:       []partialItem{{Name: "a"}, {Name: "b", Qty: 1}},
+		Work:        &partialAddress{Street: "Work"},
+	}
+
+	tests := []struct {
+		name   string
+		change func(u *partialUser)
+		want   []string
+	}{
+		{
+			name:   "unchanged",
+			change: func(u *partialUser) {},
+		},
+		{
+			name:   "unrelated change",
+			change: func(u *partialUser) { u.Address.Street = "Side" },
+		},
+		{
+			name:   "changed field",
+			change: func(u *partialUser) { u.Address.Zip = "2" },
+			want:   []string{"Address.Zip"},
+		},
+		{
+			name:   "changed element",
+			change: func(u *partialUser) { u.Items[1].Qty = 0 },
+			want:   []string{"Items[1].Qty"},
+		},
+		{
+			name:   "appended element",
+			change: func(u *partialUser) { u.Items = append(u.Items, partialItem{Qty: 1}) },
+			want:   []string{"Items[0].Qty", "Items[2].Name"},
+		},
+		{
+			name:   "embedded",
+			change: func(u *partialUser) { u.ID = 0 },
+			want:   []string{"ID"},
+		},
+		{
+			name:   "removed pointer",
+			change: func(u *partialUser) { u.Work = nil },
+			want:   []string{"Work"},
+		},
+		{
+			name:   "changed pointer",
+			change: func(u *partialUser) { u.Work = &partialAddress{Zip: "1"} },
+			want:   []string{"Work.Street", "Work.Zip"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := legacy
+			after.Items = append([]partialItem(nil), legacy.Items...)
+			tt.change(&after)
+
+			err := ValidateChanged(legacy, after)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var fields []string
+			for _, e := range err.(ValidationErrors) {
+				fields = append(fields, e.Field())
+			}
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestValidateChangedRoot(t *testing.T) {
+	// A created value is validated entirely.
+	created := &partialUser{Email: "nope", Name: "a"}
+	var errs ValidationErrors
+	require.ErrorAs(t, ValidateChanged[*partialUser](nil, created), &errs)
+	assert.Equal(t, []string{"ID", "Email", "Name", "Address", "Address.Street", "Address.Zip", "Items", "Work"}, fieldsOf(errs))
+	assert.ErrorIs(t, ValidateChanged[*partialUser](created, nil), ErrNotStruct)
+
+	// So is a value replaced by one of another type.
+	var before, after any = partialAddress{}, partialUser{Name: "a", Items: []partialItem{{Name: "a", Qty: 1}}, Work: &partialAddress{}}
+	require.ErrorAs(t, ValidateChanged(before, after, WithFields("Items")), &errs)
+	assert.Equal(t, []string{"ID", "Email", "Name", "Address", "Address.Street", "Address.Zip", "Work.Street", "Work.Zip"}, fieldsOf(errs))
+}
+
+func TestEqualValues(t *testing.T) {
+	type inner struct {
+		m map[string][]int
+		p *int
+		f func()
+	}
+	one, other := 1, 1
+	a := inner{m: map[string][]int{"a": {1}}, p: &one}
+	b := inner{m: map[string][]int{"a": {1}}, p: &other}
+	assert.True(t, equalValues(reflect.ValueOf(a), reflect.ValueOf(b)))
+
+	b.m["a"] = []int{2}
+	assert.False(t, equalValues(reflect.ValueOf(a), reflect.ValueOf(b)))
+	assert.False(t, equalValues(reflect.ValueOf(inner{f: func() {}}), reflect.ValueOf(inner{})))
+}
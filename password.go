@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy decides whether a password is strong enough. Policies can be
+// registered under a name with RegisterPasswordPolicy and referenced from tags
+// as `validate:"password:name"`.
+type PasswordPolicy interface {
+	Check(password string) bool
+}
+
+// PasswordRules is a composition based policy. Min is the minimal length in
+// runes, the other fields are the minimal number of runes of each class.
+// It is the policy built from tags like `validate:"password:min=12,upper=1"`.
+type PasswordRules struct {
+	Min    int
+	Upper  int
+	Lower  int
+	Digit  int
+	Symbol int
+}
+
+func (p PasswordRules) Check(password string) bool {
+	if utf8.RuneCountInString(password) < p.Min {
+		return false
+	}
+
+	var upper, lower, digit, symbol int
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsDigit(r):
+			digit++
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol++
+		}
+	}
+	return upper >= p.Upper && lower >= p.Lower && digit >= p.Digit && symbol >= p.Symbol
+}
+
+var passwordPolicies = map[string]PasswordPolicy{}
+
+// RegisterPasswordPolicy makes policy available to the password validator
+// under name. It is meant to be called during program initialization.
+func RegisterPasswordPolicy(name string, policy PasswordPolicy) error {
+	return register("RegisterPasswordPolicy", func() {
+		passwordPolicies[name] = policy
+		resetPlanCache()
+	})
+}
+
+// parsePasswordPolicy parses the arguments of the password validator: either
+// the name of a registered policy or a list of key=value requirements.
+func parsePasswordPolicy(params string) (PasswordPolicy, error) {
+	params = strings.TrimSpace(params)
+	if policy, ok := registered(passwordPolicies, params); ok {
+		return policy, nil
+	}
+
+	var rules PasswordRules
+	for _, arg := range strings.Split(params, ",") {
+		key, value, found := strings.Cut(arg, "=")
+		if !found {
+			return nil, ErrInvalidValidatorSyntax
+		}
+		num, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || num < 0 {
+			return nil, ErrInvalidValidatorSyntax
+		}
+
+		switch strings.TrimSpace(key) {
+		case "min":
+			rules.Min = num
+		case "upper":
+			rules.Upper = num
+		case "lower":
+			rules.Lower = num
+		case "digit":
+			rules.Digit = num
+		case "symbol":
+			rules.Symbol = num
+		default:
+			return nil, ErrInvalidValidatorSyntax
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type noSpacesPolicy struct{}
+
+func (noSpacesPolicy) Check(password string) bool {
+	return !strings.Contains(password, " ")
+}
+
+func TestValidatePassword(t *testing.T) {
+	RegisterPasswordPolicy("nospaces", noSpacesPolicy{})
+
+	tests := []struct {
+		name    string
+		v       any
+		wantErr int
+	}{
+		{
+			name: "strong passwords",
+			v: struct {
+				Full     string `validate:"password:min=12,upper=1,digit=1,symbol=1"`
+				Lower    string `validate:"password:lower=3"`
+				Unicode  string `validate:"password:min=4,upper=2"`
+				Policy   string `validate:"password:nospaces"`
+				Combined string `validate:"password:min=8&max:16"`
+			}{
+				Full:     "correct-Horse7battery",
+				Lower:    "abc",
+				Unicode:  "ПАРоль",
+				Policy:   "nospaces",
+				Combined: "eightchr",
+			},
+		},
+		{
+			name: "weak passwords",
+			v: struct {
+				Short  string `validate:"password:min=12"`
+				Upper  string `validate:"password:upper=1"`
+				Digit  string `validate:"password:digit=2"`
+				Symbol string `validate:"password:symbol=1"`
+				Policy string `validate:"password:nospaces"`
+				NotStr int    `validate:"password:min=1"`
+			}{
+				Short:  "Sh0rt!",
+				Upper:  "lowercase",
+				Digit:  "only1digit",
+				Symbol: "NoSymbols123",
+				Policy: "has spaces",
+				NotStr: 12,
+			},
+			wantErr: 6,
+		},
+		{
+			name: "invalid policy syntax",
+			v: struct {
+				Unknown  string `validate:"password:length=3"`
+				BadValue string `validate:"password:min=x"`
+				Negative string `validate:"password:min=-1"`
+				NoPolicy string `validate:"password:missing"`
+				Empty    string `validate:"password:"`
+			}{},
+			wantErr: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), tt.wantErr)
+		})
+	}
+}
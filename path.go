@@ -0,0 +1,92 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldPath is the path of a nested struct, e.g. "Address" for the fields of
+// the struct in the Address field or "Items[2]" for the fields of an element
+// of Items. Paths are kept by value and only joined into a string when an
+// error is reported, so validating a valid value does not allocate. The zero
+// path is the path of the validated struct itself.
+type fieldPath struct {
+	elems [8]pathElem
+	depth int
+}
+
+// pathElem is a single step of a fieldPath: a struct field, optionally
+// followed by the index or key of one of its elements.
+type pathElem struct {
+	name  string
+	index int
+	key   reflect.Value
+	// hasIndex is set when index is meaningful.
+	hasIndex bool
+}
+
+func (e *pathElem) String() string {
+	switch {
+	case e.hasIndex:
+		return e.name + "[" + strconv.Itoa(e.index) + "]"
+	case e.key.IsValid():
+		return e.name + "[" + fmt.Sprint(e.key.Interface()) + "]"
+	}
+	return e.name
+}
+
+// child returns the path of the struct in the field name of the struct at p.
+func (p fieldPath) child(name string) fieldPath {
+	return p.push(pathElem{name: name})
+}
+
+// index returns the path of the element i of the slice or array at p.
+func (p fieldPath) index(i int) fieldPath {
+	p.elems[p.depth-1].index, p.elems[p.depth-1].hasIndex = i, true
+	return p
+}
+
+// key returns the path of the value for key of the map at p.
+func (p fieldPath) key(key reflect.Value) fieldPath {
+	p.elems[p.depth-1].key = key
+	return p
+}
+
+func (p fieldPath) push(e pathElem) fieldPath {
+	if p.depth == len(p.elems) {
+		// Deeper structs are rare enough to pay for the concatenation.
+		p.elems[p.depth-1] = pathElem{name: p.elems[p.depth-1].String() + "." + e.String()}
+		return p
+	}
+	p.elems[p.depth] = e
+	p.depth++
+	return p
+}
+
+// String returns the dotted path of the struct at p, "" for the validated
+// struct itself.
+func (p *fieldPath) String() string {
+	if p.depth == 0 {
+		return ""
+	}
+	parent := *p
+	parent.depth--
+	return parent.join(p.elems[p.depth-1].String())
+}
+
+// join returns the dotted path of the field name of the struct at p.
+func (p *fieldPath) join(name string) string {
+	if p.depth == 0 {
+		return name
+	}
+
+	var sb strings.Builder
+	for i := 0; i < p.depth; i++ {
+		sb.WriteString(p.elems[i].String())
+		sb.WriteByte('.')
+	}
+	sb.WriteString(name)
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var payloadTypes = map[string]reflect.Type{}
+
+// RegisterPayload makes the struct type T available under name to the
+// jsonschema rule, which decodes a JSON document held by a json.RawMessage,
+// a []byte or a string into a T and validates the fields of the T, for
+// envelopes whose payload depends on their type:
+//
+//	func init() {
+//		validator.RegisterPayload[UserCreated]("user_created")
+//	}
+//
+//	type Envelope struct {
+//		Type    string          `validate:"required"`
+//		Payload json.RawMessage `validate:"required&jsonschema:user_created"`
+//	}
+//
+// A payload that cannot be decoded or whose fields are not valid fails the
+// rule. The error unwraps to the error of encoding/json or to the
+// ValidationErrors of the payload, whose fields have paths relative to it,
+// like "Name". It is meant to be called during program initialization.
+func RegisterPayload[T any](name string) error {
+	return register("RegisterPayload", func() {
+		payloadTypes[name] = reflect.TypeOf((*T)(nil)).Elem()
+		resetPlanCache()
+	})
+}
+
+// parsePayload parses the argument of the jsonschema rule, the name of a
+// registered payload type.
+func parsePayload(params string) (rule, error) {
+	name := strings.TrimSpace(params)
+	typ, ok := registered(payloadTypes, name)
+	if !ok {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	return rule{name: "jsonschema", argsStr: []string{name}, payload: typ}, nil
+}
+
+// validatePayload decodes the JSON document field into a new value of typ
+// and validates it with the configuration of s.
+func (s *validation) validatePayload(typ reflect.Type, field reflect.Value) error {
+	var data []byte
+	switch {
+	case field.Kind() == reflect.String:
+		data = []byte(field.String())
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		data = field.Bytes()
+	default:
+		return ErrFieldNotValid
+	}
+
+	payload := reflect.New(typ)
+	if err := json.Unmarshal(data, payload.Interface()); err != nil {
+		return fmt.Errorf("%w: %v", ErrFieldNotValid, err)
+	}
+	// The payload is part of the validation of its envelope, it is not
+	// reported to hooks and traced on its own.
+	c := s.config
+	c.hooks, c.tracer = nil, nil
+	return validateCtx(s.ctx, payload.Interface(), c)
+}
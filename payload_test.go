@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userCreated struct {
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"min:18"`
+}
+
+type envelope struct {
+	Type    string          `validate:"required"`
+	Payload json.RawMessage `validate:"required&jsonschema:user_created"`
+	Text    string          `validate:"omitempty&jsonschema:user_created"`
+}
+
+func TestRegisterPayload(t *testing.T) {
+	RegisterPayload[userCreated]("user_created")
+	t.Cleanup(func() {
+		delete(payloadTypes, "user_created")
+		resetPlanCache()
+	})
+
+	assert.NoError(t, Validate(envelope{Type: "created", Payload: json.RawMessage(`{"name": "Ann", "age": 30}`)}))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(envelope{Type: "created", Payload: json.RawMessage(`{"age": 3}`), Text: `{"name": "Bob", "age": 20}`}), &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Payload", errs[0].Field())
+	assert.Equal(t, "jsonschema", errs[0].Rule())
+	var payloadErrs ValidationErrors
+	require.ErrorAs(t, errs[0].Err, &payloadErrs)
+	assert.Equal(t, []string{"Name", "Age"}, fieldsOf(payloadErrs))
+
+	require.ErrorAs(t, Validate(envelope{Type: "created", Payload: json.RawMessage(`{}`), Text: `[`}), &errs)
+	assert.Equal(t, []string{"Payload", "Text"}, fieldsOf(errs))
+	assert.ErrorIs(t, errs[1].Err, ErrFieldNotValid)
+
+	require.ErrorAs(t, Validate(envelope{Type: "created"}), &errs)
+	assert.Equal(t, "required", errs[0].Rule())
+
+	require.ErrorAs(t, ValidateVar(`{}`, "jsonschema:unknown"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
@@ -0,0 +1,295 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// structPlan is the parsed form of the validate tags of a struct type.
+type structPlan struct {
+	fields []fieldPlan
+	// errs are the errors of the fields whose tags can never be applied,
+	// found once when the plan is built.
+	errs ValidationErrors
+}
+
+// fieldPlan describes how a single struct field is validated.
+type fieldPlan struct {
+	index     int
+	name      string
+	anonymous bool
+
+	// tagged fields have rules of their own: validators parsed from cond or
+	// err when they cannot be applied at all.
+	tagged     bool
+	cond       string
+	validators []rule
+	err        error
+	// unexported is set for tagged fields that are not exported, whose
+	// rules only apply with ValidateUnexportedUnsafe, see fieldErr.
+	unexported bool
+	// dflt is the value of a default rule, warnings are the rules reporting
+	// warnings along with the rules controlling when they apply.
+	dflt     reflect.Value
+	warnings []rule
+	// strictErr is the error WithStrictRules reports instead of validating
+	// the field: its rules name an unknown rule or cannot apply to it.
+	strictErr error
+	// failures are the errors of the field of the validated struct itself
+	// failing each of its rules, keyed by rule name, see fieldError.
+	failures map[string]*fieldError
+	// conditional is set when the rules only apply under the conditions of
+	// if rules, refs when their arguments reference other fields.
+	conditional bool
+	refs        bool
+
+	// descend is set when the field may hold a struct whose fields should be
+	// validated too, structLevel when its struct level validation should run.
+	// elems is set when the structs are the elements of a slice, an array or
+	// a map.
+	descend     bool
+	elems       bool
+	structLevel bool
+}
+
+func newStructPlan(typeV reflect.Type, tagName string) *structPlan {
+	plan := &structPlan{}
+
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+
+		validCond := fieldRules(typeV, fieldT, tagName)
+		if validCond == "-" {
+			continue
+		}
+
+		f := newFieldPlan(typeV, fieldT, validCond)
+		if f.tagged || f.descend {
+			plan.fields = append(plan.fields, f)
+		}
+		if f.err != nil {
+			plan.errs = append(plan.errs, ValidationError{f.err})
+		}
+	}
+	return plan
+}
+
+// newFieldPlan describes how the field fieldT of the struct type typeV is
+// validated by the rules validCond.
+func newFieldPlan(typeV reflect.Type, fieldT reflect.StructField, validCond string) fieldPlan {
+	f := fieldPlan{
+		index:       fieldT.Index[0],
+		name:        fieldT.Name,
+		anonymous:   fieldT.Anonymous,
+		descend:     fieldT.IsExported() || fieldT.Anonymous,
+		structLevel: true,
+	}
+
+	if len(validCond) != 0 {
+		f.tagged = true
+		f.cond = validCond
+		f.unexported = !fieldT.IsExported()
+
+		f.validators, f.err = parseValidators(validCond)
+		f.err = withField(f.err, fieldT.Name)
+		f.descend = f.descend && !hasValidator(f.validators, "structonly")
+		f.structLevel = !hasValidator(f.validators, "nostructlevel")
+		for _, validator := range f.validators {
+			if validator.name == "default" && f.err == nil {
+				f.dflt, f.err = parseDefault(validator.argsStr[0], derefType(fieldT.Type))
+			}
+		}
+		if f.err == nil {
+			f.err = typeIn(f.validators, fieldT.Type, fieldT.Name)
+		}
+		f.warnings = warningRules(f.validators)
+		if f.conditional = hasValidator(f.validators, "if"); f.conditional && f.err == nil {
+			f.err = conditionError(typeV, fieldT.Name, f.validators)
+		}
+		if f.refs = hasRefs(f.validators); f.refs && f.err == nil {
+			f.err = refsError(typeV, fieldT.Name, f.validators)
+		}
+		if f.err == nil {
+			f.err = aggregateError(fieldT.Type, fieldT.Name, f.validators)
+		}
+		if f.err == nil {
+			f.err = nilOnlyError(fieldT.Type, fieldT.Name, f.validators)
+		}
+		if f.err == nil {
+			f.strictErr = strictError(typeV.Name()+"."+fieldT.Name, fieldT.Type, f.validators)
+			f.failures = make(map[string]*fieldError, len(f.validators))
+			for _, validator := range f.validators {
+				f.failures[validator.name] = &fieldError{field: fieldT.Name, cond: validCond, rule: validator.name, err: ErrFieldNotValid}
+			}
+		}
+	}
+
+	f.elems = isCollection(fieldT.Type) && mayHoldStruct(fieldT.Type.Elem())
+	f.descend = f.descend && (f.elems || mayHoldStruct(fieldT.Type))
+	return f
+}
+
+// defaultTagName is the key of the struct tags holding the rules.
+const defaultTagName = "validate"
+
+// planCache maps a struct type to its *structPlan, so the tags of a type are
+// parsed only once no matter how many values of it are validated.
+// tagPlanCaches maps tag keys other than the default one to caches of their
+// own.
+var (
+	planCache     sync.Map
+	tagPlanCaches sync.Map
+)
+
+func cachedPlan(typeV reflect.Type) *structPlan {
+	return cachedTagPlan(&planCache, typeV, defaultTagName)
+}
+
+// cachedTagPlan returns the plan of typeV from cache, building it on first
+// use. Plans are cached along with the errors of their tags, so a broken type
+// is parsed once however many times it is validated.
+func cachedTagPlan(cache *sync.Map, typeV reflect.Type, tagName string) *structPlan {
+	if plan, ok := cache.Load(typeV); ok {
+		return plan.(*structPlan)
+	}
+	plan, _ := cache.LoadOrStore(typeV, newStructPlan(typeV, tagName))
+	return plan.(*structPlan)
+}
+
+// checkRegistered panics in the MustRegister mode when the tags of typeV,
+// parsed into plan, can never be applied.
+func checkRegistered(typeV reflect.Type, plan *structPlan) {
+	if plan.errs != nil && mustRegister.Load() {
+		panic(fmt.Errorf("validator: invalid tags of %s: %w", typeV, plan.errs))
+	}
+}
+
+// tagPlanCache returns the plan cache for the tag key name.
+func tagPlanCache(name string) *sync.Map {
+	cache, _ := tagPlanCaches.LoadOrStore(name, new(sync.Map))
+	return cache.(*sync.Map)
+}
+
+// cachedPlan returns the plan of typeV for the tag key of the configuration.
+func (c *config) cachedPlan(typeV reflect.Type) *structPlan {
+	if c.tagPlans == nil {
+		return cachedPlan(typeV)
+	}
+	return cachedTagPlan(c.tagPlans, typeV, c.tagName)
+}
+
+// resetPlanCache drops all cached plans and rules. It is called by registrations that
+// change how tags are parsed.
+func resetPlanCache() {
+	caches := []*sync.Map{&planCache, &overlayCache, &modCache}
+	tagPlanCaches.Range(func(_, cache any) bool {
+		caches = append(caches, cache.(*sync.Map))
+		return true
+	})
+	for _, cache := range caches {
+		clearMap(cache)
+	}
+
+	ruleCacheMu.Lock()
+	clearMap(&ruleCache)
+	ruleCacheSize = 0
+	ruleCacheMu.Unlock()
+}
+
+// clearMap deletes all entries of m.
+func clearMap(m *sync.Map) {
+	m.Range(func(key, _ any) bool {
+		m.Delete(key)
+		return true
+	})
+}
+
+// collectPlans adds the cached plans of typeV and of the struct types
+// statically reachable from its fields to plans, appending the errors of
+// tags that can never be applied to errs.
+func collectPlans(c *config, typeV reflect.Type, plans map[reflect.Type]*structPlan, errs *ValidationErrors) {
+	if _, ok := plans[typeV]; ok {
+		return
+	}
+	plan := c.cachedPlan(typeV)
+	checkRegistered(typeV, plan)
+	plans[typeV] = plan
+
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		if err := c.fieldErr(f); err != nil {
+			*errs = append(*errs, ValidationError{err})
+		} else if c.skips(f) {
+			// Its rules are not applied at all.
+		} else if c.strictRules && f.strictErr != nil {
+			*errs = append(*errs, ValidationError{f.strictErr})
+		}
+		if !f.descend {
+			continue
+		}
+
+		fieldT := typeV.Field(f.index).Type
+		if f.elems {
+			fieldT = fieldT.Elem()
+		}
+		for fieldT.Kind() == reflect.Pointer {
+			fieldT = fieldT.Elem()
+		}
+		if fieldT.Kind() == reflect.Struct && c.recurses(fieldT) {
+			collectPlans(c, fieldT, plans, errs)
+		}
+	}
+}
+
+// WarmUp parses and caches the validate tags of the struct types of the given
+// values, and of the structs nested in them, so that the first Validate call
+// for them does not pay for it. Pointers to structs are accepted too, e.g.
+// WarmUp(User{}, (*Order)(nil)). Tags that can never be applied are reported
+// like Compile does.
+func WarmUp(types ...any) error {
+	var errs ValidationErrors
+	plans := map[reflect.Type]*structPlan{}
+
+	for _, t := range types {
+		typeV := reflect.TypeOf(t)
+		for typeV != nil && typeV.Kind() == reflect.Pointer {
+			typeV = typeV.Elem()
+		}
+		if typeV == nil || typeV.Kind() != reflect.Struct {
+			return ErrNotStruct
+		}
+		collectPlans(&config{}, typeV, plans, &errs)
+	}
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// mayHoldStruct reports whether a field of type t can hold a nested struct.
+func mayHoldStruct(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Pointer, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// isCollection reports whether t is a slice, an array or a map.
+func isCollection(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+func hasValidator(validators []rule, name string) bool {
+	for _, validator := range validators {
+		if validator.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cachedUser struct {
+	Name    string `validate:"required"`
+	Address Address
+}
+
+func TestPlanCache(t *testing.T) {
+	resetPlanCache()
+
+	assert.Error(t, Validate(cachedUser{}))
+	plan, ok := planCache.Load(reflect.TypeOf(cachedUser{}))
+	assert.True(t, ok)
+	assert.Same(t, plan, cachedPlan(reflect.TypeOf(cachedUser{})))
+
+	RegisterPasswordPolicy("cache-reset", PasswordRules{})
+	_, ok = planCache.Load(reflect.TypeOf(cachedUser{}))
+	assert.False(t, ok)
+}
+
+func TestPlanCacheConcurrent(t *testing.T) {
+	resetPlanCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Error(t, Validate(cachedUser{Address: Address{City: "Moscow"}}))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWarmUp(t *testing.T) {
+	resetPlanCache()
+
+	assert.NoError(t, WarmUp(cachedUser{}, (*Period)(nil)))
+	for _, v := range []any{cachedUser{}, Address{}, Period{}} {
+		_, ok := planCache.Load(reflect.TypeOf(v))
+		assert.True(t, ok)
+	}
+
+	type broken struct {
+		Len string `validate:"len:x"`
+	}
+	assert.ErrorIs(t, WarmUp(broken{}).(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, WarmUp(42), ErrNotStruct)
+	assert.ErrorIs(t, WarmUp(nil), ErrNotStruct)
+}
+
+func BenchmarkValidate(b *testing.B) {
+	v := cachedUser{Name: "Ann", Address: Address{City: "Moscow", Zip: "123456"}}
+	for i := 0; i < b.N; i++ {
+		_ = Validate(v)
+	}
+}
+
+func TestPlanCacheErrors(t *testing.T) {
+	resetPlanCache()
+
+	type broken struct {
+		Name string `validate:"min:x"`
+		Age  int    `validate:"min:1"`
+	}
+	plan := cachedPlan(reflect.TypeOf(broken{}))
+	assert.Len(t, plan.errs, 1)
+	assert.ErrorIs(t, plan.errs[0].Err, ErrInvalidValidatorSyntax)
+
+	assert.Error(t, Validate(broken{}))
+	assert.Same(t, plan, cachedPlan(reflect.TypeOf(broken{})))
+	assert.Nil(t, cachedPlan(reflect.TypeOf(cachedUser{})).errs)
+}
@@ -0,0 +1,29 @@
+package validator
+
+import "errors"
+
+// redactedError hides the message of an error that may tell the value of a
+// field, like the error of a custom rule quoting it. errors.Is still finds
+// the errors it wraps, errors.As does not reach them.
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return "value redacted"
+}
+
+func (e *redactedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// redacted returns err, the error of a field with validators, with its
+// message hidden when the errors of the field are redacted: with
+// WithRedactedErrors or the sensitive rule. Errors of built-in rules never
+// tell the value and are kept.
+func (s *validation) redacted(validators []rule, err error) error {
+	if err == ErrFieldNotValid || !s.redact && !hasValidator(validators, "sensitive") {
+		return err
+	}
+	return &redactedError{err}
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactedErrors(t *testing.T) {
+	errWeak := errors.New("weak password")
+	RegisterRule("strongpass", func(ctx context.Context, field reflect.Value, params []string) error {
+		return fmt.Errorf("%w: %q is too short", errWeak, field.String())
+	})
+	var messages []string
+	RegisterErrorMessage("strongpass", func(e FailedField) string {
+		messages = append(messages, e.Err.Error())
+		return e.Field + ": " + e.Err.Error()
+	})
+	t.Cleanup(func() {
+		delete(customRules, "strongpass")
+		delete(errorMessages, "strongpass")
+		resetPlanCache()
+	})
+
+	type login struct {
+		Password string `validate:"sensitive&strongpass"`
+		Nickname string `validate:"strongpass"`
+		Email    string `validate:"required&email"`
+	}
+	v := login{Password: "hunter2", Nickname: "bob", Email: "x"}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(v), &errs)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "Password: value redacted", errs[0].Err.Error())
+	assert.ErrorIs(t, errs[0].Err, errWeak)
+	assert.NotContains(t, newViolation(errs[0]).Err.Error(), "hunter2")
+	assert.Equal(t, `Nickname: weak password: "bob" is too short`, errs[1].Err.Error())
+	assert.Equal(t, "field: Email not valid for required&email", errs[2].Err.Error())
+
+	messages = nil
+	require.ErrorAs(t, Validate(v, WithRedactedErrors()), &errs)
+	assert.Equal(t, "Password: value redacted", errs[0].Err.Error())
+	assert.Equal(t, "Nickname: value redacted", errs[1].Err.Error())
+	assert.Equal(t, []string{"value redacted", "value redacted"}, messages)
+	assert.ErrorIs(t, errs[1].Err, errWeak)
+	assert.ErrorIs(t, errs[2].Err, ErrFieldNotValid)
+
+	assert.NoError(t, ValidateVar("", "sensitive&omitempty&email"))
+}
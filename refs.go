@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+// fieldRef returns the path of the field referenced by the argument arg of
+// the rule name, like "MaxItems" for "$MaxItems". The arguments of len, min
+// and max can reference a field holding a number, whose value is taken as
+// the argument when the field is validated:
+//
+//	type Cart struct {
+//		MaxItems int
+//		Items    []Item `validate:"max:$MaxItems"`
+//	}
+//
+// Paths like "$Limits.Items" reach the fields of nested structs.
+func fieldRef(name, arg string) (string, bool) {
+	switch name {
+	case "len", "min", "max":
+	default:
+		return "", false
+	}
+	ref, ok := strings.CutPrefix(strings.TrimSpace(arg), "$")
+	return ref, ok && tags.IsFieldPath(ref)
+}
+
+// hasRefs reports whether an argument of validators references a field.
+func hasRefs(validators []rule) bool {
+	for _, validator := range validators {
+		if validator.refs != nil || hasRefs(validator.each) {
+			return true
+		}
+		for _, alternative := range validator.alternatives {
+			if hasRefs(alternative) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withRefs returns a copy of validators with the arguments referencing
+// fields set to the values of the fields of root, the struct or map holding
+// the validated value, or to whether they are set for exclusive rules. The
+// numbers of the fields of assertions are read as floats.
+func withRefs(validators []rule, root reflect.Value) ([]rule, error) {
+	resolved := make([]rule, len(validators))
+	copy(resolved, validators)
+	for i, validator := range resolved {
+		if validator.each != nil {
+			each, err := withRefs(validator.each, root)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i].each = each
+		}
+		if validator.alternatives != nil {
+			alternatives := make([][]rule, len(validator.alternatives))
+			for j, alternative := range validator.alternatives {
+				var err error
+				if alternatives[j], err = withRefs(alternative, root); err != nil {
+					return nil, err
+				}
+			}
+			resolved[i].alternatives = alternatives
+		}
+		if validator.refs == nil {
+			continue
+		}
+		if validator.name == "assert" {
+			nums := make([]float64, len(validator.refs))
+			for j, ref := range validator.refs {
+				var err error
+				if nums[j], err = refNumber(root, ref); err != nil {
+					return nil, err
+				}
+			}
+			resolved[i].nums = nums
+			continue
+		}
+		if validator.name == "checksum" {
+			sum, err := refChecksum(root, validator.refs[0])
+			if err != nil {
+				return nil, err
+			}
+			resolved[i].sum = sum
+			continue
+		}
+		args := make([]int, len(validator.argsInt))
+		copy(args, validator.argsInt)
+		for j, ref := range validator.refs {
+			if ref == "" {
+				continue
+			}
+			var num int
+			var err error
+			if isExclusive(validator.name) {
+				num, err = refSet(root, ref)
+			} else {
+				num, err = refValue(root, ref)
+			}
+			if err != nil {
+				return nil, err
+			}
+			args[j] = num
+		}
+		resolved[i].argsInt = args
+	}
+	return resolved, nil
+}
+
+// refValue returns the number held by the field at path in root.
+func refValue(root reflect.Value, path string) (int, error) {
+	raw, ok := reflect.Value{}, false
+	if root.IsValid() {
+		raw, ok = fieldByKey(root, path)
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: %s referenced by a rule", ErrUnknownField, path)
+	}
+	field, err := customValue(raw)
+	if err != nil {
+		return 0, err
+	}
+	switch kindClass(field.Kind()) {
+	case reflect.Int:
+		return int(field.Int()), nil
+	case reflect.Uint:
+		return int(field.Uint()), nil
+	case reflect.Float64:
+		return int(field.Float()), nil
+	}
+	return 0, fmt.Errorf("%w: field %s referenced by a rule does not hold a number", ErrInvalidValidatorSyntax, path)
+}
+
+// refsError returns an error when an argument of validators, the rules of
+// the field name of typeV, references a field typeV does not have.
+func refsError(typeV reflect.Type, name string, validators []rule) error {
+	for _, validator := range validators {
+		if err := refsError(typeV, name, validator.each); err != nil {
+			return err
+		}
+		for _, alternative := range validator.alternatives {
+			if err := refsError(typeV, name, alternative); err != nil {
+				return err
+			}
+		}
+		for _, ref := range validator.refs {
+			if ref == "" {
+				continue
+			}
+			first, _, _ := strings.Cut(ref, ".")
+			if _, ok := structFieldIndex(typeV, first); !ok {
+				return fmt.Errorf("%w: %s referenced by field %s", ErrUnknownField, first, name)
+			}
+		}
+	}
+	return nil
+}
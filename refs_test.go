@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refLimits struct {
+	Items uint8
+}
+
+type refCart struct {
+	MaxItems int
+	MinTotal float64
+	Limits   refLimits
+	Items    []string          `validate:"max:$MaxItems"`
+	Total    int               `validate:"min:$MinTotal&warn:max:$Limits.Items"`
+	Codes    []string          `validate:"len:$Limits.Items"`
+	Tags     map[string]string `validate:"values:max:$MaxItems"`
+}
+
+func TestFieldRefs(t *testing.T) {
+	valid := refCart{MaxItems: 2, MinTotal: 10, Limits: refLimits{Items: 3}, Items: []string{"a", "b"}, Total: 10, Codes: []string{"abc"}}
+	assert.NoError(t, Validate(valid))
+
+	var errs ValidationErrors
+	invalid := refCart{MaxItems: 1, MinTotal: 20, Limits: refLimits{Items: 2}, Items: []string{"ab"}, Total: 10, Codes: []string{"abc"}, Tags: map[string]string{"a": "xy"}}
+	require.ErrorAs(t, Validate(invalid), &errs)
+	assert.Equal(t, []string{"Items", "Total", "Codes", "Tags"}, fieldsOf(errs))
+
+	res := ValidateResult(refCart{MaxItems: 2, Limits: refLimits{Items: 3}, Total: 5, Codes: []string{"abc"}})
+	assert.True(t, res.Valid())
+	assert.Equal(t, []string{"Total"}, fieldsOf(res.Warnings()))
+
+	assert.NoError(t, ValidateMap(map[string]any{"max": 3.0, "name": "abc"}, map[string]string{"name": "max:$max"}))
+	assert.Error(t, ValidateMap(map[string]any{"max": 2.0, "name": "abc"}, map[string]string{"name": "max:$max"}))
+}
+
+func TestFieldRefErrors(t *testing.T) {
+	type unknownRef struct {
+		Items []string `validate:"max:$MaxItems"`
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(unknownRef{}), &errs)
+	assert.EqualError(t, errs[0].Err, "unknown field: MaxItems referenced by field Items")
+
+	type notNumber struct {
+		Limit string
+		Items []string `validate:"max:$Limit"`
+	}
+	require.ErrorAs(t, Validate(notNumber{}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+
+	require.ErrorAs(t, ValidateVar("abc", "max:$Limit"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+
+	require.ErrorAs(t, ValidateVar("abc", "in:$Limit"), &errs)
+	assert.Equal(t, "in", errs[0].Rule())
+	require.ErrorAs(t, ValidateVar("abc", "max:$1"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+}
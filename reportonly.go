@@ -0,0 +1,59 @@
+package validator
+
+import "reflect"
+
+// WithReportOnly checks the rules named like rules, usually rules being
+// rolled out, without failing the validation: their violations are reported
+// as warnings, to WithHooks by OnRuleFail and to ValidateResult by Warnings,
+// while Validate returns no error for them. The other rules of a field are
+// checked as if the rules were not there:
+//
+//	err := validator.Validate(order, validator.WithReportOnly("max", "unique_sku"), validator.WithHooks(metrics))
+//
+// Names are those of ValidationError.Rule, like "max" for max:10, and apply
+// to built-in, custom and batch rules alike. OnValidate does not count the
+// violations as errors.
+func WithReportOnly(rules ...string) Option {
+	return func(c *config) {
+		if c.reportOnly == nil {
+			c.reportOnly = map[string]bool{}
+		}
+		for _, name := range rules {
+			c.reportOnly[name] = true
+		}
+	}
+}
+
+// splitReportOnly returns validators without the rules of reportOnly, and
+// these rules preceded by the rules deciding whether they apply at all, or
+// nil when validators have none.
+func splitReportOnly(validators []rule, reportOnly map[string]bool) (enforced, reported []rule) {
+	for _, validator := range validators {
+		if reportOnly[validator.name] && !validator.warn {
+			reported = append(reported, validator)
+		} else {
+			enforced = append(enforced, validator)
+		}
+	}
+	if reported == nil {
+		return validators, nil
+	}
+	return enforced, append(controlRules(validators), reported...)
+}
+
+// checkReported applies the rules of WithReportOnly to fieldV, the field name
+// of the struct at path, and reports a warning when it does not satisfy them.
+// The warnings of batch rules are reported by runBatches.
+func (s *validation) checkReported(path *fieldPath, name, cond string, reported []rule, fieldV reflect.Value) {
+	pending := len(s.pending)
+	if err := s.validateField(reported, fieldV); err != nil {
+		s.pending = s.pending[:pending]
+		s.warnings = append(s.warnings, ValidationError{&fieldError{field: path.join(name), cond: cond, rule: s.failed, err: s.redacted(reported, err), reportOnly: true}})
+		return
+	}
+	field := path.join(name)
+	for i := pending; i < len(s.pending); i++ {
+		s.pending[i].field, s.pending[i].cond, s.pending[i].fieldRules = field, cond, reported
+		s.pending[i].reportOnly = true
+	}
+}
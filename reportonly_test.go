@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithReportOnly(t *testing.T) {
+	type order struct {
+		SKU   string `validate:"required&max:5"`
+		Count int    `validate:"omitempty&min:1&max:10"`
+		Note  string `validate:"max:3"`
+	}
+	hooks := &recordingHooks{}
+	opts := []Option{WithReportOnly("max"), WithHooks(hooks)}
+
+	assert.NoError(t, Validate(order{SKU: "too-long", Count: 20, Note: "long"}, opts...))
+	assert.Equal(t, []string{"SKU:max", "Count:max", "Note:max"}, hooks.failures)
+	assert.Equal(t, []recordedValidation{{typ: reflect.TypeOf(order{})}}, hooks.validations)
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(order{Count: -1}, opts...), &errs)
+	assert.Equal(t, []string{"SKU", "Count"}, fieldsOf(errs))
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+
+	result := ValidateResult(order{SKU: "ok", Count: 0, Note: "long"}, opts...)
+	assert.NoError(t, result.Err())
+	require.Len(t, result.Warnings(), 1)
+	assert.Equal(t, "Note", result.Warnings()[0].Field())
+	assert.Equal(t, "max", result.Warnings()[0].Rule())
+	assert.NoError(t, ValidateResult(order{SKU: "ok"}, opts...).Err())
+	assert.Empty(t, ValidateResult(order{SKU: "ok"}, opts...).Warnings())
+
+	require.ErrorAs(t, Validate(order{SKU: "too-long"}), &errs)
+	assert.Equal(t, []string{"SKU"}, fieldsOf(errs))
+}
+
+func TestWithReportOnlyBatchRule(t *testing.T) {
+	errTaken := errors.New("taken")
+	RegisterBatchRule("free_login", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		errs := make([]error, len(values))
+		for i, v := range values {
+			if v.String() == "taken" {
+				errs[i] = errTaken
+			}
+		}
+		return errs, nil
+	})
+	t.Cleanup(func() {
+		delete(batchRules, "free_login")
+		resetPlanCache()
+	})
+
+	type user struct {
+		Login string `validate:"min:3&free_login"`
+	}
+	result := ValidateResult(user{Login: "taken"}, WithReportOnly("free_login"))
+	assert.NoError(t, result.Err())
+	require.Len(t, result.Warnings(), 1)
+	assert.Equal(t, "free_login", result.Warnings()[0].Rule())
+	assert.ErrorIs(t, result.Warnings()[0].Err, errTaken)
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(user{Login: "x"}, WithReportOnly("free_login")), &errs)
+	assert.Equal(t, "min", errs[0].Rule())
+	require.ErrorAs(t, Validate(user{Login: "taken"}), &errs)
+	assert.Equal(t, "free_login", errs[0].Rule())
+}
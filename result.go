@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"context"
+	"strings"
+)
+
+// Result is the outcome of a validation, with helpers to inspect the
+// violations without matching error messages:
+//
+//	result := validator.ValidateResult(u)
+//	if result.Failed("Email") {
+//		...
+//	}
+type Result struct {
+	// err stopped the validation, e.g. ErrNotStruct or a done context.
+	err      error
+	errors   ValidationErrors
+	warnings ValidationErrors
+	changes  []FieldChange
+}
+
+// ValidateResult validates v like Validate and also reports the violations
+// of warning rules, which do not make v invalid. A rule is a warning when
+// prefixed by warn, like the max rule in
+//
+//	Bio string `validate:"required&warn:max:500"`
+//
+// ValidateVar ignores warnings.
+func ValidateResult(v any, opts ...Option) *Result {
+	return validateResult(context.Background(), v, newConfig(opts))
+}
+
+// ValidateResult validates value like the package level ValidateResult
+// function.
+func (v *Validator) ValidateResult(value any) *Result {
+	return validateResult(context.Background(), value, v.config)
+}
+
+func validateResult(ctx context.Context, v any, c config) *Result {
+	s := validation{ctx: ctx, config: c}
+	if err := s.validateRoot(v); err != nil {
+		return &Result{err: err}
+	}
+	r := &Result{changes: s.changes}
+	if err := s.result(); err != nil {
+		if errs, ok := err.(ValidationErrors); ok {
+			r.errors = errs
+		} else {
+			r.err = err
+		}
+	}
+	// Batch rules of WithReportOnly report warnings while checked by result.
+	r.warnings = s.warnings
+	return r
+}
+
+// Err returns the error Validate returns for the value: nil when it is
+// valid, ValidationErrors or an error stopping the validation.
+func (r *Result) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.errors) != 0 {
+		return r.errors
+	}
+	return nil
+}
+
+// Errors returns the errors making the value invalid.
+func (r *Result) Errors() ValidationErrors {
+	return r.errors
+}
+
+// Warnings returns the violations of warning rules, reported even when the
+// value has errors.
+func (r *Result) Warnings() ValidationErrors {
+	return r.warnings
+}
+
+// Valid reports whether the value has no errors. It may still have warnings.
+func (r *Result) Valid() bool {
+	return r.err == nil && len(r.errors) == 0
+}
+
+// Violation is an error of a Result in a form to branch on.
+type Violation struct {
+	// Field is the path of the field that is not valid, e.g. "Address.Zip",
+	// or "" when the error is not about the value of a struct field.
+	Field string
+	// Rules are the rules of the field as written in the tag.
+	Rules string
+	// Rule is the name of the rule that failed and Code its code, see
+	// ValidationError.Code.
+	Rule string
+	Code string
+	// Err is the error of the ValidationError.
+	Err error
+}
+
+func newViolation(e ValidationError) Violation {
+	return Violation{Field: e.Field(), Rules: e.Rules(), Rule: e.Rule(), Code: e.Code(), Err: e.Err}
+}
+
+// Violations returns the errors of the result as violations, in the order
+// they were found.
+func (r *Result) Violations() []Violation {
+	if len(r.errors) == 0 {
+		return nil
+	}
+	violations := make([]Violation, len(r.errors))
+	for i, e := range r.errors {
+		violations[i] = newViolation(e)
+	}
+	return violations
+}
+
+// Failed reports whether the field with the path field, or a field nested
+// in it, is not valid. Paths are written as in errors, e.g. "Address.Zip";
+// a path without indexes like "Items.Name" matches the field of every
+// element.
+func (r *Result) Failed(field string) bool {
+	pattern := strings.Split(field, ".")
+	for _, e := range r.errors {
+		path := e.Field()
+		if path == "" {
+			continue
+		}
+		if covers, _ := matchSegments(pattern, strings.Split(path, ".")); covers {
+			return true
+		}
+	}
+	return false
+}
+
+// ByField groups the errors of the result by the paths of their fields.
+// Errors not about a field are grouped under "".
+func (r *Result) ByField() map[string][]Violation {
+	byField := make(map[string][]Violation)
+	for _, e := range r.errors {
+		v := newViolation(e)
+		byField[v.Field] = append(byField[v.Field], v)
+	}
+	return byField
+}
+
+// First returns the first error of the result, or nil when it has none.
+func (r *Result) First() *Violation {
+	if len(r.errors) == 0 {
+		return nil
+	}
+	v := newViolation(r.errors[0])
+	return &v
+}
+
+// Merge adds the errors, warnings and modifications of other to r, e.g. to report the
+// validation of several values at once. An error stopping the validation of
+// other is kept unless r has one of its own.
+func (r *Result) Merge(other *Result) {
+	if r.err == nil {
+		r.err = other.err
+	}
+	r.errors = append(r.errors, other.errors...)
+	r.warnings = append(r.warnings, other.warnings...)
+	r.changes = append(r.changes, other.changes...)
+}
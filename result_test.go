@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultItem struct {
+	Name string `validate:"required"`
+}
+
+type resultOrder struct {
+	Email   string          `validate:"email"`
+	Address partialAddress  `validate:"required"`
+	Items   []resultItem    `validate:"required"`
+	Code    string          `validate:"len:3&warn:in:abc"`
+	hidden  string          `validate:"len:1"`
+	Extra   *partialAddress `validate:"omitempty"`
+}
+
+func TestResult(t *testing.T) {
+	result := ValidateResult(resultOrder{
+		Email:   "nope",
+		Address: partialAddress{Zip: "1"},
+		Items:   []resultItem{{Name: "a"}, {}},
+		Code:    "xyz",
+	})
+	require.False(t, result.Valid())
+
+	assert.True(t, result.Failed("Email"))
+	assert.True(t, result.Failed("Address"))
+	assert.True(t, result.Failed("Address.Zip"))
+	assert.True(t, result.Failed("Items.Name"))
+	assert.True(t, result.Failed("Items[1]"))
+	assert.False(t, result.Failed("Items[0]"))
+	assert.False(t, result.Failed("Code"))
+	assert.False(t, result.Failed("Extra"))
+
+	first := result.First()
+	require.NotNil(t, first)
+	assert.Equal(t, "Email", first.Field)
+	assert.Equal(t, "email", first.Rules)
+	assert.ErrorIs(t, first.Err, ErrFieldNotValid)
+
+	byField := result.ByField()
+	assert.Len(t, byField, 5)
+	assert.Len(t, byField[""], 1)
+	assert.ErrorIs(t, byField[""][0].Err, ErrValidateForUnexportedFields)
+	assert.Equal(t, "len:5", byField["Address.Zip"][0].Rules)
+
+	violations := result.Violations()
+	require.Len(t, violations, 5)
+	assert.Equal(t, "Items[1].Name", violations[3].Field)
+	assert.Len(t, result.Warnings(), 1)
+
+	valid := ValidateResult(resultItem{Name: "a"})
+	assert.True(t, valid.Valid())
+	assert.Nil(t, valid.First())
+	assert.Nil(t, valid.Violations())
+	assert.Empty(t, valid.ByField())
+	assert.NoError(t, valid.Err())
+
+	valid.Merge(ValidateResult(resultItem{}))
+	valid.Merge(ValidateResult(1))
+	assert.False(t, valid.Valid())
+	assert.Len(t, valid.Errors(), 1)
+	assert.ErrorIs(t, valid.Err(), ErrNotStruct)
+}
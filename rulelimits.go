@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RuleLimits configures how often a custom or batch rule doing I/O is
+// called, see RegisterRuleLimits.
+type RuleLimits struct {
+	// TTL is how long the result of a custom rule is reused for the same
+	// value and parameters, 0 for no cache.
+	TTL time.Duration
+	// MaxEntries limits the number of cached results, 10000 if 0.
+	MaxEntries int
+	// Rate is the number of calls allowed per second, 0 for no limit, and
+	// Burst the number of calls allowed at once, 1 if 0.
+	Rate  float64
+	Burst int
+}
+
+// defaultMaxEntries is the number of cached results of a rule when
+// RuleLimits.MaxEntries is 0.
+const defaultMaxEntries = 10000
+
+var ruleLimits = map[string]*ruleLimiter{}
+
+// RegisterRuleLimits caches the results of the custom rule name and limits
+// the rate of its calls, or the rate of the calls of the batch rule name, so
+// bursts of values do not overload the store behind it:
+//
+//	validator.RegisterRuleLimits("unique_email", validator.RuleLimits{
+//		TTL:   time.Minute,
+//		Rate:  50,
+//		Burst: 10,
+//	})
+//
+// Results are cached for strings, numbers and bools, shared by all
+// validations, and a value being checked is not checked again by a
+// concurrent validation, which waits for the result instead. Errors of a
+// done context are not cached. Calls beyond the rate wait for their turn,
+// unless the context of the validation or the timeout of WithRuleTimeout
+// ends first, which fails the field with the error of the context. Calls
+// answered from the cache are not counted. It is meant to be called during
+// program initialization, like RegisterRule.
+func RegisterRuleLimits(name string, limits RuleLimits) error {
+	if limits.MaxEntries <= 0 {
+		limits.MaxEntries = defaultMaxEntries
+	}
+	if limits.Burst <= 0 {
+		limits.Burst = 1
+	}
+	limiter := &ruleLimiter{
+		limits:  limits,
+		tokens:  float64(limits.Burst),
+		results: map[resultKey]*cachedResult{},
+	}
+	return register("RegisterRuleLimits", func() {
+		ruleLimits[name] = limiter
+	})
+}
+
+// ruleLimiter caches the results of a rule and limits its calls with a
+// token bucket.
+type ruleLimiter struct {
+	limits RuleLimits
+
+	mu      sync.Mutex
+	tokens  float64
+	last    time.Time
+	results map[resultKey]*cachedResult
+}
+
+// resultKey identifies a call of a rule by its parameters and the value
+// checked.
+type resultKey struct {
+	params string
+	value  any
+}
+
+// cachedResult is the result of a call, done once it is known.
+type cachedResult struct {
+	done    chan struct{}
+	err     error
+	expires time.Time
+}
+
+// call calls fn for field with params, or returns its cached result.
+func (l *ruleLimiter) call(ctx context.Context, fn RuleFunc, field reflect.Value, params []string) error {
+	if l.limits.TTL <= 0 || !field.IsValid() || !isScalar(field.Kind()) || !field.CanInterface() {
+		if err := l.wait(ctx); err != nil {
+			return err
+		}
+		return fn(ctx, field, params)
+	}
+
+	key := resultKey{params: strings.Join(params, ","), value: field.Interface()}
+	for {
+		l.mu.Lock()
+		result, ok := l.results[key]
+		if ok && isDone(result.done) && time.Now().After(result.expires) {
+			delete(l.results, key)
+			ok = false
+		}
+		if !ok {
+			result = &cachedResult{done: make(chan struct{})}
+			l.store(key, result)
+			l.mu.Unlock()
+			return l.fill(ctx, key, result, fn, field, params)
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-result.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if result.err == nil || !isContextError(result.err) {
+			return result.err
+		}
+		// The call of another validation was canceled, make one.
+	}
+}
+
+// fill calls fn for the result at key in the cache.
+func (l *ruleLimiter) fill(ctx context.Context, key resultKey, result *cachedResult, fn RuleFunc, field reflect.Value, params []string) (err error) {
+	defer func() {
+		result.err = err
+		result.expires = time.Now().Add(l.limits.TTL)
+		if err != nil && isContextError(err) {
+			l.mu.Lock()
+			if l.results[key] == result {
+				delete(l.results, key)
+			}
+			l.mu.Unlock()
+		}
+		close(result.done)
+	}()
+	if err := l.wait(ctx); err != nil {
+		return err
+	}
+	return fn(ctx, field, params)
+}
+
+// store adds result to the cache, dropping expired results or else any
+// other one when it is full. l.mu must be held.
+func (l *ruleLimiter) store(key resultKey, result *cachedResult) {
+	if len(l.results) >= l.limits.MaxEntries {
+		now := time.Now()
+		for k, r := range l.results {
+			if isDone(r.done) && now.After(r.expires) {
+				delete(l.results, k)
+			}
+		}
+		for k := range l.results {
+			if len(l.results) < l.limits.MaxEntries {
+				break
+			}
+			delete(l.results, k)
+		}
+	}
+	l.results[key] = result
+}
+
+// wait waits until a call is allowed by the rate of the limits, or returns
+// the error of ctx when it is done first.
+func (l *ruleLimiter) wait(ctx context.Context) error {
+	if l.limits.Rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.limits.Rate
+		if burst := float64(l.limits.Burst); l.tokens > burst {
+			l.tokens = burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.limits.Rate * float64(time.Second))
+	l.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the turn back to the calls waiting after this one.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// isDone reports whether done is closed.
+func isDone(done chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
+
+// isContextError reports whether err is the error of a done context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,124 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterRuleLimitsCache(t *testing.T) {
+	var calls atomic.Int32
+	RegisterRule("blocklisted", func(ctx context.Context, field reflect.Value, params []string) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		if field.String() == "spam" {
+			return errTaken
+		}
+		return nil
+	})
+	RegisterRuleLimits("blocklisted", RuleLimits{TTL: time.Minute})
+	t.Cleanup(func() {
+		delete(customRules, "blocklisted")
+		delete(ruleLimits, "blocklisted")
+		resetPlanCache()
+	})
+
+	type comment struct {
+		Authors []string `validate:"blocklisted"`
+	}
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(comment{Authors: []string{"ann", "spam", "ann", "spam"}}), &errs)
+	assert.ErrorIs(t, errs[0].Err, errTaken)
+	assert.EqualValues(t, 2, calls.Load())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, ValidateVar("bob", "blocklisted"))
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 3, calls.Load())
+
+	require.NoError(t, ValidateVar("bob", "blocklisted:strict"))
+	assert.EqualValues(t, 4, calls.Load())
+}
+
+func TestRegisterRuleLimitsExpiry(t *testing.T) {
+	calls := 0
+	RegisterRule("lookup", func(ctx context.Context, field reflect.Value, params []string) error {
+		calls++
+		return ctx.Err()
+	})
+	RegisterRuleLimits("lookup", RuleLimits{TTL: 20 * time.Millisecond, MaxEntries: 1})
+	t.Cleanup(func() {
+		delete(customRules, "lookup")
+		delete(ruleLimits, "lookup")
+		resetPlanCache()
+	})
+
+	require.NoError(t, ValidateVar("a", "lookup"))
+	require.NoError(t, ValidateVar("a", "lookup"))
+	assert.Equal(t, 1, calls)
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, ValidateVar("a", "lookup"))
+	assert.Equal(t, 2, calls)
+
+	require.NoError(t, ValidateVar("b", "lookup"))
+	assert.Len(t, ruleLimits["lookup"].results, 1)
+
+	// Errors of a done context are not cached.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	type value struct {
+		A string `validate:"lookup"`
+	}
+	assert.ErrorIs(t, ValidateCtx(ctx, value{A: "c"}), context.Canceled)
+	require.NoError(t, Validate(value{A: "c"}))
+	assert.Equal(t, 4, calls)
+}
+
+func TestRegisterRuleLimitsRate(t *testing.T) {
+	calls := 0
+	RegisterRule("remote", func(ctx context.Context, field reflect.Value, params []string) error {
+		calls++
+		return nil
+	})
+	RegisterRuleLimits("remote", RuleLimits{Rate: 50, Burst: 2})
+	RegisterBatchRule("remote_batch", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		return make([]error, len(values)), nil
+	})
+	RegisterRuleLimits("remote_batch", RuleLimits{Rate: 1})
+	t.Cleanup(func() {
+		delete(customRules, "remote")
+		delete(batchRules, "remote_batch")
+		delete(ruleLimits, "remote")
+		delete(ruleLimits, "remote_batch")
+		resetPlanCache()
+	})
+
+	type item struct {
+		Names []string `validate:"remote"`
+	}
+	start := time.Now()
+	require.NoError(t, Validate(item{Names: []string{"a", "b", "c", "d"}}))
+	assert.Equal(t, 4, calls)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	type batch struct {
+		Name string `validate:"remote_batch"`
+	}
+	require.NoError(t, Validate(batch{Name: "a"}))
+	err := Validate(batch{Name: "b"}, WithRuleTimeout(10*time.Millisecond, "remote_batch"))
+	assert.ErrorIs(t, err, ErrRuleTimeout)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
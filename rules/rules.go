@@ -0,0 +1,235 @@
+// Package rules provides type-safe constructors for the built-in validation
+// rules, for use with validator.Field and validator.Struct:
+//
+//	err := validator.Struct(u,
+//		validator.F("Email", rules.Required(), rules.Email()),
+//		validator.F("Age", rules.Min(18)),
+//	)
+package rules
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nadya2002/validator"
+)
+
+// Required requires a non-empty value.
+func Required() validator.Rule { return rule("required") }
+
+// NotNil requires a pointer, interface, map, slice, channel or func that is
+// not nil, which may still be empty.
+func NotNil() validator.Rule { return rule("notnil") }
+
+// OmitEmpty skips the following rules for an empty value.
+func OmitEmpty() validator.Rule { return rule("omitempty") }
+
+// Bail stops checking a value at the first rule it fails, as is the default.
+func Bail() validator.Rule { return rule("bail") }
+
+// Continue checks a value against all the rules, reporting each it fails.
+func Continue() validator.Rule { return rule("continue") }
+
+// Len requires a string of exactly n bytes.
+func Len(n int) validator.Rule { return rule("len", strconv.Itoa(n)) }
+
+// Min requires a number of at least n or a string of at least n bytes.
+func Min(n int) validator.Rule { return rule("min", strconv.Itoa(n)) }
+
+// Max requires a number of at most n or a string of at most n bytes.
+func Max(n int) validator.Rule { return rule("max", strconv.Itoa(n)) }
+
+// In requires the value to be one of values.
+func In[T ~string](values ...T) validator.Rule {
+	params := make([]string, len(values))
+	for i, v := range values {
+		params[i] = string(v)
+	}
+	return rule("in", params...)
+}
+
+// InInt requires the number to be one of values.
+func InInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](values ...T) validator.Rule {
+	params := make([]string, len(values))
+	for i, v := range values {
+		params[i] = strconv.FormatInt(int64(v), 10)
+	}
+	return rule("in", params...)
+}
+
+// AsNum applies the other rules to the number a string holds, like
+// "8080", and requires it to hold one.
+func AsNum() validator.Rule { return rule("asnum") }
+
+// Email requires a bare email address like "user@example.com".
+func Email() validator.Rule { return rule("email") }
+
+// ULID requires a ULID in its canonical base32 form.
+func ULID() validator.Rule { return rule("ulid") }
+
+// ObjectID requires a MongoDB ObjectID in hexadecimal form.
+func ObjectID() validator.Rule { return rule("objectid") }
+
+// File requires the path of an existing regular file.
+func File() validator.Rule { return rule("file") }
+
+// Dir requires the path of an existing directory.
+func Dir() validator.Rule { return rule("dir") }
+
+// FilePath requires a syntactically valid path.
+func FilePath() validator.Rule { return rule("filepath") }
+
+// NoHTML rejects strings containing HTML tags or script content.
+func NoHTML() validator.Rule { return rule("no_html") }
+
+// URLEncoded requires a percent-encoded string, like the query values
+// escaped by url.QueryEscape.
+func URLEncoded() validator.Rule { return rule("urlencoded") }
+
+// Slug requires lowercase letters and digits in words separated by single
+// hyphens, like "my-post-1".
+func Slug() validator.Rule { return rule("slug") }
+
+// DNSLabel requires an RFC 1123 DNS label, like the names of Kubernetes
+// objects.
+func DNSLabel() validator.Rule { return rule("dns_label") }
+
+// K8sQuantity requires a Kubernetes resource quantity, like "500Mi" or "2".
+func K8sQuantity() validator.Rule { return rule("k8s_quantity") }
+
+// K8sLabel requires a Kubernetes label key, like "app.kubernetes.io/name".
+func K8sLabel() validator.Rule { return rule("k8s_label") }
+
+// HexColor requires a hexadecimal color, like "#ff8800" or "#f80".
+func HexColor() validator.Rule { return rule("hexcolor") }
+
+// RGB requires a CSS rgb color, like "rgb(255, 136, 0)".
+func RGB() validator.Rule { return rule("rgb") }
+
+// RGBA requires a CSS rgba color, like "rgba(255, 136, 0, 0.5)".
+func RGBA() validator.Rule { return rule("rgba") }
+
+// HSL requires a CSS hsl color, like "hsl(32, 100%, 50%)".
+func HSL() validator.Rule { return rule("hsl") }
+
+// Cron requires a cron expression of five or six fields, like
+// "*/15 9-17 * * MON-FRI", or a macro like "@daily".
+func Cron() validator.Rule { return rule("cron") }
+
+// MIMEType requires a media type, like "image/png" or
+// "text/html; charset=utf-8".
+func MIMEType() validator.Rule { return rule("mimetype") }
+
+// Charset requires the name of a character set, like "utf-8".
+func Charset() validator.Rule { return rule("charset") }
+
+// CardExpiry requires the expiry date of a payment card, like "09/27", not
+// in the past of the clock of validator.WithClock.
+func CardExpiry() validator.Rule { return rule("card_expiry") }
+
+// CVV requires a card security code of one of the numbers of digits.
+func CVV(digits ...int) validator.Rule {
+	params := make([]string, len(digits))
+	for i, n := range digits {
+		params[i] = strconv.Itoa(n)
+	}
+	return rule("cvv", params...)
+}
+
+// PrintableUnicode requires valid UTF-8 without control characters.
+func PrintableUnicode() validator.Rule { return rule("printable_unicode") }
+
+// Regexp requires a string matching the regular expression pattern, which
+// must not contain "&".
+func Regexp(pattern string) validator.Rule { return rule("regexp", pattern) }
+
+// MaxSize requires an uploaded *multipart.FileHeader of at most n bytes.
+func MaxSize(n int64) validator.Rule { return rule("maxsize", strconv.FormatInt(n, 10)) }
+
+// MaxBytes requires the strings and byte slices a value holds to take at
+// most n bytes in total.
+func MaxBytes(n int64) validator.Rule { return rule("maxbytes", strconv.FormatInt(n, 10)) }
+
+// MIME requires an uploaded file declaring one of the content types, which
+// may be wildcards like "image/*".
+func MIME(types ...string) validator.Rule { return rule("mime", types...) }
+
+// Ext requires an uploaded file whose name has one of the extensions, like
+// ".png". They are compared case-insensitively.
+func Ext(exts ...string) validator.Rule { return rule("ext", exts...) }
+
+// Or requires a value satisfying all the rules of one of the alternatives,
+// like Or(All(Email()), All(Len(0))) for "(email|len:0)".
+func Or(alternatives ...[]validator.Rule) validator.Rule {
+	params := make([]string, len(alternatives))
+	for i, alternative := range alternatives {
+		rules := make([]string, len(alternative))
+		for j, r := range alternative {
+			rules[j] = r.String()
+		}
+		params[i] = strings.Join(rules, "&")
+	}
+	return rule("or", params...)
+}
+
+// All returns rules that must all hold, for an alternative of Or.
+func All(rules ...validator.Rule) []validator.Rule { return rules }
+
+// Within requires a time.Time at most d before or after now.
+func Within(d time.Duration) validator.Rule { return rule("within", d.String()) }
+
+// MinAge requires a birth date, a time.Time or a string like "2006-01-02",
+// at least years before now.
+func MinAge(years int) validator.Rule { return rule("minage", strconv.Itoa(years)) }
+
+// Checksum requires the checksum of a string or byte slice computed by the
+// algorithm, like "crc32", to be sum, in hexadecimal, or to be held by a
+// field referenced like "$Digest".
+func Checksum(algorithm, sum string) validator.Rule { return rule("checksum", algorithm+":"+sum) }
+
+// UniqueField requires the structs of a slice to differ in the fields, all
+// of them at once when several are given.
+func UniqueField(fields ...string) validator.Rule { return rule("uniquefield", fields...) }
+
+// ExcludedWith requires an empty value when any of the fields of the struct
+// holding it is set.
+func ExcludedWith(fields ...string) validator.Rule { return rule("excluded_with", fields...) }
+
+// ExactlyOneOf requires exactly one of the value and the fields of the
+// struct holding it to be set.
+func ExactlyOneOf(fields ...string) validator.Rule { return rule("exactly_one_of", fields...) }
+
+// Assert requires the comparison of arithmetic expressions over the numeric
+// fields of the struct holding the value to hold, like
+// "Total==Subtotal+Tax".
+func Assert(assertion string) validator.Rule { return rule("assert", assertion) }
+
+// SumField requires the sum of the field over the structs of a slice to
+// satisfy the bounds, Min and Max rules.
+func SumField(field string, bounds ...validator.Rule) validator.Rule {
+	params := []string{field}
+	for _, bound := range bounds {
+		params = append(params, bound.String())
+	}
+	return rule("sumfield", params...)
+}
+
+// Password requires a password satisfying the composition requirements p.
+func Password(p validator.PasswordRules) validator.Rule {
+	return rule("password",
+		"min="+strconv.Itoa(p.Min),
+		"upper="+strconv.Itoa(p.Upper),
+		"lower="+strconv.Itoa(p.Lower),
+		"digit="+strconv.Itoa(p.Digit),
+		"symbol="+strconv.Itoa(p.Symbol),
+	)
+}
+
+// PasswordPolicy requires a password accepted by the policy registered under
+// name with validator.RegisterPasswordPolicy.
+func PasswordPolicy(name string) validator.Rule { return rule("password", name) }
+
+func rule(name string, params ...string) validator.Rule {
+	return validator.Rule{Name: name, Params: params}
+}
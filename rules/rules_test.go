@@ -0,0 +1,106 @@
+package rules_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nadya2002/validator"
+	"github.com/Nadya2002/validator/rules"
+)
+
+type Status string
+
+type Address struct {
+	Zip string
+}
+
+type user struct {
+	Email   string
+	Age     int
+	Status  Status
+	Address *Address
+	secret  string
+}
+
+func TestRuleString(t *testing.T) {
+	assert.Equal(t, "required", rules.Required().String())
+	assert.Equal(t, "min:18", rules.Min(18).String())
+	assert.Equal(t, "in:new,done", rules.In[Status]("new", "done").String())
+	assert.Equal(t, "in:-1,2", rules.InInt(-1, 2).String())
+	assert.Equal(t, "within:24h0m0s", rules.Within(24*time.Hour).String())
+	assert.Equal(t, "asnum", rules.AsNum().String())
+	assert.Equal(t, "continue", rules.Continue().String())
+	assert.Equal(t, "notnil", rules.NotNil().String())
+	assert.Equal(t, "uniquefield:OrderID,Position", rules.UniqueField("OrderID", "Position").String())
+	assert.Equal(t, "sumfield:Amount,min:1,max:1000", rules.SumField("Amount", rules.Min(1), rules.Max(1000)).String())
+	assert.Equal(t, "maxbytes:4096", rules.MaxBytes(4096).String())
+	assert.Equal(t, "dns_label", rules.DNSLabel().String())
+	assert.Equal(t, "k8s_quantity", rules.K8sQuantity().String())
+	assert.Equal(t, "hexcolor", rules.HexColor().String())
+	assert.Equal(t, "cron", rules.Cron().String())
+	assert.Equal(t, "mimetype", rules.MIMEType().String())
+	assert.Equal(t, "cvv:3,4", rules.CVV(3, 4).String())
+	assert.Equal(t, "minage:18", rules.MinAge(18).String())
+	assert.Equal(t, "checksum:crc32:414fa339", rules.Checksum("crc32", "414fa339").String())
+	assert.Equal(t, "exactly_one_of:Card,Bank", rules.ExactlyOneOf("Card", "Bank").String())
+	assert.Equal(t, "assert:Total==Subtotal+Tax", rules.Assert("Total==Subtotal+Tax").String())
+	assert.Equal(t, "(email|len:0&required)", rules.Or(rules.All(rules.Email()), rules.All(rules.Len(0), rules.Required())).String())
+	assert.Equal(t, "password:min=12,upper=1,lower=0,digit=1,symbol=0",
+		rules.Password(validator.PasswordRules{Min: 12, Upper: 1, Digit: 1}).String())
+}
+
+func TestField(t *testing.T) {
+	u := user{Email: "user@example.com", Age: 16}
+
+	assert.NoError(t, validator.Field(&u.Email, rules.Required(), rules.Email()))
+
+	err := validator.Field(&u.Age, rules.Min(18), rules.Max(120))
+	assert.Error(t, err)
+	assert.Equal(t, "value not valid for min:18&max:120", err.Error())
+
+	err = validator.Field(&u.Email, validator.Rule{Name: "min", Params: []string{"x"}})
+	assert.True(t, errors.Is(err.(validator.ValidationErrors)[0].Err, validator.ErrInvalidValidatorSyntax))
+
+	var empty user
+	err = validator.Field(&empty.Email, rules.Required())
+	assert.EqualError(t, err, "value not valid for required")
+	assert.Error(t, validator.Field(&empty.Age, rules.Required()))
+	assert.NoError(t, validator.Field(&empty.Age, rules.OmitEmpty(), rules.Min(18)))
+
+	var nickname *string
+	assert.Error(t, validator.Field(&nickname, rules.Required()))
+	name := ""
+	nickname = &name
+	assert.NoError(t, validator.Field(&nickname, rules.Required()))
+}
+
+func TestStruct(t *testing.T) {
+	fields := []validator.FieldRules{
+		validator.F("Email", rules.Required(), rules.Email()),
+		validator.F("Age", rules.Min(18)),
+		validator.F("Status", rules.In[Status]("new", "done")),
+		validator.F("Address.Zip", rules.Len(6)),
+	}
+
+	valid := user{Email: "user@example.com", Age: 18, Status: "new", Address: &Address{Zip: "123456"}}
+	assert.NoError(t, validator.Struct(valid, fields...))
+	assert.NoError(t, validator.Struct(&valid, fields...))
+
+	err := validator.Struct(user{Email: "nope", Age: 17, Status: "lost", Address: &Address{}}, fields...)
+	assert.Error(t, err)
+	assert.Equal(t, "field: Email not valid for required&email"+
+		"field: Age not valid for min:18"+
+		"field: Status not valid for in:new,done"+
+		"field: Address.Zip not valid for len:6", err.Error())
+
+	err = validator.Struct(valid, validator.F("Missing", rules.Required()), validator.F("secret", rules.Required()))
+	assert.Error(t, err)
+	for _, e := range err.(validator.ValidationErrors) {
+		assert.ErrorIs(t, e.Err, validator.ErrUnknownField)
+	}
+
+	assert.ErrorIs(t, validator.Struct(42), validator.ErrNotStruct)
+}
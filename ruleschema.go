@@ -0,0 +1,174 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Schema holds rules for the fields of values defined outside of the code,
+// like in a file loaded at startup, so rules can change without
+// recompiling, e.g. per tenant. A schema maps field names to rules, with
+// nested objects for the fields of nested structs or maps:
+//
+//	{
+//		"email": "required&email",
+//		"address": {
+//			"zip": "len:6"
+//		}
+//	}
+//
+// ParseSchemaJSON reads such a document; configvalidate.ParseSchemaYAML
+// reads YAML. A compiled Schema is safe for concurrent use.
+type Schema struct {
+	fields []schemaField
+}
+
+type schemaField struct {
+	path       string
+	rules      string
+	validators []rule
+}
+
+// NewSchema compiles a schema from fields, which map names to rules written
+// in the syntax of the validate tag, or to maps like fields themselves for
+// nested fields. Rules that cannot be parsed are reported as
+// ValidationErrors.
+func NewSchema(fields map[string]any) (*Schema, error) {
+	s := &Schema{}
+	var errs ValidationErrors
+	s.add("", fields, &errs)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	return s, nil
+}
+
+// ParseSchemaJSON compiles the schema in the JSON document data.
+func ParseSchemaJSON(data []byte) (*Schema, error) {
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return NewSchema(fields)
+}
+
+// UnmarshalJSON compiles the schema in the JSON document data, so schemas
+// can be part of configuration files.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseSchemaJSON(data)
+	if err != nil {
+		return err
+	}
+	*s = *parsed
+	return nil
+}
+
+// add adds fields at the path prefix, sorted by name, so fields are
+// validated and errors reported in a deterministic order.
+func (s *Schema) add(prefix string, fields map[string]any, errs *ValidationErrors) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := joinPath(prefix, name)
+		switch value := fields[name].(type) {
+		case string:
+			validators, err := parseValidators(value)
+			if err != nil {
+				*errs = append(*errs, ValidationError{withField(err, path)})
+				continue
+			}
+			s.fields = append(s.fields, schemaField{path: path, rules: value, validators: validators})
+		case map[string]any:
+			s.add(path, value, errs)
+		default:
+			*errs = append(*errs, ValidationError{fmt.Errorf("%w: field %s has rules of type %T", ErrInvalidValidatorSyntax, path, value)})
+		}
+	}
+}
+
+// Validate validates v against the rules of the schema instead of the rules
+// of its tags. v is a struct, a pointer to one, or a map with string keys,
+// like a decoded JSON object. Fields of structs are found by their names or
+// by the names of their json tags, a field missing from a struct is reported
+// with ErrUnknownField. A key missing from a map is an empty value.
+func (s *Schema) Validate(v any) error {
+	return s.ValidateCtx(context.Background(), v)
+}
+
+// ValidateCtx validates v like Validate, passing ctx to custom rules.
+func (s *Schema) ValidateCtx(ctx context.Context, v any) error {
+	valueV := reflect.ValueOf(v)
+	for valueV.Kind() == reflect.Pointer && !valueV.IsNil() {
+		valueV = valueV.Elem()
+	}
+	if valueV.Kind() != reflect.Struct && (valueV.Kind() != reflect.Map || valueV.Type().Key().Kind() != reflect.String) {
+		return ErrNotStruct
+	}
+
+	state := validation{ctx: ctx}
+	for _, f := range s.fields {
+		field, ok := fieldByKey(valueV, f.path)
+		if !ok {
+			state.errors = append(state.errors, ValidationError{fmt.Errorf("%w: %s", ErrUnknownField, f.path)})
+			continue
+		}
+		state.checkVar(f.path, f.rules, f.validators, valueV, field)
+	}
+	return state.result()
+}
+
+// fieldByKey returns the value at the dotted path in v, made of struct
+// fields and map keys. A key missing from a map is the invalid value, ok is
+// false for a missing struct field.
+func fieldByKey(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, true
+			}
+			v = v.Elem()
+		}
+		switch {
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		case v.Kind() == reflect.Struct:
+			index, ok := structFieldIndex(v.Type(), name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			// A nil embedded pointer holds no value.
+			v, _ = v.FieldByIndexErr(index)
+		default:
+			return reflect.Value{}, true
+		}
+		if !v.IsValid() {
+			return v, true
+		}
+	}
+	return v, true
+}
+
+// structFieldIndex returns the index of the exported field of t named name
+// or having name in its json tag.
+func structFieldIndex(t reflect.Type, name string) ([]int, bool) {
+	if f, ok := t.FieldByName(name); ok && f.IsExported() {
+		return f.Index, true
+	}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == name {
+			return f.Index, true
+		}
+	}
+	return nil, false
+}
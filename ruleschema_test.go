@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantAddress struct {
+	Zip string `json:"zip"`
+}
+
+type tenantUser struct {
+	Email   string `json:"email" validate:"required"`
+	Age     int
+	Address *tenantAddress `json:"address"`
+}
+
+const tenantSchema = `{
+	"email": "required&email",
+	"Age": "min:18",
+	"address": {
+		"zip": "len:6"
+	}
+}`
+
+func TestSchema(t *testing.T) {
+	schema, err := ParseSchemaJSON([]byte(tenantSchema))
+	require.NoError(t, err)
+
+	assert.NoError(t, schema.Validate(tenantUser{Email: "ann@example.com", Age: 30, Address: &tenantAddress{Zip: "123456"}}))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, schema.Validate(&tenantUser{Email: "ann", Age: 17, Address: &tenantAddress{Zip: "1"}}), &errs)
+	assert.Equal(t, []string{"Age", "address.zip", "email"}, fieldsOf(errs))
+
+	// The zip of a nil address is empty, so it fails len.
+	assert.Error(t, schema.Validate(tenantUser{Email: "ann@example.com", Age: 30}))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{"email": "ann@example.com", "Age": 20, "address": {"zip": "12"}}`), &payload))
+	require.ErrorAs(t, schema.Validate(payload), &errs)
+	assert.Equal(t, []string{"address.zip"}, fieldsOf(errs))
+
+	assert.ErrorIs(t, schema.Validate(42), ErrNotStruct)
+}
+
+func TestSchemaUnknownField(t *testing.T) {
+	schema, err := NewSchema(map[string]any{"Phone": "required"})
+	require.NoError(t, err)
+	var errs ValidationErrors
+	require.ErrorAs(t, schema.Validate(tenantUser{}), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrUnknownField)
+}
+
+func TestSchemaErrors(t *testing.T) {
+	_, err := NewSchema(map[string]any{"a": "max:x", "b": 5, "c": map[string]any{"d": "min:1"}})
+	var errs ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0].Err, `invalid validator syntax: rule "max:x" at offset 0 of field a`)
+	assert.EqualError(t, errs[1].Err, "invalid validator syntax: field b has rules of type int")
+
+	_, err = ParseSchemaJSON([]byte(`[`))
+	assert.Error(t, err)
+
+	var config struct {
+		Users Schema `json:"users"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"users": `+tenantSchema+`}`), &config))
+	assert.Error(t, config.Users.Validate(tenantUser{}))
+}
@@ -0,0 +1,556 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// JSONSchemaFunc translates the rule r applied to values of type t to JSON
+// Schema keywords, e.g. {"pattern": "^[A-Z]{3}$"}, nil for none.
+type JSONSchemaFunc func(r Rule, t reflect.Type) map[string]any
+
+var schemaRules = map[string]JSONSchemaFunc{}
+
+// RegisterJSONSchema makes ExportJSONSchema and ExportOpenAPIComponents
+// translate the rule name with fn, e.g. for a custom rule, or a built-in one
+// translated otherwise:
+//
+//	validator.RegisterJSONSchema("currency", func(r validator.Rule, t reflect.Type) map[string]any {
+//		return map[string]any{"pattern": "^[A-Z]{3}$"}
+//	})
+//
+// The keywords apply to the values the rule checks, like the elements of a
+// slice, and do not replace the ones the rules of the field set themselves.
+// It is meant to be called during program initialization.
+func RegisterJSONSchema(name string, fn JSONSchemaFunc) error {
+	return register("RegisterJSONSchema", func() {
+		schemaRules[name] = fn
+	})
+}
+
+// ExportJSONSchema returns a draft 2020-12 JSON Schema for the struct type of
+// v, which may also be a pointer to a struct, so API docs and client side
+// validation stay in sync with the Go type. Properties are named like
+// encoding/json names them and nested structs are put in $defs.
+//
+// The rules are translated as far as JSON Schema can express them: required,
+// len, min, max, in, email, ulid, objectid, regexp and the length of
+// password rules. Rules without an equivalent, like no_html or custom
+// rules, are left out, so the schema may accept values Validate rejects.
+// Lengths are counted in bytes by Validate but in characters by JSON
+// Schema. Tags Lint reports are returned as errors.
+func ExportJSONSchema(v any) ([]byte, error) {
+	typeV := reflect.TypeOf(v)
+	if err := Lint(typeV); err != nil {
+		return nil, err
+	}
+	for typeV.Kind() == reflect.Pointer {
+		typeV = typeV.Elem()
+	}
+
+	b := newSchemaBuilder("#/$defs/")
+	b.root = typeV
+	root := b.structSchema(typeV)
+	root.Schema = jsonSchemaDraft
+	if len(b.defs.names) != 0 {
+		root.Defs = b.defs
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// ExportOpenAPIComponents returns OpenAPI 3.1 component schemas for the
+// given struct types and the structs nested in them, translated like
+// ExportJSONSchema does, e.g. for ExportOpenAPIComponents(User{}, Order{}):
+//
+//	{"components": {"schemas": {"User": {...}, "Address": {...}, "Order": {...}}}}
+//
+// Nested structs are referred to as "#/components/schemas/Address", so the
+// result can be merged into an API spec as is. Instantiations of generic
+// types are named after their type arguments, like "Page_User" for
+// Page[User].
+func ExportOpenAPIComponents(types ...any) ([]byte, error) {
+	b := newSchemaBuilder("#/components/schemas/")
+	for _, t := range types {
+		typeV := reflect.TypeOf(t)
+		if err := Lint(typeV); err != nil {
+			return nil, err
+		}
+		for typeV.Kind() == reflect.Pointer {
+			typeV = typeV.Elem()
+		}
+		if typeV.Name() == "" {
+			return nil, ErrNotStruct
+		}
+		b.ref(typeV)
+	}
+
+	doc := struct {
+		Components struct {
+			Schemas *schemaProperties `json:"schemas"`
+		} `json:"components"`
+	}{}
+	doc.Components.Schemas = b.defs
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// jsonSchema is the subset of JSON Schema ExportJSONSchema produces, in the
+// order the keywords are written.
+type jsonSchema struct {
+	Schema string `json:"$schema,omitempty"`
+	Ref    string `json:"$ref,omitempty"`
+	Type   string `json:"type,omitempty"`
+
+	Format    string `json:"format,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Minimum   *int   `json:"minimum,omitempty"`
+	Maximum   *int   `json:"maximum,omitempty"`
+	MinItems  *int   `json:"minItems,omitempty"`
+	MaxItems  *int   `json:"maxItems,omitempty"`
+	Enum      []any  `json:"enum,omitempty"`
+	Const     any    `json:"const,omitempty"`
+	Default   any    `json:"default,omitempty"`
+
+	Not   *jsonSchema   `json:"not,omitempty"`
+	AnyOf []*jsonSchema `json:"anyOf,omitempty"`
+
+	Items                *jsonSchema       `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema       `json:"additionalProperties,omitempty"`
+	PropertyNames        *jsonSchema       `json:"propertyNames,omitempty"`
+	Properties           *schemaProperties `json:"properties,omitempty"`
+	Required             []string          `json:"required,omitempty"`
+
+	Defs *schemaProperties `json:"$defs,omitempty"`
+
+	// extra holds the keywords of RegisterJSONSchema.
+	extra map[string]any
+}
+
+// MarshalJSON writes the keywords of s, followed by the extra ones it does
+// not set itself, sorted.
+func (s *jsonSchema) MarshalJSON() ([]byte, error) {
+	type keywords jsonSchema
+	data, err := json.Marshal((*keywords)(s))
+	if err != nil || len(s.extra) == 0 {
+		return data, err
+	}
+	var set map[string]json.RawMessage
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(s.extra))
+	for name := range s.extra {
+		if _, ok := set[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	buf := bytes.NewBuffer(data[:len(data)-1])
+	for _, name := range names {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, err := json.Marshal(s.extra[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// schemaProperties are named schemas written in the order they were added.
+type schemaProperties struct {
+	names   []string
+	schemas []*jsonSchema
+}
+
+func (p *schemaProperties) add(name string, schema *jsonSchema) {
+	p.names = append(p.names, name)
+	p.schemas = append(p.schemas, schema)
+}
+
+func (p *schemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(p.schemas[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type schemaBuilder struct {
+	// root is the type of the whole schema, all other structs are put into
+	// defs and referred to by refPrefix followed by their name.
+	root      reflect.Type
+	refPrefix string
+	// names maps the struct types in defs to their names there, taken holds
+	// the names used.
+	names map[reflect.Type]string
+	taken map[string]bool
+	defs  *schemaProperties
+}
+
+func newSchemaBuilder(refPrefix string) *schemaBuilder {
+	return &schemaBuilder{
+		refPrefix: refPrefix,
+		names:     map[reflect.Type]string{},
+		taken:     map[string]bool{},
+		defs:      &schemaProperties{},
+	}
+}
+
+func (b *schemaBuilder) structSchema(typeV reflect.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object", Properties: &schemaProperties{}}
+	b.addFields(s, typeV)
+	return s
+}
+
+// addFields adds the fields of the struct type typeV to s, flattening
+// embedded structs like encoding/json does.
+func (b *schemaBuilder) addFields(s *jsonSchema, typeV reflect.Type) {
+	for i := 0; i < typeV.NumField(); i++ {
+		fieldT := typeV.Field(i)
+		jsonTag := fieldT.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(jsonTag, ",")
+
+		if fieldT.Anonymous && name == "" {
+			embedded := fieldT.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(s, embedded)
+				continue
+			}
+		}
+		if !fieldT.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = fieldT.Name
+		}
+
+		prop := b.typeSchema(fieldT.Type)
+		if cond := fieldRules(typeV, fieldT, defaultTagName); cond != "" && cond != "-" {
+			validators, _ := cachedValidators(cond)
+			if applyRules(prop, fieldT.Type, validators) {
+				s.Required = append(s.Required, name)
+			}
+		}
+		s.Properties.add(name, prop)
+	}
+}
+
+func (b *schemaBuilder) typeSchema(t reflect.Type) *jsonSchema {
+	if t == timeType {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return &jsonSchema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.typeSchema(t.Elem())
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes byte slices as base64 strings.
+			return &jsonSchema{Type: "string"}
+		}
+		return &jsonSchema{Type: "array", Items: b.typeSchema(t.Elem())}
+	case reflect.Array:
+		n := t.Len()
+		return &jsonSchema{Type: "array", Items: b.typeSchema(t.Elem()), MinItems: &n, MaxItems: &n}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: b.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return b.ref(t)
+	}
+	return &jsonSchema{}
+}
+
+// ref returns a reference to the schema of the struct type t, adding it to
+// the $defs on first use. Anonymous structs are inlined.
+func (b *schemaBuilder) ref(t reflect.Type) *jsonSchema {
+	if t == b.root {
+		return &jsonSchema{Ref: "#"}
+	}
+	if t.Name() == "" {
+		return b.structSchema(t)
+	}
+
+	name, ok := b.names[t]
+	if !ok {
+		base := schemaName(t)
+		name = base
+		for i := 2; b.taken[name]; i++ {
+			name = base + strconv.Itoa(i)
+		}
+		b.names[t], b.taken[name] = name, true
+
+		// The name is reserved first, so recursive types refer to it.
+		def := &jsonSchema{}
+		b.defs.add(name, def)
+		*def = *b.structSchema(t)
+	}
+	return &jsonSchema{Ref: b.refPrefix + name}
+}
+
+// schemaName returns the name of the schema of the named struct type t. The
+// instantiations of generic types are named after their type arguments
+// without their packages, like "Page_User" for Page[example.com/app.User], as
+// references cannot hold slashes and OpenAPI component names brackets.
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	if !strings.Contains(name, "[") {
+		return name
+	}
+	var parts []string
+	start := 0
+	for i := 0; i <= len(name); i++ {
+		if i < len(name) && (isAlnum(name[i]) || name[i] == '_' || name[i] == '-') {
+			continue
+		}
+		// Segments followed by a dot or a slash belong to the package path.
+		if i > start && (i == len(name) || name[i] != '.' && name[i] != '/') {
+			parts = append(parts, name[start:i])
+		}
+		start = i + 1
+	}
+	return strings.Join(parts, "_")
+}
+
+// ulidPattern, objectIDPattern and the other patterns describe the values
+// accepted by the ulid, objectid, urlencoded, slug, dns_label and hexcolor
+// rules.
+const (
+	ulidPattern       = "^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$"
+	objectIDPattern   = "^[0-9a-fA-F]{24}$"
+	urlEncodedPattern = "^([0-9A-Za-z._~+-]|%[0-9A-Fa-f]{2})*$"
+	slugPattern       = "^[a-z0-9]+(-[a-z0-9]+)*$"
+	dnsLabelPattern   = "^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$"
+	hexColorPattern   = "^#([0-9A-Fa-f]{3,4}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{8})$"
+)
+
+// applyRules adds the keywords for validators to prop, the schema of a field
+// of type fieldT, and reports whether the field is required. Like Validate,
+// it applies the rules of a slice to its elements.
+func applyRules(prop *jsonSchema, fieldT reflect.Type, validators []rule) (required bool) {
+	if len(validators) != 0 && validators[0].name == "groups" {
+		// The rules do not apply without their groups.
+		return false
+	}
+
+	pointer := fieldT.Kind() == reflect.Pointer
+	for fieldT.Kind() == reflect.Pointer {
+		fieldT = fieldT.Elem()
+	}
+	elemT, target := fieldT, prop
+	if fieldT.Kind() == reflect.Slice && prop.Items != nil {
+		elemT, target = fieldT.Elem(), prop.Items
+		for elemT.Kind() == reflect.Pointer {
+			elemT = elemT.Elem()
+		}
+	}
+
+	mode := ""
+	for _, validator := range validators {
+		if validator.name == "required" || validator.name == "omitempty" {
+			mode = validator.name
+			break
+		}
+	}
+	if asText(validators) || asNumber(validators) {
+		// The rules apply to a textual form or a number the schema does not
+		// describe.
+		validators = nil
+	}
+
+	// An omitted zero value satisfies the rules anyway.
+	omitZero := mode == "omitempty" && !pointer && target == prop && isScalar(elemT.Kind())
+	rules := target
+	if omitZero {
+		rules = &jsonSchema{}
+	}
+
+	kind := elemT.Kind()
+	for _, validator := range validators {
+		if validator.warn || validator.refs != nil {
+			// Values violating warnings are still valid, bounds read from
+			// other fields are not known.
+			continue
+		}
+		if fn, ok := registered(schemaRules, validator.name); ok {
+			for name, value := range fn(describeRule(validator), elemT) {
+				if rules.extra == nil {
+					rules.extra = map[string]any{}
+				}
+				rules.extra[name] = value
+			}
+			continue
+		}
+		switch validator.name {
+		case "len":
+			if kind == reflect.String {
+				setMin(&rules.MinLength, validator.argsInt[0])
+				setMax(&rules.MaxLength, validator.argsInt[0])
+			}
+		case "min":
+			if kind == reflect.String {
+				setMin(&rules.MinLength, validator.argsInt[0])
+			} else if isScalar(kind) {
+				setMin(&rules.Minimum, validator.argsInt[0])
+			}
+		case "max":
+			if kind == reflect.String {
+				setMax(&rules.MaxLength, validator.argsInt[0])
+			} else if isScalar(kind) {
+				setMax(&rules.Maximum, validator.argsInt[0])
+			}
+		case "in":
+			rules.Enum = []any{}
+			switch {
+			case validator.enum != nil:
+				for _, value := range validator.enum {
+					rules.Enum = append(rules.Enum, value.Interface())
+				}
+			case kind == reflect.String:
+				for _, arg := range validator.argsStr {
+					rules.Enum = append(rules.Enum, arg)
+				}
+			default:
+				for _, arg := range validator.argsInt {
+					rules.Enum = append(rules.Enum, arg)
+				}
+			}
+		case "enum":
+			rules.Enum = []any{}
+			for _, value := range validator.enum {
+				rules.Enum = append(rules.Enum, value.Interface())
+			}
+		case "email":
+			rules.Format = "email"
+		case "ulid":
+			rules.Pattern = ulidPattern
+		case "objectid":
+			rules.Pattern = objectIDPattern
+		case "urlencoded":
+			rules.Pattern = urlEncodedPattern
+		case "slug":
+			rules.Pattern = slugPattern
+		case "dns_label":
+			rules.Pattern = dnsLabelPattern
+		case "k8s_quantity":
+			rules.Pattern = k8sQuantityRegexp.String()
+		case "hexcolor":
+			rules.Pattern = hexColorPattern
+		case "regexp":
+			rules.Pattern = validator.argsStr[0]
+		case "default":
+			if value, err := parseDefault(validator.argsStr[0], elemT); err == nil {
+				prop.Default = value.Interface()
+			}
+		case "password":
+			if policy, ok := validator.policy.(PasswordRules); ok && policy.Min > 0 {
+				setMin(&rules.MinLength, policy.Min)
+			}
+		case "keys":
+			// Object keys are strings, whatever the type of the map keys.
+			if kind == reflect.Map && elemT.Key().Kind() == reflect.String {
+				rules.PropertyNames = &jsonSchema{Type: "string"}
+				applyRules(rules.PropertyNames, elemT.Key(), validator.each)
+			}
+		case "values":
+			if kind == reflect.Map && rules.AdditionalProperties != nil {
+				applyRules(rules.AdditionalProperties, elemT.Elem(), validator.each)
+			}
+		}
+	}
+
+	if omitZero && !reflect.DeepEqual(*rules, jsonSchema{}) {
+		prop.AnyOf = []*jsonSchema{{Const: reflect.Zero(elemT).Interface()}, rules}
+	}
+
+	if mode != "required" {
+		return false
+	}
+	if !pointer {
+		// A required value must not be the zero value.
+		switch {
+		case fieldT.Kind() == reflect.Slice:
+			setMin(&prop.MinItems, 1)
+		case fieldT.Kind() == reflect.String:
+			setMin(&prop.MinLength, 1)
+		case fieldT.Kind() == reflect.Bool:
+			prop.Const = true
+		case isScalar(fieldT.Kind()):
+			prop.Not = &jsonSchema{Const: 0}
+		}
+	}
+	return true
+}
+
+// isScalar reports whether kind is a string, a number or a bool.
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func setMin(p **int, n int) {
+	if *p == nil || **p < n {
+		*p = &n
+	}
+}
+
+func setMax(p **int, n int) {
+	if *p == nil || **p > n {
+		*p = &n
+	}
+}
@@ -0,0 +1,210 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaAddress struct {
+	Zip string `json:"zip" validate:"len:5"`
+}
+
+type schemaNode struct {
+	Name     string        `json:"name" validate:"required"`
+	Children []*schemaNode `json:"children,omitempty"`
+}
+
+type schemaUser struct {
+	Base
+	Email    string            `json:"email" validate:"required&email"`
+	Nickname string            `json:"nickname,omitempty" validate:"omitempty&min:3&max:20"`
+	Age      *int              `json:"age" validate:"required&min:18&max:130"`
+	Role     string            `json:"role" validate:"in:admin,user"`
+	Tags     []string          `json:"tags" validate:"required&regexp:^[a-z]+$"`
+	Level    uint8             `json:"level" validate:"in:1,2"`
+	Password string            `json:"password" validate:"password:min=12"`
+	Active   bool              `json:"active" validate:"required"`
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels"`
+	Home     schemaAddress     `json:"home"`
+	Work     *schemaAddress    `json:"work"`
+	Tree     schemaNode        `json:"tree"`
+	Secret   string            `json:"-" validate:"required"`
+	internal string
+}
+
+func TestExportJSONSchema(t *testing.T) {
+	schema, err := ExportJSONSchema(&schemaUser{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"ID": {"type": "integer", "minimum": 1},
+			"Name": {"type": "string", "minLength": 1},
+			"email": {"type": "string", "format": "email", "minLength": 1},
+			"nickname": {"type": "string", "anyOf": [{"const": ""}, {"minLength": 3, "maxLength": 20}]},
+			"age": {"type": "integer", "minimum": 18, "maximum": 130},
+			"role": {"type": "string", "enum": ["admin", "user"]},
+			"tags": {"type": "array", "minItems": 1, "items": {"type": "string", "pattern": "^[a-z]+$"}},
+			"level": {"type": "integer", "enum": [1, 2]},
+			"password": {"type": "string", "minLength": 12},
+			"active": {"type": "boolean", "const": true},
+			"created": {"type": "string", "format": "date-time"},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"home": {"$ref": "#/$defs/schemaAddress"},
+			"work": {"$ref": "#/$defs/schemaAddress"},
+			"tree": {"$ref": "#/$defs/schemaNode"}
+		},
+		"required": ["Name", "email", "age", "tags", "active"],
+		"$defs": {
+			"schemaAddress": {
+				"type": "object",
+				"properties": {"zip": {"type": "string", "minLength": 5, "maxLength": 5}}
+			},
+			"schemaNode": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "minLength": 1},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/schemaNode"}}
+				},
+				"required": ["name"]
+			}
+		}
+	}`, string(schema))
+}
+
+func TestExportJSONSchemaErrors(t *testing.T) {
+	_, err := ExportJSONSchema(42)
+	assert.ErrorIs(t, err, ErrNotStruct)
+
+	_, err = ExportJSONSchema(lintUser{})
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, ErrRuleNotApplicable)
+}
+
+func TestExportOpenAPIComponents(t *testing.T) {
+	doc, err := ExportOpenAPIComponents(schemaNode{}, &schemaAddress{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"components": {
+			"schemas": {
+				"schemaNode": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string", "minLength": 1},
+						"children": {"type": "array", "items": {"$ref": "#/components/schemas/schemaNode"}}
+					},
+					"required": ["name"]
+				},
+				"schemaAddress": {
+					"type": "object",
+					"properties": {"zip": {"type": "string", "minLength": 5, "maxLength": 5}}
+				}
+			}
+		}
+	}`, string(doc))
+
+	_, err = ExportOpenAPIComponents(struct{ A int }{})
+	assert.ErrorIs(t, err, ErrNotStruct)
+}
+
+func TestExportJSONSchemaGroups(t *testing.T) {
+	schema, err := ExportJSONSchema(struct {
+		ID int `json:"id" validate:"required&min:1&groups:update"`
+	}{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {"id": {"type": "integer"}}
+	}`, string(schema))
+}
+
+func TestExportJSONSchemaDefault(t *testing.T) {
+	schema, err := ExportJSONSchema(struct {
+		Port int     `json:"port" validate:"default:8080&min:1"`
+		Mode *string `json:"mode" validate:"default:fast"`
+	}{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"port": {"type": "integer", "minimum": 1, "default": 8080},
+			"mode": {"type": "string", "default": "fast"}
+		}
+	}`, string(schema))
+}
+
+func TestExportJSONSchemaKeys(t *testing.T) {
+	schema, err := ExportJSONSchema(struct {
+		Quotas map[string]int `json:"quotas" validate:"keys:len:2,endkeys&values:min:0"`
+	}{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"quotas": {
+				"type": "object",
+				"propertyNames": {"type": "string", "minLength": 2, "maxLength": 2},
+				"additionalProperties": {"type": "integer", "minimum": 0}
+			}
+		}
+	}`, string(schema))
+}
+
+func TestRegisterJSONSchema(t *testing.T) {
+	RegisterRule("currency", func(ctx context.Context, field reflect.Value, params []string) error { return nil })
+	RegisterJSONSchema("currency", func(r Rule, t reflect.Type) map[string]any {
+		return map[string]any{"pattern": "^[A-Z]{3}$", "x-currency": r.Params, "minLength": 99}
+	})
+	t.Cleanup(func() {
+		delete(customRules, "currency")
+		delete(schemaRules, "currency")
+		resetPlanCache()
+	})
+
+	schema, err := ExportJSONSchema(struct {
+		Price  string   `json:"price" validate:"min:3&currency:eur,usd"`
+		Prices []string `json:"prices" validate:"currency"`
+	}{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"price": {"type": "string", "minLength": 3, "pattern": "^[A-Z]{3}$", "x-currency": ["eur", "usd"]},
+			"prices": {"type": "array", "items": {"type": "string", "minLength": 99, "pattern": "^[A-Z]{3}$", "x-currency": null}}
+		}
+	}`, string(schema))
+}
+
+type schemaPage[T any] struct {
+	Items []T `json:"items"`
+	Limit int `json:"limit" validate:"min:1"`
+}
+
+func TestExportJSONSchemaGeneric(t *testing.T) {
+	schema, err := ExportJSONSchema(struct {
+		Addresses schemaPage[schemaAddress]             `json:"addresses"`
+		Counts    schemaPage[int]                       `json:"counts"`
+		Nested    schemaPage[schemaPage[time.Duration]] `json:"nested"`
+	}{})
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), `"$ref": "#/$defs/schemaPage_schemaAddress"`)
+	assert.Contains(t, string(schema), `"$ref": "#/$defs/schemaPage_int"`)
+	assert.Contains(t, string(schema), `"$ref": "#/$defs/schemaPage_schemaPage_Duration"`)
+	assert.NotContains(t, string(schema), "github.com")
+
+	assert.Equal(t, "schemaPage_pair_string_Address", schemaName(reflect.TypeOf(schemaPage[pair[string, Address]]{})))
+	assert.Equal(t, "schemaPage_map_string_Address", schemaName(reflect.TypeOf(schemaPage[map[string]*Address]{})))
+	assert.Equal(t, "schemaAddress", schemaName(reflect.TypeOf(schemaAddress{})))
+}
@@ -0,0 +1,55 @@
+package validator
+
+import "context"
+
+// Stream validates the values received from in as they arrive and sends the
+// errors of the values that are not valid on the returned channel, indexed
+// by the order the values were received in. Unlike ValidateAll it keeps no
+// results, so inputs of any size can be validated as they are read:
+//
+//	rows := make(chan Row)
+//	go readRows(ctx, rows)
+//	for failure := range users.Stream(ctx, rows, 100) {
+//		log.Printf("row %d: %v", failure.Index, failure.Err)
+//	}
+//
+// The returned channel is closed when in is closed, when ctx is done or
+// after budget values were not valid; a budget of 0 or less is unlimited.
+// Stream stops receiving from in then, so senders should also stop when ctx
+// is done.
+func (t *Typed[T]) Stream(ctx context.Context, in <-chan T, budget int) <-chan ItemError {
+	out := make(chan ItemError)
+	go func() {
+		defer close(out)
+		failed := 0
+		for i := 0; ; i++ {
+			var item T
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				item = v
+			}
+
+			err := t.ValidateCtx(ctx, item)
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- ItemError{Index: i, Err: err}:
+			}
+			if failed++; budget > 0 && failed == budget {
+				return
+			}
+		}
+	}()
+	return out
+}
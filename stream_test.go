@@ -0,0 +1,52 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func streamOf(items ...compiledUser) <-chan compiledUser {
+	in := make(chan compiledUser, len(items))
+	for _, item := range items {
+		in <- item
+	}
+	close(in)
+	return in
+}
+
+func collect(out <-chan ItemError) []int {
+	var indexes []int
+	for failure := range out {
+		indexes = append(indexes, failure.Index)
+	}
+	return indexes
+}
+
+func TestStream(t *testing.T) {
+	typed, err := Compile[compiledUser]()
+	require.NoError(t, err)
+
+	valid, invalid := compiledUser{Name: "Ann", Age: 30}, compiledUser{Name: "Bob", Age: 17}
+	in := streamOf(valid, invalid, valid, invalid, invalid)
+
+	out := typed.Stream(context.Background(), in, 0)
+	first := <-out
+	assert.Equal(t, 1, first.Index)
+	assert.EqualError(t, first.Err, "field: Age not valid for min:18")
+	assert.Equal(t, []int{3, 4}, collect(out))
+
+	t.Run("budget", func(t *testing.T) {
+		in := streamOf(invalid, valid, invalid, invalid)
+		assert.Equal(t, []int{0, 2}, collect(typed.Stream(context.Background(), in, 2)))
+		assert.Len(t, in, 1)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Empty(t, collect(typed.Stream(ctx, make(chan compiledUser), 0)))
+	})
+}
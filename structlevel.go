@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+)
+
+// StructLevelFunc validates a struct as a whole, e.g. constraints spanning
+// several fields. It is called after the rules of the struct fields. Returned
+// ValidationErrors are merged into the result, any other error is reported as
+// a single ValidationError.
+type StructLevelFunc func(v reflect.Value) error
+
+var structValidations = map[reflect.Type]StructLevelFunc{}
+
+// RegisterStructValidation registers fn as the struct level validation for
+// each of types. It runs for top level and nested structs unless the field
+// holding a nested struct is tagged with "nostructlevel". It is meant to be
+// called during program initialization.
+func RegisterStructValidation(fn StructLevelFunc, types ...any) error {
+	return register("RegisterStructValidation", func() {
+		for _, t := range types {
+			structValidations[reflect.TypeOf(t)] = fn
+		}
+	})
+}
+
+func (s *validation) validateStructLevel(valueV reflect.Value) {
+	fn, ok := registered(structValidations, valueV.Type())
+	if !ok {
+		return
+	}
+
+	err := fn(valueV)
+	var errs ValidationErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &errs):
+		s.errors = append(s.errors, errs...)
+	default:
+		s.errors = append(s.errors, ValidationError{err})
+	}
+}
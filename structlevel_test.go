@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Period struct {
+	From int `validate:"min:0"`
+	To   int `validate:"min:0"`
+}
+
+var errPeriodOrder = errors.New("period: From must not be after To")
+
+func TestValidateControlTags(t *testing.T) {
+	RegisterStructValidation(func(v reflect.Value) error {
+		if p := v.Interface().(Period); p.From > p.To {
+			return errPeriodOrder
+		}
+		return nil
+	}, Period{})
+	t.Cleanup(func() {
+		delete(structValidations, reflect.TypeOf(Period{}))
+	})
+
+	type booking struct {
+		Stay     Period
+		Skipped  Period  `validate:"-"`
+		Shallow  Period  `validate:"structonly"`
+		Required *Period `validate:"required&structonly"`
+		NoHook   Period  `validate:"nostructlevel"`
+		ignored  string  `validate:"-"`
+	}
+	broken := Period{From: 5, To: -1}
+
+	err := Validate(booking{
+		Stay:     Period{From: 3, To: 1},
+		Skipped:  broken,
+		Shallow:  broken,
+		Required: &broken,
+		NoHook:   broken,
+	})
+	assert.Error(t, err)
+
+	var got []string
+	for _, e := range err.(ValidationErrors) {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		errPeriodOrder.Error(),
+		"field: NoHook.To not valid for min:0",
+	}, got)
+
+	err = Validate(booking{})
+	assert.Error(t, err)
+	assert.Equal(t, "field: Required not valid for required&structonly", err.Error())
+
+	err = Validate(Period{From: 2, To: 1})
+	assert.ErrorIs(t, err.(ValidationErrors)[0].Err, errPeriodOrder)
+}
+
+func TestStructValidationErrorsAreMerged(t *testing.T) {
+	RegisterStructValidation(func(v reflect.Value) error {
+		return ValidationErrors{
+			{errors.New("first")},
+			{errors.New("second")},
+		}
+	}, Address{})
+	t.Cleanup(func() {
+		delete(structValidations, reflect.TypeOf(Address{}))
+	})
+
+	err := Validate(struct {
+		Home Address
+	}{Home: Address{City: "Moscow", Zip: "123456"}})
+	assert.Error(t, err)
+	assert.Len(t, err.(ValidationErrors), 2)
+	assert.Equal(t, "firstsecond", err.Error())
+}
@@ -0,0 +1,90 @@
+package validator
+
+import "strings"
+
+// TagSyntax describes how rules are written in validate tags, so tags
+// written for other validation libraries can be used unchanged.
+type TagSyntax struct {
+	// RuleSeparator separates the rules of a tag, "&" by default.
+	RuleSeparator string
+	// Assign separates the name of a rule from its arguments, ":" by default.
+	Assign string
+	// ArgSeparator separates the arguments of a rule, "," by default.
+	ArgSeparator string
+	// Names maps names of rules to the names of the rules of this package,
+	// e.g. "oneof" to "in". Rules mapped to "" are dropped.
+	Names map[string]string
+}
+
+// DefaultSyntax is the syntax of tags like
+// `validate:"required&min:3&in:a,b,c"`. Arguments containing separators are
+// quoted, like `validate:"in:\"a,b\",\"c:d\""`, or the separators escaped
+// by a backslash, like `validate:"in:a\\,b"`.
+var DefaultSyntax = TagSyntax{RuleSeparator: "&", Assign: ":", ArgSeparator: ","}
+
+// PlaygroundSyntax is the syntax of github.com/go-playground/validator, for
+// tags like `validate:"required,min=3,max=32,oneof=a b c"`. Rules of the
+// same meaning are mapped to the rules of this package; dive is dropped, as
+// the rules of a slice apply to its elements anyway.
+var PlaygroundSyntax = TagSyntax{
+	RuleSeparator: ",",
+	Assign:        "=",
+	ArgSeparator:  " ",
+	Names: map[string]string{
+		"oneof":   "in",
+		"oneofci": "in_ci",
+		"gte":     "min",
+		"lte":     "max",
+		"dive":    "",
+	},
+}
+
+var tagSyntax = DefaultSyntax
+
+// SetTagSyntax selects the syntax of validate tags and of the rules passed
+// to ValidateVar and RegisterAlias. Arguments containing one of the
+// separators, like regular expressions, cannot be written in every syntax.
+// It is meant to be called during program initialization.
+func SetTagSyntax(syntax TagSyntax) error {
+	return register("SetTagSyntax", func() {
+		tagSyntax = syntax
+		resetPlanCache()
+	})
+}
+
+// currentSyntax returns a copy of the syntax set by SetTagSyntax.
+func currentSyntax() *TagSyntax {
+	registry.RLock()
+	defer registry.RUnlock()
+	syntax := tagSyntax
+	return &syntax
+}
+
+// canonical rewrites rules written in the syntax to DefaultSyntax.
+func (syntax *TagSyntax) canonical(rules string) string {
+	if syntax.RuleSeparator == DefaultSyntax.RuleSeparator && syntax.Assign == DefaultSyntax.Assign &&
+		syntax.ArgSeparator == DefaultSyntax.ArgSeparator && syntax.Names == nil {
+		return rules
+	}
+
+	var sb strings.Builder
+	for _, rule := range strings.Split(rules, syntax.RuleSeparator) {
+		name, args, found := strings.Cut(rule, syntax.Assign)
+		if mapped, ok := syntax.Names[strings.TrimSpace(name)]; ok {
+			if mapped == "" {
+				continue
+			}
+			name = mapped
+		}
+
+		if sb.Len() != 0 {
+			sb.WriteString(DefaultSyntax.RuleSeparator)
+		}
+		sb.WriteString(name)
+		if found {
+			sb.WriteString(DefaultSyntax.Assign)
+			sb.WriteString(strings.Join(strings.Split(args, syntax.ArgSeparator), DefaultSyntax.ArgSeparator))
+		}
+	}
+	return sb.String()
+}
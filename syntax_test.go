@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagSyntaxCanonical(t *testing.T) {
+	tests := []struct {
+		syntax TagSyntax
+		rules  string
+		want   string
+	}{
+		{syntax: DefaultSyntax, rules: "required&in:a,b", want: "required&in:a,b"},
+		{syntax: PlaygroundSyntax, rules: "required,min=3,max=32,oneof=a b c", want: "required&min:3&max:32&in:a,b,c"},
+		{syntax: PlaygroundSyntax, rules: "oneofci=ru en", want: "in_ci:ru,en"},
+		{syntax: PlaygroundSyntax, rules: "omitempty,dive,gte=1,lte=5", want: "omitempty&min:1&max:5"},
+		{syntax: TagSyntax{RuleSeparator: ";", Assign: "(", ArgSeparator: "|"}, rules: "len(5;in(1|2", want: "len:5&in:1,2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.syntax.canonical(tt.rules))
+		})
+	}
+}
+
+func TestSetTagSyntax(t *testing.T) {
+	SetTagSyntax(PlaygroundSyntax)
+	RegisterAlias("username", "required,min=3")
+	t.Cleanup(func() {
+		delete(aliases, "username")
+		SetTagSyntax(DefaultSyntax)
+	})
+
+	type user struct {
+		Name  string   `validate:"username,max=8"`
+		Role  string   `validate:"oneof=admin user"`
+		Tags  []string `validate:"required,dive,min=2"`
+		Email string   `validate:"omitempty,email"`
+	}
+	assert.NoError(t, Validate(user{Name: "alice", Role: "user", Tags: []string{"go"}}))
+
+	err := Validate(user{Name: "al", Role: "root", Tags: []string{"g"}, Email: "x"})
+	require.Error(t, err)
+	var got []string
+	for _, e := range err.(ValidationErrors) {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"field: Name not valid for username,max=8",
+		"field: Role not valid for oneof=admin user",
+		"field: Tags not valid for required,dive,min=2",
+		"field: Email not valid for omitempty,email",
+	}, got)
+
+	assert.NoError(t, ValidateVar(5, "gte=1,lte=5"))
+	assert.Error(t, ValidateVar(6, "gte=1,lte=5"))
+}
@@ -0,0 +1,17 @@
+// Command validatorvet reports validate struct tags that the validator
+// package would reject or could never satisfy at run time. It runs on its
+// own or as a vet tool:
+//
+//	validatorvet ./...
+//	go vet -vettool=$(which validatorvet) ./...
+//
+// Rules registered at run time are passed with -rules=unique_email,prefix.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/Nadya2002/validator/tagcheck"
+)
+
+func main() { singlechecker.Main(tagcheck.Analyzer) }
@@ -0,0 +1,17 @@
+module github.com/Nadya2002/validator/tagcheck
+
+go 1.22.0
+
+require (
+	github.com/Nadya2002/validator v0.0.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+)
+
+// Builds against the validator in this repository until a release of it
+// with the APIs used here is tagged.
+replace github.com/Nadya2002/validator => ../
@@ -0,0 +1,205 @@
+// Package tagcheck defines an Analyzer that reports validate struct tags the
+// validator package would reject or could never satisfy at run time: invalid
+// rule syntax, unknown rule names, tags on unexported fields and rules that
+// do not apply to the type of their field, like email on an int.
+//
+// Rules registered at run time with validator.RegisterRule or
+// validator.RegisterBatchRule, and aliases registered with
+// validator.RegisterAlias, are unknown to the analyzer and have to be listed
+// with the -rules flag.
+package tagcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/Nadya2002/validator/internal/tags"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "validatetags",
+	Doc:      "check validate struct tags of the validator package",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var customRules string
+
+func init() {
+	Analyzer.Flags.StringVar(&customRules, "rules", "", "comma-separated names of rules and aliases registered at run time")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	custom := map[string]bool{}
+	for _, name := range strings.Split(customRules, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			custom[name] = true
+		}
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	inspect.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		for _, field := range n.(*ast.StructType).Fields.List {
+			checkField(pass, field, custom)
+		}
+	})
+	return nil, nil
+}
+
+func checkField(pass *analysis.Pass, field *ast.Field, custom map[string]bool) {
+	if field.Tag == nil {
+		return
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return
+	}
+	cond := reflect.StructTag(tag).Get("validate")
+	if cond == "" || cond == "-" {
+		return
+	}
+
+	name := embeddedName(field.Type)
+	if len(field.Names) != 0 {
+		name = field.Names[0].Name
+	}
+	if !ast.IsExported(name) {
+		pass.Reportf(field.Tag.Pos(), "validate tag on unexported field %s", name)
+		return
+	}
+
+	rules, err := tags.Parse(cond, func(name string) bool { return custom[name] })
+	if err != nil {
+		pass.Reportf(field.Tag.Pos(), "invalid validate tag on field %s: %v", name, err)
+		return
+	}
+
+	for _, r := range rules {
+		if r.Name == "astext" {
+			// The rules apply to the textual form of the field.
+			return
+		}
+	}
+	typ := pass.TypesInfo.TypeOf(field.Type)
+	checkRules(pass, field, name, typ, rules, custom)
+}
+
+// checkRules reports the rules that do not apply to the field name of type
+// typ, and the rules of keys and values that do not apply to the keys and
+// values of the map.
+func checkRules(pass *analysis.Pass, field *ast.Field, name string, typ types.Type, rules []tags.Rule, custom map[string]bool) {
+	k, known := kindOf(typ)
+	if !known {
+		return
+	}
+	for _, r := range rules {
+		if r.Name != "asnum" {
+			continue
+		}
+		// The rules apply to the number the text holds.
+		if k != tags.String {
+			pass.Reportf(field.Tag.Pos(), "rule asnum does not apply to field %s of type %s", name, typ)
+			return
+		}
+		k = tags.Float
+	}
+	for _, r := range rules {
+		switch {
+		case !custom[r.Name] && !tags.Accepts(r.Name, k):
+			pass.Reportf(field.Tag.Pos(), "rule %s does not apply to field %s of type %s", r.Name, name, typ)
+		case r.Name == "keys" || r.Name == "values":
+			m := deref(typ).Underlying()
+			if slice, ok := m.(*types.Slice); ok {
+				m = deref(slice.Elem()).Underlying()
+			}
+			elem := m.(*types.Map).Elem()
+			if r.Name == "keys" {
+				elem = m.(*types.Map).Key()
+			}
+			checkRules(pass, field, name, elem, r.Rules, custom)
+		case r.Name == "or":
+			for _, alternative := range r.Any {
+				checkRules(pass, field, name, typ, alternative, custom)
+			}
+		case r.Name == "in" && !custom[r.Name]:
+			for _, arg := range r.Args {
+				if _, err := tags.ParseIn(arg, k); err != nil {
+					pass.Reportf(field.Tag.Pos(), "invalid validate tag on field %s: %v", name, err)
+					break
+				}
+			}
+		}
+	}
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// kindOf returns the kind of the values rules are applied to for a field of
+// type t, i.e. of the elements of a slice. known is false for structs,
+// interfaces and types with a Value or ValidateValue method, as they may be
+// turned into other values at run time by validator.RegisterCustomType,
+// driver.Valuer or validator.Opener.
+func kindOf(t types.Type) (k tags.Kind, known bool) {
+	if t == nil {
+		return tags.Other, false
+	}
+	t = deref(t)
+	if slice, ok := t.Underlying().(*types.Slice); ok {
+		t = deref(slice.Elem())
+	}
+	for _, method := range []string{"Value", "ValidateValue"} {
+		if obj, _, _ := types.LookupFieldOrMethod(t, true, nil, method); obj != nil {
+			if _, ok := obj.(*types.Func); ok {
+				return tags.Other, false
+			}
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		info := u.Info()
+		switch {
+		case info&types.IsString != 0:
+			return tags.String, true
+		case info&types.IsBoolean != 0:
+			return tags.Bool, true
+		case info&types.IsUnsigned != 0:
+			return tags.Uint, true
+		case info&types.IsInteger != 0:
+			return tags.Int, true
+		case info&types.IsFloat != 0:
+			return tags.Float, true
+		}
+	case *types.Map:
+		return tags.Map, true
+	case *types.Struct, *types.Interface, *types.TypeParam:
+		return tags.Other, false
+	}
+	return tags.Other, true
+}
+
+func deref(t types.Type) types.Type {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
@@ -0,0 +1,43 @@
+package a
+
+import "database/sql"
+
+type Level int
+
+type Money struct{ Cents int64 }
+
+type Base struct {
+	ID string `validate:"ulid"`
+}
+
+type User struct {
+	Base
+	Name    string         `validate:"required&min:2&max:64"`
+	Email   string         `validate:"emial"`        // want `invalid validate tag on field Email: invalid validator syntax: unknown rule "emial"`
+	Age     int            `validate:"min:18&email"` // want `rule email does not apply to field Age of type int`
+	Zip     *string        `validate:"len"`          // want `invalid validate tag on field Zip: invalid validator syntax: rule len needs arguments`
+	Tags    []string       `validate:"in:a,b"`
+	Codes   []*uint8       `validate:"in:1,2&no_html"` // want `rule no_html does not apply to field Codes of type \[\]\*uint8`
+	Admin   bool           `validate:"required&max:1"` // want `rule max does not apply to field Admin of type bool`
+	Level   Level          `validate:"in:1,2,3"`
+	Scale   uint           `validate:"in:1,-1"` // want `invalid validate tag on field Scale: invalid validator syntax: argument "-1" of rule in is not an unsigned integer`
+	Active  bool           `validate:"in:true"`
+	Code    Level          `validate:"astext&len:3"`
+	Port    string         `validate:"asnum&min:1&max:65535"`
+	Ports   []string       `validate:"asnum&email"` // want `rule email does not apply to field Ports of type \[\]string`
+	Count   int            `validate:"asnum&min:1"` // want `rule asnum does not apply to field Count of type int`
+	Price   Money          `validate:"min:1"`
+	Note    sql.NullString `validate:"max:10"`
+	Rating  float64        `validate:"min:x"` // want `invalid validate tag on field Rating: invalid validator syntax: argument "x" of rule min is not a number`
+	Secret  string         `validate:"password:min=12,digit=1"`
+	Pin     string         `validate:"password:min=4,pin=1"` // want `invalid validate tag on field Pin: invalid validator syntax: invalid password requirement "pin=1"`
+	Login   string         `validate:"unique_login&email"`
+	Skipped int            `validate:"-"`
+	Quotas  map[string]int `validate:"keys:len:2,endkeys&values:min:0"`
+	Limits  map[int]string `validate:"keys:email,endkeys"` // want `rule email does not apply to field Limits of type int`
+	Flags   []string       `validate:"values:min:1"`       // want `rule values does not apply to field Flags of type \[\]string`
+	Address struct {
+		Street string `validate:"ulid:1"` // want `invalid validate tag on field Street: invalid validator syntax: rule ulid takes no arguments`
+	}
+	password string `validate:"min:8"` // want `validate tag on unexported field password`
+}
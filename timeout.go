@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ErrRuleTimeout is wrapped by the errors of custom and batch rules that did
+// not finish within the timeout of WithRuleTimeout.
+var ErrRuleTimeout = errors.New("rule timed out")
+
+// WithTimeout limits the whole validation to d: the context passed to
+// custom and batch rules is done after d, and the validation stops with
+// context.DeadlineExceeded once it is, like ValidateCtx does when its
+// context is done.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// WithRuleTimeout limits every call of the custom and batch rules names, or
+// of all of them without names, to d, so a slow check like a uniqueness
+// query cannot take the whole budget of a request:
+//
+//	err := validator.ValidateCtx(ctx, u,
+//		validator.WithTimeout(time.Second),
+//		validator.WithRuleTimeout(200*time.Millisecond, "unique_email"),
+//	)
+//
+// The context passed to the rule is done after d. A custom rule returning an
+// error once it is fails the field with an error wrapping ErrRuleTimeout and
+// the error of the rule; a batch rule doing so stops the validation with
+// such an error. Rules that ignore their context are not interrupted.
+func WithRuleTimeout(d time.Duration, names ...string) Option {
+	return func(c *config) {
+		if len(names) == 0 {
+			c.ruleTimeout = d
+			return
+		}
+		if c.ruleTimeouts == nil {
+			c.ruleTimeouts = map[string]time.Duration{}
+		}
+		for _, name := range names {
+			c.ruleTimeouts[name] = d
+		}
+	}
+}
+
+// startTimeout limits the validation to the timeout of WithTimeout, if any.
+// stopTimeout must follow it.
+func (s *validation) startTimeout() {
+	if s.timeout > 0 && s.stop == nil {
+		s.ctx, s.stop = context.WithTimeout(s.ctx, s.timeout)
+	}
+}
+
+// stopTimeout releases the resources of the timeout of the validation.
+func (s *validation) stopTimeout() {
+	if s.stop != nil {
+		s.stop()
+		s.stop = nil
+	}
+}
+
+// ruleTimeoutOf returns the timeout of WithRuleTimeout for the rule name, 0
+// without one.
+func (s *validation) ruleTimeoutOf(name string) time.Duration {
+	if timeout, ok := s.ruleTimeouts[name]; ok {
+		return timeout
+	}
+	return s.ruleTimeout
+}
+
+// runCustom calls the custom rule of validator for field with ctx, limited by
+// the timeout of the rule and by its RuleLimits.
+func (s *validation) runCustom(ctx context.Context, validator rule, field reflect.Value) error {
+	call := validator.custom
+	if limiter, ok := registered(ruleLimits, validator.name); ok {
+		call = func(ctx context.Context, field reflect.Value, params []string) error {
+			return limiter.call(ctx, validator.custom, field, params)
+		}
+	}
+	timeout := s.ruleTimeoutOf(validator.name)
+	if timeout <= 0 {
+		return call(ctx, field, validator.argsStr)
+	}
+	ruleCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := call(ruleCtx, field, validator.argsStr)
+	return ruleTimedOut(ctx, ruleCtx, validator.name, timeout, err)
+}
+
+// runBatch calls the batch rule of validator for values with ctx, limited by
+// the timeout of the rule and by the rate of its RuleLimits.
+func (s *validation) runBatch(ctx context.Context, validator rule, values []reflect.Value) ([]error, error) {
+	call := validator.batch
+	if limiter, ok := registered(ruleLimits, validator.name); ok {
+		call = func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+			if err := limiter.wait(ctx); err != nil {
+				return nil, err
+			}
+			return validator.batch(ctx, values, params)
+		}
+	}
+	timeout := s.ruleTimeoutOf(validator.name)
+	if timeout <= 0 {
+		return call(ctx, values, validator.argsStr)
+	}
+	ruleCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	errs, err := call(ruleCtx, values, validator.argsStr)
+	return errs, ruleTimedOut(ctx, ruleCtx, validator.name, timeout, err)
+}
+
+// ruleTimedOut wraps err, returned by the rule name called with ruleCtx
+// derived from ctx, with ErrRuleTimeout when the timeout of the rule is the
+// reason for it.
+func ruleTimedOut(ctx, ruleCtx context.Context, name string, timeout time.Duration, err error) error {
+	if err == nil || ctx.Err() != nil || ruleCtx.Err() != context.DeadlineExceeded {
+		return err
+	}
+	return fmt.Errorf("%w: %s after %s: %w", ErrRuleTimeout, name, timeout, err)
+}
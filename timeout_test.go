@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleTimeout(t *testing.T) {
+	slow := func(ctx context.Context, field reflect.Value, params []string) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	}
+	RegisterRule("slow_unique", slow)
+	RegisterRule("slow_check", slow)
+	RegisterBatchRule("slow_batch", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	t.Cleanup(func() {
+		delete(customRules, "slow_unique")
+		delete(customRules, "slow_check")
+		delete(batchRules, "slow_batch")
+		resetPlanCache()
+	})
+
+	type user struct {
+		Email string `validate:"slow_unique"`
+		Login string `validate:"slow_check"`
+		Name  string `validate:"min:3"`
+	}
+	var errs ValidationErrors
+	err := Validate(user{Name: "An"}, WithRuleTimeout(10*time.Millisecond, "slow_unique", "slow_check"))
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{"Email", "Login", "Name"}, fieldsOf(errs))
+	assert.ErrorIs(t, errs[0].Err, ErrRuleTimeout)
+	assert.ErrorIs(t, errs[0].Err, context.DeadlineExceeded)
+
+	// A timeout for all rules, overridden for some.
+	err = Validate(user{Name: "Ann"}, WithRuleTimeout(10*time.Millisecond), WithRuleTimeout(time.Minute, "slow_check"))
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{"Email"}, fieldsOf(errs))
+
+	type batch struct {
+		Login string `validate:"slow_batch"`
+	}
+	err = Validate(batch{}, WithRuleTimeout(10*time.Millisecond))
+	assert.ErrorIs(t, err, ErrRuleTimeout)
+}
+
+func TestTimeout(t *testing.T) {
+	RegisterRule("blocking", func(ctx context.Context, field reflect.Value, params []string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	t.Cleanup(func() {
+		delete(customRules, "blocking")
+		resetPlanCache()
+	})
+
+	type user struct {
+		Email string `validate:"blocking"`
+		Login string `validate:"blocking"`
+	}
+	err := Validate(user{}, WithTimeout(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, ErrRuleTimeout)
+
+	// The budget of the validation bounds the timeouts of the rules.
+	start := time.Now()
+	err = Validate(user{}, WithTimeout(10*time.Millisecond), WithRuleTimeout(time.Minute))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, ErrRuleTimeout)
+	assert.Less(t, time.Since(start), time.Minute)
+}
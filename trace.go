@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Tracer starts the spans of traced validations. It has the shape of the
+// Tracer of OpenTelemetry, which is adapted in a few lines without this
+// package depending on it:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, validator.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttributes(attrs ...validator.Attribute) {
+//		for _, a := range attrs {
+//			s.Span.SetAttributes(attribute.String(a.Key, fmt.Sprint(a.Value)))
+//		}
+//	}
+//
+//	func (s otelSpan) End() { s.Span.End() }
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	End()
+}
+
+// Attribute is an attribute of a span. Value is a string, an int or a bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// WithTracer traces the validation with t. Every validation gets a span
+// named "validator.Validate" with the attributes
+//
+//	validator.type    the type of the validated value
+//	validator.rules   the number of rules of the validated fields
+//	validator.errors  the number of errors
+//	validator.valid   whether the value is valid
+//
+// and every call of a custom or batch rule, which may do I/O, a child span
+// named "validator.rule" with the attributes validator.rule, the name of the
+// rule, and validator.valid. Custom rules receive the context of their span.
+func WithTracer(t Tracer) Option {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// startSpan starts the span of the validation of a value of type t.
+func (s *validation) startSpan(t reflect.Type) {
+	if s.tracer == nil {
+		return
+	}
+	s.ctx, s.span = s.tracer.Start(s.ctx, "validator.Validate")
+	name := ""
+	if t != nil {
+		name = t.String()
+	}
+	s.span.SetAttributes(Attribute{Key: "validator.type", Value: name})
+}
+
+// endSpan ends the span of the validation.
+func (s *validation) endSpan() {
+	if s.span == nil {
+		return
+	}
+	s.span.SetAttributes(
+		Attribute{Key: "validator.rules", Value: s.checked},
+		Attribute{Key: "validator.errors", Value: len(s.errors)},
+		Attribute{Key: "validator.valid", Value: s.err == nil && len(s.errors) == 0},
+	)
+	s.span.End()
+	s.span = nil
+}
+
+// callCustom calls the custom rule of validator for field, in a span of its
+// own when the validation is traced.
+func (s *validation) callCustom(validator rule, field reflect.Value) error {
+	if s.tracer == nil {
+		return s.runCustom(s.ctx, validator, field)
+	}
+	ctx, span := s.startRuleSpan(validator.name)
+	err := s.runCustom(ctx, validator, field)
+	endRuleSpan(span, err == nil)
+	return err
+}
+
+// startRuleSpan starts the span of a call of the custom or batch rule name.
+func (s *validation) startRuleSpan(name string) (context.Context, Span) {
+	ctx, span := s.tracer.Start(s.ctx, "validator.rule")
+	span.SetAttributes(Attribute{Key: "validator.rule", Value: name})
+	return ctx, span
+}
+
+func endRuleSpan(span Span, valid bool) {
+	span.SetAttributes(Attribute{Key: "validator.valid", Value: valid})
+	span.End()
+}
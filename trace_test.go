@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]any
+	ended  bool
+}
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordedSpan{name: name, attrs: map[string]any{}}
+	if parent, ok := ctx.Value(spanKey{}).(*recordedSpan); ok {
+		span.parent = parent.name
+	}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordedSpan) End() { s.ended = true }
+
+func TestWithTracer(t *testing.T) {
+	var inSpan bool
+	RegisterRule("traced", func(ctx context.Context, field reflect.Value, params []string) error {
+		span, _ := ctx.Value(spanKey{}).(*recordedSpan)
+		inSpan = span != nil && span.name == "validator.rule"
+		if field.String() == "taken" {
+			return errors.New("taken")
+		}
+		return nil
+	})
+	RegisterBatchRule("tracedbatch", func(ctx context.Context, values []reflect.Value, params []string) ([]error, error) {
+		return make([]error, len(values)), nil
+	})
+	t.Cleanup(func() {
+		delete(customRules, "traced")
+		delete(batchRules, "tracedbatch")
+		resetPlanCache()
+	})
+
+	type account struct {
+		Login string `validate:"required&traced"`
+		Email string `validate:"tracedbatch"`
+		Age   int    `validate:"min:18"`
+	}
+
+	tracer := &recordingTracer{}
+	err := ValidateCtx(context.Background(), account{Login: "taken", Age: 20}, WithTracer(tracer))
+	assert.Error(t, err)
+	assert.True(t, inSpan)
+
+	if assert.Len(t, tracer.spans, 3) {
+		root, custom, batch := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+		assert.Equal(t, &recordedSpan{name: "validator.Validate", ended: true, attrs: map[string]any{
+			"validator.type":   "validator.account",
+			"validator.rules":  4,
+			"validator.errors": 1,
+			"validator.valid":  false,
+		}}, root)
+		assert.Equal(t, &recordedSpan{name: "validator.rule", parent: "validator.Validate", ended: true, attrs: map[string]any{
+			"validator.rule":  "traced",
+			"validator.valid": false,
+		}}, custom)
+		assert.Equal(t, &recordedSpan{name: "validator.rule", parent: "validator.Validate", ended: true, attrs: map[string]any{
+			"validator.rule":  "tracedbatch",
+			"validator.valid": true,
+		}}, batch)
+	}
+
+	tracer.spans = nil
+	assert.NoError(t, New(WithTracer(tracer)).ValidateVar(20, "min:18"))
+	if assert.Len(t, tracer.spans, 1) {
+		assert.Equal(t, "int", tracer.spans[0].attrs["validator.type"])
+		assert.Equal(t, true, tracer.spans[0].attrs["validator.valid"])
+	}
+}
@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var typeRules = map[reflect.Type]map[string]string{}
+
+// RegisterTypeRules sets the rules of the fields of each of types, mapping
+// the names of the fields to rules written in the syntax of the validate
+// tag, for types whose tags cannot be edited, like messages generated by
+// protoc:
+//
+//	validator.RegisterTypeRules(map[string]string{
+//		"Email": "required&email",
+//		"Age":   "min:18",
+//	}, pb.CreateUserRequest{})
+//
+// The rules replace the tags of the fields they name, other fields keep
+// their tags. Types are structs or pointers to structs. It panics when a
+// type has no exported field of one of the names. It is meant to be called
+// during program initialization.
+func RegisterTypeRules(rules map[string]string, types ...any) error {
+	typeVs := make([]reflect.Type, len(types))
+	for i, t := range types {
+		typeVs[i] = derefType(reflect.TypeOf(t))
+		for name := range rules {
+			if f, ok := typeVs[i].FieldByName(name); !ok || !f.IsExported() || len(f.Index) != 1 {
+				panic(fmt.Sprintf("validator: %s has no field %s", typeVs[i], name))
+			}
+		}
+	}
+	return register("RegisterTypeRules", func() {
+		for _, typeV := range typeVs {
+			typeRules[typeV] = rules
+		}
+		resetPlanCache()
+	})
+}
+
+// fieldRules returns the rules of the field fieldT of typeV: the registered
+// ones if any, or the ones of its tag with the key tagName.
+func fieldRules(typeV reflect.Type, fieldT reflect.StructField, tagName string) string {
+	byField, _ := registered(typeRules, typeV)
+	if rules, ok := byField[fieldT.Name]; ok {
+		return rules
+	}
+	return fieldT.Tag.Get(tagName)
+}
@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// protoUser is shaped like a message generated by protoc.
+type protoUser struct {
+	state         struct{ done bool }
+	sizeCache     int32
+	unknownFields []byte
+
+	Email   string        `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Age     int32         `protobuf:"varint,2,opt,name=age,proto3" json:"age,omitempty"`
+	Contact isUserContact `protobuf_oneof:"contact"`
+}
+
+type isUserContact interface{ isUserContact() }
+
+type protoUserPhone struct {
+	Phone string `protobuf:"bytes,3,opt,name=phone,proto3,oneof"`
+}
+
+func (*protoUserPhone) isUserContact() {}
+
+func TestRegisterTypeRules(t *testing.T) {
+	RegisterTypeRules(map[string]string{"Email": "required&email", "Age": "min:18", "Contact": "required"}, protoUser{})
+	RegisterTypeRules(map[string]string{"Phone": "len:10"}, (*protoUserPhone)(nil))
+	t.Cleanup(func() {
+		delete(typeRules, reflect.TypeOf(protoUser{}))
+		delete(typeRules, reflect.TypeOf(protoUserPhone{}))
+		resetPlanCache()
+	})
+
+	assert.NoError(t, Validate(&protoUser{Email: "ann@example.com", Age: 20, Contact: &protoUserPhone{Phone: "0123456789"}}))
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(&protoUser{Email: "ann", Age: 17, Contact: &protoUserPhone{Phone: "1"}}), &errs)
+	assert.Equal(t, []string{"Email", "Age", "Contact.Phone"}, fieldsOf(errs))
+
+	require.ErrorAs(t, Validate(protoUser{Email: "ann@example.com", Age: 20}), &errs)
+	assert.Equal(t, []string{"Contact"}, fieldsOf(errs))
+
+	assert.Panics(t, func() { RegisterTypeRules(map[string]string{"state": "required"}, protoUser{}) })
+	assert.Panics(t, func() { RegisterTypeRules(map[string]string{"Name": "required"}, protoUser{}) })
+}
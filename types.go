@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+// CustomTypeFunc extracts the value that should be validated from a field of
+// a wrapper type. Returning nil makes the field an empty value, returning an
+// error marks the field as not valid.
+type CustomTypeFunc func(field reflect.Value) (any, error)
+
+var customTypes = map[reflect.Type]CustomTypeFunc{}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// RegisterCustomType registers fn as the value extractor for each of types.
+// Fields of those types are validated through the value returned by fn, so
+// for example a Money struct can be checked with min and max on its amount.
+// It is meant to be called during program initialization.
+func RegisterCustomType(fn CustomTypeFunc, types ...any) error {
+	return register("RegisterCustomType", func() {
+		for _, t := range types {
+			customTypes[reflect.TypeOf(t)] = fn
+		}
+	})
+}
+
+// customValue returns the value of field that rules are applied to. Fields of
+// defined types like `type UserID int64` are validated through their
+// underlying kind, so only registered custom types need extraction.
+//
+// Types implementing driver.Valuer, like sql.NullString or sql.NullInt64, are
+// validated through the value they store in the database: a NULL becomes an
+// empty value and []byte is validated as a string.
+//
+// Types implementing Opener are validated through the value they hold, an
+// absent one is an empty value.
+//
+// Interfaces and pointers are validated through the value they hold, a nil
+// one is an empty value.
+func customValue(field reflect.Value) (reflect.Value, error) {
+	for field.IsValid() {
+		if fn, ok := registered(customTypes, field.Type()); ok {
+			v, err := fn(field)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(v), nil
+		}
+
+		if held, _, ok := open(field); ok {
+			field = held
+			continue
+		}
+
+		if field.Type().Implements(valuerType) && field.CanInterface() {
+			if field.Kind() == reflect.Pointer && field.IsNil() {
+				return reflect.Value{}, nil
+			}
+			v, err := field.Interface().(driver.Valuer).Value()
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			return reflect.ValueOf(v), nil
+		}
+
+		switch field.Kind() {
+		case reflect.Interface, reflect.Pointer:
+			if field.IsNil() {
+				return reflect.Value{}, nil
+			}
+			field = field.Elem()
+		default:
+			return field, nil
+		}
+	}
+	return field, nil
+}
@@ -0,0 +1,212 @@
+package validator
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	UserID int64
+	Email  string
+	Score  uint8
+	Ratio  float64
+	Tags   []Email
+)
+
+type Money struct {
+	cents int64
+}
+
+type Secret struct {
+	value string
+}
+
+func TestValidateDefinedTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       any
+		wantErr int
+	}{
+		{
+			name: "valid defined types",
+			v: struct {
+				ID      UserID  `validate:"min:1"`
+				IDIn    UserID  `validate:"in:1,2,3"`
+				Email   Email   `validate:"min:3&max:64"`
+				Score   Score   `validate:"max:100"`
+				Small   int8    `validate:"min:-5&max:5"`
+				Large   uint64  `validate:"min:10"`
+				Ratio   Ratio   `validate:"min:0&max:1"`
+				Tags    Tags    `validate:"in:go,rust"`
+				Extreme uint64  `validate:"min:-1"`
+				Float   float32 `validate:"max:3"`
+			}{
+				ID:      42,
+				IDIn:    2,
+				Email:   "me@example.com",
+				Score:   99,
+				Small:   -5,
+				Large:   1 << 63,
+				Ratio:   0.5,
+				Tags:    Tags{"go"},
+				Extreme: 0,
+				Float:   2.5,
+			},
+		},
+		{
+			name: "wrong defined types",
+			v: struct {
+				ID    UserID `validate:"min:1"`
+				IDIn  UserID `validate:"in:1,2,3"`
+				Email Email  `validate:"len:5"`
+				Score Score  `validate:"max:100"`
+				Large uint64 `validate:"max:-1"`
+				Ratio Ratio  `validate:"max:1"`
+				Tags  Tags   `validate:"in:go,rust"`
+			}{
+				ID:    0,
+				IDIn:  4,
+				Email: "me@example.com",
+				Score: 101,
+				Large: 0,
+				Ratio: 1.5,
+				Tags:  Tags{"go", "java"},
+			},
+			wantErr: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantErr == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), tt.wantErr)
+		})
+	}
+}
+
+func TestRegisterCustomType(t *testing.T) {
+	RegisterCustomType(func(field reflect.Value) (any, error) {
+		return field.Interface().(Money).cents, nil
+	}, Money{})
+	RegisterCustomType(func(field reflect.Value) (any, error) {
+		return nil, errors.New("secrets cannot be read")
+	}, Secret{})
+	t.Cleanup(func() {
+		delete(customTypes, reflect.TypeOf(Money{}))
+		delete(customTypes, reflect.TypeOf(Secret{}))
+	})
+
+	type order struct {
+		Price  Money   `validate:"min:100"`
+		Prices []Money `validate:"max:1000"`
+	}
+
+	assert.NoError(t, Validate(order{Price: Money{150}, Prices: []Money{{1}, {1000}}}))
+
+	err := Validate(order{Price: Money{50}, Prices: []Money{{1001}}})
+	assert.Error(t, err)
+	assert.Len(t, err.(ValidationErrors), 2)
+
+	err = Validate(struct {
+		Secret Secret `validate:"min:1"`
+	}{})
+	assert.Error(t, err)
+	assert.Len(t, err.(ValidationErrors), 1)
+}
+
+func TestValidateSQLNullTypes(t *testing.T) {
+	type row struct {
+		Name     sql.NullString  `validate:"required&min:2"`
+		Nickname sql.NullString  `validate:"omitempty&len:4"`
+		Age      sql.NullInt64   `validate:"omitempty&min:18"`
+		Rank     sql.NullInt32   `validate:"in:1,2,3"`
+		Score    sql.NullFloat64 `validate:"max:10"`
+		Deleted  sql.NullTime    `validate:"omitempty"`
+		Parent   *sql.NullString `validate:"omitempty&min:2"`
+	}
+
+	valid := row{
+		Name:  sql.NullString{String: "Ann", Valid: true},
+		Rank:  sql.NullInt32{Int32: 2, Valid: true},
+		Score: sql.NullFloat64{Float64: 9.5, Valid: true},
+	}
+	assert.NoError(t, Validate(valid))
+
+	invalid := row{
+		Name:     sql.NullString{},
+		Nickname: sql.NullString{String: "Anna-Maria", Valid: true},
+		Age:      sql.NullInt64{Int64: 16, Valid: true},
+		Rank:     sql.NullInt32{},
+		Score:    sql.NullFloat64{Float64: 11, Valid: true},
+		Parent:   &sql.NullString{String: "A", Valid: true},
+	}
+	err := Validate(invalid)
+	assert.Error(t, err)
+	assert.Len(t, err.(ValidationErrors), 6)
+}
+
+type Shape interface {
+	Area() int
+}
+
+type Square struct {
+	Side int `validate:"min:1"`
+}
+
+func (s Square) Area() int { return s.Side * s.Side }
+
+func TestValidateInterfaces(t *testing.T) {
+	type payload struct {
+		Value   any   `validate:"required&min:3"`
+		Shape   Shape `validate:"required"`
+		Shapes  []any `validate:"max:5"`
+		Note    any   `validate:"omitempty&len:2"`
+		Count   *int  `validate:"required&max:10"`
+		Pointer any   `validate:"max:10"`
+	}
+	zero, eleven := 0, 11
+
+	valid := payload{
+		Value:   "abc",
+		Shape:   Square{Side: 2},
+		Shapes:  []any{1, "abcde", 4.5},
+		Count:   &zero,
+		Pointer: &zero,
+	}
+	assert.NoError(t, Validate(valid))
+
+	err := Validate(payload{
+		Value:   2,
+		Shape:   &Square{},
+		Shapes:  []any{1, "abcdef"},
+		Note:    "abc",
+		Count:   &eleven,
+		Pointer: &eleven,
+	})
+	assert.Error(t, err)
+
+	var got []string
+	for _, e := range err.(ValidationErrors) {
+		got = append(got, e.Err.Error())
+	}
+	assert.Equal(t, []string{
+		"field: Value not valid for required&min:3",
+		"field: Shape.Side not valid for min:1",
+		"field: Shapes not valid for max:5",
+		"field: Note not valid for omitempty&len:2",
+		"field: Count not valid for required&max:10",
+		"field: Pointer not valid for max:10",
+	}, got)
+
+	err = Validate(payload{})
+	assert.Error(t, err)
+	assert.Len(t, err.(ValidationErrors), 4)
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// fieldErr returns the error reported for the tagged field f instead of
+// validating it: the error of its tag or, for an unexported field, the
+// error of the UnexportedPolicy.
+func (c *config) fieldErr(f *fieldPlan) error {
+	if !f.unexported {
+		return f.err
+	}
+	switch c.unexported {
+	case SkipUnexported:
+		return nil
+	case ValidateUnexportedUnsafe:
+		return f.err
+	}
+	return ErrValidateForUnexportedFields
+}
+
+// skips reports whether the rules of the field f are ignored.
+func (c *config) skips(f *fieldPlan) bool {
+	return f.unexported && c.unexported == SkipUnexported
+}
+
+// unexportedField returns the unexported field index of the struct structV
+// as a value that can be read like an exported field. ok is false when
+// structV can neither be addressed nor copied, as it was itself read from
+// an unexported field.
+func unexportedField(structV reflect.Value, index int) (reflect.Value, bool) {
+	if !structV.CanAddr() {
+		if !structV.CanInterface() {
+			return reflect.Value{}, false
+		}
+		copied := reflect.New(structV.Type()).Elem()
+		copied.Set(structV)
+		structV = copied
+	}
+	fieldV := structV.Field(index)
+	return reflect.NewAt(fieldV.Type(), unsafe.Pointer(fieldV.UnsafeAddr())).Elem(), true
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithUnexportedPolicy(t *testing.T) {
+	type account struct {
+		Name   string `validate:"required"`
+		secret string `validate:"min:8"`
+		pin    *int   `validate:"required"`
+	}
+	pin := 1234
+	invalid := account{Name: "ann", secret: "short"}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(invalid), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrValidateForUnexportedFields)
+	require.ErrorAs(t, Validate(invalid, WithUnexportedPolicy(ErrorUnexported)), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrValidateForUnexportedFields)
+
+	assert.NoError(t, Validate(invalid, WithUnexportedPolicy(SkipUnexported)))
+	_, err := Compile[account](WithUnexportedPolicy(SkipUnexported))
+	assert.NoError(t, err)
+	_, err = Compile[account]()
+	assert.Error(t, err)
+
+	unsafe := WithUnexportedPolicy(ValidateUnexportedUnsafe)
+	for _, v := range []any{invalid, &invalid, map[string]account{"": invalid}} {
+		require.ErrorAs(t, Validate(v, unsafe), &errs)
+		assert.Len(t, errs, 2, "%T", v)
+		assert.Equal(t, "min", errs[0].Rule())
+	}
+	assert.NoError(t, Validate(account{Name: "ann", secret: "long enough", pin: &pin}, unsafe))
+	assert.NoError(t, Validate(map[string]account{"a": {secret: "long enough", pin: &pin, Name: "ann"}}, unsafe))
+}
@@ -1,21 +1,89 @@
 package validator
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nadya2002/validator/internal/tags"
 )
 
 var ErrNotStruct = errors.New("wrong argument given, should be a struct")
+
+// ErrInvalidValidatorSyntax is wrapped by the errors of malformed tags and
+// rules, usually as a SyntaxError. Whatever the tag, parsing it never
+// panics: malformed rules are reported as such errors when validating.
 var ErrInvalidValidatorSyntax = errors.New("invalid validator syntax")
+
 var ErrValidateForUnexportedFields = errors.New("validation for unexported field is not allowed")
 var ErrFieldNotValid = errors.New("field not valid")
+var ErrMaxDepth = errors.New("structs nested too deep")
+var ErrPanic = errors.New("panic during validation")
 
 type ValidationError struct {
 	Err error
 }
 
+// fieldError is the error of a field whose value does not satisfy its rules.
+// The message is only built when it is requested. err is the error returned
+// by a custom rule, if any.
+type fieldError struct {
+	field string
+	cond  string
+	// rule is the name of the rule that failed, "" when the value could not
+	// be checked at all.
+	rule string
+	err  error
+	// reportOnly is set for the warnings of the rules of WithReportOnly.
+	reportOnly bool
+}
+
+func (e *fieldError) Error() string {
+	if msg, ok := e.message(); ok {
+		return msg
+	}
+	if e.field == "" {
+		return "value not valid for " + e.cond
+	}
+	return "field: " + e.field + " not valid for " + e.cond
+}
+
+func (e *fieldError) Unwrap() []error {
+	if e.err == nil || e.err == ErrFieldNotValid {
+		return []error{ErrFieldNotValid}
+	}
+	return []error{ErrFieldNotValid, e.err}
+}
+
+// Field returns the path of the field that is not valid, e.g. "Address.Zip",
+// or "" when the error is not about the value of a struct field.
+func (e ValidationError) Field() string {
+	var fe *fieldError
+	if errors.As(e.Err, &fe) {
+		return fe.field
+	}
+	return ""
+}
+
+// Rules returns the rules the value does not satisfy as written in the tag,
+// e.g. "required&len:5", or "" when the error is not about a value.
+func (e ValidationError) Rules() string {
+	var fe *fieldError
+	if errors.As(e.Err, &fe) {
+		return fe.cond
+	}
+	return ""
+}
+
+// ValidationErrors lists the errors of a validation in the order the fields
+// are declared, elements of slices and arrays by index and of maps by key,
+// whether or not the validation runs in parallel.
 type ValidationErrors []ValidationError
 
 func (v ValidationErrors) Error() string {
@@ -27,71 +95,743 @@ func (v ValidationErrors) Error() string {
 	return sb.String()
 }
 
-func Validate(v any) error {
-	var allErrors ValidationErrors
+// Validate validates the fields of the struct v, or of the struct v points
+// to. Zero fields with a default rule, like `validate:"default:8080&min:1"`,
+// are set to the default of the rule in a struct passed by pointer, and
+// validated as if they held it otherwise.
+//
+// The structs of a slice, an array or a map v are validated one by one, with
+// errors reported for paths like "[2].Name" or "[key].Name".
+func Validate(v any, opts ...Option) error {
+	return ValidateCtx(context.Background(), v, opts...)
+}
 
+// ValidateCtx validates v like Validate, passing ctx to custom rules.
+// Validation stops as soon as ctx is done, returning ctx.Err().
+func ValidateCtx(ctx context.Context, v any, opts ...Option) error {
+	return validateCtx(ctx, v, newConfig(opts))
+}
+
+func validateCtx(ctx context.Context, v any, c config) error {
+	s := validation{ctx: ctx, config: c}
+	if err := s.validateRoot(v); err != nil {
+		return err
+	}
+	return s.result()
+}
+
+// validateRoot validates the struct v or the struct v points to, or the
+// structs of the slice, array or map v.
+func (s *validation) validateRoot(v any) error {
 	valueV := reflect.ValueOf(v)
 	typeV := reflect.TypeOf(v)
 
-	if typeV.Kind() != reflect.Struct {
+	if typeV != nil && typeV.Kind() == reflect.Pointer && !valueV.IsNil() && (typeV.Elem().Kind() == reflect.Struct || structElems(typeV.Elem())) {
+		// Fields of a struct behind a pointer can take their default values.
+		valueV, typeV = valueV.Elem(), typeV.Elem()
+	}
+	if typeV == nil || typeV.Kind() != reflect.Struct && !structElems(typeV) {
 		return ErrNotStruct
 	}
 
-	for i := 0; i < typeV.NumField(); i++ {
-		validCond := typeV.Field(i).Tag.Get("validate")
-		if len(validCond) == 0 {
-			continue
+	s.validateTop(valueV)
+	return nil
+}
+
+// structElems reports whether t is a slice, an array or a map of structs or
+// of pointers to structs.
+func structElems(t reflect.Type) bool {
+	return isCollection(t) && derefType(t.Elem()).Kind() == reflect.Struct
+}
+
+// validateTop validates the struct valueV at the top of the validation, or
+// the structs of the collection valueV. Errors of their fields are reported
+// with the index or key of the struct, like "[2].Name".
+func (s *validation) validateTop(valueV reflect.Value) {
+	s.begin(valueV)
+	defer s.recoverPanic()
+	if s.maxBytes > 0 && byteSize(valueV, s.maxBytes) > s.maxBytes {
+		s.err = fmt.Errorf("%w: more than %d bytes", ErrTooLarge, s.maxBytes)
+		return
+	}
+	if valueV.Kind() != reflect.Struct {
+		s.validateElems(fieldPath{}.child(""), valueV, true)
+		return
+	}
+	s.validateStruct(fieldPath{}, valueV, true)
+}
+
+// recoverPanic stops the validation with an error wrapping ErrPanic when it
+// panics and panics are recovered. It must be deferred.
+func (s *validation) recoverPanic() {
+	if !s.recoverPanics {
+		return
+	}
+	if r := recover(); r != nil {
+		s.err = fmt.Errorf("%w: %v", ErrPanic, r)
+	}
+}
+
+// ValidateVar validates a single value against rules written in the same
+// syntax as the validate struct tag, e.g.
+//
+//	err := ValidateVar(email, "required&email")
+//
+// The rules only apply to value itself, fields of a struct value are not
+// validated.
+func ValidateVar(value any, rules string) error {
+	return validateVar(value, rules, config{})
+}
+
+func validateVar(value any, rules string, c config) error {
+	validator, errParse := cachedValidators(rules)
+	if errParse != nil {
+		return ValidationErrors{{errParse}}
+	}
+
+	s := validation{ctx: context.Background(), config: c}
+	s.checkVar("", rules, validator, reflect.Value{}, reflect.ValueOf(value))
+	return s.result()
+}
+
+// checkVar applies the validators of ValidateVar to value, reporting errors
+// for the field name. The conditions of if rules are checked against root,
+// the struct or map holding value, if any. The arguments of in rules are
+// parsed for the type of value, like for the fields of structs.
+func (s *validation) checkVar(name, rules string, validators []rule, root, value reflect.Value) {
+	s.begin(value)
+	defer s.recoverPanic()
+	if !conditionsHold(validators, root) {
+		return
+	}
+	if hasRefs(validators) {
+		var err error
+		if validators, err = withRefs(validators, root); err != nil {
+			s.errors = append(s.errors, ValidationError{err})
+			return
+		}
+	}
+	if value.IsValid() && hasIn(validators) {
+		validators = copyRules(validators)
+		if err := typeIn(validators, value.Type(), name); err != nil {
+			s.errors = append(s.errors, ValidationError{err})
+			return
 		}
+	}
+	s.checkField(&fieldPath{}, name, rules, validators, value)
+}
 
-		if !typeV.Field(i).IsExported() {
-			allErrors = append(allErrors, ValidationError{ErrValidateForUnexportedFields})
-			continue
+// validation holds the state of a single Validate call.
+type validation struct {
+	config
+	ctx context.Context
+	// err stops the validation once set: ctx is done or a batch rule could
+	// not check its values.
+	err error
+	// plans holds plans prepared in advance, e.g. by Compile.
+	plans    map[reflect.Type]*structPlan
+	errors   ValidationErrors
+	warnings ValidationErrors
+	// changes are the default values set, for Result.Modifications.
+	changes []FieldChange
+	// failed is the name of the rule the last field not valid failed.
+	failed string
+	// failures are the errors of the plan of the field being checked, see
+	// fieldError.
+	failures map[string]*fieldError
+	// pending holds the values of batch rules, checked by result.
+	pending []pendingCheck
+	// depth is the nesting of the struct being validated. The structs
+	// enclosing it are recorded in ancestors, and in deeper beyond its size,
+	// to detect values referencing themselves.
+	depth     int
+	ancestors [16]visit
+	deeper    []visit
+	// typ is the type of the validated value and start the time the
+	// validation started, both only set for hooks and tracing. span is the
+	// span of a traced validation, checked the number of rules of the
+	// validated fields for it.
+	typ     reflect.Type
+	start   time.Time
+	span    Span
+	checked int
+	// stop releases the timeout of WithTimeout.
+	stop context.CancelFunc
+}
+
+// result checks the values of batch rules and returns the outcome of the
+// validation.
+func (s *validation) result() error {
+	s.runBatches()
+	s.report()
+	s.stopTimeout()
+	if s.err != nil {
+		return s.err
+	}
+	if len(s.errors) == 0 {
+		return nil
+	}
+	return s.errors
+}
+
+func (s *validation) planFor(typeV reflect.Type) *structPlan {
+	if plan, ok := s.plans[typeV]; ok {
+		return plan
+	}
+	plan := s.config.cachedPlan(typeV)
+	checkRegistered(typeV, plan)
+	return plan
+}
+
+// canceled reports whether the validation should stop because ctx is done.
+func (s *validation) canceled() bool {
+	if s.err == nil {
+		s.err = s.ctx.Err()
+	}
+	return s.err != nil
+}
+
+// visit identifies a struct value by its address and type, as the first
+// field of a struct shares its address with the struct. Structs that are not
+// addressable, which cannot be referenced by pointers, have the zero visit.
+type visit struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// enter records that the struct valueV at path is validated. It reports
+// false when valueV must be skipped: it is validated further up, so its
+// value references itself, or it is nested deeper than WithMaxDepth allows,
+// which stops the validation. Every call must be followed by one of leave.
+func (s *validation) enter(path *fieldPath, valueV reflect.Value) bool {
+	var key visit
+	if valueV.CanAddr() {
+		key = visit{valueV.UnsafeAddr(), valueV.Type()}
+	}
+	ok := key == (visit{}) || !s.visiting(key)
+	if ok && s.maxDepth > 0 && s.depth >= s.maxDepth {
+		if s.err == nil {
+			s.err = fmt.Errorf("%w: more than %d levels at %s", ErrMaxDepth, s.maxDepth, path.String())
+		}
+		ok = false
+	}
+
+	if s.depth < len(s.ancestors) {
+		s.ancestors[s.depth] = key
+	} else {
+		s.deeper = append(s.deeper, key)
+	}
+	s.depth++
+	return ok
+}
+
+// leave undoes enter once the struct is validated.
+func (s *validation) leave() {
+	s.depth--
+	if s.depth >= len(s.ancestors) {
+		s.deeper = s.deeper[:len(s.deeper)-1]
+	}
+}
+
+// visiting reports whether the struct key is validated further up.
+func (s *validation) visiting(key visit) bool {
+	n := s.depth
+	if n > len(s.ancestors) {
+		n = len(s.ancestors)
+	}
+	for _, ancestor := range s.ancestors[:n] {
+		if ancestor == key {
+			return true
+		}
+	}
+	for _, ancestor := range s.deeper {
+		if ancestor == key {
+			return true
 		}
+	}
+	return false
+}
 
-		validator, errParse := parseValidators(validCond)
-		if errParse != nil {
-			allErrors = append(allErrors, ValidationError{errParse})
+// validateStruct validates the fields of the struct valueV found at path. The
+// struct level validation registered for the type runs when structLevel is
+// set. Nested structs of types excluded by WithRecurseOnly and WithNoRecurse
+// are skipped.
+func (s *validation) validateStruct(path fieldPath, valueV reflect.Value, structLevel bool) {
+	if s.depth > 0 && !s.recurses(valueV.Type()) {
+		return
+	}
+	defer s.leave()
+	if !s.enter(&path, valueV) {
+		return
+	}
+	plan := s.planFor(valueV.Type())
+	if s.overrides != nil {
+		plan = s.overlaid(&path, valueV.Type(), plan)
+	}
+
+	for i := range plan.fields {
+		if s.canceled() {
+			return
+		}
+		f := &plan.fields[i]
+		fieldV := valueV.Field(f.index)
+
+		apply, descend := true, f.descend
+		if s.fields != nil {
+			apply, descend = s.fields.match(path.join(f.name))
+			// The fields of flattened embedded structs are matched one by one.
+			descend = f.descend && (descend || f.anonymous && s.embeddedNaming == FlattenEmbedded)
+		}
+
+		if f.tagged && apply {
+			s.validateTagged(&path, f, valueV, fieldV)
+		}
+
+		if !descend {
 			continue
 		}
+		if f.elems {
+			s.validateElems(path.child(f.name), fieldV, f.structLevel)
+			continue
+		}
+		if nested, ok := nestedStruct(fieldV); ok {
+			if f.anonymous && s.embeddedNaming == FlattenEmbedded {
+				s.validateStruct(path, nested, f.structLevel)
+			} else {
+				s.validateStruct(path.child(f.name), nested, f.structLevel)
+			}
+		}
+	}
 
-		kind := typeV.Field(i).Type.Kind()
+	if structLevel && s.fields != nil {
+		structLevel, _ = s.fields.match(path.String())
+	}
+	if structLevel {
+		s.validateStructLevel(valueV)
+	}
+}
 
+// validateTagged applies the rules of a tagged field of the struct structV.
+func (s *validation) validateTagged(path *fieldPath, f *fieldPlan, structV, fieldV reflect.Value) {
+	if err := s.fieldErr(f); err != nil {
+		s.errors = append(s.errors, ValidationError{err})
+		return
+	}
+	if s.skips(f) {
+		return
+	}
+	if s.strictRules && f.strictErr != nil {
+		s.errors = append(s.errors, ValidationError{f.strictErr})
+		return
+	}
+
+	if f.conditional && !conditionsHold(f.validators, structV) {
+		return
+	}
+	validators, warnings := f.validators, f.warnings
+	if f.refs {
 		var err error
+		if validators, err = withRefs(validators, structV); err == nil {
+			warnings, err = withRefs(warnings, structV)
+		}
+		if err != nil {
+			s.errors = append(s.errors, ValidationError{err})
+			return
+		}
+	}
 
-		switch kind {
-		case reflect.Slice:
-			err = validateSlice(validator, valueV.Field(i))
-		default:
-			err = validateValue(validator, kind, valueV.Field(i))
+	if f.unexported {
+		var ok bool
+		if fieldV, ok = unexportedField(structV, f.index); !ok {
+			s.errors = append(s.errors, ValidationError{ErrValidateForUnexportedFields})
+			return
 		}
+	}
+	if f.dflt.IsValid() {
+		if fieldV.CanSet() && unset(fieldV) {
+			old := changedValue(fieldV)
+			fieldV = withDefault(fieldV, f.dflt)
+			s.changes = append(s.changes, FieldChange{Field: path.join(f.name), Modifier: "default", Old: old, New: changedValue(fieldV)})
+		} else {
+			fieldV = withDefault(fieldV, f.dflt)
+		}
+	}
+	s.failures = f.failures
+	s.checkField(path, f.name, f.cond, validators, fieldV)
+	s.failures = nil
+	if warnings != nil {
+		s.checkWarnings(path, f.name, f.cond, warnings, fieldV)
+	}
+}
 
-		if err != nil {
-			allErrors = append(allErrors, ValidationError{errors.New("field: " + typeV.Field(i).Name + " not valid for " + validCond)})
+// checkField applies validators to fieldV, the field name of the struct at
+// path, and reports an error when it does not satisfy them.
+func (s *validation) checkField(path *fieldPath, name, cond string, validators []rule, fieldV reflect.Value) {
+	if s.reportOnly != nil {
+		if enforced, reported := splitReportOnly(validators, s.reportOnly); reported != nil {
+			s.checkReported(path, name, cond, reported, fieldV)
+			validators = enforced
 		}
 	}
-	if len(allErrors) == 0 {
-		return nil
+	pending := len(s.pending)
+	s.checked += len(validators)
+
+	if hasValidator(validators, "continue") {
+		s.checkAll(path, name, cond, validators, fieldV)
+	} else if err := s.validateField(validators, fieldV); err != nil {
+		// The field is not valid anyway, its batch rules need no checks.
+		s.pending = s.pending[:pending]
+		s.errors = append(s.errors, ValidationError{s.fieldError(path, name, cond, validators, err)})
+		return
+	}
+
+	if len(s.pending) > pending {
+		field := path.join(name)
+		for i := pending; i < len(s.pending); i++ {
+			s.pending[i].field, s.pending[i].cond, s.pending[i].at = field, cond, len(s.errors)
+			s.pending[i].fieldRules = validators
+		}
 	}
-	return allErrors
 }
 
-func parseValidators(get string) ([]Validator, error) {
-	parts := strings.Split(get, "&")
-	var allValidators []Validator
-	for _, cond := range parts {
-		validator, err := parseValidator(cond)
-		if err != nil {
-			return nil, err
+// fieldError returns the error of the field name of the struct at path not
+// satisfying validators, its rules written as cond, with err. The errors of
+// the fields of the validated struct failing a rule without an error of its
+// own are those of their plan, so reporting them does not allocate; their
+// messages are only built when read, like those of all fieldErrors.
+func (s *validation) fieldError(path *fieldPath, name, cond string, validators []rule, err error) *fieldError {
+	if fe := s.failures[s.failed]; fe != nil && path.depth == 0 && err == ErrFieldNotValid {
+		return fe
+	}
+	return &fieldError{field: path.join(name), cond: cond, rule: s.failed, err: s.redacted(validators, err)}
+}
+
+// nestedStruct reports whether the rules of field's own fields should be
+// validated and returns the struct to descend into, the one held by an
+// Opener too. Nil pointers and interfaces as well as types validated through
+// an extracted value, like sql.NullString, and registered numeric types are
+// not nested structs.
+func nestedStruct(field reflect.Value) (reflect.Value, bool) {
+	for field.Kind() == reflect.Pointer || field.Kind() == reflect.Interface || field.IsValid() && field.Type().Implements(openerType) {
+		if held, _, ok := open(field); ok {
+			field = held
+			continue
+		}
+		if field.IsNil() {
+			return reflect.Value{}, false
 		}
-		allValidators = append(allValidators, validator)
+		field = field.Elem()
+	}
+	if field.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	if _, ok := registered(customTypes, field.Type()); ok || field.Type().Implements(valuerType) {
+		return reflect.Value{}, false
 	}
+	if _, ok := registered(numberTypes, field.Type()); ok {
+		return reflect.Value{}, false
+	}
+	return field, true
+}
+
+func parseValidators(get string) ([]rule, error) {
+	get = currentSyntax().canonical(get)
+	allValidators, err := appendValidators(make([]rule, 0, strings.Count(get, "&")+1), get, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if hasValidator(allValidators, "bail") && hasValidator(allValidators, "continue") {
+		return nil, ErrInvalidValidatorSyntax
+	}
+	// validateField looks for the groups, astext and asnum rules in front.
+	moveToFront(allValidators, "asnum")
+	moveToFront(allValidators, "astext")
+	moveToFront(allValidators, "groups")
 	return allValidators, nil
 }
 
-func validateSlice(validators []Validator, value reflect.Value) error {
+// moveToFront moves the first rule named name in validators to the front.
+func moveToFront(validators []rule, name string) {
+	for i, validator := range validators {
+		if validator.name == name {
+			copy(validators[1:i+1], validators[:i])
+			validators[0] = validator
+			return
+		}
+	}
+}
+
+// appendValidators appends the validators parsed from get to validators,
+// expanding aliases. depth counts the aliases being expanded, nesting the
+// keys, values and or rules get is part of.
+func appendValidators(validators []rule, get string, depth, nesting int) ([]rule, error) {
+	exprs, err := tags.ParseExpr(get)
+	if err != nil {
+		var group *tags.GroupError
+		if depth != 0 || !errors.As(err, &group) {
+			return nil, ErrInvalidValidatorSyntax
+		}
+		return nil, &SyntaxError{Rule: strings.TrimSpace(get[group.Offset:]), Offset: group.Offset, Reason: group.Reason}
+	}
+	return appendExprs(validators, get, exprs, depth, nesting)
+}
+
+// appendExprs appends the validators parsed from exprs, the rules of get
+// that must all hold, to validators.
+func appendExprs(validators []rule, get string, exprs []tags.Expr, depth, nesting int) ([]rule, error) {
+	for i := 0; i < len(exprs); i++ {
+		cond := exprs[i].Rule
+		start, end := exprs[i].Offset, exprs[i].Offset+len(cond)
+
+		if exprs[i].Any != nil {
+			validator, err := parseOr(get, exprs[i].Any, depth, nesting)
+			if _, ok := err.(*SyntaxError); !ok && err != nil {
+				err = ruleSyntaxError(err, get, start, end, depth)
+			}
+			if err != nil {
+				return nil, err
+			}
+			validators = append(validators, validator)
+			continue
+		}
+
+		if rules, ok := registered(aliases, strings.TrimSpace(cond)); ok {
+			if depth == maxAliasDepth {
+				return nil, ErrInvalidValidatorSyntax
+			}
+			var err error
+			if validators, err = appendValidators(validators, currentSyntax().canonical(rules), depth+1, nesting); err != nil {
+				return nil, ruleSyntaxError(err, get, start, end, depth)
+			}
+			continue
+		}
+
+		var validator rule
+		var err error
+		switch name, params, _ := strings.Cut(cond, ":"); strings.TrimSpace(name) {
+		case "keys":
+			var rules string
+			var n int
+			rules, n, err = cutKeys(params, exprs[i+1:])
+			if i += n; err == nil {
+				validator, err = parseEach("keys", rules, depth, nesting)
+			}
+			end = exprs[i].Offset + len(exprs[i].Rule)
+		case "values":
+			validator, err = parseEach("values", params, depth, nesting)
+		default:
+			validator, err = parseValidator(cond)
+		}
+		if err != nil {
+			return nil, ruleSyntaxError(err, get, start, end, depth)
+		}
+		validators = append(validators, validator)
+	}
+	return validators, nil
+}
+
+// ruleSyntaxError returns the SyntaxError of the rule of get from start to
+// end, for syntax errors of rules written in tags. Errors in the rules of an
+// alias are reported for the alias, errors in a keys group for the whole
+// group, errors in a group of alternatives for the rule of the group. Rules
+// of unknown names come with the name of the closest rule, if any.
+func ruleSyntaxError(err error, get string, start, end int, depth int) error {
+	if depth != 0 || !errors.Is(err, ErrInvalidValidatorSyntax) {
+		return err
+	}
+	cond := strings.TrimSpace(get[start:end])
+	syntax := &SyntaxError{Rule: cond, Offset: start + strings.Index(get[start:end], cond)}
+	if unknown, ok := err.(*unknownRuleError); ok && unknown.name == ruleName(cond) {
+		syntax.Reason, syntax.Suggestion = "unknown rule", suggestRule(unknown.name)
+	}
+	return syntax
+}
+
+// ruleName returns the name of the rule cond, without its arguments.
+func ruleName(cond string) string {
+	name, _, _ := strings.Cut(cond, ":")
+	return strings.TrimSpace(name)
+}
+
+type parsedRules struct {
+	validators []rule
+	err        error
+}
+
+// ruleCache maps rule strings passed to ValidateVar to their parsedRules.
+// ruleCacheSize counts its entries, which are dropped once there are
+// maxCachedRules, as callers building rule strings at run time, e.g. per
+// tenant, would otherwise grow it without limit.
+var (
+	ruleCache     sync.Map
+	ruleCacheMu   sync.Mutex
+	ruleCacheSize int
+)
+
+// maxCachedRules limits the number of rule strings in ruleCache.
+const maxCachedRules = 4096
+
+func cachedValidators(rules string) ([]rule, error) {
+	if parsed, ok := ruleCache.Load(rules); ok {
+		return parsed.(parsedRules).validators, parsed.(parsedRules).err
+	}
+	validators, err := parseValidators(rules)
+
+	ruleCacheMu.Lock()
+	defer ruleCacheMu.Unlock()
+	if ruleCacheSize >= maxCachedRules {
+		// The rules in use are parsed again and cached anew.
+		clearMap(&ruleCache)
+		ruleCacheSize = 0
+	}
+	if _, loaded := ruleCache.LoadOrStore(rules, parsedRules{validators: validators, err: err}); !loaded {
+		ruleCacheSize++
+	}
+	return validators, err
+}
+
+// validateField applies validators to a field. Empty values, including nil
+// interfaces and NULL database values, fail "required" and skip all rules
+// after "omitempty". Channels and funcs only support the rules checking
+// whether they are nil.
+func (s *validation) validateField(validators []rule, raw reflect.Value) error {
+	if len(validators) != 0 && validators[0].name == "groups" && !s.inGroups(validators[0].argsStr) {
+		return nil
+	}
+	s.failed = ""
+
+	field, err := customValue(raw)
+	if asText(validators) {
+		if text, ok, errText := textValue(raw); ok {
+			field, err = text, errText
+		} else if err == nil && raw.IsValid() && field.Kind() != reflect.Slice {
+			err = errNoText(raw.Type())
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	empty := isEmpty(raw, field)
 	for _, validator := range validators {
+		if isExclusive(validator.name) && !validator.warn && !exclusiveHolds(validator, empty) ||
+			validator.name == "assert" && !assertionHolds(validator) {
+			// Applied whether the field is empty or not.
+			s.failed = validator.name
+			return ErrFieldNotValid
+		}
+	}
+	if empty {
+		for _, validator := range validators {
+			if validator.warn {
+				continue
+			}
+			switch validator.name {
+			case "omitempty":
+				return nil
+			case "required":
+				s.failed = validator.name
+				return ErrFieldNotValid
+			case "notnil":
+				if isNil(raw) {
+					s.failed = validator.name
+					return ErrFieldNotValid
+				}
+			}
+		}
+	}
+
+	if field.IsValid() && nilOnly(field.Kind()) {
+		if rule := notNilCheck(validators); rule != "" {
+			s.failed = rule
+			return fmt.Errorf("%w: %s on %s, only required and notnil apply", ErrRuleNotApplicable, rule, field.Type())
+		}
+		return nil
+	}
+
+	if asNumber(validators) && field.Kind() != reflect.Slice {
+		number, ok := numberValue(field)
+		if !ok {
+			s.failed = "asnum"
+			return ErrFieldNotValid
+		}
+		field = number
+	}
+
+	switch kind := field.Kind(); kind {
+	case reflect.Slice:
+		return s.validateSlice(validators, field)
+	default:
+		return s.validateValue(validators, kind, field)
+	}
+}
+
+// isEmpty reports whether the field raw holding the value field is empty. A
+// non-nil pointer is never empty, so pointers can tell an explicit zero from
+// a missing value. Values of types registered with RegisterZeroChecker are
+// empty when their checker says so.
+func isEmpty(raw, field reflect.Value) bool {
+	if !field.IsValid() {
+		return true
+	}
+	for raw.Kind() == reflect.Interface {
+		raw = raw.Elem()
+	}
+	if raw.Kind() == reflect.Pointer {
+		return false
+	}
+	if _, present, ok := open(raw); ok {
+		return !present
+	}
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Map:
+		return field.Len() == 0
+	}
+	return isZero(field)
+}
+
+func (s *validation) validateSlice(validators []rule, value reflect.Value) error {
+	text, number := asText(validators), asNumber(validators)
+	for j := range validators {
+		if isAggregate(validators[j].name) && !validators[j].warn {
+			// The rule applies to the elements as a whole.
+			if err := s.validateAggregate(validators[j], value); err != nil {
+				s.failed = validators[j].name
+				return err
+			}
+			continue
+		}
+		if validators[j].name == "maxbytes" || (validators[j].name == "jsonschema" || validators[j].name == "checksum") && value.Type().Elem().Kind() == reflect.Uint8 {
+			// The bytes of a slice, and a JSON document or checksummed data
+			// held by bytes, are checked as a whole.
+			if err := s.validateValue(validators[j:j+1], value.Kind(), value); err != nil {
+				return err
+			}
+			continue
+		}
 		for i := 0; i < value.Len(); i++ {
-			err := validateValue([]Validator{validator}, value.Index(i).Kind(), value.Index(i))
+			var elem reflect.Value
+			var err error
+			if text {
+				var ok bool
+				if elem, ok, err = textValue(value.Index(i)); !ok {
+					err = errNoText(value.Type().Elem())
+				}
+			} else {
+				elem, err = customValue(value.Index(i))
+			}
+			if err != nil {
+				return err
+			}
+			if number {
+				var ok bool
+				if elem, ok = numberValue(elem); !ok {
+					s.failed = "asnum"
+					return ErrFieldNotValid
+				}
+			}
+			err = s.validateValue(validators[j:j+1], elem.Kind(), elem)
 			if err != nil {
 				return err
 			}
@@ -101,10 +841,47 @@ func validateSlice(validators []Validator, value reflect.Value) error {
 	return nil
 }
 
-func validateValue(validators []Validator, kind reflect.Kind, field reflect.Value) error {
+// validateValue applies validators to a single value. Built-in rules fail
+// with ErrFieldNotValid, custom rules with the error they return. Batch rules
+// are only recorded and checked later for all values at once.
+func (s *validation) validateValue(validators []rule, kind reflect.Kind, field reflect.Value) error {
 	var err error
 	for _, validator := range validators {
+		if validator.warn {
+			// Checked by checkWarnings, they never fail the field.
+			continue
+		}
+		if validator.custom != nil {
+			if err := s.callCustom(validator, field); err != nil {
+				s.failed = validator.name
+				return err
+			}
+			continue
+		}
+		if validator.batch != nil {
+			s.pending = append(s.pending, pendingCheck{validator: validator, value: field})
+			continue
+		}
+
 		switch validator.name {
+		case "required", "notnil", "omitempty":
+			// Handled by validateField for the whole field.
+		case "structonly", "nostructlevel":
+			// Control how validateStruct descends into the field.
+		case "astext", "asnum":
+			// Applied by validateField before the rules run.
+		case "sensitive":
+			// Applied to the errors of the field, see redacted.
+		case "bail", "continue":
+			// Applied by checkField to the rules after a failing one.
+		case "if":
+			// Checked by validateTagged and checkVar for the whole field.
+		case "excluded_with", "exactly_one_of", "assert":
+			// Checked by validateField for the whole field.
+		case "groups":
+			// Checked by validateField for the whole field.
+		case "default":
+			// Applied by validateTagged before the rules run.
 		case "len":
 			if kind == reflect.String {
 				err = validateLen(field.String(), validator.argsInt[0])
@@ -115,67 +892,427 @@ func validateValue(validators []Validator, kind reflect.Kind, field reflect.Valu
 			switch kind {
 			case reflect.String:
 				err = validateMin(len(field.String()), validator.argsInt[0])
-			case reflect.Int:
-				err = validateMin(int(field.Int()), validator.argsInt[0])
 			default:
-				err = ErrFieldNotValid
+				err = validateMinNumber(field, validator.argsInt[0])
 			}
 		case "max":
 			switch kind {
 			case reflect.String:
 				err = validateMax(len(field.String()), validator.argsInt[0])
-			case reflect.Int:
-				err = validateMax(int(field.Int()), validator.argsInt[0])
 			default:
-				err = ErrFieldNotValid
+				err = validateMaxNumber(field, validator.argsInt[0])
 			}
 		case "in":
-			switch kind {
-			case reflect.String:
+			switch {
+			case validator.enum != nil:
+				err = validateEnum(field, validator.enum)
+			case kind == reflect.String:
 				err = validateIn(field.String(), validator.argsStr)
-			case reflect.Int:
-				err = validateIn(int(field.Int()), validator.argsInt)
 			default:
+				err = validateInNumber(field, validator)
+			}
+		case "enum":
+			err = validateEnum(field, validator.enum)
+		case "jsonschema":
+			if err := s.validatePayload(validator.payload, field); err != nil {
+				s.failed = validator.name
+				return err
+			}
+		case "in_ci":
+			if kind == reflect.String {
+				err = validateInFold(field.String(), validator.argsStr)
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "ulid":
+			if kind == reflect.String {
+				err = validateULID(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "objectid":
+			if kind == reflect.String {
+				err = validateObjectID(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "email":
+			if kind == reflect.String {
+				err = validateEmail(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "no_html":
+			if kind == reflect.String {
+				err = validateNoHTML(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "printable_unicode":
+			if kind == reflect.String {
+				err = validatePrintableUnicode(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "urlencoded":
+			if kind == reflect.String {
+				err = validateURLEncoded(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "slug":
+			if kind == reflect.String {
+				err = validateSlug(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "dns_label":
+			if kind == reflect.String {
+				err = validateDNSLabel(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "k8s_quantity":
+			if kind == reflect.String {
+				err = validateK8sQuantity(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "k8s_label":
+			if kind == reflect.String {
+				err = validateK8sLabel(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "hexcolor":
+			if kind == reflect.String {
+				err = validateHexColor(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "rgb":
+			if kind == reflect.String {
+				err = validateRGB(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "rgba":
+			if kind == reflect.String {
+				err = validateRGBA(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "hsl":
+			if kind == reflect.String {
+				err = validateHSL(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "cron":
+			if kind == reflect.String {
+				err = validateCron(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "mimetype":
+			if kind == reflect.String {
+				err = validateMIMEType(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "charset":
+			if kind == reflect.String {
+				err = validateCharset(field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "card_expiry":
+			if kind == reflect.String {
+				err = validateCardExpiry(field.String(), s.now())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "cvv":
+			if kind == reflect.String {
+				err = validateCVV(field.String(), validator.argsInt)
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "file", "dir", "filepath":
+			if kind == reflect.String {
+				err = validatePath(validator.name, field.String())
+			} else {
+				err = ErrFieldNotValid
+			}
+		case "password":
+			if kind != reflect.String || !validator.policy.Check(field.String()) {
 				err = ErrFieldNotValid
 			}
+		case "regexp":
+			if kind != reflect.String || !validator.re.MatchString(field.String()) {
+				err = ErrFieldNotValid
+			}
+		case "maxsize", "mime", "ext":
+			err = validateUpload(validator, field)
+		case "maxbytes":
+			err = validateMaxBytes(field, validator.argsInt[0])
+		case "uniquefield", "sumfield":
+			// Applied by validateSlice to the elements of slices.
+			err = ErrFieldNotValid
+		case "within":
+			err = validateWithin(field, validator.within, s.now())
+		case "minage":
+			err = validateMinAge(field, validator.argsInt[0], s.now())
+		case "checksum":
+			err = validateChecksum(field, validator.checksum, validator.sum)
+		case "or":
+			if err := s.validateOr(validator.alternatives, field); err != nil {
+				s.failed = validator.name
+				return err
+			}
+		case "keys", "values":
+			if kind != reflect.Map {
+				err = ErrFieldNotValid
+			} else if err := s.validateMap(validator, field); err != nil {
+				return err
+			}
 		default:
 			err = ErrInvalidValidatorSyntax
 		}
 
 		if err != nil {
+			s.failed = validator.name
 			return ErrFieldNotValid
 		}
 	}
 	return nil
 }
 
-type Validator struct {
+type rule struct {
+	// warn is set for rules reporting warnings, written like "warn:max:100".
+	warn    bool
 	name    string
 	argsStr []string
 	argsInt []int
+	policy  PasswordPolicy
+	re      *regexp.Regexp
+	custom  RuleFunc
+	batch   BatchRuleFunc
+	// each holds the rules of keys and values rules, applied to every key or
+	// value of a map.
+	each []rule
+	// enum holds the values of an enum rule, and the arguments of an in rule
+	// parsed for the type of the field, see typeIn.
+	enum []reflect.Value
+	// payload is the type of the payloads of a jsonschema rule.
+	payload reflect.Type
+	// checksum is the algorithm of a checksum rule and sum the checksum it
+	// requires, decoded from the argument or read from the field it
+	// references.
+	checksum ChecksumFunc
+	sum      []byte
+	// assertion is the comparison of an assert rule and nums the numbers
+	// of the fields it references, in the order of refs.
+	assertion *tags.Assertion
+	nums      []float64
+	// within is the duration of a within rule.
+	within time.Duration
+	// cond is the condition of an if rule.
+	cond *condition
+	// refs holds the paths of the fields referenced by the arguments, like
+	// "MaxItems" for "max:$MaxItems", or "" for the literal arguments.
+	refs []string
+	// alternatives holds the rules of the alternatives of an or rule.
+	alternatives [][]rule
+	// notInts marks the arguments of an in rule that are not integers, like
+	// "a" or "99999999999999999999", which no number is equal to.
+	notInts []bool
 }
 
-func parseValidator(get string) (Validator, error) {
-	parts := strings.Split(get, ":")
-	name := strings.TrimSpace(parts[0])
-	argsStr := strings.Split(parts[1], ",")
+// noArgsValidators lists the validators that are written without a colon and
+// take no arguments, e.g. `validate:"ulid"`.
+var noArgsValidators = map[string]bool{
+	"required":      true,
+	"notnil":        true,
+	"omitempty":     true,
+	"structonly":    true,
+	"nostructlevel": true,
+	"astext":        true,
+	"asnum":         true,
+	"sensitive":     true,
+	"bail":          true,
+	"continue":      true,
+
+	"ulid":     true,
+	"objectid": true,
+	"file":     true,
+	"dir":      true,
+	"filepath": true,
+
+	"email":             true,
+	"no_html":           true,
+	"printable_unicode": true,
+
+	"urlencoded": true,
+	"slug":       true,
+	"dns_label":  true,
+
+	"k8s_quantity": true,
+	"k8s_label":    true,
+
+	"hexcolor": true,
+	"rgb":      true,
+	"rgba":     true,
+	"hsl":      true,
+
+	"cron": true,
+
+	"mimetype": true,
+	"charset":  true,
+
+	"card_expiry": true,
+}
+
+func parseValidator(get string) (rule, error) {
+	name, params, found := strings.Cut(get, ":")
+	name = strings.TrimSpace(name)
+	if name == "warn" {
+		return parseWarning(params)
+	}
+	if fn, ok := registered(customRules, name); ok {
+		var args []string
+		if found {
+			args = tags.SplitArgs(params)
+		}
+		return rule{name: name, argsStr: withDefaultParams(name, args), custom: fn}, nil
+	}
+	if fn, ok := registered(batchRules, name); ok {
+		var args []string
+		if found {
+			args = tags.SplitArgs(params)
+		}
+		return rule{name: name, argsStr: withDefaultParams(name, args), batch: fn}, nil
+	}
+	if noArgsValidators[name] {
+		if found {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+		return rule{name: name}, nil
+	}
+	if name != "" && !tags.IsBuiltin(name) {
+		return rule{}, &unknownRuleError{name: name}
+	}
+	if !found {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	if name == "password" {
+		policy, err := parsePasswordPolicy(params)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{name: name, argsStr: []string{params}, policy: policy}, nil
+	}
+	if name == "default" {
+		// The value is parsed for the type of the field by newStructPlan.
+		return rule{name: name, argsStr: []string{tags.Unescape(params)}}, nil
+	}
+	if name == "regexp" {
+		// The pattern is taken as a whole, so it may contain commas, and
+		// is not unescaped: "\&" matches "&" anyway.
+		re, err := regexp.Compile(params)
+		if err != nil {
+			return rule{}, ErrInvalidValidatorSyntax
+		}
+		return rule{name: name, argsStr: []string{params}, re: re}, nil
+	}
+	switch name {
+	case "groups":
+		groups := tags.SplitArgs(params)
+		for i, group := range groups {
+			if groups[i] = strings.TrimSpace(group); groups[i] == "" {
+				return rule{}, ErrInvalidValidatorSyntax
+			}
+		}
+		return rule{name: name, argsStr: groups}, nil
+	case "maxsize", "maxbytes":
+		size, err := parseSize(params)
+		if err != nil {
+			return rule{}, err
+		}
+		return rule{name: name, argsStr: []string{params}, argsInt: []int{size}}, nil
+	case "mime", "ext":
+		return rule{name: name, argsStr: tags.SplitArgs(params)}, nil
+	case "enum":
+		return parseEnum(params)
+	case "uniquefield":
+		return parseUniqueField(params)
+	case "sumfield":
+		return parseSumField(params)
+	case "jsonschema":
+		return parsePayload(params)
+	case "if":
+		return parseCondition(params)
+	case "within":
+		return parseWithin(params)
+	case "cvv":
+		return parseCVV(params)
+	case "minage":
+		return parseMinAge(params)
+	case "checksum":
+		return parseChecksum(params)
+	case "excluded_with", "exactly_one_of":
+		return parseExclusive(name, params)
+	case "assert":
+		return parseAssert(params)
+	case "in_ci":
+		// Values are matched ignoring case and surrounding whitespace, so
+		// are the arguments.
+		args := tags.SplitArgs(params)
+		for i, arg := range args {
+			args[i] = strings.TrimSpace(arg)
+		}
+		if params == "" {
+			args = []string{}
+		}
+		return rule{name: name, argsStr: args}, nil
+	}
+
+	argsStr := tags.SplitArgs(params)
 	var args []int
-	for _, arg := range argsStr {
+	var refs []string
+	var notInts []bool
+	for i, arg := range argsStr {
+		if ref, ok := fieldRef(name, arg); ok {
+			if refs == nil {
+				refs = make([]string, len(argsStr))
+			}
+			refs[i] = ref
+			args = append(args, 0)
+			continue
+		}
 		num, err := strconv.Atoi(strings.TrimSpace(arg))
-		if err != nil && name != "in" {
-			return Validator{}, ErrInvalidValidatorSyntax
+		if err != nil {
+			if name != "in" {
+				return rule{}, ErrInvalidValidatorSyntax
+			}
+			if notInts == nil {
+				notInts = make([]bool, len(argsStr))
+			}
+			notInts[i], num = true, 0
 		}
 		args = append(args, num)
 	}
 
-	if name == "in" && len(argsStr) == 1 && argsStr[0] == "" {
+	if name == "in" && params == "" {
 		argsStr = []string{}
 	}
 
-	return Validator{
+	return rule{
 		name:    name,
 		argsStr: argsStr,
 		argsInt: args,
+		refs:    refs,
+		notInts: notInts,
 	}, nil
 }
 
@@ -210,3 +1347,62 @@ func validateIn[T comparable](field T, args []T) error {
 	}
 	return ErrFieldNotValid
 }
+
+// validateInFold reports whether field is one of args, ignoring case and
+// whitespace around field.
+func validateInFold(field string, args []string) error {
+	field = strings.TrimSpace(field)
+	for _, arg := range args {
+		if strings.EqualFold(arg, field) {
+			return nil
+		}
+	}
+	return ErrFieldNotValid
+}
+
+// compareNumber compares a field of any integer or float kind with num and
+// returns -1, 0 or +1. ok is false when the field is not a number.
+func compareNumber(field reflect.Value, num int) (c int, ok bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(field.Int(), int64(num)), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if num < 0 {
+			return 1, true
+		}
+		return compare(field.Uint(), uint64(num)), true
+	case reflect.Float32, reflect.Float64:
+		return compare(field.Float(), float64(num)), true
+	}
+	if !field.IsValid() {
+		return 0, false
+	}
+	if cmp, ok := registered(numberTypes, field.Type()); ok {
+		return cmp(field, num), true
+	}
+	return 0, false
+}
+
+func compare[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func validateMinNumber(field reflect.Value, num int) error {
+	if c, ok := compareNumber(field, num); ok && c >= 0 {
+		return nil
+	}
+	return ErrFieldNotValid
+}
+
+func validateMaxNumber(field reflect.Value, num int) error {
+	if c, ok := compareNumber(field, num); ok && c <= 0 {
+		return nil
+	}
+	return ErrFieldNotValid
+}
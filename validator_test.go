@@ -1,10 +1,20 @@
 package validator
 
 import (
+	"context"
 	"errors"
+	"math"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator/internal/tags"
 )
 
 func TestValidate(t *testing.T) {
@@ -87,7 +97,7 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 			checkErr: func(err error) bool {
 				e := &ValidationErrors{}
-				return errors.As(err, e) && e.Error() == ErrInvalidValidatorSyntax.Error()
+				return errors.As(err, e) && e.Error() == (&SyntaxError{Field: "Foo", Rule: "len:abcdef"}).Error()
 			},
 		},
 		{
@@ -298,3 +308,664 @@ func TestValidate(t *testing.T) {
 	}
 
 }
+
+func TestValidateRequired(t *testing.T) {
+	type args struct {
+		v any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr int
+	}{
+		{
+			name: "required and omitempty satisfied",
+			args: args{
+				v: struct {
+					Name     string   `validate:"required&max:10"`
+					Age      int      `validate:"required"`
+					Tags     []string `validate:"required&len:2"`
+					Nickname string   `validate:"omitempty&min:3"`
+					Limit    int      `validate:"omitempty&min:10"`
+					Labels   []string `validate:"omitempty&in:a,b"`
+				}{
+					Name: "Ann",
+					Age:  30,
+					Tags: []string{"go", "js"},
+				},
+			},
+		},
+		{
+			name: "required and omitempty violated",
+			args: args{
+				v: struct {
+					Name     string   `validate:"required&max:10"`
+					Age      int      `validate:"required"`
+					Tags     []string `validate:"required"`
+					Nickname string   `validate:"omitempty&min:3"`
+					Limit    int      `validate:"omitempty&min:10"`
+					Empty    string   `validate:"len:3"`
+				}{
+					Tags:     []string{},
+					Nickname: "An",
+					Limit:    5,
+				},
+			},
+			wantErr: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.args.v)
+			if tt.wantErr == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), tt.wantErr)
+		})
+	}
+}
+
+type Base struct {
+	ID   int    `validate:"min:1"`
+	Name string `validate:"required"`
+}
+
+type audit struct {
+	CreatedBy string `validate:"min:3"`
+}
+
+type Address struct {
+	City string `validate:"min:2"`
+	Zip  string `validate:"len:6"`
+}
+
+func TestValidateNested(t *testing.T) {
+	type user struct {
+		Base
+		*audit
+		Email   string `validate:"required"`
+		Address Address
+		Billing *Address
+		Backup  *Address
+		private Address
+	}
+	v := user{
+		Base:    Base{ID: 0, Name: "Ann"},
+		audit:   &audit{CreatedBy: "me"},
+		Email:   "ann@example.com",
+		Address: Address{City: "M", Zip: "123456"},
+		Billing: &Address{City: "Moscow", Zip: "123"},
+		private: Address{},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "flattened embedded names",
+			want: []string{
+				"field: ID not valid for min:1",
+				"field: CreatedBy not valid for min:3",
+				"field: Address.City not valid for min:2",
+				"field: Billing.Zip not valid for len:6",
+			},
+		},
+		{
+			name: "qualified embedded names",
+			opts: []Option{WithEmbeddedNaming(QualifyEmbedded)},
+			want: []string{
+				"field: Base.ID not valid for min:1",
+				"field: audit.CreatedBy not valid for min:3",
+				"field: Address.City not valid for min:2",
+				"field: Billing.Zip not valid for len:6",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(v, tt.opts...)
+			assert.Error(t, err)
+
+			var got []string
+			for _, e := range err.(ValidationErrors) {
+				got = append(got, e.Err.Error())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	v.ID = 1
+	v.audit = nil
+	v.Address.City = "Moscow"
+	v.Billing.Zip = "123456"
+	assert.NoError(t, Validate(v))
+}
+
+type page[T any] struct {
+	Items []T `validate:"required"`
+	Limit int `validate:"min:1&max:100"`
+	Next  *T
+	ByID  map[string]T
+}
+
+type pair[K comparable, V any] struct {
+	Key   K `validate:"required"`
+	Value V
+}
+
+func TestValidateGeneric(t *testing.T) {
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(page[Address]{
+		Items: []Address{{City: "Moscow", Zip: "123456"}, {City: "M", Zip: "123456"}},
+		Limit: 0,
+		Next:  &Address{City: "Moscow", Zip: "1"},
+		ByID:  map[string]Address{"a": {City: "Moscow"}},
+	}), &errs)
+	assert.Equal(t, []string{"Items[1].City", "Limit", "Next.Zip", "ByID[a].Zip"}, fieldsOf(errs))
+
+	require.ErrorAs(t, Validate(page[string]{Limit: 10}), &errs)
+	assert.Equal(t, []string{"Items"}, fieldsOf(errs))
+	assert.NoError(t, Validate(page[*Address]{Items: []*Address{nil, {City: "Moscow", Zip: "123456"}}, Limit: 10}))
+
+	require.ErrorAs(t, Validate(page[pair[string, Address]]{Items: []pair[string, Address]{{Value: Address{City: "M", Zip: "123456"}}}, Limit: 1}), &errs)
+	assert.Equal(t, []string{"Items[0].Key", "Items[0].Value.City"}, fieldsOf(errs))
+
+	typed, err := Compile[page[Address]]()
+	require.NoError(t, err)
+	require.ErrorAs(t, typed.Validate(page[Address]{Items: []Address{{}}, Limit: 1}), &errs)
+	assert.Equal(t, []string{"Items[0].City", "Items[0].Zip"}, fieldsOf(errs))
+
+	_, err = Compile[page[func()]]()
+	assert.NoError(t, err)
+	assert.NoError(t, Lint(reflect.TypeOf(page[int]{})))
+}
+
+func TestValidateVar(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		rules   string
+		wantErr error
+	}{
+		{name: "valid email", value: "user@example.com", rules: "required&email"},
+		{name: "valid int", value: 8080, rules: "min:1&max:65535"},
+		{name: "valid slice", value: []string{"a", "b"}, rules: "in:a,b,c"},
+		{name: "valid pointer", value: new(int), rules: "required&max:0"},
+		{name: "empty optional", value: "", rules: "omitempty&email"},
+		{name: "quoted arguments", value: "c:d", rules: `in:"a,b","c:d"`},
+		{name: "escaped arguments", value: "a&b", rules: `in:a\&b,c\,d`},
+		{name: "quoted empty argument", value: "", rules: `in:"",a`},
+		{name: "in ignoring case", value: " ru ", rules: "in_ci:RU, EN,de"},
+		{name: "in ignoring case slice", value: []string{"En", "DE"}, rules: "in_ci:ru,en,de"},
+		{
+			name:    "escaped separator",
+			value:   "c",
+			rules:   `in:a\,b&len:1`,
+			wantErr: errors.New(`value not valid for in:a\,b&len:1`),
+		},
+		{
+			name:    "not in ignoring case",
+			value:   "fr",
+			rules:   "in_ci:RU,EN",
+			wantErr: errors.New("value not valid for in_ci:RU,EN"),
+		},
+		{
+			name:    "in ignoring case number",
+			value:   1,
+			rules:   "in_ci:1",
+			wantErr: errors.New("value not valid for in_ci:1"),
+		},
+		{
+			name:    "missing required",
+			value:   "",
+			rules:   "required&email",
+			wantErr: errors.New("value not valid for required&email"),
+		},
+		{
+			name:    "wrong email",
+			value:   "John <user@example.com>",
+			rules:   "email",
+			wantErr: errors.New("value not valid for email"),
+		},
+		{
+			name:    "out of range",
+			value:   70000,
+			rules:   "min:1&max:65535",
+			wantErr: errors.New("value not valid for min:1&max:65535"),
+		},
+		{
+			name:    "nil value",
+			value:   nil,
+			rules:   "required",
+			wantErr: errors.New("value not valid for required"),
+		},
+		{
+			name:    "invalid rules",
+			value:   "abc",
+			rules:   "len:x",
+			wantErr: &SyntaxError{Rule: "len:x"},
+		},
+		{
+			name:    "missing arguments",
+			value:   "abc",
+			rules:   "required&min",
+			wantErr: &SyntaxError{Rule: "min", Offset: 9},
+		},
+		{
+			name:    "arguments of rule without arguments",
+			value:   "abc",
+			rules:   "required:1",
+			wantErr: &SyntaxError{Rule: "required:1"},
+		},
+		{
+			name:    "empty rule",
+			value:   "abc",
+			rules:   "required&&min:1",
+			wantErr: &SyntaxError{Rule: "", Offset: 9},
+		},
+		{
+			name:    "argument out of range",
+			value:   "abc",
+			rules:   "len:9223372036854775808",
+			wantErr: &SyntaxError{Rule: "len:9223372036854775808"},
+		},
+		{
+			name:    "warning of warning",
+			value:   "abc",
+			rules:   "warn:warn:len:1",
+			wantErr: &SyntaxError{Rule: "warn:warn:len:1"},
+		},
+		{
+			name:    "nested too deeply",
+			value:   map[string]int{},
+			rules:   strings.Repeat("values:", 17) + "min:1",
+			wantErr: &SyntaxError{Rule: strings.Repeat("values:", 17) + "min:1"},
+		},
+		{
+			name:    "groups nested too deeply",
+			value:   "abc",
+			rules:   strings.Repeat("(", 17) + "len:3" + strings.Repeat(")", 17),
+			wantErr: &SyntaxError{Rule: "(len:3" + strings.Repeat(")", 17), Offset: 16, Reason: "parentheses nested too deeply"},
+		},
+		{
+			name:    "number not in text arguments",
+			value:   0,
+			rules:   "in:a,b",
+			wantErr: errors.New(`invalid validator syntax: argument "a" of rule in is not a value of int`),
+		},
+		{
+			name:    "number not in huge arguments",
+			value:   math.MaxInt,
+			rules:   "in:99999999999999999999",
+			wantErr: errors.New(`invalid validator syntax: argument "99999999999999999999" of rule in is not a value of int`),
+		},
+		{
+			name:    "text of nil value",
+			value:   nil,
+			rules:   "astext&len:1",
+			wantErr: errors.New("value not valid for astext&len:1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVar(tt.value, tt.rules)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Len(t, err.(ValidationErrors), 1)
+			assert.Equal(t, tt.wantErr.Error(), err.Error())
+		})
+	}
+}
+
+func TestValidateVarRuleCacheBounded(t *testing.T) {
+	t.Cleanup(resetPlanCache)
+	resetPlanCache()
+
+	for i := 0; i < maxCachedRules+10; i++ {
+		require.NoError(t, ValidateVar(i, "max:"+strconv.Itoa(i)))
+	}
+	require.LessOrEqual(t, ruleCacheSize, maxCachedRules)
+
+	entries := 0
+	ruleCache.Range(func(_, _ any) bool {
+		entries++
+		return true
+	})
+	require.Equal(t, ruleCacheSize, entries)
+	require.Error(t, ValidateVar(2, "max:1"))
+}
+
+func TestValidationErrorField(t *testing.T) {
+	err := Validate(struct {
+		Zip  string `validate:"required&len:5"`
+		code string `validate:"len:2"`
+	}{Zip: "1"})
+	require.Error(t, err)
+
+	errs := err.(ValidationErrors)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "Zip", errs[0].Field())
+	assert.Equal(t, "required&len:5", errs[0].Rules())
+	assert.Equal(t, "", errs[1].Field())
+	assert.Equal(t, "", errs[1].Rules())
+}
+
+func TestValidateDefault(t *testing.T) {
+	type server struct {
+		Host string `validate:"default:localhost&required"`
+	}
+	type config struct {
+		Port    int     `validate:"default:8080&min:1&max:65535"`
+		Mode    string  `validate:"default:pending&in:pending,done"`
+		Ratio   float64 `validate:"default:0.5"`
+		Debug   *bool   `validate:"default:true"`
+		Server  server
+		Workers []server
+	}
+
+	cfg := config{Workers: []server{{}, {Host: "w2"}}}
+	require.NoError(t, Validate(&cfg))
+	assert.Equal(t, 8080, cfg.Port)
+	assert.Equal(t, "pending", cfg.Mode)
+	assert.Equal(t, 0.5, cfg.Ratio)
+	require.NotNil(t, cfg.Debug)
+	assert.True(t, *cfg.Debug)
+	assert.Equal(t, "localhost", cfg.Server.Host)
+	assert.Equal(t, []server{{Host: "localhost"}, {Host: "w2"}}, cfg.Workers)
+
+	// Without a pointer the defaults are validated but not set.
+	cfg = config{Mode: "done"}
+	require.NoError(t, Validate(cfg))
+	assert.Equal(t, 0, cfg.Port)
+
+	err := Validate(&config{Port: 70000})
+	require.Error(t, err)
+	assert.Equal(t, "Port", err.(ValidationErrors)[0].Field())
+
+	err = Validate(struct {
+		Port int       `validate:"default:x"`
+		Tags []string  `validate:"default:a"`
+		Next *struct{} `validate:"default:a"`
+	}{})
+	require.Error(t, err)
+	errs := err.(ValidationErrors)
+	require.Len(t, errs, 3)
+	assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, errs[1].Err, ErrRuleNotApplicable)
+	assert.ErrorIs(t, errs[2].Err, ErrRuleNotApplicable)
+
+	var nilConfig *config
+	assert.ErrorIs(t, Validate(nilConfig), ErrNotStruct)
+}
+
+type cyclicNode struct {
+	Name string `validate:"required"`
+	Next *cyclicNode
+	Kids []*cyclicNode
+}
+
+func TestValidateCycles(t *testing.T) {
+	ring := &cyclicNode{Name: "a"}
+	ring.Next = &cyclicNode{Name: "b", Next: ring}
+	assert.NoError(t, Validate(ring))
+
+	self := &cyclicNode{}
+	self.Next = self
+	self.Kids = []*cyclicNode{self, self}
+	err := Validate(self, WithParallelism(4))
+	require.Error(t, err)
+	assert.Equal(t, "Name", err.(ValidationErrors)[0].Field())
+
+	// Values shared but not cyclic are validated wherever they are.
+	shared := &cyclicNode{}
+	err = Validate(cyclicNode{Name: "root", Next: shared, Kids: []*cyclicNode{shared}})
+	require.Error(t, err)
+	assert.Equal(t, []string{"Next.Name", "Kids[0].Name"}, err.(ValidationErrors).Fields())
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	root := &cyclicNode{Name: "0"}
+	current := root
+	for i := 1; i < 5; i++ {
+		current.Next = &cyclicNode{Name: "x"}
+		current = current.Next
+	}
+
+	assert.NoError(t, Validate(root, WithMaxDepth(5)))
+	err := Validate(root, WithMaxDepth(3))
+	assert.ErrorIs(t, err, ErrMaxDepth)
+	assert.EqualError(t, err, "structs nested too deep: more than 3 levels at Next.Next.Next")
+}
+
+func TestWithRecurse(t *testing.T) {
+	type internal struct {
+		ID string `validate:"required"`
+	}
+	type model struct {
+		internal
+		Deleted *internal
+	}
+	type item struct {
+		SKU string `validate:"len:4"`
+	}
+	type order struct {
+		model
+		Items   []item
+		Address *Address
+		Broken  struct {
+			Code string `validate:"len:x"`
+		}
+	}
+	v := order{model: model{Deleted: &internal{}}, Items: []item{{}}, Address: &Address{}}
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		fields []string
+	}{
+		{"no recurse", []Option{WithNoRecurse(internal{}, struct {
+			Code string `validate:"len:x"`
+		}{})}, []string{"Items[0].SKU", "Address.City", "Address.Zip"}},
+		{"recurse only", []Option{WithRecurseOnly((*item)(nil), model{})}, []string{"Items[0].SKU"}},
+		{"both", []Option{WithRecurseOnly(model{}, internal{}), WithNoRecurse(internal{})}, nil},
+		{"added up", []Option{WithRecurseOnly(item{}), WithRecurseOnly(Address{})}, []string{"Items[0].SKU", "Address.City", "Address.Zip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(v, tt.opts...)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.fields, fieldsOf(errs))
+		})
+	}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(v), &errs)
+	assert.Equal(t, []string{"ID", "Deleted.ID", "Items[0].SKU", "Address.City", "Address.Zip", ""}, fieldsOf(errs))
+
+	_, err := Compile[order]()
+	assert.Error(t, err)
+	_, err = Compile[order](WithRecurseOnly(item{}))
+	assert.NoError(t, err)
+	fields, err := Describe(order{}, WithRecurseOnly(item{}))
+	require.NoError(t, err)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "Items.SKU", fields[0].Name())
+
+	assert.NoError(t, Validate([]internal{{ID: "a"}}, WithNoRecurse(item{})))
+	assert.Error(t, Validate([]internal{{}}, WithNoRecurse(internal{})))
+}
+
+func TestWithPanicRecovery(t *testing.T) {
+	RegisterRule("explode", func(ctx context.Context, field reflect.Value, params []string) error {
+		panic("boom")
+	})
+	t.Cleanup(func() {
+		delete(customRules, "explode")
+	})
+
+	type input struct {
+		Name string `validate:"explode"`
+	}
+	assert.Panics(t, func() { _ = Validate(input{}) })
+
+	err := Validate(input{}, WithPanicRecovery())
+	assert.ErrorIs(t, err, ErrPanic)
+	assert.EqualError(t, err, "panic during validation: boom")
+
+	items := make([]struct{ In input }, 300)
+	assert.ErrorIs(t, Validate(struct{ Items []struct{ In input } }{items}, WithParallelism(4), WithPanicRecovery()), ErrPanic)
+	assert.ErrorIs(t, New(WithPanicRecovery()).ValidateVar("", "explode"), ErrPanic)
+}
+
+func TestValidateCollections(t *testing.T) {
+	users := []compiledUser{{Name: "Ann", Age: 30}, {Name: "Bob", Age: 17}, {Age: 40}}
+	want := []string{"[1].Age", "[2].Name"}
+
+	for _, v := range []any{users, &users, [3]compiledUser(users), []*compiledUser{&users[0], &users[1], nil, &users[2]}} {
+		var errs ValidationErrors
+		require.ErrorAs(t, Validate(v), &errs)
+		if _, ok := v.([]*compiledUser); ok {
+			assert.Equal(t, []string{"[1].Age", "[3].Name"}, fieldsOf(errs))
+			continue
+		}
+		assert.Equal(t, want, fieldsOf(errs))
+	}
+
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(map[string]compiledUser{"bob": users[1], "ann": users[0]}), &errs)
+	assert.Equal(t, []string{"[bob].Age"}, fieldsOf(errs))
+
+	assert.NoError(t, Validate([]compiledUser{}))
+	assert.NoError(t, Validate(users[:1]))
+	assert.ErrorIs(t, Validate([]int{1}), ErrNotStruct)
+	assert.ErrorIs(t, Validate([]any{users[0]}), ErrNotStruct)
+
+	result := ValidateResult(users)
+	assert.True(t, result.Failed("[1].Age"))
+}
+
+// fuzzTags seeds the fuzz targets with tags around the edge cases of the
+// parser: empty rules, missing or huge arguments, nesting and groups.
+var fuzzTags = []string{
+	"required&(email|len:0)",
+	"min:3&max:5|len:0",
+	"values:(min:1|max:-1)",
+	`in:"a|b",c&regexp:^(a|b)$`,
+	"keys:len:2,endkeys&(min:1|max:2)",
+	"((len:1|len:2)&min:1)|",
+	`in:a\(,b\)&(`,
+	"required&&min:1",
+	"in:",
+	"in:,",
+	"len:99999999999999999999",
+	"min:-",
+	"keys:",
+	"endkeys",
+	"warn:warn:max:1",
+	"astext&len:1",
+	"default:",
+	"if:",
+	"enum:",
+	"within:",
+	"maxsize:1e400",
+	"min:$Other&max:$",
+	"values:keys:len:1,endkeys",
+}
+
+func FuzzParseValidators(f *testing.F) {
+	for _, tag := range fuzzTags {
+		f.Add(tag)
+	}
+	f.Fuzz(func(t *testing.T, tag string) {
+		_, err := parseValidators(tag)
+		if err != nil && !errors.Is(err, ErrInvalidValidatorSyntax) {
+			t.Fatalf("parsing %q: %v", tag, err)
+		}
+		if err == nil {
+			// Valid rules apply to any value without panicking.
+			_ = ValidateVar("x", tag)
+		}
+	})
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, tag := range fuzzTags {
+		f.Add(tag)
+	}
+	types := []reflect.Type{
+		reflect.TypeOf(""),
+		reflect.TypeOf(0),
+		reflect.TypeOf(uint8(0)),
+		reflect.TypeOf(1.5),
+		reflect.TypeOf([]string{}),
+		reflect.TypeOf(map[string]int{}),
+		reflect.TypeOf(time.Time{}),
+		reflect.TypeOf(&struct{ A int }{}),
+		reflect.TypeOf((*any)(nil)).Elem(),
+		reflect.TypeOf((*time.Time)(nil)),
+		reflect.TypeOf((*multipart.FileHeader)(nil)),
+	}
+	f.Fuzz(func(t *testing.T, tag string) {
+		if tag == "" || tag == "-" {
+			// Such fields are not validated at all.
+			return
+		}
+		_, syntaxErr := parseValidators(tag)
+		for _, fieldT := range types {
+			structT := reflect.StructOf([]reflect.StructField{
+				{Name: "Field", Type: fieldT, Tag: reflect.StructTag("validate:" + strconv.Quote(tag))},
+				{Name: "Other", Type: reflect.TypeOf(0)},
+			})
+			err := Validate(reflect.New(structT).Elem().Interface())
+			_ = ValidateVar(reflect.Zero(fieldT).Interface(), tag)
+			_ = Lint(structT)
+			_, _ = Describe(reflect.New(structT).Interface())
+			_, _ = ExplainRules(reflect.New(structT).Interface(), "en")
+			_, _ = ExportJSONSchema(reflect.New(structT).Interface())
+			if syntaxErr == nil {
+				continue
+			}
+			var errs ValidationErrors
+			if !errors.As(err, &errs) || !errors.Is(errs[0].Err, ErrInvalidValidatorSyntax) {
+				t.Fatalf("validating %q on %s: %v, want a syntax error", tag, fieldT, err)
+			}
+		}
+	})
+}
+
+func TestRulesOnNilValues(t *testing.T) {
+	types := []reflect.Type{
+		reflect.TypeOf((*any)(nil)).Elem(),
+		reflect.TypeOf((*string)(nil)),
+		reflect.TypeOf((*int)(nil)),
+		reflect.TypeOf((*time.Time)(nil)),
+		reflect.TypeOf((*multipart.FileHeader)(nil)),
+		reflect.TypeOf((*[]string)(nil)),
+	}
+	for _, name := range tags.Builtins() {
+		for _, args := range []string{"", ":0", ":1", ":a,b", ":1h", ":$Other"} {
+			tag := name + args
+			for _, fieldT := range types {
+				structT := reflect.StructOf([]reflect.StructField{
+					{Name: "Field", Type: fieldT, Tag: reflect.StructTag("validate:" + strconv.Quote(tag))},
+					{Name: "Other", Type: reflect.TypeOf(0)},
+				})
+				assert.NotPanics(t, func() { _ = Validate(reflect.New(structT).Elem().Interface()) }, "%s on %s", tag, fieldT)
+				assert.NotPanics(t, func() { _ = ValidateVar(reflect.Zero(fieldT).Interface(), tag) }, "%s on %s", tag, fieldT)
+			}
+			assert.NotPanics(t, func() { _ = ValidateVar(nil, tag) }, tag)
+		}
+	}
+}
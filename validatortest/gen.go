@@ -0,0 +1,624 @@
+package validatortest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nadya2002/validator"
+)
+
+// ErrCannotGenerate is returned by GenValid and GenInvalid when they find
+// no value of the type they are asked for.
+var ErrCannotGenerate = errors.New("validatortest: cannot generate value")
+
+// attempts is the number of values GenValid generates before giving up.
+const attempts = 100
+
+// GenValid returns a random T whose fields satisfy their validate tags, for
+// property tests of code consuming validated values and to check that the
+// rules accept the values they are meant to:
+//
+//	r := rand.New(rand.NewSource(1))
+//	for i := 0; i < 100; i++ {
+//		order, err := validatortest.GenValid[Order](r)
+//		require.NoError(t, err)
+//		require.NoError(t, process(order))
+//	}
+//
+// Values are built from the rules they must satisfy: lengths and bounds, in
+// arguments, formats like email and ulid, the rules of keys and values and
+// one of the alternatives of a group. Other rules, like regexp, custom rules
+// and struct level validations, are left to chance: every candidate is
+// validated and GenValid returns an error wrapping ErrCannotGenerate when
+// none is valid. A nil r uses a source seeded with the current time.
+func GenValid[T any](r *rand.Rand) (T, error) {
+	var zero T
+	g, err := newGenerator[T](r)
+	if err != nil {
+		return zero, err
+	}
+	v, err := g.valid()
+	if err != nil {
+		return zero, err
+	}
+	return v.Interface().(T), nil
+}
+
+// GenInvalid returns a random T failing a single rule of one of its fields,
+// chosen at random, which are otherwise valid like those of GenValid, e.g. an
+// empty required field, a string one byte longer than its max or a number
+// not among the arguments of its in rule. It returns an error wrapping
+// ErrCannotGenerate when no rule can be violated.
+func GenInvalid[T any](r *rand.Rand) (T, error) {
+	var zero T
+	g, err := newGenerator[T](r)
+	if err != nil {
+		return zero, err
+	}
+	v, err := g.valid()
+	if err != nil {
+		return zero, err
+	}
+
+	targets := g.targets
+	g.r.Shuffle(len(targets), func(i, j int) { targets[i], targets[j] = targets[j], targets[i] })
+	for _, t := range targets {
+		for _, r := range t.rules {
+			old := reflect.New(t.value.Type()).Elem()
+			old.Set(t.value)
+			if !g.violate(t.value, r) {
+				continue
+			}
+			err := validator.Validate(v.Interface())
+			if _, config := validator.SplitErrors(err); err != nil && config == nil {
+				return v.Interface().(T), nil
+			}
+			t.value.Set(old)
+		}
+	}
+	return zero, fmt.Errorf("%w: no rule of %s can be violated", ErrCannotGenerate, v.Type())
+}
+
+// generator builds random values of a struct type from its rules.
+type generator struct {
+	r     *rand.Rand
+	typ   reflect.Type
+	rules map[string][]validator.Rule
+	// targets are the values of the last generated value with rules GenInvalid
+	// may violate.
+	targets []target
+}
+
+type target struct {
+	value reflect.Value
+	rules []validator.Rule
+}
+
+func newGenerator[T any](r *rand.Rand) (*generator, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, validator.ErrNotStruct
+	}
+	fields, err := validator.Describe(reflect.New(typ).Interface())
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	g := &generator{r: r, typ: typ, rules: map[string][]validator.Rule{}}
+	for _, f := range fields {
+		g.rules[f.Name()] = f.Rules()
+	}
+	return g, nil
+}
+
+// valid returns a new value of the type of g satisfying its rules.
+func (g *generator) valid() (reflect.Value, error) {
+	var err error
+	for i := 0; i < attempts; i++ {
+		g.targets = g.targets[:0]
+		v := reflect.New(g.typ).Elem()
+		g.fillStruct(v, "", 0)
+		if err = validator.Validate(v.Interface()); err == nil {
+			return v, nil
+		}
+		if _, config := validator.SplitErrors(err); config != nil {
+			return reflect.Value{}, config
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("%w: no valid %s found, last errors: %v", ErrCannotGenerate, g.typ, err)
+}
+
+// maxDepth limits the nesting of generated structs, which may reference
+// themselves through pointers or slices.
+const maxDepth = 8
+
+// fillStruct fills the exported fields of the struct v, whose rules are
+// described with the prefix path.
+func (g *generator) fillStruct(v reflect.Value, path string, depth int) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := path
+		if !f.Anonymous {
+			name = joinPath(path, f.Name)
+		}
+		g.fill(v.Field(i), name, g.rules[name], depth)
+	}
+}
+
+// fill sets v to a random value satisfying rules, the rules of the field at
+// path.
+func (g *generator) fill(v reflect.Value, path string, rules []validator.Rule, depth int) {
+	mode := emptyMode(rules)
+	if mode == "omitempty" && g.r.Intn(4) == 0 {
+		// Empty values satisfy the other rules anyway.
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if mode == "" && len(rules) == 0 && (depth >= maxDepth || g.r.Intn(2) == 0) {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		g.fill(v.Elem(), path, rules, depth)
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(g.birthdate(rules, time.Now())))
+			return
+		}
+		if depth < maxDepth {
+			g.fillStruct(v, path, depth+1)
+		}
+	case reflect.Slice:
+		if asText(rules) || depth >= maxDepth && mode != "required" {
+			return
+		}
+		n := 1 + g.r.Intn(3)
+		if mode == "" && g.r.Intn(4) == 0 {
+			n = 0
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			g.fill(v.Index(i), path, elemRules(rules), depth+1)
+		}
+	case reflect.Map:
+		if depth >= maxDepth && mode != "required" {
+			return
+		}
+		keys, values := mapRules(rules)
+		v.Set(reflect.MakeMap(v.Type()))
+		for i, n := 0, 1+g.r.Intn(2); i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			g.fill(key, path, keys, depth+1)
+			value := reflect.New(v.Type().Elem()).Elem()
+			g.fill(value, path, values, depth+1)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		rules = g.alternative(rules)
+		g.scalar(v, rules)
+		if len(rules) != 0 && v.CanAddr() {
+			g.targets = append(g.targets, target{value: v, rules: rules})
+		}
+	}
+}
+
+// alternative replaces the groups of alternatives of rules with the rules of
+// one of their alternatives, chosen at random.
+func (g *generator) alternative(rules []validator.Rule) []validator.Rule {
+	var chosen []validator.Rule
+	for _, r := range rules {
+		if r.Name == "or" && len(r.Params) != 0 {
+			chosen = append(chosen, parseRules(r.Params[g.r.Intn(len(r.Params))])...)
+			continue
+		}
+		chosen = append(chosen, r)
+	}
+	return chosen
+}
+
+// scalar sets the string, bool or number v to a random value satisfying
+// rules.
+func (g *generator) scalar(v reflect.Value, rules []validator.Rule) {
+	b := boundsOf(rules)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(g.text(rules, b))
+	case reflect.Bool:
+		switch {
+		case b.in != nil:
+			v.SetBool(b.in[g.r.Intn(len(b.in))] == "true")
+		case b.required:
+			v.SetBool(true)
+		default:
+			v.SetBool(g.r.Intn(2) == 0)
+		}
+	case reflect.Float32, reflect.Float64:
+		if b.in != nil {
+			f, _ := strconv.ParseFloat(b.in[g.r.Intn(len(b.in))], 64)
+			v.SetFloat(f)
+			return
+		}
+		lo, hi := b.numberRange(0, math.MaxInt64)
+		v.SetFloat(float64(lo) + g.r.Float64()*float64(hi-lo))
+		if b.required && v.Float() == 0 {
+			v.SetFloat(float64(hi))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if b.in != nil {
+			n, _ := strconv.ParseUint(b.in[g.r.Intn(len(b.in))], 10, 64)
+			v.SetUint(n)
+			return
+		}
+		hi := int64(math.MaxInt64)
+		if bits := v.Type().Bits(); bits < 64 {
+			hi = 1<<bits - 1
+		}
+		lo, hi := b.numberRange(0, hi)
+		v.SetUint(uint64(g.number(lo, hi, b.required)))
+	default:
+		if b.in != nil {
+			n, _ := strconv.ParseInt(b.in[g.r.Intn(len(b.in))], 10, 64)
+			v.SetInt(n)
+			return
+		}
+		bits := v.Type().Bits()
+		lo, hi := b.numberRange(int64(-1)<<(bits-1), int64(math.MaxInt64>>(64-bits)))
+		v.SetInt(g.number(lo, hi, b.required))
+	}
+}
+
+// number returns a random integer from lo to hi, not 0 when required.
+func (g *generator) number(lo, hi int64, required bool) int64 {
+	span := hi - lo
+	if span < 0 || span > 1<<40 {
+		span = 1 << 40
+	}
+	n := lo + g.r.Int63n(span+1)
+	if required && n == 0 {
+		n = hi
+	}
+	return n
+}
+
+// text returns a random string satisfying rules of bounds b.
+func (g *generator) text(rules []validator.Rule, b bounds) string {
+	if b.in != nil {
+		return b.in[g.r.Intn(len(b.in))]
+	}
+	for _, r := range rules {
+		switch r.Name {
+		case "asnum":
+			lo, hi := b.numberRange(math.MinInt32, math.MaxInt32)
+			return strconv.FormatInt(g.number(lo, hi, b.required), 10)
+		case "email":
+			return g.letters(1+g.r.Intn(8)) + "@" + g.letters(1+g.r.Intn(8)) + ".com"
+		case "ulid":
+			return g.chars("01234567", 1) + g.chars("0123456789ABCDEFGHJKMNPQRSTVWXYZ", 25)
+		case "objectid":
+			return g.chars("0123456789abcdef", 24)
+		case "urlencoded", "slug", "dns_label", "k8s_label":
+			return g.chars("abcdefghijklmnopqrstuvwxyz0123456789", 1+g.r.Intn(20))
+		case "hexcolor":
+			return "#" + g.chars("0123456789abcdef", 6)
+		case "rgb":
+			return fmt.Sprintf("rgb(%d, %d, %d)", g.r.Intn(256), g.r.Intn(256), g.r.Intn(256))
+		case "rgba":
+			return fmt.Sprintf("rgba(%d, %d, %d, %.2f)", g.r.Intn(256), g.r.Intn(256), g.r.Intn(256), g.r.Float64())
+		case "hsl":
+			return fmt.Sprintf("hsl(%d, %d%%, %d%%)", g.r.Intn(361), g.r.Intn(101), g.r.Intn(101))
+		case "mimetype":
+			return []string{"text/plain", "image/png", "application/json; charset=utf-8"}[g.r.Intn(3)]
+		case "charset":
+			return []string{"utf-8", "ISO-8859-1", "windows-1251"}[g.r.Intn(3)]
+		case "minage":
+			return g.birthdate(rules, time.Now()).Format("2006-01-02")
+		case "card_expiry":
+			return fmt.Sprintf("%02d/%02d", 1+g.r.Intn(12), (time.Now().Year()+1+g.r.Intn(5))%100)
+		case "cvv":
+			n, _ := strconv.Atoi(strings.TrimSpace(r.Params[g.r.Intn(len(r.Params))]))
+			return g.chars("0123456789", n)
+		case "cron":
+			return fmt.Sprintf("*/%d %d * * *", 1+g.r.Intn(30), g.r.Intn(24))
+		case "k8s_quantity":
+			return strconv.Itoa(g.r.Intn(1000)) + []string{"", "m", "k", "Mi", "Gi"}[g.r.Intn(5)]
+		case "password":
+			return g.password(r.Params)
+		}
+	}
+	lo, hi := b.lengthRange()
+	return g.letters(lo + g.r.Intn(hi-lo+1))
+}
+
+// birthdate returns a time old enough for the minage rule of rules, if any,
+// now otherwise.
+func (g *generator) birthdate(rules []validator.Rule, now time.Time) time.Time {
+	for _, r := range rules {
+		if r.Name == "minage" {
+			years, _ := strconv.Atoi(strings.TrimSpace(r.Params[0]))
+			return now.AddDate(-years-1-g.r.Intn(40), 0, -g.r.Intn(365))
+		}
+	}
+	return now
+}
+
+// password returns a random password satisfying the requirements params,
+// like "min=12" and "upper=1", or a long mixed one for named policies.
+func (g *generator) password(params []string) string {
+	counts := map[string]int{"min": 16, "upper": 1, "lower": 1, "digit": 1, "symbol": 1}
+	for _, param := range strings.Split(strings.Join(params, ","), ",") {
+		key, value, _ := strings.Cut(param, "=")
+		if n, err := strconv.Atoi(strings.TrimSpace(value)); err == nil && n > counts[strings.TrimSpace(key)] {
+			counts[strings.TrimSpace(key)] = n
+		}
+	}
+	s := g.chars("ABCDEFGHIJKLMNOPQRSTUVWXYZ", counts["upper"]) +
+		g.chars("abcdefghijklmnopqrstuvwxyz", counts["lower"]) +
+		g.chars("0123456789", counts["digit"]) +
+		g.chars("!#$%*+-.?@", counts["symbol"])
+	if len(s) < counts["min"] {
+		s += g.letters(counts["min"] - len(s))
+	}
+	return s
+}
+
+func (g *generator) letters(n int) string {
+	return g.chars("abcdefghijklmnopqrstuvwxyz", n)
+}
+
+// chars returns n bytes chosen at random among those of alphabet.
+func (g *generator) chars(alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[g.r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// violate changes v so that it fails the rule r, reporting whether it knows
+// how to.
+func (g *generator) violate(v reflect.Value, r validator.Rule) bool {
+	n, errNum := 0, errors.New("no argument")
+	if len(r.Params) != 0 {
+		n, errNum = strconv.Atoi(strings.TrimSpace(r.Params[0]))
+	}
+	text := v.Kind() == reflect.String
+	switch {
+	case r.Name == "required":
+		v.Set(reflect.Zero(v.Type()))
+	case (r.Name == "min" || r.Name == "len") && errNum == nil && text && n > 0:
+		v.SetString(g.letters(n - 1))
+	case (r.Name == "max" || r.Name == "len") && errNum == nil && text:
+		v.SetString(g.letters(n + 1))
+	case r.Name == "min" && errNum == nil && !text:
+		return setNumber(v, float64(n)-1)
+	case r.Name == "max" && errNum == nil && !text:
+		return setNumber(v, float64(n)+1)
+	case r.Name == "in" && text:
+		v.SetString("not-" + strings.Join(r.Params, "-"))
+	case r.Name == "in" && v.Kind() == reflect.Bool:
+		v.SetBool(!v.Bool())
+	case r.Name == "in":
+		largest := 0.0
+		for i, param := range r.Params {
+			f, _ := strconv.ParseFloat(strings.TrimSpace(param), 64)
+			if i == 0 || f > largest {
+				largest = f
+			}
+		}
+		return setNumber(v, largest+1)
+	case text && (r.Name == "email" || r.Name == "ulid" || r.Name == "objectid" || r.Name == "asnum" || r.Name == "printable_unicode" ||
+		r.Name == "urlencoded" || r.Name == "slug" || r.Name == "dns_label" ||
+		r.Name == "k8s_quantity" || r.Name == "k8s_label" || r.Name == "hexcolor" ||
+		r.Name == "rgb" || r.Name == "rgba" || r.Name == "hsl" || r.Name == "cron" ||
+		r.Name == "mimetype" || r.Name == "charset" || r.Name == "card_expiry" || r.Name == "cvv" ||
+		r.Name == "minage"):
+		v.SetString("\x00")
+	case text && r.Name == "no_html":
+		v.SetString("<b>" + g.letters(1) + "</b>")
+	default:
+		return false
+	}
+	return true
+}
+
+// setNumber sets the number v to f, reporting whether it fits its type.
+func setNumber(v reflect.Value, f float64) bool {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(f)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if f < 0 || v.OverflowUint(uint64(f)) {
+			return false
+		}
+		v.SetUint(uint64(f))
+	default:
+		if v.OverflowInt(int64(f)) {
+			return false
+		}
+		v.SetInt(int64(f))
+	}
+	return true
+}
+
+// bounds are the constraints rules set on the length of a string or on a
+// number.
+type bounds struct {
+	required bool
+	min, max *int
+	in       []string
+}
+
+func boundsOf(rules []validator.Rule) bounds {
+	var b bounds
+	for _, r := range rules {
+		var n int
+		var err error
+		if len(r.Params) != 0 {
+			n, err = strconv.Atoi(strings.TrimSpace(r.Params[0]))
+		}
+		switch {
+		case r.Name == "required":
+			b.required = true
+		case r.Name == "in":
+			b.in = make([]string, len(r.Params))
+			for i, param := range r.Params {
+				b.in[i] = strings.TrimSpace(param)
+			}
+		case r.Name == "len" && err == nil:
+			b.min, b.max = &n, &n
+		case r.Name == "min" && err == nil && len(r.Params) == 1:
+			b.min = &n
+		case r.Name == "max" && err == nil && len(r.Params) == 1:
+			b.max = &n
+		}
+	}
+	if len(b.in) == 0 {
+		b.in = nil
+	}
+	return b
+}
+
+// numberRange returns the range of the numbers satisfying b within lo to hi,
+// the range of the type, spanning at most 100 when b leaves it open.
+func (b bounds) numberRange(lo, hi int64) (int64, int64) {
+	min, max := larger(lo, 0), smaller(hi, 100)
+	switch {
+	case b.min != nil && b.max != nil:
+		min, max = int64(*b.min), int64(*b.max)
+	case b.min != nil:
+		min, max = int64(*b.min), hi
+		if d := hi - min; d < 0 || d > 100 {
+			max = min + 100
+		}
+	case b.max != nil:
+		min, max = lo, int64(*b.max)
+		if d := max - lo; d < 0 || d > 100 {
+			min = max - 100
+		}
+		if max >= 0 {
+			min = larger(min, 0)
+		}
+	}
+	return larger(min, lo), smaller(max, hi)
+}
+
+// lengthRange returns the range of the lengths of strings satisfying b.
+func (b bounds) lengthRange() (int, int) {
+	lo, hi := 0, 16
+	if b.required {
+		lo = 1
+	}
+	if b.min != nil {
+		lo = larger(lo, *b.min)
+		hi = larger(hi, lo)
+	}
+	if b.max != nil {
+		hi = *b.max
+		lo = smaller(lo, hi)
+	}
+	return larger(lo, 0), larger(hi, 0)
+}
+
+// emptyMode returns "required" or "omitempty" when rules decide about empty
+// values, "" otherwise.
+func emptyMode(rules []validator.Rule) string {
+	for _, r := range rules {
+		if r.Name == "required" || r.Name == "omitempty" {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// asText reports whether rules apply to the textual form of a value, which
+// the generator does not know how to build.
+func asText(rules []validator.Rule) bool {
+	for _, r := range rules {
+		if r.Name == "astext" {
+			return true
+		}
+	}
+	return false
+}
+
+// elemRules returns the rules of a slice that apply to its elements.
+func elemRules(rules []validator.Rule) []validator.Rule {
+	var elems []validator.Rule
+	for _, r := range rules {
+		switch r.Name {
+		case "omitempty", "uniquefield", "sumfield", "maxbytes":
+			continue
+		}
+		elems = append(elems, r)
+	}
+	return elems
+}
+
+// mapRules returns the rules of the keys and values of a map.
+func mapRules(rules []validator.Rule) (keys, values []validator.Rule) {
+	for _, r := range rules {
+		switch r.Name {
+		case "keys":
+			for _, param := range r.Params {
+				keys = append(keys, parseRules(param)...)
+			}
+		case "values":
+			for _, param := range r.Params {
+				values = append(values, parseRules(param)...)
+			}
+		}
+	}
+	return keys, values
+}
+
+// parseRules parses the rules of s written like in tags, like
+// "min:1&max:2", as far as the generator needs them.
+func parseRules(s string) []validator.Rule {
+	var rules []validator.Rule
+	for _, part := range strings.Split(s, "&") {
+		name, params, found := strings.Cut(strings.TrimSpace(part), ":")
+		r := validator.Rule{Name: name}
+		if found {
+			r.Params = strings.Split(params, ",")
+		}
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+func smaller[T int | int64](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func larger[T int | int64](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
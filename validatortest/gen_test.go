@@ -0,0 +1,83 @@
+package validatortest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+type genAddress struct {
+	Zip  string `validate:"len:5"`
+	City string `validate:"required&max:20"`
+}
+
+type genOrder struct {
+	ID       string         `validate:"ulid"`
+	Email    string         `validate:"required&email"`
+	Name     string         `validate:"min:3&max:10"`
+	Age      int8           `validate:"min:18&max:120"`
+	Ratio    float64        `validate:"in:0.5,1.5"`
+	Status   string         `validate:"in:new,paid"`
+	Accepted bool           `validate:"in:true"`
+	Count    uint16         `validate:"required&max:3"`
+	Note     *string        `validate:"omitempty&min:2"`
+	Contact  string         `validate:"(email|len:0)"`
+	Tags     []string       `validate:"required&min:1&max:5"`
+	Labels   map[string]int `validate:"keys:min:2,endkeys&values:min:1"`
+	Address  genAddress
+	Previous []genAddress
+	Secret   string `validate:"password:min=12,digit=2"`
+	Amount   string `validate:"asnum&min:10&max:20"`
+}
+
+func TestGenValid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		v, err := GenValid[genOrder](r)
+		require.NoError(t, err)
+		require.NoError(t, validator.Validate(v))
+	}
+
+	_, err := GenValid[int](r)
+	assert.ErrorIs(t, err, validator.ErrNotStruct)
+
+	type impossible struct {
+		A string `validate:"required&regexp:^x{40}$"`
+	}
+	_, err = GenValid[impossible](r)
+	assert.ErrorIs(t, err, ErrCannotGenerate)
+
+	type broken struct {
+		A string `validate:"min:x"`
+	}
+	_, err = GenValid[broken](r)
+	var errs validator.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.ErrorIs(t, errs[0].Err, validator.ErrInvalidValidatorSyntax)
+}
+
+func TestGenInvalid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	fields := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		v, err := GenInvalid[genOrder](r)
+		require.NoError(t, err)
+		var errs validator.ValidationErrors
+		require.ErrorAs(t, validator.Validate(v), &errs)
+		require.Len(t, errs, 1)
+		fields[errs[0].Field()] = true
+	}
+	for _, field := range []string{"ID", "Email", "Name", "Age", "Ratio", "Status", "Accepted", "Count", "Address.Zip", "Amount"} {
+		assert.True(t, fields[field], field)
+	}
+
+	type free struct {
+		A string
+	}
+	_, err := GenInvalid[free](r)
+	assert.ErrorIs(t, err, ErrCannotGenerate)
+}
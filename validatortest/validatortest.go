@@ -0,0 +1,147 @@
+// Package validatortest helps testing the validate tags of struct types:
+//
+//	func TestSignupRules(t *testing.T) {
+//		req := validSignup()
+//		validatortest.AssertValid(t, req)
+//
+//		req.Email = "nadya"
+//		validatortest.AssertInvalid(t, req, "Email", "email")
+//	}
+//
+// Format writes ValidationErrors one per line in a stable order, to be
+// compared with golden files by AssertGolden.
+package validatortest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Nadya2002/validator"
+)
+
+// update makes AssertGolden write the golden files instead of comparing
+// them, with go test -validatortest.update.
+var update = flag.Bool("validatortest.update", false, "update the golden files of validatortest.AssertGolden")
+
+// AssertValid reports a test error unless v validates with opts, listing the
+// errors found. It returns whether v is valid.
+func AssertValid(t testing.TB, v any, opts ...validator.Option) bool {
+	t.Helper()
+	if err := validator.Validate(v, opts...); err != nil {
+		t.Errorf("validatortest: %T is not valid:\n%s", v, indent(Format(err)))
+		return false
+	}
+	return true
+}
+
+// AssertInvalid reports a test error unless validating v with opts fails
+// the rule of the field, like "Email" and "email" or "Items[2].SKU" and
+// "len", in which rule "" stands for any rule. Other errors may be found
+// too. It returns whether the field failed the rule.
+func AssertInvalid(t testing.TB, v any, field, rule string, opts ...validator.Option) bool {
+	t.Helper()
+	err := validator.Validate(v, opts...)
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			if e.Field() == field && (rule == "" || e.Rule() == rule) {
+				return true
+			}
+		}
+	}
+
+	want := field
+	if rule != "" {
+		want += " failing " + rule
+	}
+	if err == nil {
+		t.Errorf("validatortest: %T is valid, want %s", v, want)
+	} else {
+		t.Errorf("validatortest: %T has no error for %s, got:\n%s", v, want, indent(Format(err)))
+	}
+	return false
+}
+
+// Format formats err for golden files, a line per error of its
+// ValidationErrors sorted by field: the field, the rule it failed and
+// its rules, like
+//
+//	Email: email (required&email)
+//	Items[2].SKU: len (len:4)
+//
+// Values that could not be checked at all are reported with the code of the
+// error instead of a rule, errors not about a field with their code and
+// message, like "VAL_SYNTAX: invalid validator syntax: ...". Other errors
+// are formatted as their message, nil as "".
+func Format(err error) string {
+	if err == nil {
+		return ""
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err.Error() + "\n"
+	}
+	sorted := append(validator.ValidationErrors(nil), errs...)
+	sorted.Sort()
+
+	var sb strings.Builder
+	for _, e := range sorted {
+		rules := e.Rules()
+		if rules == "" {
+			fmt.Fprintf(&sb, "%s: %s\n", e.Code(), e.Err)
+			continue
+		}
+		rule := e.Rule()
+		if rule == "" {
+			rule = e.Code()
+		}
+		field := e.Field()
+		if field == "" {
+			field = "(value)"
+		}
+		fmt.Fprintf(&sb, "%s: %s (%s)\n", field, rule, rules)
+	}
+	return sb.String()
+}
+
+// AssertGolden reports a test error unless Format(err) equals the contents
+// of the file at path, usually under testdata. Running the tests with the
+// flag -validatortest.update writes the file instead. It returns whether
+// they are equal.
+func AssertGolden(t testing.TB, path string, err error) bool {
+	t.Helper()
+	got := Format(err)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("validatortest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("validatortest: %v", err)
+		}
+		return true
+	}
+
+	want, errRead := os.ReadFile(path)
+	if errRead != nil {
+		t.Errorf("validatortest: %v; run the tests with -validatortest.update to create it", errRead)
+		return false
+	}
+	if got != string(want) {
+		t.Errorf("validatortest: errors differ from %s:\ngot:\n%swant:\n%s", path, indent(got), indent(string(want)))
+		return false
+	}
+	return true
+}
+
+// indent indents the lines of s for a test error message.
+func indent(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "\t" + strings.ReplaceAll(strings.TrimSuffix(s, "\n"), "\n", "\n\t") + "\n"
+}
@@ -0,0 +1,114 @@
+package validatortest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Nadya2002/validator"
+)
+
+type item struct {
+	SKU string `validate:"len:4"`
+}
+
+type order struct {
+	Email string `validate:"required&email"`
+	Items []item
+	Note  string `validate:"min:x"`
+}
+
+// recorder records the errors a test reports.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+
+func TestAssertValid(t *testing.T) {
+	type user struct {
+		Email string `validate:"required&email"`
+	}
+	r := &recorder{}
+	assert.True(t, AssertValid(r, user{Email: "a@b.co"}))
+	assert.Empty(t, r.errors)
+
+	assert.False(t, AssertValid(r, user{}))
+	assert.Equal(t, []string{"validatortest: validatortest.user is not valid:\n\tEmail: required (required&email)\n"}, r.errors)
+}
+
+func TestAssertInvalid(t *testing.T) {
+	v := order{Email: "x", Items: []item{{SKU: "abcd"}, {SKU: "a"}}}
+	r := &recorder{}
+	assert.True(t, AssertInvalid(r, v, "Email", "email"))
+	assert.True(t, AssertInvalid(r, v, "Items[1].SKU", ""))
+	assert.Empty(t, r.errors)
+
+	assert.False(t, AssertInvalid(r, v, "Email", "required"))
+	assert.False(t, AssertInvalid(r, item{SKU: "abcd"}, "SKU", "len"))
+	assert.Equal(t, []string{
+		"validatortest: validatortest.order has no error for Email failing required, got:\n" +
+			"\tVAL_SYNTAX: invalid validator syntax: rule \"min:x\" at offset 0 of field Note\n" +
+			"\tEmail: email (required&email)\n" +
+			"\tItems[1].SKU: len (len:4)\n",
+		"validatortest: validatortest.item is valid, want SKU failing len",
+	}, r.errors)
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "", Format(nil))
+	assert.Equal(t, "boom\n", Format(errors.New("boom")))
+	assert.Equal(t, "(value): min (min:2)\n", Format(validator.ValidateVar("a", "min:2")))
+
+	err := validator.Join(
+		validator.Prefix("b", validator.Validate(order{Email: "a@b.co", Items: []item{{}, {}}})),
+		validator.Prefix("a", validator.Validate(order{})),
+	)
+	assert.Equal(t, "VAL_SYNTAX: b: invalid validator syntax: rule \"min:x\" at offset 0 of field Note\n"+
+		"VAL_SYNTAX: a: invalid validator syntax: rule \"min:x\" at offset 0 of field Note\n"+
+		"a.Email: required (required&email)\n"+
+		"b.Items[0].SKU: len (len:4)\n"+
+		"b.Items[1].SKU: len (len:4)\n", Format(err))
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "order.golden")
+	err := validator.Validate(order{Email: "x"})
+
+	r := &recorder{}
+	assert.False(t, AssertGolden(r, path, err))
+	require.Len(t, r.errors, 1)
+	assert.Contains(t, r.errors[0], "run the tests with -validatortest.update to create it")
+
+	*update = true
+	t.Cleanup(func() { *update = false })
+	assert.True(t, AssertGolden(r, path, err))
+	*update = false
+	data, errRead := os.ReadFile(path)
+	require.NoError(t, errRead)
+	assert.Equal(t, Format(err), string(data))
+
+	r.errors = nil
+	assert.True(t, AssertGolden(r, path, err))
+	assert.False(t, AssertGolden(r, path, nil))
+	assert.Equal(t, []string{"validatortest: errors differ from " + path + ":\ngot:\nwant:\n" +
+		"\tVAL_SYNTAX: invalid validator syntax: rule \"min:x\" at offset 0 of field Note\n" +
+		"\tEmail: email (required&email)\n"}, r.errors)
+	assert.False(t, r.fatal)
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// parseWarning parses the rule of a warning like "warn:max:100". Rules that
+// control how a field is validated, instead of checking its value, and batch
+// rules cannot be warnings, nor can warnings themselves.
+func parseWarning(get string) (rule, error) {
+	if name, _, _ := strings.Cut(get, ":"); strings.TrimSpace(name) == "warn" {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	validator, err := parseValidator(get)
+	if err != nil {
+		return rule{}, err
+	}
+	switch validator.name {
+	case "omitempty", "groups", "default", "structonly", "nostructlevel", "astext", "asnum", "sensitive", "bail", "continue", "if", "excluded_with", "exactly_one_of", "assert":
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	if validator.warn || validator.batch != nil {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	validator.warn = true
+	return validator, nil
+}
+
+// warningRules returns the rules of warnings in validators, preceded by the
+// rules deciding whether they apply at all, or nil without warnings.
+func warningRules(validators []rule) []rule {
+	var warnings []rule
+	for _, validator := range validators {
+		if validator.warn {
+			validator.warn = false
+			warnings = append(warnings, validator)
+		}
+	}
+	if warnings == nil {
+		return nil
+	}
+
+	return append(controlRules(validators), warnings...)
+}
+
+// controlRules returns the rules of validators deciding whether and how the
+// other rules apply, for rules checked on their own like warnings.
+func controlRules(validators []rule) []rule {
+	var control []rule
+	for _, validator := range validators {
+		if validator.name == "groups" || validator.name == "astext" || validator.name == "asnum" || validator.name == "omitempty" || validator.name == "sensitive" {
+			control = append(control, validator)
+		}
+	}
+	return control
+}
+
+// checkWarnings applies the rules of warnings to fieldV, the field name of
+// the struct at path, and reports a warning when it does not satisfy them.
+func (s *validation) checkWarnings(path *fieldPath, name, cond string, warnings []rule, fieldV reflect.Value) {
+	if err := s.validateField(warnings, fieldV); err != nil {
+		s.warnings = append(s.warnings, ValidationError{&fieldError{field: path.join(name), cond: cond, rule: s.failed, err: s.redacted(warnings, err)}})
+	}
+}
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type warningsRecord struct {
+	Name  string   `validate:"required&warn:min:3"`
+	Bio   string   `validate:"omitempty&warn:max:5"`
+	Email string   `validate:"warn:required&warn:email"`
+	Tags  []string `validate:"warn:len:2"`
+	Note  string   `validate:"groups:strict&warn:required"`
+}
+
+func TestValidateResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   warningsRecord
+		opts     []Option
+		errors   []string
+		warnings []string
+	}{
+		{
+			name:   "valid",
+			record: warningsRecord{Name: "alice", Email: "a@example.com", Tags: []string{"go"}},
+		},
+		{
+			name:     "warnings only",
+			record:   warningsRecord{Name: "al", Bio: "too long", Tags: []string{"g"}},
+			warnings: []string{"Name", "Bio", "Email", "Tags"},
+		},
+		{
+			name:     "errors and warnings",
+			record:   warningsRecord{Email: "nope"},
+			errors:   []string{"Name"},
+			warnings: []string{"Name", "Email"},
+		},
+		{
+			name:     "groups",
+			record:   warningsRecord{Name: "alice", Email: "a@example.com"},
+			opts:     []Option{WithGroups("strict")},
+			warnings: []string{"Note"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateResult(tt.record, tt.opts...)
+			assert.Equal(t, tt.errors == nil, result.Valid())
+			assert.Equal(t, tt.errors, fieldsOf(result.Errors()))
+			assert.Equal(t, tt.warnings, fieldsOf(result.Warnings()))
+			assert.Equal(t, Validate(tt.record, tt.opts...), result.Err())
+		})
+	}
+}
+
+func fieldsOf(errs ValidationErrors) []string {
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field())
+	}
+	return fields
+}
+
+func TestWarningsSyntax(t *testing.T) {
+	for _, rules := range []string{"warn:omitempty", "warn:warn:min:1", "warn:groups:a", "warn:min"} {
+		err := ValidateVar("", rules)
+		require.Error(t, err, rules)
+		assert.True(t, errors.Is(err.(ValidationErrors)[0].Err, ErrInvalidValidatorSyntax), rules)
+	}
+	assert.NoError(t, ValidateVar("", "warn:required"), "ValidateVar ignores warnings")
+
+	result := ValidateResult(1)
+	assert.ErrorIs(t, result.Err(), ErrNotStruct)
+	assert.Nil(t, result.Errors())
+	assert.False(t, result.Valid())
+
+	result = New().ValidateResult(&warningsRecord{Name: "alice"})
+	assert.Equal(t, []string{"Email"}, fieldsOf(result.Warnings()))
+}
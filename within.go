@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// parseWithin parses the argument of within, a positive duration like "24h"
+// or "15m".
+func parseWithin(params string) (rule, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(params))
+	if err != nil || d <= 0 {
+		return rule{}, ErrInvalidValidatorSyntax
+	}
+	return rule{name: "within", argsStr: []string{params}, within: d}, nil
+}
+
+// validateWithin requires a time.Time at most d before or after now.
+func validateWithin(field reflect.Value, d time.Duration, now time.Time) error {
+	if !field.IsValid() || field.Type() != timeType || !field.CanInterface() {
+		return ErrFieldNotValid
+	}
+	diff := now.Sub(field.Interface().(time.Time))
+	if diff < -d || diff > d {
+		return ErrFieldNotValid
+	}
+	return nil
+}
+
+// now returns the current time, read from the clock of WithClock if set.
+func (s *validation) now() time.Time {
+	if s.clock != nil {
+		return s.clock()
+	}
+	return time.Now()
+}
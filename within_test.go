@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWithin(t *testing.T) {
+	type token struct {
+		IssuedAt  time.Time  `validate:"within:24h"`
+		ExpiresAt *time.Time `validate:"omitempty&within:1h"`
+		Seen      time.Time  `validate:"omitempty&warn:within:15m"`
+	}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+
+	expires := now.Add(time.Hour)
+	assert.NoError(t, Validate(token{IssuedAt: now.Add(-24 * time.Hour), ExpiresAt: &expires}, clock))
+
+	expires = now.Add(time.Hour + time.Second)
+	var errs ValidationErrors
+	require.ErrorAs(t, Validate(token{IssuedAt: now.Add(25 * time.Hour), ExpiresAt: &expires}, clock), &errs)
+	assert.Equal(t, []string{"IssuedAt", "ExpiresAt"}, fieldsOf(errs))
+	assert.Equal(t, "within", errs[0].Rule())
+
+	res := ValidateResult(token{IssuedAt: now, Seen: now.Add(-time.Hour)}, clock)
+	assert.True(t, res.Valid())
+	assert.Equal(t, []string{"Seen"}, fieldsOf(res.Warnings()))
+
+	// Without a clock, times are compared with time.Now.
+	assert.NoError(t, Validate(token{IssuedAt: time.Now()}))
+	assert.Error(t, Validate(token{IssuedAt: now}))
+
+	// A nil time is not within any duration of now.
+	require.ErrorAs(t, Validate(struct {
+		At *time.Time `validate:"within:1h"`
+	}{}, clock), &errs)
+	assert.Equal(t, []string{"At"}, fieldsOf(errs))
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+
+	require.ErrorAs(t, ValidateVar("2024-01-01", "within:1h"), &errs)
+	assert.ErrorIs(t, errs[0].Err, ErrFieldNotValid)
+	for _, rules := range []string{"within", "within:", "within:1x", "within:-1h", "within:0s"} {
+		require.ErrorAs(t, ValidateVar(time.Now(), rules), &errs, rules)
+		assert.ErrorIs(t, errs[0].Err, ErrInvalidValidatorSyntax, rules)
+	}
+}
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"reflect"
+	"time"
+)
+
+// ZeroFunc reports whether field, a value of a type registered with
+// RegisterZeroChecker, is empty.
+type ZeroFunc func(field reflect.Value) bool
+
+// zeroCheckers holds the checkers of the types whose empty values are not
+// only their zero values, keyed by the type of the values, not of pointers to
+// them. time.Time is registered out of the box.
+var zeroCheckers = map[reflect.Type]ZeroFunc{
+	reflect.TypeOf(time.Time{}): func(field reflect.Value) bool {
+		return field.Interface().(time.Time).IsZero()
+	},
+}
+
+// RegisterZeroChecker registers fn as the checker of the empty values of
+// each of types, for required, omitempty and the conditions on empty fields.
+// Values of other types are empty when they are their zero value, like a
+// zero uuid array, or when they are an empty slice or map. Values of struct
+// types may have several empty states, like decimals of 0 with any exponent:
+//
+//	validator.RegisterZeroChecker(func(field reflect.Value) bool {
+//		return field.Interface().(decimal.Decimal).IsZero()
+//	}, decimal.Decimal{})
+//
+//	type Event struct {
+//		At     time.Time       `validate:"required"`
+//		Amount decimal.Decimal `validate:"required"`
+//	}
+//
+// Pointers to the types are still never empty when not nil. time.Time is
+// registered already, so the zero instant is empty in any location. It is
+// meant to be called during program initialization.
+func RegisterZeroChecker(fn ZeroFunc, types ...any) error {
+	return register("RegisterZeroChecker", func() {
+		for _, t := range types {
+			zeroCheckers[reflect.TypeOf(t)] = fn
+		}
+	})
+}
+
+// isZero reports whether field is empty according to the checker of its
+// type, its zero value otherwise.
+func isZero(field reflect.Value) bool {
+	if fn, ok := registered(zeroCheckers, field.Type()); ok && field.CanInterface() {
+		return fn(field)
+	}
+	return field.IsZero()
+}
@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroChecker(t *testing.T) {
+	RegisterZeroChecker(func(field reflect.Value) bool {
+		return field.Interface().(Decimal).units == 0
+	}, Decimal{})
+	t.Cleanup(func() { delete(zeroCheckers, reflect.TypeOf(Decimal{})) })
+
+	type event struct {
+		At     time.Time  `validate:"required"`
+		ID     [16]byte   `validate:"required"`
+		Amount Decimal    `validate:"required"`
+		Ends   time.Time  `validate:"omitempty&email"`
+		Starts *time.Time `validate:"required"`
+		Note   string     `validate:"if:!At&required"`
+	}
+
+	zero := time.Time{}
+	valid := event{At: time.Now(), ID: [16]byte{1}, Amount: Decimal{units: 5, exp: 2}, Starts: &zero}
+	require.NoError(t, Validate(valid))
+
+	tests := []struct {
+		name   string
+		change func(*event)
+		fields []string
+	}{
+		{"zero time", func(e *event) { e.At = time.Time{} }, []string{"At", "Note"}},
+		{"zero time in a location", func(e *event) { e.At = time.Time{}.In(time.FixedZone("X", 3600)) }, []string{"At", "Note"}},
+		{"zero array", func(e *event) { e.ID = [16]byte{} }, []string{"ID"}},
+		{"registered", func(e *event) { e.Amount = Decimal{exp: 2} }, []string{"Amount"}},
+		{"nil pointer", func(e *event) { e.Starts = nil }, []string{"Starts"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := valid
+			tt.change(&e)
+			var errs ValidationErrors
+			require.True(t, errors.As(Validate(e), &errs))
+			assert.Equal(t, tt.fields, fieldsOf(errs))
+		})
+	}
+
+	valid.Ends = time.Time{}.In(time.UTC)
+	assert.NoError(t, Validate(valid))
+	assert.Error(t, ValidateVar(time.Time{}.In(time.UTC), "required"))
+}
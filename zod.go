@@ -0,0 +1,219 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ExportZod returns a TypeScript module declaring a Zod schema and its type
+// for each of the given struct types and the structs nested in them, so web
+// frontends enforce the rules the backend does, e.g. for ExportZod(User{}):
+//
+//	import { z } from "zod";
+//
+//	export const UserSchema = z.object({
+//	  email: z.string().email(),
+//	  age: z.number().int().min(18).optional(),
+//	  address: z.lazy(() => AddressSchema),
+//	});
+//	export type User = z.infer<typeof UserSchema>;
+//
+// The rules are translated like ExportJSONSchema does, patterns as
+// JavaScript regular expressions, which accept the syntax of the built-in
+// rules. Fields that are not required are optional. Keywords added by
+// RegisterJSONSchema are left out. Schemas of types referring to themselves
+// need a type annotation to compile with TypeScript.
+func ExportZod(types ...any) ([]byte, error) {
+	b := newSchemaBuilder("")
+	for _, t := range types {
+		typeV := reflect.TypeOf(t)
+		if err := Lint(typeV); err != nil {
+			return nil, err
+		}
+		for typeV.Kind() == reflect.Pointer {
+			typeV = typeV.Elem()
+		}
+		if typeV.Name() == "" {
+			return nil, ErrNotStruct
+		}
+		b.ref(typeV)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by validator.ExportZod. DO NOT EDIT.\n\nimport { z } from \"zod\";\n")
+	for i, name := range b.defs.names {
+		name = zodName(name)
+		fmt.Fprintf(&sb, "\nexport const %sSchema = %s;\nexport type %s = z.infer<typeof %sSchema>;\n", name, zodSchema(b.defs.schemas[i], ""), name, name)
+	}
+	return []byte(sb.String()), nil
+}
+
+// zodName returns name, the name of a schema, as a TypeScript identifier.
+func zodName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// zodSchema returns the Zod schema of s, nested in an object at indent.
+func zodSchema(s *jsonSchema, indent string) string {
+	if len(s.AnyOf) == 2 {
+		// An omitted zero value, see applyRules: the rules apply to the other
+		// values.
+		merged := *s
+		merged.AnyOf = nil
+		mergeKeywords(&merged, s.AnyOf[1])
+		return zodSchema(&merged, indent) + ".or(z.literal(" + zodValue(s.AnyOf[0].Const) + "))"
+	}
+
+	var sb strings.Builder
+	switch {
+	case s.Ref != "":
+		fmt.Fprintf(&sb, "z.lazy(() => %sSchema)", zodName(s.Ref))
+	case s.Enum != nil:
+		sb.WriteString(zodEnum(s.Enum))
+	case s.Const != nil:
+		fmt.Fprintf(&sb, "z.literal(%s)", zodValue(s.Const))
+	case s.Type == "string":
+		sb.WriteString("z.string()")
+		writeLengths(&sb, s.MinLength, s.MaxLength)
+		switch s.Format {
+		case "email":
+			sb.WriteString(".email()")
+		case "date-time":
+			sb.WriteString(".datetime()")
+		}
+		if s.Pattern != "" {
+			fmt.Fprintf(&sb, ".regex(new RegExp(%s))", zodValue(s.Pattern))
+		}
+	case s.Type == "integer", s.Type == "number":
+		sb.WriteString("z.number()")
+		if s.Type == "integer" {
+			sb.WriteString(".int()")
+		}
+		writeBound(&sb, "min", s.Minimum)
+		writeBound(&sb, "max", s.Maximum)
+	case s.Type == "boolean":
+		sb.WriteString("z.boolean()")
+	case s.Type == "array":
+		fmt.Fprintf(&sb, "z.array(%s)", zodSchema(s.Items, indent))
+		writeLengths(&sb, s.MinItems, s.MaxItems)
+	case s.Type == "object" && s.Properties != nil:
+		sb.WriteString(zodObject(s, indent))
+	case s.Type == "object":
+		keys, values := "z.string()", "z.unknown()"
+		if s.PropertyNames != nil {
+			keys = zodSchema(s.PropertyNames, indent)
+		}
+		if s.AdditionalProperties != nil {
+			values = zodSchema(s.AdditionalProperties, indent)
+		}
+		fmt.Fprintf(&sb, "z.record(%s, %s)", keys, values)
+	default:
+		sb.WriteString("z.unknown()")
+	}
+
+	if s.Not != nil && s.Not.Const != nil {
+		fmt.Fprintf(&sb, ".refine((v) => v !== %s)", zodValue(s.Not.Const))
+	}
+	if s.Default != nil {
+		fmt.Fprintf(&sb, ".default(%s)", zodValue(s.Default))
+	}
+	return sb.String()
+}
+
+// zodObject returns the Zod schema of the object s, whose properties are
+// written one per line below indent.
+func zodObject(s *jsonSchema, indent string) string {
+	if len(s.Properties.names) == 0 {
+		return "z.object({})"
+	}
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	var sb strings.Builder
+	sb.WriteString("z.object({\n")
+	for i, name := range s.Properties.names {
+		key := name
+		if !isIdentifier(name) {
+			key = zodValue(name)
+		}
+		fmt.Fprintf(&sb, "%s  %s: %s", indent, key, zodSchema(s.Properties.schemas[i], indent+"  "))
+		if !required[name] {
+			sb.WriteString(".optional()")
+		}
+		sb.WriteString(",\n")
+	}
+	sb.WriteString(indent + "})")
+	return sb.String()
+}
+
+// zodEnum returns the Zod schema of a value among values.
+func zodEnum(values []any) string {
+	literals := make([]string, len(values))
+	strs := true
+	for i, value := range values {
+		literals[i] = zodValue(value)
+		_, ok := value.(string)
+		strs = strs && ok
+	}
+	switch {
+	case len(values) == 0:
+		return "z.never()"
+	case len(values) == 1:
+		return "z.literal(" + literals[0] + ")"
+	case strs:
+		return "z.enum([" + strings.Join(literals, ", ") + "])"
+	}
+	for i, literal := range literals {
+		literals[i] = "z.literal(" + literal + ")"
+	}
+	return "z.union([" + strings.Join(literals, ", ") + "])"
+}
+
+// mergeKeywords sets the keywords of from in s.
+func mergeKeywords(s, from *jsonSchema) {
+	to, src := reflect.ValueOf(s).Elem(), reflect.ValueOf(from).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if field := src.Field(i); to.Field(i).CanSet() && !field.IsZero() {
+			to.Field(i).Set(field)
+		}
+	}
+}
+
+// writeLengths writes the Zod checks of a length between min and max.
+func writeLengths(sb *strings.Builder, min, max *int) {
+	if min != nil && max != nil && *min == *max {
+		fmt.Fprintf(sb, ".length(%d)", *min)
+		return
+	}
+	writeBound(sb, "min", min)
+	writeBound(sb, "max", max)
+}
+
+func writeBound(sb *strings.Builder, check string, n *int) {
+	if n != nil {
+		fmt.Fprintf(sb, ".%s(%d)", check, *n)
+	}
+}
+
+// zodValue returns value as a JavaScript literal.
+func zodValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "undefined"
+	}
+	return string(data)
+}
+
+// isIdentifier reports whether name can be written as a key of a
+// JavaScript object without quotes.
+func isIdentifier(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !(isAlnum(c) || c == '_' || c == '$') || i == 0 && c >= '0' && c <= '9' {
+			return false
+		}
+	}
+	return name != ""
+}
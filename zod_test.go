@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportZod(t *testing.T) {
+	src, err := ExportZod(schemaUser{})
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by validator.ExportZod. DO NOT EDIT.
+
+import { z } from "zod";
+
+export const schemaUserSchema = z.object({
+  ID: z.number().int().min(1).optional(),
+  Name: z.string().min(1),
+  email: z.string().min(1).email(),
+  nickname: z.string().min(3).max(20).or(z.literal("")).optional(),
+  age: z.number().int().min(18).max(130),
+  role: z.enum(["admin", "user"]).optional(),
+  tags: z.array(z.string().regex(new RegExp("^[a-z]+$"))).min(1),
+  level: z.union([z.literal(1), z.literal(2)]).optional(),
+  password: z.string().min(12).optional(),
+  active: z.literal(true),
+  created: z.string().datetime().optional(),
+  labels: z.record(z.string(), z.string()).optional(),
+  home: z.lazy(() => schemaAddressSchema).optional(),
+  work: z.lazy(() => schemaAddressSchema).optional(),
+  tree: z.lazy(() => schemaNodeSchema).optional(),
+});
+export type schemaUser = z.infer<typeof schemaUserSchema>;
+
+export const schemaAddressSchema = z.object({
+  zip: z.string().length(5).optional(),
+});
+export type schemaAddress = z.infer<typeof schemaAddressSchema>;
+
+export const schemaNodeSchema = z.object({
+  name: z.string().min(1),
+  children: z.array(z.lazy(() => schemaNodeSchema)).optional(),
+});
+export type schemaNode = z.infer<typeof schemaNodeSchema>;
+`, string(src))
+}
+
+func TestExportZodRules(t *testing.T) {
+	type quotas struct {
+		Limits  map[string]int `json:"limits" validate:"keys:len:2,endkeys&values:min:0"`
+		Count   int            `json:"count" validate:"required"`
+		Mode    string         `json:"mode" validate:"in:a,b&default:a"`
+		Level   int            `json:"level" validate:"omitempty&in:1"`
+		Pair    [2]string      `json:"pair"`
+		Nothing string         `json:"nothing" validate:"in:"`
+	}
+	type named quotas
+	src, err := ExportZod(named{})
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `
+  limits: z.record(z.string().length(2), z.number().int().min(0)).optional(),
+  count: z.number().int().refine((v) => v !== 0),
+  mode: z.enum(["a", "b"]).default("a").optional(),
+  level: z.literal(1).or(z.literal(0)).optional(),
+  pair: z.array(z.string()).length(2).optional(),
+`)
+
+	_, err = ExportZod(struct{ A string }{})
+	assert.ErrorIs(t, err, ErrNotStruct)
+	_, err = ExportZod(struct {
+		A string `validate:"min:x"`
+	}{})
+	assert.Error(t, err)
+}